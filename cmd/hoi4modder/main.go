@@ -22,6 +22,9 @@ func main() {
 	ebiten.SetWindowTitle(windowTitle)
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
+	// Closing the window asks about unsaved documents first
+	ebiten.SetWindowClosingHandled(true)
+
 	// Run the game loop
 	if err := ebiten.RunGame(game); err != nil {
 		log.Fatal(err)
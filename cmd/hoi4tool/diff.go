@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// runDiff prints the semantic diff of a focus or technology file against
+// another file or a git revision
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	focusFile := fs.String("focus", "", "national focus file (working copy)")
+	techFile := fs.String("tech", "", "technology file (working copy)")
+	oldPath := fs.String("old", "", "older version of the file to compare with")
+	revision := fs.String("rev", "HEAD", "git revision to compare with when -old is not set")
+	asJSON := fs.Bool("json", false, "print the diff as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var diff *domain.TreeDiff
+	var err error
+	switch {
+	case *focusFile != "" && *techFile == "":
+		diff, err = app.DiffFocusFile(*focusFile, *oldPath, *revision)
+	case *techFile != "" && *focusFile == "":
+		diff, err = app.DiffTechnologyFile(*techFile, *oldPath, *revision)
+	default:
+		return fmt.Errorf("one of -focus or -tech is required")
+	}
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(diff)
+	}
+
+	markers := map[domain.ChangeKind]string{domain.ChangeAdded: "+", domain.ChangeRemoved: "-", domain.ChangeModified: "~"}
+	for _, change := range diff.Changes {
+		if change.Kind != domain.ChangeModified {
+			fmt.Printf("%s %s (%s)\n", markers[change.Kind], change.ID, change.Kind)
+			continue
+		}
+		fmt.Printf("%s %s\n", markers[change.Kind], change.ID)
+		for _, line := range change.Lines() {
+			fmt.Println("    " + line)
+		}
+	}
+	fmt.Println(diff.Summary())
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// runLayout arranges a focus tree automatically and writes the positions
+// back into the focus file
+func runLayout(args []string) error {
+	fs := flag.NewFlagSet("layout", flag.ContinueOnError)
+	focusFile := fs.String("focus", "", "national focus file to lay out")
+	relative := fs.Bool("relative", false, "write relative_position_id offsets instead of absolute x/y")
+	lock := fs.String("lock", "", "comma-separated focus IDs that keep their position")
+	spacing := fs.Int("spacing", 2, "columns between neighbouring focuses")
+	write := fs.Bool("write", false, "write the positions into the focus file (a .bak backup is kept)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *focusFile == "" {
+		return fmt.Errorf("-focus is required")
+	}
+
+	focuses, err := app.LoadFocusFile(*focusFile)
+	if err != nil {
+		return err
+	}
+	tree := app.NewFocusTreeFromFocuses("", focuses)
+
+	options := domain.FocusLayoutOptions{Locked: make(map[string]bool), Spacing: *spacing}
+	if *relative {
+		options.Mode = domain.FocusLayoutRelative
+	}
+	for _, id := range strings.Split(*lock, ",") {
+		if id = strings.TrimSpace(id); id == "" {
+			continue
+		}
+		if _, ok := tree.Focuses[id]; !ok {
+			return fmt.Errorf("unknown focus to lock: %s", id)
+		}
+		options.Locked[id] = true
+	}
+
+	before := domain.CountFocusCrossings(tree, tree.AbsolutePositions())
+	layout := domain.LayoutFocusTree(tree, options)
+	layout.Apply(tree, options)
+
+	for _, focus := range focuses {
+		pos := layout.Positions[focus.ID]
+		line := fmt.Sprintf("%-40s x = %3d  y = %3d", focus.ID, pos.X, pos.Y)
+		if focus.RelativePositionID != "" {
+			line += fmt.Sprintf("  (%+d, %+d from %s)", focus.Position.X, focus.Position.Y, focus.RelativePositionID)
+		}
+		fmt.Println(line)
+	}
+	fmt.Printf("%d focuses in %d rows, crossings %d -> %d\n", len(focuses), len(layout.Layers), before, domain.CountFocusCrossings(tree, layout.Positions))
+
+	if !*write {
+		fmt.Println("Dry run: use -write to update " + *focusFile)
+		return nil
+	}
+	if err := app.SaveFocuses(*focusFile, focuses); err != nil {
+		return err
+	}
+	fmt.Println("Wrote " + *focusFile)
+	return nil
+}
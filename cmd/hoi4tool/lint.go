@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/lint"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+)
+
+// runLint checks a focus file and/or the technologies with the lint rules
+func runLint(args []string) error {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	modPath := fs.String("mod", "", "mod root directory")
+	gamePath := fs.String("game", "", "HOI4 install directory (vanilla localisation, sprites and technologies)")
+	focusFile := fs.String("focus", "", "national focus file to check")
+	techs := fs.Bool("tech", false, "check technologies of the mod and game")
+	rulesPath := fs.String("rules", "", "rules file (default: "+lint.ConfigFile+" in the mod root)")
+	tag := fs.String("tag", "", "country tag for country-specific technology icons")
+	assets := fs.Bool("assets", true, "check localisation and icons")
+	listRules := fs.Bool("list", false, "list rules and exit")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	engine, err := app.NewLintEngine(*modPath, *rulesPath)
+	if err != nil {
+		return err
+	}
+	if *listRules {
+		for _, rule := range engine.Rules() {
+			fmt.Printf("%-22s %-8s %s\n", rule.ID, rule.Severity, rule.Description)
+		}
+		return nil
+	}
+	if *focusFile == "" && !*techs {
+		return fmt.Errorf("-focus or -tech is required")
+	}
+
+	input := &lint.Input{FocusFile: *focusFile}
+	if *focusFile != "" {
+		focuses, err := app.LoadFocusFile(*focusFile)
+		if err != nil {
+			return err
+		}
+		input.FocusTree = app.NewFocusTreeFromFocuses("", focuses)
+	}
+	if *techs {
+		input.Technologies, err = app.NewTechnologyLoader(*modPath, *gamePath).LoadAllTechnologies()
+		if err != nil {
+			return err
+		}
+	}
+	if *assets {
+		localisation, err := parser.NewLocalizationParser(*modPath, *gamePath, "english").LoadLocalizations()
+		if err != nil {
+			return err
+		}
+		sprites := app.NewSpriteRegistry(*modPath, *gamePath)
+		// Without sprites the icon rules are skipped; Load logs the warning itself
+		if err := sprites.Load(); err != nil {
+			sprites = nil
+		}
+		app.SetLintAssets(input, localisation, sprites, *tag)
+	}
+
+	issues := engine.Run(input)
+	for _, issue := range issues {
+		fmt.Println(issue)
+		if issue.Fix != nil {
+			fmt.Println("    fix: " + issue.Fix.Description)
+		}
+	}
+
+	counts := lint.Count(issues)
+	fmt.Printf("%d errors, %d warnings, %d infos\n", counts[lint.SeverityError], counts[lint.SeverityWarning], counts[lint.SeverityInfo])
+	if counts[lint.SeverityError] > 0 {
+		return fmt.Errorf("%d errors", counts[lint.SeverityError])
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Command hoi4tool runs editor features headlessly (no window required)
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// command is a hoi4tool subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands = []command{
+	{name: "render", summary: "render a focus tree or technology folder to PNG/SVG", run: runRender},
+	{name: "modifiers", summary: "report unknown modifiers in technologies, ideas and focus rewards", run: runModifiers},
+	{name: "lint", summary: "check a focus file or technologies with configurable lint rules", run: runLint},
+	{name: "layout", summary: "arrange a focus tree automatically (layers, fewer crossings)", run: runLayout},
+	{name: "techgrid", summary: "check and lay out a technology folder on year rows and column variables", run: runTechGrid},
+	{name: "diff", summary: "semantic diff of a focus or technology file against another file or a git revision", run: runDiff},
+	{name: "vanilla", summary: "list vanilla technologies and focuses overridden by the mod, with changed fields", run: runVanilla},
+	{name: "merge", summary: "three-way merge of a game update into the mod copy of a technology or focus file", run: runMerge},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	name := os.Args[1]
+	for _, cmd := range commands {
+		if cmd.name == name {
+			if err := cmd.run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "hoi4tool %s: %v\n", name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	if name != "help" && name != "-h" && name != "--help" {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", name)
+	}
+	usage()
+	os.Exit(2)
+}
+
+// usage prints the list of subcommands
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: hoi4tool <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Run 'hoi4tool <command> -h' for command flags.")
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// runMerge merges a game update into the mod copy of a technology or focus
+// file block by block
+func runMerge(args []string) error {
+	fs := flag.NewFlagSet("merge", flag.ContinueOnError)
+	basePath := fs.String("base", "", "the file from the old game version the mod copy is based on")
+	oursPath := fs.String("ours", "", "the mod copy of the file")
+	theirsPath := fs.String("theirs", "", "the file from the new game version")
+	outPath := fs.String("out", "", "merged file (default: the mod copy, a .bak backup is kept)")
+	prefer := fs.String("prefer", "", "resolve conflicts with ours (mod) or theirs (game)")
+	write := fs.Bool("write", false, "write the merged file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *basePath == "" || *oursPath == "" || *theirsPath == "" {
+		return fmt.Errorf("-base, -ours and -theirs are required")
+	}
+
+	merged, err := app.MergeFiles(*basePath, *oursPath, *theirsPath)
+	if err != nil {
+		return err
+	}
+	switch domain.MergeSide(*prefer) {
+	case domain.MergeSideNone:
+	case domain.MergeSideOurs, domain.MergeSideTheirs:
+		merged.ResolveAll(domain.MergeSide(*prefer))
+	default:
+		return fmt.Errorf("unknown -prefer %q (ours or theirs)", *prefer)
+	}
+
+	merged.Walk(func(block *domain.MergeBlock) {
+		switch block.Status {
+		case domain.MergeUnchanged:
+		case domain.MergeConflict:
+			resolution := string(block.Resolution)
+			if resolution == "" {
+				resolution = "unresolved"
+			}
+			fmt.Printf("%-9s %s (%s)\n", block.Status, block.Key, resolution)
+		default:
+			if block.Result() == "" {
+				fmt.Printf("%-9s %s (removed)\n", block.Status, block.Key)
+				return
+			}
+			fmt.Printf("%-9s %s\n", block.Status, block.Key)
+		}
+	})
+	fmt.Println(merged.Summary())
+
+	if !*write {
+		return nil
+	}
+	if unresolved := merged.Unresolved(); unresolved > 0 {
+		return fmt.Errorf("%d unresolved conflicts, use -prefer or the merge tool", unresolved)
+	}
+	out := *outPath
+	if out == "" {
+		out = *oursPath
+	}
+	if err := app.SaveMerge(merged, out); err != nil {
+		return err
+	}
+	fmt.Printf("Merged file written to %s\n", out)
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// runModifiers checks modifier names in technologies, ideas and focus rewards
+func runModifiers(args []string) error {
+	fs := flag.NewFlagSet("modifiers", flag.ContinueOnError)
+	modPath := fs.String("mod", "", "mod root directory")
+	gamePath := fs.String("game", "", "HOI4 install directory (vanilla files and modifiers_documentation.md)")
+	focusFile := fs.String("focus", "", "national focus file whose rewards are checked as well")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *modPath == "" && *gamePath == "" {
+		return fmt.Errorf("-mod or -game is required")
+	}
+
+	catalogue := app.LoadModifierCatalogue(*gamePath)
+
+	techs, err := app.NewTechnologyLoader(*modPath, *gamePath).LoadAllTechnologies()
+	if err != nil {
+		return err
+	}
+	// A mod without common/ideas gets an empty set, as in the editor
+	ideas, err := app.NewIdeaLoader(*modPath, *gamePath).LoadAll()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: ideas not loaded: %v\n", err)
+	}
+	var tree *domain.FocusTree
+	if *focusFile != "" {
+		focuses, err := app.LoadFocusFile(*focusFile)
+		if err != nil {
+			return err
+		}
+		tree = app.NewFocusTreeFromFocuses("", focuses)
+	}
+
+	issues := app.ModifierIssues(catalogue, techs, ideas, tree)
+	for _, issue := range issues {
+		line := fmt.Sprintf("%s: unknown modifier %s", issue.Source, issue.Modifier)
+		if issue.Suggestion != "" {
+			line += " (did you mean " + issue.Suggestion + "?)"
+		}
+		fmt.Println(line)
+	}
+
+	fmt.Printf("Checked against %d known modifiers: %d unknown\n", catalogue.Count(), len(issues))
+	if len(issues) > 0 {
+		return fmt.Errorf("%d unknown modifiers", len(issues))
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/render"
+)
+
+// runRender renders a focus file or technology folder to an image file
+func runRender(args []string) error {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	modPath := fs.String("mod", "", "mod root directory (detected from -focus if omitted)")
+	gamePath := fs.String("game", "", "HOI4 install directory for vanilla icons and localisation")
+	focusFile := fs.String("focus", "", "national focus file to render")
+	techFolder := fs.String("tech", "", "technology folder to render (e.g. infantry_folder)")
+	output := fs.String("o", "", "output file (.png or .svg)")
+	scale := fs.Int("scale", 2, "PNG resolution multiplier (1-8)")
+	language := fs.String("lang", "english", "localisation language")
+	tag := fs.String("tag", "", "country tag for country-specific tech icons")
+	title := fs.String("title", "", "title drawn above the tree")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if (*focusFile == "") == (*techFolder == "") {
+		return fmt.Errorf("exactly one of -focus or -tech is required")
+	}
+	if *output == "" {
+		return fmt.Errorf("-o is required")
+	}
+	ext := strings.ToLower(filepath.Ext(*output))
+	if ext != ".png" && ext != ".svg" {
+		return fmt.Errorf("unsupported output format %q (use .png or .svg)", ext)
+	}
+
+	if *modPath == "" && *focusFile != "" {
+		base, err := app.DetectBasePath(*focusFile)
+		if err != nil {
+			return err
+		}
+		*modPath = base
+	}
+	if *modPath == "" && *gamePath == "" {
+		return fmt.Errorf("-mod or -game is required")
+	}
+
+	registry := app.NewSpriteRegistry(*modPath, *gamePath)
+	// Missing sprites only mean empty icon areas; Load logs the warning itself
+	_ = registry.Load()
+
+	localizations, err := parser.NewLocalizationParser(*modPath, *gamePath, *language).LoadLocalizations()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: localisation not loaded: %v\n", err)
+	}
+
+	opts := render.Options{
+		Title: *title,
+		Localize: func(key string) string {
+			return parser.GetLocalization(localizations, key)
+		},
+	}
+
+	var diagram *render.Diagram
+	if *focusFile != "" {
+		focuses, err := app.LoadFocusFile(*focusFile)
+		if err != nil {
+			return err
+		}
+		opts.Icon = func(icon string) image.Image {
+			sprite, ok := registry.ResolveFocusIcon(icon)
+			return loadSpriteImage(registry, sprite, ok)
+		}
+		diagram = render.NewFocusDiagram(app.NewFocusTreeFromFocuses(*tag, focuses), opts)
+	} else {
+		techs, err := app.NewTechnologyLoader(*modPath, *gamePath).LoadTechnologiesForFolder(*techFolder)
+		if err != nil {
+			return err
+		}
+		if len(techs) == 0 {
+			return fmt.Errorf("no technologies found in folder %s", *techFolder)
+		}
+		opts.Icon = func(techID string) image.Image {
+			sprite, ok := registry.ResolveTechIcon(techID, *tag)
+			return loadSpriteImage(registry, sprite, ok)
+		}
+		diagram = render.NewTechDiagram(techs, opts)
+	}
+
+	out, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("failed to create output: %w", err)
+	}
+	defer out.Close()
+
+	if ext == ".svg" {
+		err = render.WriteSVG(out, diagram)
+	} else {
+		err = render.WritePNG(out, diagram, *scale)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Rendered %d nodes, %d connections to %s\n", len(diagram.Nodes), len(diagram.Edges), *output)
+	return out.Close()
+}
+
+// loadSpriteImage returns the sprite's first frame, or nil if it cannot be loaded
+func loadSpriteImage(registry *app.SpriteRegistry, sprite *domain.Sprite, ok bool) image.Image {
+	if !ok {
+		return nil
+	}
+	img, err := registry.LoadImage(sprite)
+	if err != nil {
+		return nil
+	}
+	return img
+}
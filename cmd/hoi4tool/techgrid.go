@@ -0,0 +1,89 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// runTechGrid checks a technology folder against the grid rules and lays it
+// out on year rows with column variables
+func runTechGrid(args []string) error {
+	fs := flag.NewFlagSet("techgrid", flag.ContinueOnError)
+	modPath := fs.String("mod", "", "mod root directory")
+	gamePath := fs.String("game", "", "HOI4 install directory (vanilla technologies)")
+	folder := fs.String("folder", "", "technology folder to lay out (e.g. electronics_folder)")
+	check := fs.Bool("check", false, "only report grid rule violations")
+	write := fs.Bool("write", false, "write positions and new column variables into the mod files (a .bak backup is kept)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *folder == "" {
+		return fmt.Errorf("-folder is required")
+	}
+
+	technologies, err := app.NewTechnologyLoader(*modPath, *gamePath).LoadTechnologiesForFolder(*folder)
+	if err != nil {
+		return err
+	}
+	if len(technologies) == 0 {
+		return fmt.Errorf("no technologies in folder %s", *folder)
+	}
+	grid, err := app.LoadTechGrid(technologies)
+	if err != nil {
+		return err
+	}
+	layout := domain.NewTechGridLayout(grid, *folder)
+
+	before := layout.Check(technologies)
+	for _, issue := range before {
+		fmt.Printf("%s:%d (%s): %s: %s\n", issue.Tech.File, issue.Tech.Line, issue.Tech.ID, issue.Kind, issue.Message)
+	}
+	if *check {
+		fmt.Printf("%d technologies, %d issues\n", len(technologies), len(before))
+		return nil
+	}
+
+	layout.Layout(technologies)
+	sort.Slice(technologies, func(i, j int) bool {
+		a, b := technologies[i].Position, technologies[j].Position
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		return a.Y < b.Y
+	})
+	for _, tech := range technologies {
+		pos := tech.Position
+		fmt.Printf("%-40s x = %-24s y = %s\n", tech.ID, gridCoordinate(pos.XVar, pos.X), gridCoordinate(pos.YVar, pos.Y))
+	}
+	names := make([]string, 0, len(layout.NewColumns))
+	for name := range layout.NewColumns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("new column %s = %d\n", name, layout.NewColumns[name])
+	}
+	fmt.Printf("%d technologies, issues %d -> %d\n", len(technologies), len(before), len(layout.Check(technologies)))
+
+	if !*write {
+		fmt.Println("Dry run: use -write to update the technology files")
+		return nil
+	}
+	if err := app.SaveTechnologies(*modPath, technologies, layout.NewColumns); err != nil {
+		return err
+	}
+	fmt.Println("Wrote technology positions")
+	return nil
+}
+
+// gridCoordinate formats a coordinate as "@VAR (value)" or "value"
+func gridCoordinate(variable string, value int) string {
+	if variable == "" {
+		return fmt.Sprintf("%d", value)
+	}
+	return fmt.Sprintf("%s (%d)", variable, value)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// runVanilla reports the vanilla technologies and focuses the mod overrides,
+// with the fields that differ from the game
+func runVanilla(args []string) error {
+	fs := flag.NewFlagSet("vanilla", flag.ContinueOnError)
+	modPath := fs.String("mod", "", "mod root directory")
+	gamePath := fs.String("game", "", "HOI4 install directory")
+	all := fs.Bool("all", false, "list every technology and focus with its origin, not only overrides")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *modPath == "" || *gamePath == "" {
+		return fmt.Errorf("-mod and -game are required")
+	}
+
+	report := app.LoadProvenance(*modPath, *gamePath, nil)
+	entries := report.Overrides()
+	if *all {
+		entries = report.Entries
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	for _, entry := range entries {
+		if entry.Provenance != domain.ProvenanceOverride {
+			fmt.Printf("%-8s %-10s %s (%s)\n", entry.Provenance, entry.Kind, entry.ID, entry.File)
+			continue
+		}
+		fmt.Printf("%-8s %-10s %s (%s, vanilla %s)\n", entry.Provenance, entry.Kind, entry.ID, entry.File, entry.GameFile)
+		for _, field := range entry.Fields {
+			fmt.Printf("    %s: %q -> %q\n", field.Field, field.Old, field.New)
+		}
+	}
+	for _, kind := range []string{domain.ProvenanceTechnology, domain.ProvenanceFocus} {
+		fmt.Printf("%s: %d vanilla, %d overridden, %d new\n", kind,
+			report.Count(kind, domain.ProvenanceGame), report.Count(kind, domain.ProvenanceOverride), report.Count(kind, domain.ProvenanceMod))
+	}
+	return nil
+}
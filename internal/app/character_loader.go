@@ -0,0 +1,107 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+)
+
+// charactersDir is the character folder relative to the mod/game root
+const charactersDir = "common/characters"
+
+// CharacterLoader loads common/characters files
+type CharacterLoader struct {
+	modPath  string
+	gamePath string
+}
+
+// NewCharacterLoader creates a new character loader
+func NewCharacterLoader(modPath, gamePath string) *CharacterLoader {
+	return &CharacterLoader{
+		modPath:  modPath,
+		gamePath: gamePath,
+	}
+}
+
+// LoadAll loads character files from game and mod (see loadLayered)
+func (cl *CharacterLoader) LoadAll() (*domain.CharacterSet, error) {
+	files := loadLayered(cl.modPath, cl.gamePath, charactersDir, func(path, source string) (*domain.CharacterFile, error) {
+		file, err := ParseCharacterFile(path)
+		if err == nil {
+			file.Source = source
+		}
+		return file, err
+	})
+	if len(files) == 0 {
+		return domain.NewCharacterSet(nil), fmt.Errorf("no character files found in mod or game")
+	}
+
+	set := domain.NewCharacterSet(files)
+	println("Loaded", set.Count(), "characters from", len(files), "files")
+	return set, nil
+}
+
+// ParseCharacterFile parses a single common/characters file
+func ParseCharacterFile(path string) (*domain.CharacterFile, error) {
+	program, err := parseScriptFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &domain.CharacterFile{
+		Path:       path,
+		RelPath:    charactersDir + "/" + filepath.Base(path),
+		Characters: parser.NewCharacterParser().ParseCharacters(program),
+	}
+	for _, character := range file.Characters {
+		character.File = file
+	}
+
+	return file, nil
+}
+
+// CountryCharacterReferences collects the character IDs used by the focus
+// tree rewards and by the country's history file (mod before game)
+func CountryCharacterReferences(tree *domain.FocusTree, modPath, gamePath, tag string) []domain.CharacterReference {
+	refs := make([]domain.CharacterReference, 0)
+
+	if tree != nil {
+		focusIDs := make([]string, 0, len(tree.Focuses))
+		for id := range tree.Focuses {
+			focusIDs = append(focusIDs, id)
+		}
+		sort.Strings(focusIDs)
+
+		for _, id := range focusIDs {
+			for _, characterID := range tree.Focuses[id].RewardCharacters {
+				refs = append(refs, domain.CharacterReference{ID: characterID, Source: "focus " + id})
+			}
+		}
+	}
+
+	for _, basePath := range []string{modPath, gamePath} {
+		if basePath == "" {
+			continue
+		}
+		path, err := parser.FindCountryHistoryFile(basePath, tag)
+		if err != nil {
+			continue
+		}
+		program, err := parseScriptFile(path)
+		if err != nil {
+			println("Warning: Failed to parse", path, ":", err.Error())
+			break
+		}
+
+		relPath, _ := filepath.Rel(basePath, path)
+		for _, characterID := range parser.CollectCharacterReferences(program.Statements) {
+			refs = append(refs, domain.CharacterReference{ID: characterID, Source: filepath.ToSlash(relPath)})
+		}
+		break
+	}
+
+	return refs
+}
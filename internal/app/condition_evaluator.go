@@ -7,6 +7,11 @@ type ConditionEvaluator struct {
 	countryFlags []string
 	dlcs         []string // For future DLC support
 	isMajor      bool     // For future major_country support
+
+	// Country facts (set with SetCountry; empty values match nothing)
+	tag        string
+	government string
+	ideas      []string
 }
 
 // NewConditionEvaluator creates a new condition evaluator
@@ -18,6 +23,15 @@ func NewConditionEvaluator(flags []string) *ConditionEvaluator {
 	}
 }
 
+// SetCountry sets the facts used by tag, has_government, has_idea and
+// major_country conditions
+func (e *ConditionEvaluator) SetCountry(tag, government string, ideas []string, isMajor bool) {
+	e.tag = tag
+	e.government = government
+	e.ideas = ideas
+	e.isMajor = isMajor
+}
+
 // Evaluate checks if all conditions in AvailableCondition are met
 func (e *ConditionEvaluator) Evaluate(condition *parser.AvailableCondition) bool {
 	if condition == nil {
@@ -54,6 +68,25 @@ func (e *ConditionEvaluator) evaluateCondition(cond *parser.Condition) bool {
 		}
 		return true // All children are false, so NOT succeeds
 
+	case "AND":
+		for _, child := range cond.Children {
+			if !e.evaluateCondition(child) {
+				return false
+			}
+		}
+		return true
+
+	case "OR":
+		if len(cond.Children) == 0 {
+			return true
+		}
+		for _, child := range cond.Children {
+			if e.evaluateCondition(child) {
+				return true
+			}
+		}
+		return false
+
 	case "has_dlc":
 		// For now, assume no DLCs installed
 		hasDLC := e.hasDLC(cond.Value)
@@ -69,6 +102,20 @@ func (e *ConditionEvaluator) evaluateCondition(cond *parser.Condition) bool {
 		}
 		return e.isMajor
 
+	case "tag":
+		return e.tag != "" && e.tag == cond.Value
+
+	case "has_government":
+		return e.government != "" && e.government == cond.Value
+
+	case "has_idea":
+		for _, idea := range e.ideas {
+			if idea == cond.Value {
+				return true
+			}
+		}
+		return false
+
 	default:
 		// Unknown condition type - assume true to be permissive
 		return true
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
 	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
@@ -14,44 +15,85 @@ type CountryContext struct {
 	Country         *domain.BookmarkCountry
 	ModPath         string
 	GamePath        string
-	FocusPath       string               // Path to national focus file
-	TechFolders     []string             // Available technology folders (IDs)
-	Localizations   map[string]string    // Localized strings
-	AllTechnologies []*domain.Technology // All loaded technologies (cached)
-	CountryFlags    []string             // Country flags from history files
+	FocusPath       string                  // Path to national focus file
+	TechFolders     []string                // Available technology folders (IDs)
+	Localizations   map[string]string       // Localized strings
+	AllTechnologies []*domain.Technology    // All loaded technologies (cached)
+	CountryFlags    []string                // Country flags from history files
+	Scripted        *parser.ScriptedLibrary // Scripted triggers/effects
+	Cache           *ParseCache             // Parsed files (nil: always parse)
+
+	progress *Progress // Set while the context is loading
+
+	// Keys of each mod localisation file, to drop the keys deleted from a
+	// file when it is reloaded
+	localisationKeys map[string][]string
 }
 
-// NewCountryContext creates a new country context
-func NewCountryContext(country *domain.BookmarkCountry, modPath, gamePath string) *CountryContext {
+// NewCountryContext creates a new country context; unchanged files are
+// taken from the cache (nil parses everything)
+func NewCountryContext(country *domain.BookmarkCountry, modPath, gamePath string, cache *ParseCache) *CountryContext {
+	ctx, _ := LoadCountryContext(country, modPath, gamePath, cache, nil)
+	return ctx
+}
+
+// LoadCountryContext creates a country context reporting parsed files to
+// progress (may be nil); it returns ErrCancelled once the progress is
+// cancelled. Safe to run off the UI goroutine
+func LoadCountryContext(country *domain.BookmarkCountry, modPath, gamePath string, cache *ParseCache, progress *Progress) (*CountryContext, error) {
 	ctx := &CountryContext{
 		Country:       country,
 		ModPath:       modPath,
 		GamePath:      gamePath,
 		TechFolders:   make([]string, 0),
 		Localizations: make(map[string]string),
+		Cache:         cache,
+		progress:      progress,
 	}
+	defer func() { ctx.progress = nil }()
+	progress.SetStage("Loading technologies, localisation and history of " + country.Tag)
+
+	// The sources are independent, load them concurrently
+	var wg sync.WaitGroup
+	for _, load := range []func(){
+		ctx.loadCountryFlags,    // Needed for folder filtering
+		ctx.loadLocalizations,   // Folder names
+		ctx.loadAllTechnologies, // All technologies once (cached)
+		func() { ctx.Scripted = LoadScriptedLibrary(modPath, gamePath) }, // Expanded in folder conditions
+	} {
+		wg.Add(1)
+		go func(load func()) {
+			defer wg.Done()
+			load()
+		}(load)
+	}
+	wg.Wait()
 
-	// Load country flags (needed for folder filtering)
-	ctx.loadCountryFlags()
-
-	// Load localizations
-	ctx.loadLocalizations()
+	if cache != nil {
+		if err := cache.Save(); err != nil {
+			println("Warning: Failed to save parse cache:", err.Error())
+		}
+	}
+	if progress.Cancelled() {
+		return nil, ErrCancelled
+	}
 
 	// Resolve focus path
 	ctx.resolveFocusPath()
 
-	// Resolve tech folders (uses country flags)
+	// Resolve tech folders (uses country flags and scripted triggers)
+	progress.SetStage("Resolving technology folders")
 	ctx.resolveTechFolders()
 
-	// Load all technologies once (cached)
-	ctx.loadAllTechnologies()
-
-	return ctx
+	return ctx, nil
 }
 
 // loadCountryFlags loads flags from history/countries files
 func (ctx *CountryContext) loadCountryFlags() {
 	flagsParser := parser.NewCountryFlagsParser(ctx.GamePath, ctx.ModPath)
+	flagsParser.SetFileLoader(func(filePath string) ([]string, error) {
+		return cachedParse(ctx.Cache, cacheHistory, filePath, flagsParser.ParseFile)
+	})
 	flags, err := flagsParser.ParseCountryFlags(ctx.Country.Tag)
 	if err != nil {
 		println("Warning: Failed to load country flags:", err.Error())
@@ -96,7 +138,7 @@ func (ctx *CountryContext) loadCountryFlags() {
 
 // loadAllTechnologies loads all technologies once and caches them
 func (ctx *CountryContext) loadAllTechnologies() {
-	loader := NewTechnologyLoader(ctx.ModPath, ctx.GamePath)
+	loader := NewTechnologyLoader(ctx.ModPath, ctx.GamePath).SetCache(ctx.Cache).SetProgress(ctx.progress)
 	technologies, err := loader.LoadAllTechnologies()
 	if err != nil {
 		println("Warning: Failed to load technologies:", err.Error())
@@ -111,6 +153,19 @@ func (ctx *CountryContext) loadAllTechnologies() {
 // loadLocalizations loads localization strings
 func (ctx *CountryContext) loadLocalizations() {
 	locParser := parser.NewLocalizationParser(ctx.ModPath, ctx.GamePath, "english")
+	keys := make(map[string][]string)
+	var mu sync.Mutex
+	locParser.SetFileLoader(trackFile(ctx.progress, func(filePath string) (map[string]string, error) {
+		localizations, err := cachedParse(ctx.Cache, cacheLocalisation, filePath, locParser.LoadFile)
+		if err == nil && inModPath(ctx.ModPath, filePath) {
+			mu.Lock()
+			keys[filepath.Clean(filePath)] = localisationKeys(localizations)
+			mu.Unlock()
+		}
+		return localizations, err
+	}))
+	ctx.localisationKeys = keys
+	ctx.progress.AddFiles(len(locParser.Files()))
 	localizations, err := locParser.LoadLocalizations()
 	if err != nil {
 		println("Warning: Failed to load localizations:", err.Error())
@@ -122,6 +177,15 @@ func (ctx *CountryContext) loadLocalizations() {
 	println("Loaded", len(localizations), "localization strings")
 }
 
+// localisationKeys returns the keys of the strings of a localisation file
+func localisationKeys(localizations map[string]string) []string {
+	keys := make([]string, 0, len(localizations))
+	for key := range localizations {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
 // resolveFocusPath finds the national focus file for this country
 func (ctx *CountryContext) resolveFocusPath() {
 	tag := ctx.Country.Tag
@@ -160,6 +224,7 @@ func (ctx *CountryContext) resolveFocusPath() {
 func (ctx *CountryContext) resolveTechFolders() {
 	// Parse technology_folders with detailed information
 	tagsParser := parser.NewTechnologyTagsParser(ctx.GamePath, ctx.ModPath)
+	tagsParser.SetScriptedLibrary(ctx.Scripted)
 	allFolders, err := tagsParser.ParseTechnologyFoldersDetailed()
 	if err != nil {
 		println("Warning: Failed to parse technology_folders:", err.Error())
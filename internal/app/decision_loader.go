@@ -0,0 +1,162 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/serializer"
+)
+
+// Decision folders relative to the mod/game root
+const (
+	decisionsDir          = "common/decisions"
+	decisionCategoriesDir = "common/decisions/categories"
+)
+
+// DecisionLoader loads and saves common/decisions files
+type DecisionLoader struct {
+	modPath  string
+	gamePath string
+}
+
+// NewDecisionLoader creates a new decision loader
+func NewDecisionLoader(modPath, gamePath string) *DecisionLoader {
+	return &DecisionLoader{
+		modPath:  modPath,
+		gamePath: gamePath,
+	}
+}
+
+// LoadAll loads decision and category files from game and mod (see loadLayered)
+func (dl *DecisionLoader) LoadAll() (*domain.DecisionSet, error) {
+	files := loadLayered(dl.modPath, dl.gamePath, decisionsDir, func(path, source string) (*domain.DecisionFile, error) {
+		file, err := ParseDecisionFile(path)
+		if err == nil {
+			file.Source = source
+		}
+		return file, err
+	})
+	categoryFiles := loadLayered(dl.modPath, dl.gamePath, decisionCategoriesDir, func(path, source string) (*domain.DecisionCategoryFile, error) {
+		file, err := ParseDecisionCategoryFile(path)
+		if err == nil {
+			file.Source = source
+		}
+		return file, err
+	})
+	if len(files) == 0 {
+		return domain.NewDecisionSet(nil, nil), fmt.Errorf("no decision files found in mod or game")
+	}
+
+	set := domain.NewDecisionSet(files, categoryFiles)
+	println("Loaded", set.Count(), "decisions from", len(files), "files")
+	return set, nil
+}
+
+// ParseDecisionFile parses a single common/decisions file
+func ParseDecisionFile(path string) (*domain.DecisionFile, error) {
+	program, err := parseScriptFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &domain.DecisionFile{
+		Path:    path,
+		RelPath: decisionsDir + "/" + filepath.Base(path),
+		Blocks:  parser.NewDecisionParser().ParseDecisions(program),
+	}
+	for _, decision := range file.Decisions() {
+		decision.File = file
+	}
+
+	return file, nil
+}
+
+// ParseDecisionCategoryFile parses a single common/decisions/categories file
+func ParseDecisionCategoryFile(path string) (*domain.DecisionCategoryFile, error) {
+	program, err := parseScriptFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &domain.DecisionCategoryFile{
+		Path:       path,
+		RelPath:    decisionCategoriesDir + "/" + filepath.Base(path),
+		Categories: parser.NewDecisionParser().ParseCategories(program),
+	}
+	for _, category := range file.Categories {
+		category.File = file
+	}
+
+	return file, nil
+}
+
+// parseScriptFile reads and parses a Paradox script file
+func parseScriptFile(path string) (*parser.Program, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	program, err := parser.NewParser(string(content)).Parse()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+
+	return program, nil
+}
+
+// Save writes a decision file into the mod; game files become mod
+// overrides with the same name
+func (dl *DecisionLoader) Save(file *domain.DecisionFile) error {
+	if dl.modPath == "" {
+		return fmt.Errorf("mod path not set")
+	}
+
+	target := filepath.Join(dl.modPath, filepath.FromSlash(file.RelPath))
+	if err := serializer.NewDecisionWriter().WriteToFile(file, target); err != nil {
+		return fmt.Errorf("failed to save %s: %w", file.RelPath, err)
+	}
+
+	file.Path = target
+	file.Source = "mod"
+	file.Dirty = false
+	return nil
+}
+
+// SaveCategories writes a decision category file into the mod
+func (dl *DecisionLoader) SaveCategories(file *domain.DecisionCategoryFile) error {
+	if dl.modPath == "" {
+		return fmt.Errorf("mod path not set")
+	}
+
+	target := filepath.Join(dl.modPath, filepath.FromSlash(file.RelPath))
+	if err := serializer.NewDecisionWriter().WriteCategoriesToFile(file, target); err != nil {
+		return fmt.Errorf("failed to save %s: %w", file.RelPath, err)
+	}
+
+	file.Path = target
+	file.Source = "mod"
+	file.Dirty = false
+	return nil
+}
+
+// DecisionUnlockedBy returns the focuses that unlock the decision
+func DecisionUnlockedBy(tree *domain.FocusTree, decision *domain.Decision) []*domain.Focus {
+	focuses := make([]*domain.Focus, 0)
+	if tree == nil {
+		return focuses
+	}
+
+	for _, focus := range tree.Focuses {
+		if decision.UnlockedBy(focus) {
+			focuses = append(focuses, focus)
+		}
+	}
+	sort.Slice(focuses, func(i, j int) bool { return focuses[i].ID < focuses[j].ID })
+
+	return focuses
+}
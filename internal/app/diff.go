@@ -0,0 +1,206 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+)
+
+// GitShow returns the content of a file at a git revision (HEAD, main~1, a
+// commit hash) of the repository the file is in
+func GitShow(filePath, revision string) (string, error) {
+	if err := checkRevision(revision); err != nil {
+		return "", err
+	}
+	return runGit(filepath.Dir(filePath), "show", revision+":./"+filepath.Base(filePath))
+}
+
+// gitFileAt returns the content of a file at a git revision, and false if
+// the file did not exist at that revision (the revision itself must exist)
+func gitFileAt(filePath, revision string) (string, bool, error) {
+	if err := checkRevision(revision); err != nil {
+		return "", false, err
+	}
+	dir, object := filepath.Dir(filePath), revision+":./"+filepath.Base(filePath)
+	if _, err := runGit(dir, "rev-parse", "--verify", "--quiet", revision+"^{commit}"); err != nil {
+		return "", false, fmt.Errorf("unknown git revision %s: %w", revision, err)
+	}
+	if _, err := runGit(dir, "cat-file", "-e", object); err != nil {
+		return "", false, nil
+	}
+	content, err := runGit(dir, "show", object)
+	if err != nil {
+		return "", false, err
+	}
+	return content, true, nil
+}
+
+// checkRevision refuses revisions git would read as an option
+func checkRevision(revision string) error {
+	if revision == "" || strings.HasPrefix(revision, "-") {
+		return fmt.Errorf("invalid git revision %q", revision)
+	}
+	return nil
+}
+
+// runGit runs a git command in dir and returns its output
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return string(output), nil
+}
+
+// readOldVersion returns the older version of a file: the file oldPath,
+// or the file at a git revision when oldPath is empty
+func readOldVersion(filePath, oldPath, revision string) (string, error) {
+	if oldPath != "" {
+		content, err := os.ReadFile(oldPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read file: %w", err)
+		}
+		return string(content), nil
+	}
+	if revision == "" {
+		return "", fmt.Errorf("no file or git revision to compare with")
+	}
+	return GitShow(filePath, revision)
+}
+
+// LoadFocusVersion parses the older version of a focus file (oldPath, or
+// the file at a git revision when oldPath is empty)
+func LoadFocusVersion(filePath, oldPath, revision string) (*domain.FocusTree, error) {
+	source, err := readOldVersion(filePath, oldPath, revision)
+	if err != nil {
+		return nil, err
+	}
+	focuses, err := ParseFocusSource(source)
+	if err != nil {
+		return nil, fmt.Errorf("old version: %w", err)
+	}
+	return NewFocusTreeFromFocuses("", focuses), nil
+}
+
+// DiffFocusFile compares a focus file with its older version
+func DiffFocusFile(filePath, oldPath, revision string) (*domain.TreeDiff, error) {
+	old, err := LoadFocusVersion(filePath, oldPath, revision)
+	if err != nil {
+		return nil, err
+	}
+	focuses, err := LoadFocusFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return domain.DiffFocusTrees(old, NewFocusTreeFromFocuses("", focuses)), nil
+}
+
+// DiffTechnologyFile compares a technology file with its older version
+// (oldPath, or the file at a git revision when oldPath is empty)
+func DiffTechnologyFile(filePath, oldPath, revision string) (*domain.TreeDiff, error) {
+	oldSource, err := readOldVersion(filePath, oldPath, revision)
+	if err != nil {
+		return nil, err
+	}
+	old, err := parseTechnologySource(oldSource)
+	if err != nil {
+		return nil, fmt.Errorf("old version: %w", err)
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	technologies, err := parseTechnologySource(string(content))
+	if err != nil {
+		return nil, err
+	}
+	return domain.DiffTechnologyTrees(newTechnologyTree(old), newTechnologyTree(technologies)), nil
+}
+
+// DiffTechnologies compares loaded technologies (e.g. the folder shown in
+// the viewer) with an older version: the file oldPath, or their files in
+// modPath at a git revision, where a file missing at the revision makes its
+// technologies added and technologies of other files are left out; only old
+// technologies of the same folders are compared
+func DiffTechnologies(technologies []*domain.Technology, modPath, oldPath, revision string) (*domain.TreeDiff, error) {
+	folders := make(map[string]bool)
+	files := make(map[string]bool)
+	current := make([]*domain.Technology, 0, len(technologies))
+	for _, tech := range technologies {
+		folders[tech.Folder] = true
+		if oldPath != "" {
+			current = append(current, tech)
+		} else if tech.File != "" && inModPath(modPath, tech.File) {
+			files[tech.File] = true
+			current = append(current, tech)
+		}
+	}
+	if oldPath != "" {
+		files = map[string]bool{oldPath: true}
+	}
+
+	old := make([]*domain.Technology, 0)
+	for file := range files {
+		source, exists, err := oldTechnologySource(file, oldPath, revision)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue // Added since the revision
+		}
+		techs, err := parseTechnologySource(source)
+		if err != nil {
+			return nil, fmt.Errorf("old version of %s: %w", filepath.Base(file), err)
+		}
+		for _, tech := range techs {
+			if folders[tech.Folder] {
+				old = append(old, tech)
+			}
+		}
+	}
+	return domain.DiffTechnologyTrees(newTechnologyTree(old), newTechnologyTree(current)), nil
+}
+
+// oldTechnologySource returns the file oldPath, or a technology file at a
+// git revision and false if it did not exist then
+func oldTechnologySource(file, oldPath, revision string) (string, bool, error) {
+	if oldPath != "" {
+		source, err := readOldVersion(file, oldPath, revision)
+		return source, err == nil, err
+	}
+	return gitFileAt(file, revision)
+}
+
+// parseTechnologySource parses the source of a technology file
+func parseTechnologySource(source string) ([]*domain.Technology, error) {
+	program, err := parser.NewParser(source).Parse()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+	technologies, err := parser.NewTechParser().ParseTechnologies(program)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse technologies: %w", err)
+	}
+	return technologies, nil
+}
+
+// newTechnologyTree builds a technology tree from a list
+func newTechnologyTree(technologies []*domain.Technology) *domain.TechnologyTree {
+	tree := domain.NewTechnologyTree()
+	for _, tech := range technologies {
+		tree.AddTechnology(tech)
+	}
+	return tree
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+const diffTechFile = `technologies = {
+	tech_radio = {
+		folder = { name = electronics_folder position = { x = 0 y = 0 } }
+	}
+}`
+
+// gitFixture creates a git repository in dir with the files committed
+func gitFixture(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	for name, content := range files {
+		writeTestFile(t, filepath.Join(dir, name), content)
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+}
+
+// writeTestFile writes a file, creating its folder
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiffTechnologies(t *testing.T) {
+	modPath, gamePath := t.TempDir(), t.TempDir()
+	techDir := filepath.Join("common", "technologies")
+	gitFixture(t, modPath, map[string]string{filepath.Join(techDir, "electronics.txt"): diffTechFile})
+
+	// A file added since HEAD, and a game file outside the repository
+	writeTestFile(t, filepath.Join(modPath, techDir, "radar.txt"), `technologies = {
+	tech_radar = {
+		folder = { name = electronics_folder position = { x = 2 y = 0 } }
+	}
+}`)
+	writeTestFile(t, filepath.Join(gamePath, techDir, "electronics.txt"), diffTechFile)
+
+	technologies := make([]*domain.Technology, 0)
+	for _, file := range []string{
+		filepath.Join(modPath, techDir, "electronics.txt"),
+		filepath.Join(modPath, techDir, "radar.txt"),
+	} {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		techs, err := parseTechnologySource(string(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tech := range techs {
+			tech.File = file
+		}
+		technologies = append(technologies, techs...)
+	}
+	technologies[0].Position.X = 1
+	game := domain.NewTechnology("tech_game", 4, 0, "electronics_folder")
+	game.File = filepath.Join(gamePath, techDir, "electronics.txt")
+	technologies = append(technologies, game)
+
+	diff, err := DiffTechnologies(technologies, modPath, "", "HEAD")
+	if err != nil {
+		t.Fatalf("DiffTechnologies() error: %v", err)
+	}
+	kinds := make(map[string]domain.ChangeKind)
+	for _, change := range diff.Changes {
+		kinds[change.ID] = change.Kind
+	}
+	want := map[string]domain.ChangeKind{"tech_radio": domain.ChangeModified, "tech_radar": domain.ChangeAdded}
+	if len(kinds) != len(want) {
+		t.Errorf("Changes = %v, want %v", kinds, want)
+	}
+	for id, kind := range want {
+		if kinds[id] != kind {
+			t.Errorf("%s: got %q, want %q", id, kinds[id], kind)
+		}
+	}
+
+	for _, revision := range []string{"--output=/tmp/x", "no_such_branch"} {
+		if _, err := DiffTechnologies(technologies, modPath, "", revision); err == nil {
+			t.Errorf("Revision %q accepted", revision)
+		}
+	}
+}
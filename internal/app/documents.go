@@ -0,0 +1,283 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/serializer"
+)
+
+// FocusDocument is a national focus file open in the focus viewer; its
+// unsaved changes are focus positions, links and icons
+type FocusDocument struct {
+	Path     string
+	TreeID   string
+	Focuses  []*domain.Focus // In file order
+	Tree     *domain.FocusTree
+	Modified bool
+}
+
+// FocusDocumentKey is the workspace key of a focus file
+func FocusDocumentKey(path string) string {
+	return "focus:" + filepath.Clean(path)
+}
+
+// NewFocusDocument creates a document for a focus file (see Load)
+func NewFocusDocument(path, treeID string) *FocusDocument {
+	return &FocusDocument{Path: path, TreeID: treeID}
+}
+
+// Load parses the focus file, dropping unsaved changes
+func (d *FocusDocument) Load() error {
+	focuses, err := LoadFocusFile(d.Path)
+	if err != nil {
+		return err
+	}
+	d.Focuses = focuses
+	d.Tree = NewFocusTreeFromFocuses(d.TreeID, focuses)
+	d.Modified = false
+	return nil
+}
+
+func (d *FocusDocument) Key() string        { return FocusDocumentKey(d.Path) }
+func (d *FocusDocument) Title() string      { return filepath.Base(d.Path) }
+func (d *FocusDocument) Kind() DocumentKind { return DocumentFocusTree }
+func (d *FocusDocument) Dirty() bool        { return d.Modified }
+
+// Save writes the focus positions, links and icons into the file (.bak backup)
+func (d *FocusDocument) Save() error {
+	if err := SaveFocuses(d.Path, d.Focuses); err != nil {
+		return err
+	}
+	d.Modified = false
+	return nil
+}
+
+// TechDocument is a technology folder of a country (or a single technology
+// file) open in the tech viewer; its unsaved changes are grid positions,
+// paths and xor
+type TechDocument struct {
+	Tag          string // Country of the folder
+	Folder       string
+	Path         string // Set instead of Folder for a single file
+	ModPath      string // Only files under it are saved
+	Technologies []*domain.Technology
+
+	// Column variables created by the grid layout, declared on save
+	Columns  map[string]int
+	Modified bool
+}
+
+// TechFolderKey is the workspace key of a technology folder of a country
+func TechFolderKey(tag, folder string) string {
+	return "tech:" + tag + "/" + folder
+}
+
+// NewTechDocument creates a document for the technologies of a folder
+func NewTechDocument(tag, folder, modPath string, technologies []*domain.Technology) *TechDocument {
+	return &TechDocument{Tag: tag, Folder: folder, ModPath: modPath, Technologies: technologies}
+}
+
+// Key identifies the folder, or the file for single-file documents
+func (d *TechDocument) Key() string {
+	if d.Path != "" {
+		return "tech:" + filepath.Clean(d.Path)
+	}
+	return TechFolderKey(d.Tag, d.Folder)
+}
+
+// Title is the folder name, or the file name for single-file documents
+func (d *TechDocument) Title() string {
+	if d.Path != "" {
+		return filepath.Base(d.Path)
+	}
+	return d.Folder
+}
+
+func (d *TechDocument) Kind() DocumentKind { return DocumentTechFolder }
+func (d *TechDocument) Dirty() bool        { return d.Modified }
+
+// Save writes the technology positions and links into the mod files (.bak backups)
+func (d *TechDocument) Save() error {
+	if err := SaveTechnologies(d.ModPath, d.Technologies, d.Columns); err != nil {
+		return err
+	}
+	d.Modified = false
+	return nil
+}
+
+// Revert replaces the technologies after a reload, dropping unsaved changes
+func (d *TechDocument) Revert(technologies []*domain.Technology) {
+	d.Technologies = technologies
+	d.Columns = nil
+	d.Modified = false
+}
+
+// LocalizationDocument is a localisation (.yml) file open in the
+// localisation editor; edited keys are patched into the file on save
+type LocalizationDocument struct {
+	Path     string
+	Language string
+	Values   map[string]string
+	changed  map[string]bool
+}
+
+// LocalizationDocumentKey is the workspace key of a localisation file
+func LocalizationDocumentKey(path string) string {
+	return "loc:" + filepath.Clean(path)
+}
+
+// LoadLocalizationDocument parses the strings of a localisation file in the
+// language of its header (english if it has none)
+func LoadLocalizationDocument(path string) (*LocalizationDocument, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	language := parser.LocalizationLanguage(string(content))
+	if language == "" {
+		language = "english"
+	}
+
+	values, err := parser.NewLocalizationParser("", "", language).LoadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+	return &LocalizationDocument{
+		Path:     path,
+		Language: language,
+		Values:   values,
+		changed:  make(map[string]bool),
+	}, nil
+}
+
+// Reload parses the file again, dropping unsaved edits
+func (d *LocalizationDocument) Reload() error {
+	fresh, err := LoadLocalizationDocument(d.Path)
+	if err != nil {
+		return err
+	}
+	*d = *fresh
+	return nil
+}
+
+func (d *LocalizationDocument) Key() string        { return LocalizationDocumentKey(d.Path) }
+func (d *LocalizationDocument) Title() string      { return filepath.Base(d.Path) }
+func (d *LocalizationDocument) Kind() DocumentKind { return DocumentLocalization }
+func (d *LocalizationDocument) Dirty() bool        { return len(d.changed) > 0 }
+
+// Keys returns the keys of the file sorted by name
+func (d *LocalizationDocument) Keys() []string {
+	keys := make([]string, 0, len(d.Values))
+	for key := range d.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Set changes (or adds) the string of a key
+func (d *LocalizationDocument) Set(key, value string) {
+	if current, ok := d.Values[key]; ok && current == value {
+		return
+	}
+	d.Values[key] = value
+	d.changed[key] = true
+}
+
+// Changed reports whether the key was edited since the last save
+func (d *LocalizationDocument) Changed(key string) bool {
+	return d.changed[key]
+}
+
+// Save patches the edited keys into the file (.bak backup)
+func (d *LocalizationDocument) Save() error {
+	content, err := os.ReadFile(d.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	values := make(map[string]string, len(d.changed))
+	for key := range d.changed {
+		values[key] = d.Values[key]
+	}
+	patched := parser.PatchLocalization(string(content), d.Language, values)
+	if err := serializer.NewLocalizationWriter().WriteSource(d.Path, patched); err != nil {
+		return fmt.Errorf("failed to save localisation: %w", err)
+	}
+	d.changed = make(map[string]bool)
+	return nil
+}
+
+// IdeaDocumentKey is the workspace key of the idea set
+const IdeaDocumentKey = "ideas"
+
+// IdeaDocument is the idea set edited in the idea editor, tracked by the
+// workspace without a tab; its unsaved changes are the edited idea files
+type IdeaDocument struct {
+	Ideas  *domain.IdeaSet
+	loader *IdeaLoader
+}
+
+// NewIdeaDocument creates a document for an idea set saved with loader
+func NewIdeaDocument(ideas *domain.IdeaSet, loader *IdeaLoader) *IdeaDocument {
+	return &IdeaDocument{Ideas: ideas, loader: loader}
+}
+
+func (d *IdeaDocument) Key() string        { return IdeaDocumentKey }
+func (d *IdeaDocument) Title() string      { return "National spirits" }
+func (d *IdeaDocument) Kind() DocumentKind { return DocumentIdeas }
+func (d *IdeaDocument) Dirty() bool        { return len(d.Ideas.DirtyFiles()) > 0 }
+
+// Save writes the edited idea files into the mod
+func (d *IdeaDocument) Save() error {
+	for _, file := range d.Ideas.DirtyFiles() {
+		if err := d.loader.Save(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecisionDocumentKey is the workspace key of the decision set
+const DecisionDocumentKey = "decisions"
+
+// DecisionDocument is the decision set edited in the decision editor,
+// tracked by the workspace without a tab; its unsaved changes are the
+// edited decision and category files
+type DecisionDocument struct {
+	Decisions *domain.DecisionSet
+	loader    *DecisionLoader
+}
+
+// NewDecisionDocument creates a document for a decision set saved with loader
+func NewDecisionDocument(decisions *domain.DecisionSet, loader *DecisionLoader) *DecisionDocument {
+	return &DecisionDocument{Decisions: decisions, loader: loader}
+}
+
+func (d *DecisionDocument) Key() string        { return DecisionDocumentKey }
+func (d *DecisionDocument) Title() string      { return "Decisions" }
+func (d *DecisionDocument) Kind() DocumentKind { return DocumentDecisions }
+
+func (d *DecisionDocument) Dirty() bool {
+	return len(d.Decisions.DirtyFiles())+len(d.Decisions.DirtyCategoryFiles()) > 0
+}
+
+// Save writes the edited decision and category files into the mod
+func (d *DecisionDocument) Save() error {
+	for _, file := range d.Decisions.DirtyFiles() {
+		if err := d.loader.Save(file); err != nil {
+			return err
+		}
+	}
+	for _, file := range d.Decisions.DirtyCategoryFiles() {
+		if err := d.loader.SaveCategories(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+)
+
+// Unit folders relative to the mod/game root
+const (
+	unitsDir     = "common/units"
+	equipmentDir = "common/units/equipment"
+)
+
+// EquipmentLoader loads common/units/equipment and common/units files
+type EquipmentLoader struct {
+	modPath  string
+	gamePath string
+}
+
+// NewEquipmentLoader creates a new equipment loader
+func NewEquipmentLoader(modPath, gamePath string) *EquipmentLoader {
+	return &EquipmentLoader{
+		modPath:  modPath,
+		gamePath: gamePath,
+	}
+}
+
+// LoadAll loads equipment and sub-unit files from game and mod (see loadLayered)
+func (el *EquipmentLoader) LoadAll() (*domain.EquipmentSet, error) {
+	equipmentFiles := loadLayered(el.modPath, el.gamePath, equipmentDir, func(path, source string) (*domain.EquipmentFile, error) {
+		file, err := ParseEquipmentFile(path)
+		if err == nil {
+			file.Source = source
+		}
+		return file, err
+	})
+	unitFiles := loadLayered(el.modPath, el.gamePath, unitsDir, func(path, source string) (*domain.SubUnitFile, error) {
+		file, err := ParseSubUnitFile(path)
+		if err == nil {
+			file.Source = source
+		}
+		return file, err
+	})
+	if len(equipmentFiles) == 0 && len(unitFiles) == 0 {
+		return domain.NewEquipmentSet(nil, nil), fmt.Errorf("no equipment or unit files found in mod or game")
+	}
+
+	set := domain.NewEquipmentSet(equipmentFiles, unitFiles)
+	equipment, units := set.Count()
+	println("Loaded", equipment, "equipment and", units, "sub-units from", len(equipmentFiles)+len(unitFiles), "files")
+	return set, nil
+}
+
+// ParseEquipmentFile parses a single common/units/equipment file
+func ParseEquipmentFile(path string) (*domain.EquipmentFile, error) {
+	program, err := parseScriptFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &domain.EquipmentFile{
+		Path:      path,
+		RelPath:   equipmentDir + "/" + filepath.Base(path),
+		Equipment: parser.NewEquipmentParser().ParseEquipment(program),
+	}
+	for _, equipment := range file.Equipment {
+		equipment.File = file
+	}
+
+	return file, nil
+}
+
+// ParseSubUnitFile parses a single common/units file
+func ParseSubUnitFile(path string) (*domain.SubUnitFile, error) {
+	program, err := parseScriptFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &domain.SubUnitFile{
+		Path:     path,
+		RelPath:  unitsDir + "/" + filepath.Base(path),
+		SubUnits: parser.NewEquipmentParser().ParseSubUnits(program),
+	}
+	for _, unit := range file.SubUnits {
+		unit.File = file
+	}
+
+	return file, nil
+}
+
+// EquipmentUnlockedBy returns the technologies whose enable_equipments lists the equipment
+func EquipmentUnlockedBy(technologies []*domain.Technology, equipmentID string) []*domain.Technology {
+	unlocking := make([]*domain.Technology, 0)
+	for _, tech := range technologies {
+		for _, id := range tech.EnableEquipments {
+			if id == equipmentID {
+				unlocking = append(unlocking, tech)
+				break
+			}
+		}
+	}
+	sort.Slice(unlocking, func(i, j int) bool { return unlocking[i].ID < unlocking[j].ID })
+	return unlocking
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+)
+
+// eventsDir is the event folder relative to the mod/game root
+const eventsDir = "events"
+
+// EventLoader loads events/*.txt files
+type EventLoader struct {
+	modPath  string
+	gamePath string
+}
+
+// NewEventLoader creates a new event loader
+func NewEventLoader(modPath, gamePath string) *EventLoader {
+	return &EventLoader{
+		modPath:  modPath,
+		gamePath: gamePath,
+	}
+}
+
+// LoadAll loads event files from game and mod (see loadLayered)
+func (el *EventLoader) LoadAll() (*domain.EventSet, error) {
+	files := loadLayered(el.modPath, el.gamePath, eventsDir, func(path, source string) (*domain.EventFile, error) {
+		file, err := ParseEventFile(path)
+		if err == nil {
+			file.Source = source
+		}
+		return file, err
+	})
+	if len(files) == 0 {
+		return domain.NewEventSet(nil), fmt.Errorf("no event files found in mod or game")
+	}
+
+	set := domain.NewEventSet(files)
+	println("Loaded", set.Count(), "events from", len(files), "files")
+	return set, nil
+}
+
+// ParseEventFile parses a single events file
+func ParseEventFile(path string) (*domain.EventFile, error) {
+	program, err := parseScriptFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces, events := parser.NewEventParser().ParseEvents(program)
+	file := &domain.EventFile{
+		Path:       path,
+		RelPath:    eventsDir + "/" + filepath.Base(path),
+		Namespaces: namespaces,
+		Events:     events,
+	}
+	for _, event := range events {
+		event.File = file
+	}
+
+	return file, nil
+}
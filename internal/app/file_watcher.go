@@ -0,0 +1,173 @@
+package app
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileChangeKind is how a watched file changed
+type FileChangeKind string
+
+const (
+	FileCreated  FileChangeKind = "created"
+	FileModified FileChangeKind = "modified"
+	FileRemoved  FileChangeKind = "removed"
+)
+
+// FileChange is a watched file that changed on disk
+type FileChange struct {
+	Path string
+	Kind FileChangeKind
+}
+
+// fileStamp identifies a version of a file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// FileWatcher polls directory trees for script and localisation files that
+// were created, modified or removed. A change is reported once the file
+// stays the same for one poll, so half-written files are not re-parsed
+type FileWatcher struct {
+	roots      []string
+	extensions map[string]bool
+
+	mu       sync.Mutex
+	snapshot map[string]fileStamp
+	pending  map[string]fileStamp // Changed since the snapshot, not yet stable
+
+	changes chan []FileChange
+	stop    chan struct{}
+	once    sync.Once
+}
+
+// NewFileWatcher creates a watcher over the roots for files with the given
+// extensions (".txt", ".yml"); the files found by the first poll are the
+// baseline, so creating a watcher never walks the directories
+func NewFileWatcher(roots []string, extensions ...string) *FileWatcher {
+	w := &FileWatcher{
+		roots:      roots,
+		extensions: make(map[string]bool, len(extensions)),
+		pending:    make(map[string]fileStamp),
+		changes:    make(chan []FileChange, 16),
+		stop:       make(chan struct{}),
+	}
+	for _, ext := range extensions {
+		w.extensions[strings.ToLower(ext)] = true
+	}
+	return w
+}
+
+// Roots returns the watched directories
+func (w *FileWatcher) Roots() []string {
+	return w.roots
+}
+
+// scan returns the stamps of the watched files
+func (w *FileWatcher) scan() map[string]fileStamp {
+	stamps := make(map[string]fileStamp)
+	for _, root := range w.roots {
+		filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return nil // Unreadable entries are skipped
+			}
+			if entry.IsDir() {
+				if path != root && strings.HasPrefix(entry.Name(), ".") {
+					return filepath.SkipDir // .git and editor folders
+				}
+				return nil
+			}
+			if !w.extensions[strings.ToLower(filepath.Ext(path))] {
+				return nil
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return nil
+			}
+			stamps[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+	}
+	return stamps
+}
+
+// Poll compares the files with the last poll and returns the changes that
+// are stable, sorted by path
+func (w *FileWatcher) Poll() []FileChange {
+	current := w.scan()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	changes := make([]FileChange, 0)
+	if w.snapshot == nil {
+		w.snapshot = current
+		return changes
+	}
+	report := func(path string, kind FileChangeKind) {
+		changes = append(changes, FileChange{Path: path, Kind: kind})
+		delete(w.pending, path)
+	}
+	for path, stamp := range current {
+		old, known := w.snapshot[path]
+		if known && old == stamp {
+			delete(w.pending, path)
+			continue
+		}
+		if pending, ok := w.pending[path]; !ok || pending != stamp {
+			w.pending[path] = stamp // Wait for the writer to finish
+			continue
+		}
+		w.snapshot[path] = stamp
+		if known {
+			report(path, FileModified)
+		} else {
+			report(path, FileCreated)
+		}
+	}
+	for path := range w.snapshot {
+		if _, exists := current[path]; !exists {
+			delete(w.snapshot, path)
+			report(path, FileRemoved)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// Start polls in the background every interval; changes arrive on Changes()
+func (w *FileWatcher) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+				if changes := w.Poll(); len(changes) > 0 {
+					select {
+					case w.changes <- changes:
+					case <-w.stop:
+						return
+					}
+				}
+			}
+		}
+	}()
+}
+
+// Changes returns the channel of background poll results
+func (w *FileWatcher) Changes() <-chan []FileChange {
+	return w.changes
+}
+
+// Stop ends background polling
+func (w *FileWatcher) Stop() {
+	w.once.Do(func() { close(w.stop) })
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/serializer"
+)
+
+// LoadFocusFile parses a national focus file and returns its focuses in file order
+func LoadFocusFile(filePath string) ([]*domain.Focus, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return ParseFocusSource(string(content))
+}
+
+// ParseFocusSource parses the source of a national focus file
+func ParseFocusSource(source string) ([]*domain.Focus, error) {
+	p := parser.NewParser(source)
+	program, err := p.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+
+	focuses, err := parser.NewFocusParser().ParseFocusTree(program)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse focus tree: %w", err)
+	}
+
+	return focuses, nil
+}
+
+// NewFocusTreeFromFocuses builds a focus tree from a parsed focus list
+func NewFocusTreeFromFocuses(id string, focuses []*domain.Focus) *domain.FocusTree {
+	tree := domain.NewFocusTree(id)
+	for _, focus := range focuses {
+		tree.AddFocus(focus)
+	}
+	return tree
+}
+
+// SaveFocuses writes the positions, prerequisites, mutually exclusive
+// focuses and icons of the focuses back into their focus file, keeping the
+// rest of the file as it is (.bak backup)
+func SaveFocuses(filePath string, focuses []*domain.Focus) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	patched, err := parser.PatchFocusPositions(string(content), focuses)
+	if err != nil {
+		return fmt.Errorf("failed to patch positions: %w", err)
+	}
+	patched, err = parser.PatchFocusLinks(patched, focuses)
+	if err != nil {
+		return fmt.Errorf("failed to patch links: %w", err)
+	}
+	if err := serializer.NewFocusWriter().WriteSource(filePath, patched); err != nil {
+		return fmt.Errorf("failed to save focuses: %w", err)
+	}
+	return nil
+}
+
+// LoadAllFocusFiles parses the national focus files of game and mod, keyed by
+// file name; a mod file with the same name replaces the game file (as in HOI4)
+func LoadAllFocusFiles(modPath, gamePath string) map[string][]*domain.Focus {
+	files := make(map[string][]*domain.Focus)
+
+	for _, basePath := range []string{gamePath, modPath} {
+		if basePath == "" {
+			continue
+		}
+		infos, err := NewFileScanner(basePath).ScanFocusFiles()
+		if err != nil {
+			continue
+		}
+		for _, info := range infos {
+			focuses, err := LoadFocusFile(info.Path)
+			if err != nil {
+				println("Warning: Failed to parse", info.Path, ":", err.Error())
+				continue
+			}
+			files[info.Name] = focuses
+		}
+	}
+
+	return files
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// IconSource filters catalog entries by where the sprite was defined
+type IconSource int
+
+const (
+	IconSourceAll IconSource = iota
+	IconSourceMod
+	IconSourceGame
+)
+
+// String returns the display name of the source filter
+func (s IconSource) String() string {
+	switch s {
+	case IconSourceMod:
+		return "Mod"
+	case IconSourceGame:
+		return "Vanilla"
+	default:
+		return "All"
+	}
+}
+
+// Next cycles to the next source filter (All -> Mod -> Vanilla -> All)
+func (s IconSource) Next() IconSource {
+	return (s + 1) % 3
+}
+
+// IconEntry is a single sprite in the icon catalog
+type IconEntry struct {
+	Sprite *domain.Sprite
+	UsedBy int // Number of focuses, ideas, decisions and technologies referencing this sprite
+}
+
+// IconFilter selects catalog entries
+type IconFilter struct {
+	Prefix     string // Case-insensitive name prefix ("GFX_" is optional)
+	Source     IconSource
+	UnusedOnly bool
+}
+
+// IconCatalog lists registered sprites with usage counts
+type IconCatalog struct {
+	registry *SpriteRegistry
+	usage    map[string]int // sprite name -> references
+}
+
+// NewIconCatalog creates a catalog over a sprite registry
+func NewIconCatalog(registry *SpriteRegistry) *IconCatalog {
+	return &IconCatalog{
+		registry: registry,
+		usage:    make(map[string]int),
+	}
+}
+
+// IconReferences are the objects whose sprites count as icon usage
+type IconReferences struct {
+	Focuses      []*domain.Focus // Of every focus file, not only the open tree
+	Technologies []*domain.Technology
+	CountryTag   string // Country of the technologies (GFX_<TAG>_<tech>_medium)
+	Ideas        []*domain.Idea
+	Decisions    []*domain.Decision
+}
+
+// CountUsage recomputes usage counts from focus, idea and decision icons and
+// technology sprites
+func (c *IconCatalog) CountUsage(refs IconReferences) {
+	c.usage = make(map[string]int)
+
+	for _, focus := range refs.Focuses {
+		if focus.Icon != "" {
+			c.usage[focus.Icon]++
+		}
+	}
+	for _, idea := range refs.Ideas {
+		c.usage[idea.SpriteName()]++
+	}
+	for _, decision := range refs.Decisions {
+		c.usage[decision.SpriteName()]++
+	}
+
+	// A technology uses the first sprite that resolves in lookup order
+	for _, tech := range refs.Technologies {
+		for _, name := range TechSpriteNames(tech.ID, refs.CountryTag) {
+			if _, ok := c.registry.GetSprite(name); ok {
+				c.usage[name]++
+				break
+			}
+		}
+	}
+}
+
+// UsageCount returns how many objects reference a sprite
+func (c *IconCatalog) UsageCount(name string) int {
+	return c.usage[name]
+}
+
+// Entries returns sprites matching the filter, sorted by name
+func (c *IconCatalog) Entries(filter IconFilter) []IconEntry {
+	prefix := strings.ToLower(filter.Prefix)
+	entries := make([]IconEntry, 0)
+
+	for _, name := range c.registry.Names() {
+		sprite, _ := c.registry.GetSprite(name)
+
+		if prefix != "" {
+			lower := strings.ToLower(name)
+			if !strings.HasPrefix(lower, prefix) && !strings.HasPrefix(strings.TrimPrefix(lower, "gfx_"), prefix) {
+				continue
+			}
+		}
+
+		switch filter.Source {
+		case IconSourceMod:
+			if sprite.Source != "mod" {
+				continue
+			}
+		case IconSourceGame:
+			if sprite.Source != "game" {
+				continue
+			}
+		}
+
+		used := c.usage[name]
+		if filter.UnusedOnly && used > 0 {
+			continue
+		}
+
+		entries = append(entries, IconEntry{Sprite: sprite, UsedBy: used})
+	}
+
+	return entries
+}
+
+// UnusedModIcons returns mod-defined sprites nobody references
+func (c *IconCatalog) UnusedModIcons() []string {
+	names := make([]string, 0)
+	for _, entry := range c.Entries(IconFilter{Source: IconSourceMod, UnusedOnly: true}) {
+		names = append(names, entry.Sprite.Name)
+	}
+	return names
+}
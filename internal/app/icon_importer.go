@@ -0,0 +1,254 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/serializer"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/texture"
+)
+
+// Standard icon dimensions used by vanilla textures
+const (
+	FocusIconWidth  = 94
+	FocusIconHeight = 86
+	TechIconWidth   = 64
+	TechIconHeight  = 64
+)
+
+// IconKind selects the import target
+type IconKind int
+
+const (
+	IconKindFocus IconKind = iota
+	IconKindTech
+)
+
+// IconImportOptions describes a single PNG import
+type IconImportOptions struct {
+	SourcePath string              // PNG (or any decodable image) to import
+	Name       string              // Texture base name; defaults to the source file name
+	Kind       IconKind            // Focus icon (goals/) or technology icon (technologies/)
+	TechID     string              // Technology ID for IconKindTech (sprite GFX_<tech>_medium)
+	Encoding   texture.DDSEncoding // DXT5 or uncompressed
+	GfxFile    string              // Mod-relative .gfx file; defaults to interface/goals_imported.gfx or technologies_imported.gfx
+}
+
+// IconImporter converts images to DDS and declares them in the mod's .gfx files
+type IconImporter struct {
+	modPath  string
+	registry *SpriteRegistry
+	writer   *serializer.GfxWriter
+}
+
+// NewIconImporter creates an importer writing into modPath
+// Imported sprites are registered in registry so they resolve immediately
+func NewIconImporter(modPath string, registry *SpriteRegistry) *IconImporter {
+	return &IconImporter{
+		modPath:  modPath,
+		registry: registry,
+		writer:   serializer.NewGfxWriter(),
+	}
+}
+
+// Import converts the image, writes the DDS and the spriteType entries; a
+// sprite or texture the mod already has is refused rather than overwritten
+func (im *IconImporter) Import(opts IconImportOptions) (*domain.Sprite, error) {
+	if im.modPath == "" {
+		return nil, fmt.Errorf("mod path not set")
+	}
+
+	name := opts.Name
+	if name == "" && opts.Kind == IconKindTech {
+		name = opts.TechID
+	}
+	if name == "" {
+		base := filepath.Base(opts.SourcePath)
+		name = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	name = sanitizeIconName(name)
+	if name == "" {
+		return nil, fmt.Errorf("invalid icon name")
+	}
+
+	img, err := texture.DecodeFile(opts.SourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", opts.SourcePath, err)
+	}
+
+	var folder, spriteName, gfxFile string
+	var width, height int
+	switch opts.Kind {
+	case IconKindTech:
+		if opts.TechID == "" {
+			return nil, fmt.Errorf("technology ID required for tech icon import")
+		}
+		folder = "gfx/interface/technologies"
+		spriteName = "GFX_" + opts.TechID + "_medium"
+		gfxFile = "interface/technologies_imported.gfx"
+		width, height = TechIconWidth, TechIconHeight
+	default:
+		folder = "gfx/interface/goals"
+		spriteName = "GFX_" + name
+		gfxFile = "interface/goals_imported.gfx"
+		width, height = FocusIconWidth, FocusIconHeight
+	}
+	if opts.GfxFile != "" {
+		gfxFile = opts.GfxFile
+	}
+
+	textureFile := folder + "/" + name + ".dds"
+	texturePath := filepath.Join(im.modPath, filepath.FromSlash(textureFile))
+
+	sprite := domain.NewSprite(spriteName, textureFile)
+	sprite.Source = "mod"
+	sprite.GfxFile = filepath.Join(im.modPath, filepath.FromSlash(gfxFile))
+
+	// Check every name and file first so a refused import writes nothing
+	if im.registry != nil {
+		if existing, ok := im.registry.GetSprite(sprite.Name); ok && existing.Source == "mod" {
+			return nil, fmt.Errorf("%s is already declared by the mod (%s)", sprite.Name, existing.GfxFile)
+		}
+	}
+	if _, err := os.Stat(texturePath); err == nil {
+		return nil, fmt.Errorf("%s already exists in the mod", textureFile)
+	}
+	gfxSource, err := im.appendSprite(sprite.GfxFile, sprite.Name, im.writer.WriteSprite(sprite))
+	if err != nil {
+		return nil, err
+	}
+
+	// Completed focuses use the "_shine" variant
+	var shineFile, shineSource string
+	if opts.Kind == IconKindFocus {
+		shineFile = strings.TrimSuffix(sprite.GfxFile, ".gfx") + "_shine.gfx"
+		if shineSource, err = im.appendSprite(shineFile, sprite.Name+"_shine", im.writer.WriteShineSprite(sprite)); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := texture.EncodeDDS(texture.Fit(img, width, height), opts.Encoding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode DDS: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(texturePath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create texture folder: %w", err)
+	}
+	if err := os.WriteFile(texturePath, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write texture: %w", err)
+	}
+
+	// A failed .gfx write undoes the files written before it, so the import
+	// can be retried
+	undo := []func(){func() { os.Remove(texturePath) }}
+	rollback := func() {
+		for i := len(undo) - 1; i >= 0; i-- {
+			undo[i]()
+		}
+	}
+	for _, gfx := range []struct{ path, source string }{{sprite.GfxFile, gfxSource}, {shineFile, shineSource}} {
+		if gfx.path == "" {
+			continue
+		}
+		restore := restoreFile(gfx.path)
+		if err := im.writer.WriteSource(gfx.path, gfx.source); err != nil {
+			rollback()
+			return nil, fmt.Errorf("failed to write %s: %w", filepath.Base(gfx.path), err)
+		}
+		undo = append(undo, restore)
+	}
+
+	if im.registry != nil {
+		im.registry.Register(sprite)
+	}
+
+	return sprite, nil
+}
+
+// DeclareTechIcon makes a technology use the texture of an existing sprite.
+// It declares the sprite name the technology resolves first for the country
+// (GFX_<tech>_medium unless a country-specific sprite exists) in the mod's
+// technologies_imported.gfx, so the choice is kept after a restart; names
+// already declared by the mod are refused
+func (im *IconImporter) DeclareTechIcon(techID, countryTag string, sprite *domain.Sprite) (*domain.Sprite, error) {
+	if im.modPath == "" {
+		return nil, fmt.Errorf("mod path not set")
+	}
+
+	name := "GFX_" + techID + "_medium"
+	if im.registry != nil {
+		if current, ok := im.registry.ResolveTechIcon(techID, countryTag); ok {
+			if current.Name == sprite.Name {
+				return sprite, nil // Already the sprite of the technology (just imported)
+			}
+			if current.Source == "mod" {
+				return nil, fmt.Errorf("%s is already declared by the mod (%s)", current.Name, current.GfxFile)
+			}
+			name = current.Name
+		}
+	}
+
+	alias := *sprite
+	alias.Name = name
+	alias.Source = "mod"
+	alias.GfxFile = filepath.Join(im.modPath, filepath.FromSlash("interface/technologies_imported.gfx"))
+
+	source, err := im.appendSprite(alias.GfxFile, alias.Name, im.writer.WriteSprite(&alias))
+	if err != nil {
+		return nil, err
+	}
+	if err := im.writer.WriteSource(alias.GfxFile, source); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", filepath.Base(alias.GfxFile), err)
+	}
+
+	if im.registry != nil {
+		im.registry.Register(&alias)
+	}
+	return &alias, nil
+}
+
+// appendSprite returns the source of a .gfx file with a sprite block added;
+// a name already declared in the file is an error
+func (im *IconImporter) appendSprite(path, name, block string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read gfx file: %w", err)
+	}
+
+	source, added, err := parser.AppendSprite(string(content), name, block)
+	if err != nil {
+		return "", fmt.Errorf("failed to update %s: %w", filepath.Base(path), err)
+	}
+	if !added {
+		return "", fmt.Errorf("%s is already declared in %s", name, filepath.Base(path))
+	}
+	return source, nil
+}
+
+// restoreFile returns a function putting back the current content of path,
+// or removing it when it does not exist yet
+func restoreFile(path string) func() {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return func() { os.Remove(path) }
+	}
+	return func() { os.WriteFile(path, content, 0644) }
+}
+
+// sanitizeIconName keeps letters, digits and underscores (spaces become "_")
+func sanitizeIconName(name string) string {
+	var sb strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			sb.WriteRune(r)
+		case r == ' ' || r == '-':
+			sb.WriteRune('_')
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,171 @@
+package app
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+func TestIconImporterImport(t *testing.T) {
+	modPath := t.TempDir()
+	source := filepath.Join(t.TempDir(), "My Goal.png")
+	img := image.NewNRGBA(image.Rect(0, 0, 40, 30))
+	img.Set(1, 1, color.NRGBA{255, 0, 0, 255})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(source, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := NewSpriteRegistry(modPath, "")
+	importer := NewIconImporter(modPath, registry)
+	sprite, err := importer.Import(IconImportOptions{SourcePath: source})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sprite.Name != "GFX_My_Goal" || sprite.TextureFile != "gfx/interface/goals/My_Goal.dds" {
+		t.Errorf("Imported %s -> %s", sprite.Name, sprite.TextureFile)
+	}
+
+	texturePath := filepath.Join(modPath, "gfx", "interface", "goals", "My_Goal.dds")
+	written, err := os.ReadFile(texturePath)
+	if err != nil {
+		t.Fatalf("Texture not written: %v", err)
+	}
+
+	reloaded := NewSpriteRegistry(modPath, "")
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"GFX_My_Goal", "GFX_My_Goal_shine"} {
+		if declared, ok := reloaded.GetSprite(name); !ok || declared.TextureFile != sprite.TextureFile {
+			t.Errorf("%s declared as %v", name, declared)
+		}
+	}
+
+	// A second import of the same name is refused and leaves the texture
+	if _, err := importer.Import(IconImportOptions{SourcePath: source}); err == nil {
+		t.Error("Second import succeeded")
+	}
+	if _, err := NewIconImporter(modPath, nil).Import(IconImportOptions{SourcePath: source}); err == nil {
+		t.Error("Second import without a registry succeeded")
+	}
+	if current, _ := os.ReadFile(texturePath); !bytes.Equal(current, written) {
+		t.Error("Refused import changed the texture")
+	}
+}
+
+func TestIconImporterImportRollback(t *testing.T) {
+	modPath := t.TempDir()
+	source := filepath.Join(t.TempDir(), "goal.png")
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, source, buf.String())
+
+	// A folder in place of the backup makes the _shine .gfx write fail
+	shineFile := filepath.Join(modPath, "interface", "goals_imported_shine.gfx")
+	writeTestFile(t, shineFile, "spriteTypes = {\n}\n")
+	if err := os.Mkdir(shineFile+".bak", 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	importer := NewIconImporter(modPath, nil)
+	if _, err := importer.Import(IconImportOptions{SourcePath: source}); err == nil {
+		t.Fatal("Import succeeded")
+	}
+	for _, path := range []string{
+		filepath.Join(modPath, "gfx", "interface", "goals", "goal.dds"),
+		filepath.Join(modPath, "interface", "goals_imported.gfx"),
+	} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s left after the failed import", filepath.Base(path))
+		}
+	}
+
+	if err := os.Remove(shineFile + ".bak"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := importer.Import(IconImportOptions{SourcePath: source}); err != nil {
+		t.Errorf("Retry failed: %v", err)
+	}
+}
+
+func TestDeclareTechIcon(t *testing.T) {
+	modPath := t.TempDir()
+	registry := NewSpriteRegistry(modPath, "")
+	game := domain.NewSprite("GFX_GER_tech_a_medium", "gfx/interface/technologies/ger_tech_a.dds")
+	game.Source = "game"
+	registry.Register(game)
+
+	picked := domain.NewSprite("GFX_goal_generic", "gfx/interface/goals/goal_generic.dds")
+	importer := NewIconImporter(modPath, registry)
+
+	// The country-specific sprite resolves first, so it is the one declared
+	alias, err := importer.DeclareTechIcon("tech_a", "GER", picked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alias.Name != "GFX_GER_tech_a_medium" || alias.TextureFile != picked.TextureFile {
+		t.Errorf("Declared %s -> %s", alias.Name, alias.TextureFile)
+	}
+
+	// Declared in the mod: a fresh registry resolves it after a restart
+	reloaded := NewSpriteRegistry(modPath, "")
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if sprite, ok := reloaded.ResolveTechIcon("tech_a", "GER"); !ok || sprite.TextureFile != picked.TextureFile {
+		t.Errorf("After reload resolved %v, %v", sprite, ok)
+	}
+
+	if _, err := importer.DeclareTechIcon("tech_a", "GER", picked); err == nil {
+		t.Error("Declaring a sprite the mod already declares succeeded")
+	}
+
+	if _, err := importer.DeclareTechIcon("tech_b", "", picked); err != nil {
+		t.Fatal(err)
+	}
+	content, _ := os.ReadFile(filepath.Join(modPath, "interface", "technologies_imported.gfx"))
+	if reloaded := NewSpriteRegistry(modPath, ""); reloaded.Load() != nil {
+		t.Fatalf("Failed to parse:\n%s", content)
+	} else if _, ok := reloaded.GetSprite("GFX_tech_b_medium"); !ok {
+		t.Errorf("GFX_tech_b_medium not declared:\n%s", content)
+	}
+}
+
+func TestIconCatalogCountUsage(t *testing.T) {
+	registry := NewSpriteRegistry("", "")
+	registry.Register(domain.NewSprite("GFX_tech_a_medium", "a.dds"))
+	catalog := NewIconCatalog(registry)
+
+	idea := &domain.Idea{ID: "spirit", Picture: "generic_army"}
+	decision := domain.NewDecision("build", "economy")
+	decision.Icon = "generic_construction"
+	catalog.CountUsage(IconReferences{
+		Focuses:      []*domain.Focus{{ID: "a", Icon: "GFX_goal_generic"}, {ID: "b", Icon: "GFX_goal_generic"}},
+		Technologies: []*domain.Technology{{ID: "tech_a"}},
+		Ideas:        []*domain.Idea{idea},
+		Decisions:    []*domain.Decision{decision},
+	})
+
+	for name, want := range map[string]int{
+		"GFX_goal_generic":                  2,
+		"GFX_tech_a_medium":                 1,
+		"GFX_idea_generic_army":             1,
+		"GFX_decision_generic_construction": 1,
+	} {
+		if got := catalog.UsageCount(name); got != want {
+			t.Errorf("UsageCount(%s) = %d, want %d", name, got, want)
+		}
+	}
+}
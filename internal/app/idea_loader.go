@@ -0,0 +1,115 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/serializer"
+)
+
+// ideasDir is the idea folder relative to the mod/game root
+const ideasDir = "common/ideas"
+
+// IdeaLoader loads and saves common/ideas files
+type IdeaLoader struct {
+	modPath  string
+	gamePath string
+}
+
+// NewIdeaLoader creates a new idea loader
+func NewIdeaLoader(modPath, gamePath string) *IdeaLoader {
+	return &IdeaLoader{
+		modPath:  modPath,
+		gamePath: gamePath,
+	}
+}
+
+// LoadAll loads idea files from game and mod (see loadLayered); ideas with
+// the same ID in different files resolve to the mod/last file
+func (il *IdeaLoader) LoadAll() (*domain.IdeaSet, error) {
+	files := loadLayered(il.modPath, il.gamePath, ideasDir, func(path, source string) (*domain.IdeaFile, error) {
+		file, err := ParseIdeaFile(path)
+		if err == nil {
+			file.Source = source
+		}
+		return file, err
+	})
+	if len(files) == 0 {
+		return domain.NewIdeaSet(nil), fmt.Errorf("no idea files found in mod or game")
+	}
+
+	set := domain.NewIdeaSet(files)
+	println("Loaded", set.Count(), "ideas from", len(files), "files")
+	return set, nil
+}
+
+// ParseIdeaFile parses a single ideas file
+func ParseIdeaFile(path string) (*domain.IdeaFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	program, err := parser.NewParser(string(content)).Parse()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+
+	categories, err := parser.NewIdeaParser().ParseIdeas(program)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &domain.IdeaFile{
+		Path:       path,
+		RelPath:    ideasDir + "/" + filepath.Base(path),
+		Categories: categories,
+	}
+	for _, idea := range file.Ideas() {
+		idea.File = file
+	}
+
+	return file, nil
+}
+
+// Save writes an idea file into the mod; game files become mod overrides
+// with the same name so the game loads the edited copy instead
+func (il *IdeaLoader) Save(file *domain.IdeaFile) error {
+	if il.modPath == "" {
+		return fmt.Errorf("mod path not set")
+	}
+
+	target := filepath.Join(il.modPath, filepath.FromSlash(file.RelPath))
+	if err := serializer.NewIdeaWriter().WriteToFile(file, target); err != nil {
+		return fmt.Errorf("failed to save %s: %w", file.RelPath, err)
+	}
+
+	file.Path = target
+	file.Source = "mod"
+	file.Dirty = false
+	return nil
+}
+
+// IdeaGrantedBy returns the focuses whose completion reward adds the idea
+func IdeaGrantedBy(tree *domain.FocusTree, ideaID string) []*domain.Focus {
+	focuses := make([]*domain.Focus, 0)
+	if tree == nil {
+		return focuses
+	}
+
+	for _, focus := range tree.Focuses {
+		for _, id := range focus.RewardIdeas {
+			if id == ideaID {
+				focuses = append(focuses, focus)
+				break
+			}
+		}
+	}
+	sort.Slice(focuses, func(i, j int) bool { return focuses[i].ID < focuses[j].ID })
+
+	return focuses
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+)
+
+// loadLayered parses the .txt files directly inside dir (relative to the game
+// and mod roots) with parse, which receives the path and "game" or "mod".
+// A mod file with the same name replaces the game file (as in HOI4); the
+// files are returned game files first, then mod files, each by name, so
+// entries of later files win when they are merged into a set
+func loadLayered[T any](modPath, gamePath, dir string, parse func(path, source string) (T, error)) []T {
+	type layered struct {
+		file   T
+		source string
+		name   string
+	}
+	files := make(map[string]layered) // file name -> file
+
+	for _, layer := range []struct{ path, source string }{{gamePath, "game"}, {modPath, "mod"}} {
+		if layer.path == "" {
+			continue
+		}
+		paths, err := filepath.Glob(filepath.Join(layer.path, filepath.FromSlash(dir), "*.txt"))
+		if err != nil {
+			continue
+		}
+
+		results, errs := parser.ParseFiles(paths, func(path string) (T, error) {
+			return parse(path, layer.source)
+		})
+		for i, path := range paths {
+			if errs[i] != nil {
+				println("Warning: Failed to parse", path, ":", errs[i].Error())
+				continue
+			}
+			name := filepath.Base(path)
+			files[name] = layered{file: results[i], source: layer.source, name: name}
+		}
+	}
+
+	ordered := make([]layered, 0, len(files))
+	for _, file := range files {
+		ordered = append(ordered, file)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].source != ordered[j].source {
+			return ordered[i].source == "game"
+		}
+		return ordered[i].name < ordered[j].name
+	})
+
+	result := make([]T, len(ordered))
+	for i, file := range ordered {
+		result[i] = file.file
+	}
+	return result
+}
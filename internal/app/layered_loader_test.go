@@ -0,0 +1,41 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadLayered(t *testing.T) {
+	gamePath, modPath := t.TempDir(), t.TempDir()
+	for path, content := range map[string]string{
+		filepath.Join(gamePath, "common", "x", "b.txt"):        "game b",
+		filepath.Join(gamePath, "common", "x", "a.txt"):        "game a",
+		filepath.Join(gamePath, "common", "x", "broken.txt"):   "broken",
+		filepath.Join(gamePath, "common", "x", "sub", "c.txt"): "not in the folder",
+		filepath.Join(modPath, "common", "x", "a.txt"):         "mod a",
+		filepath.Join(modPath, "common", "x", "0.txt"):         "mod 0",
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files := loadLayered(modPath, gamePath, "common/x", func(path, source string) (string, error) {
+		content, err := os.ReadFile(path)
+		if err != nil || string(content) == "broken" {
+			return "", errors.New("parse error")
+		}
+		return source + ":" + string(content), nil
+	})
+
+	// Game files first, then mod files, each by name; mod a.txt replaces game a.txt
+	if got := strings.Join(files, ", "); got != "game:game b, mod:mod 0, mod:mod a" {
+		t.Errorf("loadLayered() = %s", got)
+	}
+}
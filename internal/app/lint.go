@@ -0,0 +1,42 @@
+package app
+
+import (
+	"path/filepath"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/lint"
+)
+
+// NewLintEngine creates a lint engine configured by a rules file; an empty
+// rulesPath uses hoi4lint.txt in the mod root (missing file = defaults)
+func NewLintEngine(modPath, rulesPath string) (*lint.Engine, error) {
+	if rulesPath == "" && modPath != "" {
+		rulesPath = filepath.Join(modPath, lint.ConfigFile)
+	}
+
+	engine := lint.NewEngine()
+	if rulesPath == "" {
+		return engine, nil
+	}
+	config, err := lint.LoadConfig(rulesPath)
+	if err != nil {
+		return engine, err
+	}
+	return engine, engine.SetConfig(config)
+}
+
+// SetLintAssets enables the localisation and icon rules: localisation keys
+// and sprites of the registry (technology icons as seen by a country tag)
+func SetLintAssets(input *lint.Input, localisation map[string]string, sprites *SpriteRegistry, tag string) {
+	input.Localisation = localisation
+	if sprites == nil {
+		return
+	}
+	input.FocusIcon = func(icon string) bool {
+		_, ok := sprites.ResolveFocusIcon(icon)
+		return ok
+	}
+	input.TechIcon = func(techID string) bool {
+		_, ok := sprites.ResolveTechIcon(techID, tag)
+		return ok
+	}
+}
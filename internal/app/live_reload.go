@@ -0,0 +1,198 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+)
+
+// ReloadResult describes what a batch of file changes reloaded
+type ReloadResult struct {
+	Changes      []FileChange
+	Technologies bool     // AllTechnologies changed
+	TechFolders  bool     // Available technology folders were re-resolved
+	Localisation bool     // Localisation strings changed
+	FocusFiles   []string // Changed national focus files
+	Errors       []string // Files that failed to parse (their previous data is kept)
+}
+
+// Changed reports whether a file is among the changes
+func (r *ReloadResult) Changed(path string) bool {
+	for _, change := range r.Changes {
+		if filepath.Clean(change.Path) == filepath.Clean(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary returns a one-line description of the reload
+func (r *ReloadResult) Summary() string {
+	names := make([]string, 0, len(r.Changes))
+	for i, change := range r.Changes {
+		if i == 3 {
+			names = append(names, fmt.Sprintf("+%d more", len(r.Changes)-3))
+			break
+		}
+		names = append(names, filepath.Base(change.Path))
+	}
+	summary := fmt.Sprintf("Reloaded %d changed file(s): %s", len(r.Changes), strings.Join(names, ", "))
+	if len(r.Errors) > 0 {
+		summary += fmt.Sprintf(" (%d failed to parse, previous version kept)", len(r.Errors))
+	}
+	return summary
+}
+
+// modFileArea returns the directory of a mod file relative to the mod root
+// ("common/technologies", "localisation/english", ...)
+func modFileArea(modPath, path string) string {
+	rel, err := filepath.Rel(modPath, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	return filepath.ToSlash(filepath.Dir(rel))
+}
+
+// Reload re-parses the changed mod files the context caches: technology
+// files, localisation files, the national focus path, and the inputs of the
+// technology folder filter (country history, technology tags, scripted
+// triggers). Only changed files are parsed, except when a technology was
+// dropped from a mod file (the game version may reappear)
+func (ctx *CountryContext) Reload(changes []FileChange) *ReloadResult {
+	result := &ReloadResult{Changes: changes}
+	fullTechReload, fullLocReload, resolveFolders, reloadFlags := false, false, false, false
+
+	for _, change := range changes {
+		area := modFileArea(ctx.ModPath, change.Path)
+		switch {
+		case area == "common/technologies":
+			result.Technologies = true
+			if !ctx.reloadTechnologyFile(change, result) {
+				fullTechReload = true
+			}
+		case area == "localisation" || strings.HasPrefix(area, "localisation/"):
+			result.Localisation = true
+			if change.Kind == FileRemoved || !ctx.reloadLocalisationFile(change.Path, result) {
+				fullLocReload = true
+			}
+		case area == "common/national_focus":
+			result.FocusFiles = append(result.FocusFiles, change.Path)
+			if change.Kind != FileModified {
+				ctx.resolveFocusPath()
+			}
+		case area == "history/countries":
+			reloadFlags, resolveFolders = true, true
+		case area == "common/technology_tags" || area == "common/scripted_triggers":
+			resolveFolders = true
+		}
+	}
+
+	if fullTechReload {
+		ctx.loadAllTechnologies()
+	}
+	if fullLocReload {
+		ctx.loadLocalizations()
+	}
+	if reloadFlags {
+		ctx.loadCountryFlags()
+	}
+	if resolveFolders {
+		ctx.Scripted = LoadScriptedLibrary(ctx.ModPath, ctx.GamePath)
+		ctx.resolveTechFolders()
+		result.TechFolders = true
+	}
+	if ctx.Cache != nil {
+		if err := ctx.Cache.Save(); err != nil {
+			println("Warning: Failed to save parse cache:", err.Error())
+		}
+	}
+	return result
+}
+
+// reloadTechnologyFile replaces the technologies of one mod file; false
+// means a full reload is needed
+func (ctx *CountryContext) reloadTechnologyFile(change FileChange, result *ReloadResult) bool {
+	parsed := make([]*domain.Technology, 0)
+	if change.Kind != FileRemoved {
+		technologies, err := NewTechnologyLoader(ctx.ModPath, ctx.GamePath).SetCache(ctx.Cache).loadTechnologyFile(change.Path)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", filepath.Base(change.Path), err))
+			return true
+		}
+		parsed = technologies
+	}
+
+	ids := make(map[string]bool, len(parsed))
+	for _, tech := range parsed {
+		ids[tech.ID] = true
+	}
+	kept := make([]*domain.Technology, 0, len(ctx.AllTechnologies))
+	for _, tech := range ctx.AllTechnologies {
+		if filepath.Clean(tech.File) == filepath.Clean(change.Path) {
+			if !ids[tech.ID] {
+				return false
+			}
+			continue
+		}
+		if !ids[tech.ID] { // Mod technologies override the game's by ID
+			kept = append(kept, tech)
+		}
+	}
+	ctx.AllTechnologies = append(kept, parsed...)
+	return true
+}
+
+// reloadLocalisationFile overlays the strings of a changed mod localisation
+// file; false means a full reload is needed (a key was deleted from the
+// file, and the game or another file may define it)
+func (ctx *CountryContext) reloadLocalisationFile(path string, result *ReloadResult) bool {
+	localizations, err := parser.NewLocalizationParser(ctx.ModPath, ctx.GamePath, "english").LoadFile(path)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", filepath.Base(path), err))
+		return true
+	}
+	for _, key := range ctx.localisationKeys[filepath.Clean(path)] {
+		if _, ok := localizations[key]; !ok {
+			return false
+		}
+	}
+	for key, value := range localizations {
+		ctx.Localizations[key] = value
+	}
+	if ctx.localisationKeys == nil {
+		ctx.localisationKeys = make(map[string][]string)
+	}
+	ctx.localisationKeys[filepath.Clean(path)] = localisationKeys(localizations)
+	return true
+}
+
+// ApplyFileChanges reloads the data affected by changed mod files: the
+// country context incrementally, and the lazily loaded sets that hold no
+// in-app edits (ideas and decisions keep their unsaved changes)
+func (s *State) ApplyFileChanges(changes []FileChange) *ReloadResult {
+	result := &ReloadResult{Changes: changes}
+	if s.CountryContext != nil {
+		result = s.CountryContext.Reload(changes)
+	}
+
+	for _, change := range changes {
+		area := modFileArea(s.GetModPath(), change.Path)
+		switch {
+		case area == "events":
+			s.Events = nil
+		case area == "common/characters":
+			s.Characters = nil
+		case strings.HasPrefix(area, "common/units"):
+			s.Equipment = nil
+		case area == "common/scripted_triggers" || area == "common/scripted_effects":
+			s.Scripted = nil
+		case area == "common/technologies" || area == "common/national_focus":
+			s.Provenance = nil
+			s.Research = nil
+		}
+	}
+	return result
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestReloadLocalisationDropsDeletedKeys(t *testing.T) {
+	modPath, gamePath := t.TempDir(), t.TempDir()
+	modFile := filepath.Join(modPath, "localisation", "english", "test_l_english.yml")
+	writeTestFile(t, filepath.Join(gamePath, "localisation", "english", "game_l_english.yml"),
+		"l_english:\n KEY_SHARED:0 \"Game\"\n")
+	writeTestFile(t, modFile, "l_english:\n KEY_KEPT:0 \"Kept\"\n KEY_DELETED:0 \"Deleted\"\n KEY_SHARED:0 \"Mod\"\n")
+
+	ctx := &CountryContext{ModPath: modPath, GamePath: gamePath}
+	ctx.loadLocalizations()
+	if ctx.Localizations["KEY_DELETED"] != "Deleted" || ctx.Localizations["KEY_SHARED"] != "Mod" {
+		t.Fatalf("Localisation not loaded: %v", ctx.Localizations)
+	}
+
+	writeTestFile(t, modFile, "l_english:\n KEY_KEPT:0 \"Edited\"\n")
+	result := ctx.Reload([]FileChange{{Path: modFile, Kind: FileModified}})
+	if !result.Localisation {
+		t.Error("Reload did not report localisation")
+	}
+	if _, ok := ctx.Localizations["KEY_DELETED"]; ok {
+		t.Error("Deleted key still localised")
+	}
+	if got := ctx.Localizations["KEY_SHARED"]; got != "Game" {
+		t.Errorf("KEY_SHARED = %q, want the game string back", got)
+	}
+	if got := ctx.Localizations["KEY_KEPT"]; got != "Edited" {
+		t.Errorf("KEY_KEPT = %q, want %q", got, "Edited")
+	}
+
+	// Adding a key is applied without a full reload
+	writeTestFile(t, modFile, "l_english:\n KEY_KEPT:0 \"Edited\"\n KEY_NEW:0 \"New\"\n")
+	if !ctx.reloadLocalisationFile(modFile, result) || ctx.Localizations["KEY_NEW"] != "New" {
+		t.Errorf("New key not overlaid: %v", ctx.Localizations)
+	}
+}
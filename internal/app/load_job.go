@@ -0,0 +1,172 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrCancelled is returned by loads that were cancelled
+var ErrCancelled = errors.New("loading cancelled")
+
+// maxProgressErrors caps the errors kept by a progress
+const maxProgressErrors = 50
+
+// LoadProgress is a snapshot of a loading job
+type LoadProgress struct {
+	Stage      string   // What is being loaded
+	FilesDone  int      // Files parsed (or failed)
+	FilesTotal int      // Files known so far (0: unknown)
+	Current    string   // Last file started
+	Errors     []string // Files that failed to parse
+	Cancelled  bool
+}
+
+// Fraction returns the share of files done (0 if the total is unknown)
+func (p LoadProgress) Fraction() float64 {
+	if p.FilesTotal == 0 {
+		return 0
+	}
+	return float64(p.FilesDone) / float64(p.FilesTotal)
+}
+
+// Progress collects the progress of a loading job from any goroutine and
+// carries its cancellation; a nil *Progress reports nothing and is never
+// cancelled
+type Progress struct {
+	mu        sync.Mutex
+	state     LoadProgress
+	cancelled atomic.Bool
+}
+
+// NewProgress creates an empty progress
+func NewProgress() *Progress {
+	return &Progress{}
+}
+
+// SetStage names what is being loaded
+func (p *Progress) SetStage(stage string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state.Stage = stage
+}
+
+// AddFiles adds files to the total
+func (p *Progress) AddFiles(count int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state.FilesTotal += count
+}
+
+// fileStarted records the file being parsed
+func (p *Progress) fileStarted(path string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state.Current = filepath.Base(path)
+}
+
+// fileDone counts a parsed file and records its error
+func (p *Progress) fileDone(path string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state.FilesDone++
+	if err != nil && !errors.Is(err, ErrCancelled) && len(p.state.Errors) < maxProgressErrors {
+		p.state.Errors = append(p.state.Errors, fmt.Sprintf("%s: %v", filepath.Base(path), err))
+	}
+}
+
+// Cancel asks the job to stop; files not started yet are skipped
+func (p *Progress) Cancel() {
+	if p != nil {
+		p.cancelled.Store(true)
+	}
+}
+
+// Cancelled reports whether the job was asked to stop
+func (p *Progress) Cancelled() bool {
+	return p != nil && p.cancelled.Load()
+}
+
+// Snapshot returns the current progress
+func (p *Progress) Snapshot() LoadProgress {
+	if p == nil {
+		return LoadProgress{}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	snapshot := p.state
+	snapshot.Errors = append([]string{}, p.state.Errors...)
+	snapshot.Cancelled = p.cancelled.Load()
+	return snapshot
+}
+
+// trackFile wraps a file parser to report progress and to skip files once
+// the job is cancelled
+func trackFile[T any](progress *Progress, parse func(string) (T, error)) func(string) (T, error) {
+	if progress == nil {
+		return parse
+	}
+	return func(path string) (T, error) {
+		if progress.Cancelled() {
+			var zero T
+			progress.fileDone(path, ErrCancelled)
+			return zero, ErrCancelled
+		}
+		progress.fileStarted(path)
+		value, err := parse(path)
+		progress.fileDone(path, err)
+		return value, err
+	}
+}
+
+// LoadJob runs a load in the background; the result is handed over once
+// Done reports true, so it is only touched by the goroutine that polls
+type LoadJob[T any] struct {
+	Progress *Progress
+	done     chan struct{}
+	result   T
+	err      error
+}
+
+// StartLoadJob starts load on its own goroutine
+func StartLoadJob[T any](load func(progress *Progress) (T, error)) *LoadJob[T] {
+	job := &LoadJob[T]{Progress: NewProgress(), done: make(chan struct{})}
+	go func() {
+		defer close(job.done)
+		job.result, job.err = load(job.Progress)
+		if job.err == nil && job.Progress.Cancelled() {
+			job.err = ErrCancelled
+		}
+	}()
+	return job
+}
+
+// Done reports whether the load finished (without blocking)
+func (j *LoadJob[T]) Done() bool {
+	select {
+	case <-j.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Result waits for the load and returns its result (ErrCancelled if it was
+// cancelled)
+func (j *LoadJob[T]) Result() (T, error) {
+	<-j.done
+	return j.result, j.err
+}
+
+// Cancel asks the load to stop
+func (j *LoadJob[T]) Cancel() {
+	j.Progress.Cancel()
+}
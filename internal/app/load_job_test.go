@@ -0,0 +1,46 @@
+package app
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLoadJob(t *testing.T) {
+	game := t.TempDir()
+	writeTechnologies(t, game, 6, 3)
+
+	load := func(progress *Progress) ([]string, error) {
+		progress.SetStage("Technologies")
+		technologies, err := NewTechnologyLoader("", game).SetProgress(progress).LoadAllTechnologies()
+		ids := make([]string, len(technologies))
+		for i, tech := range technologies {
+			ids[i] = tech.ID
+		}
+		return ids, err
+	}
+
+	job := StartLoadJob(load)
+	ids, err := job.Result()
+	if err != nil || len(ids) != 18 || !job.Done() {
+		t.Fatalf("Result() = %d technologies, %v", len(ids), err)
+	}
+	snapshot := job.Progress.Snapshot()
+	if snapshot.Stage != "Technologies" || snapshot.FilesDone != 6 || snapshot.FilesTotal != 6 || snapshot.Fraction() != 1 || len(snapshot.Errors) != 0 {
+		t.Errorf("Unexpected progress: %+v", snapshot)
+	}
+
+	// A cancelled job skips the remaining files and reports ErrCancelled
+	progress := NewProgress()
+	progress.Cancel()
+	ids, _ = load(progress)
+	if len(ids) != 0 || progress.Snapshot().FilesDone != 6 || len(progress.Snapshot().Errors) != 0 {
+		t.Errorf("Cancelled load parsed %d technologies: %+v", len(ids), progress.Snapshot())
+	}
+	job = StartLoadJob(func(progress *Progress) ([]string, error) {
+		progress.Cancel()
+		return load(progress)
+	})
+	if _, err := job.Result(); !errors.Is(err, ErrCancelled) {
+		t.Errorf("Expected ErrCancelled, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/serializer"
+)
+
+// MergeFiles merges the mod copy of a game file (oursPath) with the changes
+// between the old (basePath) and the new game version (theirsPath)
+func MergeFiles(basePath, oursPath, theirsPath string) (*domain.MergeFile, error) {
+	sources := make([]string, 3)
+	for i, path := range []string{basePath, oursPath, theirsPath} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		sources[i] = string(content)
+	}
+	return parser.MergeScripts(sources[0], sources[1], sources[2])
+}
+
+// SaveMerge writes the merged file (.bak backup): the mod copy with the
+// game changes spliced in; all conflicts must be resolved
+func SaveMerge(file *domain.MergeFile, path string) error {
+	source, err := parser.MergeSource(file)
+	if err != nil {
+		return err
+	}
+	if err := serializer.NewMergeWriter().WriteSource(path, source); err != nil {
+		return fmt.Errorf("failed to save merge: %w", err)
+	}
+	return nil
+}
+
+// GameCounterpart returns the game file a mod file overrides (the same path
+// under the game root), or "" if the game has no such file
+func GameCounterpart(modPath, gamePath, filePath string) string {
+	rel, err := filepath.Rel(modPath, filePath)
+	if err != nil || strings.HasPrefix(rel, "..") || gamePath == "" {
+		return ""
+	}
+	counterpart := filepath.Join(gamePath, rel)
+	if _, err := os.Stat(counterpart); err != nil {
+		return ""
+	}
+	return counterpart
+}
@@ -66,6 +66,11 @@ func DetectBasePath(filePath string) (string, error) {
 		basePath = parts[0] + string(filepath.Separator) + filepath.Join(parts[1:commonIndex]...)
 	}
 
+	// On Unix the leading empty part drops the root separator
+	if filepath.IsAbs(filePath) && !filepath.IsAbs(basePath) {
+		basePath = string(filepath.Separator) + basePath
+	}
+
 	return basePath, nil
 }
 
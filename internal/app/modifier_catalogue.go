@@ -0,0 +1,90 @@
+package app
+
+import (
+	_ "embed"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+)
+
+// modifierDocumentation is the game's modifier reference relative to the install
+const modifierDocumentation = "documentation/modifiers_documentation.md"
+
+// bundledModifiers is the fallback catalogue shipped with the editor
+//
+//go:embed modifiers.txt
+var bundledModifiers string
+
+// LoadModifierCatalogue builds the modifier catalogue from the bundled list
+// and, when present, the game's modifiers_documentation.md (which adds
+// modifiers newer than the bundled list and overrides their category/format;
+// bundled display names are kept)
+func LoadModifierCatalogue(gamePath string) *domain.ModifierCatalogue {
+	catalogue := domain.NewModifierCatalogue(parser.ParseModifierList(bundledModifiers))
+
+	if gamePath != "" {
+		content, err := os.ReadFile(filepath.Join(gamePath, filepath.FromSlash(modifierDocumentation)))
+		if err == nil {
+			documented := parser.ParseModifierDocumentation(string(content))
+			for _, definition := range documented {
+				if existing, ok := catalogue.Get(definition.Name); ok && definition.Display == "" {
+					definition.Display = existing.Display
+				}
+				catalogue.Add(definition)
+			}
+			println("Loaded", len(documented), "modifiers from", modifierDocumentation)
+		}
+	}
+
+	return catalogue
+}
+
+// ModifierIssues checks every modifier used by technologies, ideas and
+// focus rewards against the catalogue; issues are sorted by source
+func ModifierIssues(catalogue *domain.ModifierCatalogue, technologies []*domain.Technology, ideas *domain.IdeaSet, tree *domain.FocusTree) []domain.ModifierIssue {
+	issues := make([]domain.ModifierIssue, 0)
+	check := func(modifier, source string) {
+		if issue := catalogue.Check(modifier, source); issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+
+	for _, tech := range technologies {
+		for category, modifiers := range tech.Effects {
+			source := "technology " + tech.ID
+			if category != "" {
+				source += " (" + category + ")"
+			}
+			for modifier := range modifiers {
+				check(modifier, source)
+			}
+		}
+	}
+
+	if ideas != nil {
+		for _, idea := range ideas.All() {
+			for _, modifier := range idea.Modifiers {
+				check(modifier.Key, "idea "+idea.ID)
+			}
+		}
+	}
+
+	if tree != nil {
+		for _, focus := range tree.Focuses {
+			for _, modifier := range focus.RewardModifiers {
+				check(modifier.Key, "focus "+focus.ID)
+			}
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Source != issues[j].Source {
+			return issues[i].Source < issues[j].Source
+		}
+		return issues[i].Modifier < issues[j].Modifier
+	})
+	return issues
+}
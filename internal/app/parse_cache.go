@@ -0,0 +1,173 @@
+package app
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// parseCacheVersion is stored with every cache table; bump it when a parser
+// changes what it produces so stale results are dropped
+const parseCacheVersion = 2
+
+// Cache tables (one file each in the cache directory)
+const (
+	cacheTechnologies = "technologies"
+	cacheLocalisation = "localisation"
+	cacheHistory      = "history"
+)
+
+// cacheEntry is the parsed result of one file version
+type cacheEntry struct {
+	Size    int64
+	ModTime int64  // Unix nanoseconds
+	Data    []byte // gob-encoded result
+}
+
+// cacheTable holds the entries of one kind of file by path
+type cacheTable struct {
+	Version int
+	Entries map[string]cacheEntry
+	dirty   bool
+}
+
+// ParseCache keeps parsed results of game and mod files on disk, keyed by
+// path, size, modification time and parser version, so unchanged files are
+// not parsed again; it is safe for concurrent use
+type ParseCache struct {
+	dir    string
+	mu     sync.Mutex
+	tables map[string]*cacheTable
+}
+
+// NewParseCache creates a cache stored in dir; tables are read on first use
+func NewParseCache(dir string) *ParseCache {
+	return &ParseCache{dir: dir, tables: make(map[string]*cacheTable)}
+}
+
+// DefaultCacheDir returns the cache directory next to the config file
+func DefaultCacheDir() (string, error) {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), "cache"), nil
+}
+
+// table returns a table, reading it from disk on first use (c.mu held)
+func (c *ParseCache) table(kind string) *cacheTable {
+	if table, ok := c.tables[kind]; ok {
+		return table
+	}
+	table := &cacheTable{Version: parseCacheVersion, Entries: make(map[string]cacheEntry)}
+	if file, err := os.Open(c.tablePath(kind)); err == nil {
+		var stored cacheTable
+		if gob.NewDecoder(file).Decode(&stored) == nil && stored.Version == parseCacheVersion && stored.Entries != nil {
+			table.Entries = stored.Entries
+		}
+		file.Close()
+	}
+	c.tables[kind] = table
+	return table
+}
+
+// tablePath returns the file of a table
+func (c *ParseCache) tablePath(kind string) string {
+	return filepath.Join(c.dir, kind+".gob")
+}
+
+// get returns the cached data of a file if it has not changed
+func (c *ParseCache) get(kind, path string, info os.FileInfo) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.table(kind).Entries[path]
+	if !ok || entry.Size != info.Size() || entry.ModTime != info.ModTime().UnixNano() {
+		return nil, false
+	}
+	return entry.Data, true
+}
+
+// put stores the data of a file version
+func (c *ParseCache) put(kind, path string, info os.FileInfo, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	table := c.table(kind)
+	table.Entries[path] = cacheEntry{Size: info.Size(), ModTime: info.ModTime().UnixNano(), Data: data}
+	table.dirty = true
+}
+
+// Save writes the changed tables to disk
+func (c *ParseCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for kind, table := range c.tables {
+		if !table.dirty {
+			continue
+		}
+		if err := os.MkdirAll(c.dir, 0755); err != nil {
+			return fmt.Errorf("failed to create cache directory: %w", err)
+		}
+		// Write a temporary file first so a crash never leaves a torn table
+		tmp := c.tablePath(kind) + ".tmp"
+		file, err := os.Create(tmp)
+		if err != nil {
+			return fmt.Errorf("failed to write cache: %w", err)
+		}
+		err = gob.NewEncoder(file).Encode(table)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(tmp, c.tablePath(kind))
+		}
+		if err != nil {
+			os.Remove(tmp)
+			return fmt.Errorf("failed to write cache: %w", err)
+		}
+		table.dirty = false
+	}
+	return nil
+}
+
+// Clear removes the cache from memory and disk
+func (c *ParseCache) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tables = make(map[string]*cacheTable)
+	if err := os.RemoveAll(c.dir); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
+
+// cachedParse returns the cached result of a file, or parses it and caches
+// the result; every call returns a fresh copy, so callers may modify it.
+// A nil cache only parses
+func cachedParse[T any](cache *ParseCache, kind, path string, parse func(string) (T, error)) (T, error) {
+	if cache == nil {
+		return parse(path)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return parse(path)
+	}
+	if data, ok := cache.get(kind, path, info); ok {
+		var value T
+		if gob.NewDecoder(bytes.NewReader(data)).Decode(&value) == nil {
+			return value, nil
+		}
+	}
+
+	value, err := parse(path)
+	if err != nil {
+		return value, err
+	}
+	var buf bytes.Buffer
+	if gob.NewEncoder(&buf).Encode(value) == nil {
+		cache.put(kind, path, info, buf.Bytes())
+	}
+	return value, nil
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTechnologies writes a mod or game root with files technology files
+// of perFile technologies each
+func writeTechnologies(tb testing.TB, root string, files, perFile int) {
+	tb.Helper()
+	dir := filepath.Join(root, "common", "technologies")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		tb.Fatal(err)
+	}
+	for f := 0; f < files; f++ {
+		var source strings.Builder
+		source.WriteString("technologies = {\n")
+		for t := 0; t < perFile; t++ {
+			fmt.Fprintf(&source, "\ttech_%d_%d = { research_cost = 2 folder = { name = test_folder position = { x = %d y = %d } } }\n", f, t, t%10, t)
+		}
+		source.WriteString("}\n")
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("%02d_technologies.txt", f)), []byte(source.String()), 0644); err != nil {
+			tb.Fatal(err)
+		}
+	}
+}
+
+func TestParseCache(t *testing.T) {
+	game, cacheDir := t.TempDir(), t.TempDir()
+	writeTechnologies(t, game, 3, 4)
+
+	parses := 0
+	parse := func(path string) ([]string, error) {
+		parses++
+		return []string{filepath.Base(path)}, nil
+	}
+	file := filepath.Join(game, "common", "technologies", "00_technologies.txt")
+
+	cache := NewParseCache(cacheDir)
+	for i := 0; i < 2; i++ {
+		if value, err := cachedParse(cache, cacheTechnologies, file, parse); err != nil || value[0] != "00_technologies.txt" {
+			t.Fatalf("cachedParse() = %v, %v", value, err)
+		}
+	}
+	if parses != 1 {
+		t.Errorf("Parsed %d times, want 1", parses)
+	}
+
+	// A new cache reads the saved table
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	cache = NewParseCache(cacheDir)
+	cachedParse(cache, cacheTechnologies, file, parse)
+	if parses != 1 {
+		t.Errorf("Saved result not used, parsed %d times", parses)
+	}
+
+	// A changed file is parsed again
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(file, later, later); err != nil {
+		t.Fatal(err)
+	}
+	cachedParse(cache, cacheTechnologies, file, parse)
+	if parses != 2 {
+		t.Errorf("Changed file not parsed again, parsed %d times", parses)
+	}
+
+	// Loaded technologies are fresh copies with their maps set
+	loader := NewTechnologyLoader("", game).SetCache(cache)
+	first, err := loader.LoadAllTechnologies()
+	if err != nil || len(first) != 12 {
+		t.Fatalf("LoadAllTechnologies() = %d technologies, %v", len(first), err)
+	}
+	second, _ := NewTechnologyLoader("", game).SetCache(cache).LoadAllTechnologies()
+	first[0].Position.X = 99
+	for _, tech := range second {
+		if tech.Position.X == 99 {
+			t.Error("Cached technologies are shared between loads")
+		}
+		if tech.Effects == nil || tech.AIResearchWeights == nil || tech.ResearchCost != 2 || tech.File == "" {
+			t.Errorf("Unexpected cached technology: %+v", tech)
+		}
+	}
+}
+
+func BenchmarkLoadAllTechnologies(b *testing.B) {
+	game := b.TempDir()
+	writeTechnologies(b, game, 40, 60)
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewTechnologyLoader("", game).LoadAllTechnologies()
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		cache := NewParseCache(b.TempDir())
+		NewTechnologyLoader("", game).SetCache(cache).LoadAllTechnologies()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			NewTechnologyLoader("", game).SetCache(cache).LoadAllTechnologies()
+		}
+	})
+	b.Run("cached-from-disk", func(b *testing.B) {
+		dir := b.TempDir()
+		cache := NewParseCache(dir)
+		NewTechnologyLoader("", game).SetCache(cache).LoadAllTechnologies()
+		if err := cache.Save(); err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			NewTechnologyLoader("", game).SetCache(NewParseCache(dir)).LoadAllTechnologies()
+		}
+	})
+}
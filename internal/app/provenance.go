@@ -0,0 +1,62 @@
+package app
+
+import (
+	"path/filepath"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// LoadProvenance records for every technology and focus whether it comes
+// from the game, is a vanilla ID overridden by the mod, or is new in the mod
+// (cache may be nil)
+func LoadProvenance(modPath, gamePath string, cache *ParseCache) *domain.ProvenanceReport {
+	report := domain.NewProvenanceReport()
+	NewTechnologyLoader(modPath, gamePath).SetCache(cache).AddProvenance(report)
+	report.AddFocuses(loadFocusFiles(modPath), loadFocusFiles(gamePath))
+	return report
+}
+
+// AddProvenance records the technologies of the mod and the game separately
+// (LoadAllTechnologies merges them, mod first)
+func (tl *TechnologyLoader) AddProvenance(report *domain.ProvenanceReport) {
+	report.AddTechnologies(tl.technologiesFromPath(tl.modPath), tl.technologiesFromPath(tl.gamePath))
+}
+
+// technologiesFromPath returns the technologies of common/technologies of a
+// mod or game root (none if the path is empty or has no technologies)
+func (tl *TechnologyLoader) technologiesFromPath(basePath string) []*domain.Technology {
+	technologies := make([]*domain.Technology, 0)
+	if basePath == "" {
+		return technologies
+	}
+	fileMap, err := tl.loadTechnologiesFromPath(basePath)
+	if err != nil {
+		return technologies
+	}
+	for _, techs := range fileMap {
+		technologies = append(technologies, techs...)
+	}
+	return technologies
+}
+
+// loadFocusFiles parses every focus file of common/national_focus of a mod
+// or game root (file path -> focuses)
+func loadFocusFiles(basePath string) map[string][]*domain.Focus {
+	result := make(map[string][]*domain.Focus)
+	if basePath == "" {
+		return result
+	}
+	files, err := filepath.Glob(filepath.Join(basePath, "common", "national_focus", "*.txt"))
+	if err != nil {
+		return result
+	}
+	for _, file := range files {
+		focuses, err := LoadFocusFile(file)
+		if err != nil {
+			println("Warning: Failed to parse", file, ":", err.Error())
+			continue
+		}
+		result[file] = focuses
+	}
+	return result
+}
@@ -0,0 +1,208 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+)
+
+// definesDir holds the NDefines Lua files relative to the mod/game root
+const definesDir = "common/defines"
+
+// researchDefinePattern matches the NTechnology defines used for research time
+var researchDefinePattern = regexp.MustCompile(`(?m)^\s*(BASE_TECH_COST|BASE_YEAR_AHEAD_PENALTY_FACTOR|MIN_RESEARCH_SPEED)\s*=\s*([0-9.]+)`)
+
+// researchSpeedModifier is the modifier that speeds up all research
+const researchSpeedModifier = "research_speed_factor"
+
+// LoadResearchDefines reads the research defines from common/defines/*.lua
+// (game first, then mod overrides); missing values keep the vanilla defaults
+func LoadResearchDefines(modPath, gamePath string) domain.ResearchDefines {
+	defines := domain.DefaultResearchDefines()
+
+	for _, basePath := range []string{gamePath, modPath} {
+		if basePath == "" {
+			continue
+		}
+		paths, _ := filepath.Glob(filepath.Join(basePath, filepath.FromSlash(definesDir), "*.lua"))
+		for _, path := range paths {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			for _, match := range researchDefinePattern.FindAllStringSubmatch(string(content), -1) {
+				value, err := strconv.ParseFloat(match[2], 64)
+				if err != nil {
+					continue
+				}
+				switch match[1] {
+				case "BASE_TECH_COST":
+					defines.BaseTechCost = value
+				case "BASE_YEAR_AHEAD_PENALTY_FACTOR":
+					defines.AheadPenaltyPerYear = value
+				case "MIN_RESEARCH_SPEED":
+					defines.MinResearchSpeed = value
+				}
+			}
+		}
+	}
+
+	return defines
+}
+
+// ResearchSetup is the research context of the selected country
+type ResearchSetup struct {
+	Defines    domain.ResearchDefines
+	Input      domain.ResearchInput
+	Sources    []string        // Research speed sources ("idea x: +5%")
+	Researched map[string]bool // Technologies set in the country history
+
+	// Technology sharing groups and the assumed number of group members
+	// that have already researched a technology
+	Sharing          []*TechSharingStatus
+	SharingCountries int
+
+	technologies []*domain.Technology
+}
+
+// NewResearchSetup collects the country's start date and research speed
+// from its starting ideas (bookmark and history) and starting technologies,
+// and evaluates the technology sharing groups for it
+func NewResearchSetup(ctx *CountryContext, ideas *domain.IdeaSet) *ResearchSetup {
+	setup := &ResearchSetup{
+		Defines:          LoadResearchDefines(ctx.ModPath, ctx.GamePath),
+		Input:            domain.ResearchInput{Date: domain.ParseScriptDate(ctx.Country.Date)},
+		Sources:          make([]string, 0),
+		Researched:       make(map[string]bool),
+		SharingCountries: 1,
+		technologies:     ctx.AllTechnologies,
+	}
+
+	history := CountryHistoryStart(ctx.ModPath, ctx.GamePath, ctx.GetTag())
+	for _, id := range history.Technologies {
+		setup.Researched[id] = true
+	}
+
+	addSource := func(kind, id string, value float64) {
+		setup.Input.SpeedBonus += value
+		setup.Sources = append(setup.Sources, fmt.Sprintf("%s %s: %s", kind, id, domain.FormatModifierValue(value, domain.ModifierPercent)))
+	}
+
+	startIdeas := append(append([]string{}, ctx.Country.Ideas...), history.Ideas...)
+	seen := make(map[string]bool)
+	for _, id := range startIdeas {
+		if seen[id] || ideas == nil {
+			continue
+		}
+		seen[id] = true
+		if idea, ok := ideas.Get(id); ok {
+			if modifier, ok := idea.GetModifier(researchSpeedModifier); ok {
+				if value, ok := modifier.Number(); ok {
+					addSource("idea", id, value)
+				}
+			}
+		}
+	}
+
+	for _, tech := range ctx.AllTechnologies {
+		if value, ok := tech.Effects[""][researchSpeedModifier]; ok && setup.Researched[tech.ID] {
+			addSource("technology", tech.ID, value)
+		}
+	}
+
+	evaluator := NewConditionEvaluator(ctx.CountryFlags)
+	evaluator.SetCountry(ctx.GetTag(), history.RulingParty, startIdeas, ctx.Country.IsMajor)
+	setup.Sharing = EvaluateTechSharing(LoadTechSharingGroups(ctx.ModPath, ctx.GamePath, ctx.Scripted), evaluator)
+
+	return setup
+}
+
+// ToggleSharing joins or leaves a sharing group the country can join
+func (r *ResearchSetup) ToggleSharing(id string) {
+	for _, status := range r.Sharing {
+		if status.Group.ID == id && status.Available {
+			status.Joined = !status.Joined
+		}
+	}
+	r.updateSharingBonus()
+}
+
+// SetSharingCountries sets the assumed number of members that have
+// researched a technology (at least 0)
+func (r *ResearchSetup) SetSharingCountries(countries int) {
+	if countries < 0 {
+		countries = 0
+	}
+	r.SharingCountries = countries
+	r.updateSharingBonus()
+}
+
+// updateSharingBonus sums the per-country bonus of the joined groups
+func (r *ResearchSetup) updateSharingBonus() {
+	r.Input.SharingBonus = 0
+	for _, status := range r.Sharing {
+		if status.Joined {
+			r.Input.SharingBonus += status.Group.PerCountryBonus * float64(r.SharingCountries)
+		}
+	}
+}
+
+// Calculate returns the research time of a technology for the country; path
+// coefficients apply from the technologies researched at the start
+func (r *ResearchSetup) Calculate(tech *domain.Technology) domain.ResearchTime {
+	return domain.CalculateResearchTime(tech, domain.IncomingPathCoeff(r.technologies, tech.ID, r.Researched), r.Input, r.Defines)
+}
+
+// CountryHistory is the start of a country's history file (undated statements)
+type CountryHistory struct {
+	Ideas        []string // add_ideas
+	Technologies []string // set_technology
+	RulingParty  string   // set_politics = { ruling_party = ... }
+}
+
+// CountryHistoryStart reads the start of a country's history file (mod before game)
+func CountryHistoryStart(modPath, gamePath, tag string) CountryHistory {
+	for _, basePath := range []string{modPath, gamePath} {
+		if basePath == "" {
+			continue
+		}
+		path, err := parser.FindCountryHistoryFile(basePath, tag)
+		if err != nil {
+			continue
+		}
+		program, err := parseScriptFile(path)
+		if err != nil {
+			println("Warning: Failed to parse", path, ":", err.Error())
+			break
+		}
+
+		// Only undated statements describe the start of the game
+		undated := make([]parser.Statement, 0, len(program.Statements))
+		history := CountryHistory{}
+		for _, stmt := range program.Statements {
+			assign, ok := stmt.(*parser.AssignmentStatement)
+			if ok && domain.ParseScriptDate(assign.Name.Value) > 0 {
+				continue
+			}
+			undated = append(undated, stmt)
+			if ok && assign.Name.Value == "set_politics" {
+				if block, ok := assign.Value.(*parser.BlockStatement); ok {
+					for _, inner := range block.Statements {
+						if party, ok := inner.(*parser.AssignmentStatement); ok && party.Name.Value == "ruling_party" {
+							history.RulingParty = parser.FormatExpression(party.Value, 0)
+						}
+					}
+				}
+			}
+		}
+		history.Ideas = parser.CollectIdeaReferences(undated)
+		history.Technologies = parser.CollectSetTechnologies(undated)
+		return history
+	}
+	return CountryHistory{}
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+)
+
+// Scripted trigger/effect folders relative to the mod/game root
+const (
+	scriptedTriggersDir = "common/scripted_triggers"
+	scriptedEffectsDir  = "common/scripted_effects"
+)
+
+// LoadScriptedLibrary loads scripted triggers and effects from game and mod
+// A mod file with the same name replaces the game file; definitions in
+// later files (mod files after game files) override earlier ones
+func LoadScriptedLibrary(modPath, gamePath string) *parser.ScriptedLibrary {
+	library := parser.NewScriptedLibrary()
+
+	for _, dir := range []struct {
+		path string
+		kind parser.ScriptedKind
+	}{{scriptedTriggersDir, parser.ScriptedTrigger}, {scriptedEffectsDir, parser.ScriptedEffect}} {
+		files := make(map[string]string) // file name -> path
+		sources := make(map[string]string)
+		for _, layer := range []struct{ path, source string }{{gamePath, "game"}, {modPath, "mod"}} {
+			if layer.path == "" {
+				continue
+			}
+			paths, err := filepath.Glob(filepath.Join(layer.path, filepath.FromSlash(dir.path), "*.txt"))
+			if err != nil {
+				continue
+			}
+			for _, path := range paths {
+				files[filepath.Base(path)] = path
+				sources[filepath.Base(path)] = layer.source
+			}
+		}
+
+		names := make([]string, 0, len(files))
+		for name := range files {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if sources[names[i]] != sources[names[j]] {
+				return sources[names[i]] == "game"
+			}
+			return names[i] < names[j]
+		})
+
+		for _, name := range names {
+			program, err := parseScriptFile(files[name])
+			if err != nil {
+				println("Warning: Failed to parse", files[name], ":", err.Error())
+				continue
+			}
+			library.AddProgram(program, dir.kind, files[name])
+		}
+	}
+
+	triggers, effects := library.Count()
+	println("Loaded", triggers, "scripted triggers and", effects, "scripted effects")
+	return library
+}
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/texture"
+)
+
+// SpriteRegistry resolves sprite names (GFX_*) to texture files
+type SpriteRegistry struct {
+	modPath  string
+	gamePath string
+	sprites  map[string]*domain.Sprite // sprite name -> sprite
+}
+
+// NewSpriteRegistry creates an empty sprite registry
+func NewSpriteRegistry(modPath, gamePath string) *SpriteRegistry {
+	return &SpriteRegistry{
+		modPath:  modPath,
+		gamePath: gamePath,
+		sprites:  make(map[string]*domain.Sprite),
+	}
+}
+
+// Load parses all .gfx files from game and mod
+func (r *SpriteRegistry) Load() error {
+	gfxParser := parser.NewGfxParser(r.modPath, r.gamePath)
+	sprites, err := gfxParser.ParseSprites()
+	if err != nil {
+		println("Warning: Failed to load sprites:", err.Error())
+		return err
+	}
+
+	r.sprites = sprites
+	println("Loaded", len(sprites), "sprites from .gfx files")
+	return nil
+}
+
+// Register adds or replaces a sprite
+func (r *SpriteRegistry) Register(sprite *domain.Sprite) {
+	r.sprites[sprite.Name] = sprite
+}
+
+// GetSprite returns a sprite by name
+func (r *SpriteRegistry) GetSprite(name string) (*domain.Sprite, bool) {
+	sprite, ok := r.sprites[name]
+	return sprite, ok
+}
+
+// Count returns the number of registered sprites
+func (r *SpriteRegistry) Count() int {
+	return len(r.sprites)
+}
+
+// Names returns all sprite names sorted alphabetically
+func (r *SpriteRegistry) Names() []string {
+	names := make([]string, 0, len(r.sprites))
+	for name := range r.sprites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveFocusIcon returns the sprite for a focus icon value (icon = GFX_...)
+func (r *SpriteRegistry) ResolveFocusIcon(icon string) (*domain.Sprite, bool) {
+	return r.GetSprite(icon)
+}
+
+// ResolvePortrait returns the sprite for a character portrait value, which
+// is either a GFX_* sprite name or a texture path (gfx/leaders/GER/x.dds)
+func (r *SpriteRegistry) ResolvePortrait(portrait string) (*domain.Sprite, bool) {
+	if sprite, ok := r.GetSprite(portrait); ok {
+		return sprite, true
+	}
+	if strings.Contains(portrait, "/") {
+		return &domain.Sprite{Name: portrait, TextureFile: portrait}, true
+	}
+	return nil, false
+}
+
+// ResolveTechIcon returns the sprite for a technology
+// Lookup order: GFX_<TAG>_<tech>_medium, GFX_<tech>_medium, GFX_<tech>
+func (r *SpriteRegistry) ResolveTechIcon(techID, countryTag string) (*domain.Sprite, bool) {
+	for _, name := range TechSpriteNames(techID, countryTag) {
+		if sprite, ok := r.GetSprite(name); ok {
+			return sprite, true
+		}
+	}
+	return nil, false
+}
+
+// TechSpriteNames returns candidate sprite names for a technology in lookup order
+func TechSpriteNames(techID, countryTag string) []string {
+	names := make([]string, 0, 3)
+	if countryTag != "" {
+		names = append(names, "GFX_"+countryTag+"_"+techID+"_medium")
+	}
+	names = append(names, "GFX_"+techID+"_medium", "GFX_"+techID)
+	return names
+}
+
+// TexturePath resolves a sprite texture to an absolute file path
+// Mod files take priority over game files; returns "" if not found
+func (r *SpriteRegistry) TexturePath(sprite *domain.Sprite) string {
+	if sprite == nil || sprite.TextureFile == "" {
+		return ""
+	}
+
+	relPath := filepath.FromSlash(sprite.TextureFile)
+	for _, basePath := range []string{r.modPath, r.gamePath} {
+		if basePath == "" {
+			continue
+		}
+		if path := findFileCaseInsensitive(basePath, relPath); path != "" {
+			return path
+		}
+	}
+
+	return ""
+}
+
+// LoadImage decodes the sprite texture and returns its first frame
+func (r *SpriteRegistry) LoadImage(sprite *domain.Sprite) (image.Image, error) {
+	path := r.TexturePath(sprite)
+	if path == "" {
+		return nil, fmt.Errorf("texture not found for sprite %s", sprite.Name)
+	}
+
+	img, err := texture.DecodeFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return texture.Frame(img, sprite.FrameCount(), 0), nil
+}
+
+// findFileCaseInsensitive finds a file under basePath, tolerating case
+// differences (game files are authored on Windows)
+func findFileCaseInsensitive(basePath, relPath string) string {
+	exact := filepath.Join(basePath, relPath)
+	if _, err := os.Stat(exact); err == nil {
+		return exact
+	}
+
+	current := basePath
+	for _, part := range strings.Split(relPath, string(filepath.Separator)) {
+		entries, err := os.ReadDir(current)
+		if err != nil {
+			return ""
+		}
+
+		found := ""
+		for _, entry := range entries {
+			if strings.EqualFold(entry.Name(), part) {
+				found = entry.Name()
+				break
+			}
+		}
+		if found == "" {
+			return ""
+		}
+		current = filepath.Join(current, found)
+	}
+
+	return current
+}
@@ -1,7 +1,11 @@
 package app
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
 )
 
 // State represents the application state
@@ -16,6 +20,44 @@ type State struct {
 	// Country context
 	CountryContext *CountryContext
 
+	// Sprite registry (loaded lazily from interface/*.gfx)
+	SpriteRegistry *SpriteRegistry
+
+	// National spirits, laws and advisors (loaded lazily from common/ideas)
+	Ideas *domain.IdeaSet
+
+	// Decisions and decision categories (loaded lazily from common/decisions)
+	Decisions *domain.DecisionSet
+
+	// Events (loaded lazily from events/*.txt)
+	Events *domain.EventSet
+
+	// Characters (loaded lazily from common/characters)
+	Characters *domain.CharacterSet
+
+	// Scripted triggers and effects (shared with the country context)
+	Scripted *parser.ScriptedLibrary
+
+	// Modifier catalogue (bundled list plus the game's documentation)
+	Modifiers *domain.ModifierCatalogue
+
+	// Equipment and sub-units (loaded lazily from common/units)
+	Equipment *domain.EquipmentSet
+
+	// Research time context of the selected country
+	Research *ResearchSetup
+
+	// Origin of technologies and focuses: game, overridden or new in the mod
+	Provenance *domain.ProvenanceReport
+
+	// Parsed game and mod files kept on disk between runs (nil if the
+	// cache directory is unavailable)
+	Cache *ParseCache
+
+	// Focus trees, technology folders and localisation files open for
+	// editing, with their unsaved changes
+	Workspace *Workspace
+
 	// Project information (legacy, will be replaced)
 	ModPath          string
 	BasePath         string   // Root directory of the mod
@@ -57,11 +99,16 @@ func NewState() *State {
 		config = DefaultConfig()
 	}
 
-	return &State{
+	state := &State{
 		Config:      config,
 		CurrentMode: ModeNone,
 		Zoom:        1.0,
+		Workspace:   NewWorkspace(),
+	}
+	if cacheDir, err := DefaultCacheDir(); err == nil {
+		state.Cache = NewParseCache(cacheDir)
 	}
+	return state
 }
 
 // SetModPath sets the mod directory path
@@ -124,8 +171,12 @@ func (s *State) LoadFile(filePath string) error {
 	return nil
 }
 
-// SetModDescriptor sets the mod descriptor and updates config
+// SetModDescriptor sets the mod descriptor and updates config; it is
+// refused while ideas or decisions have unsaved changes
 func (s *State) SetModDescriptor(mod *ModDescriptor) error {
+	if err := s.resetGameData(); err != nil {
+		return err
+	}
 	s.ModDescriptor = mod
 
 	// Update legacy fields for backwards compatibility
@@ -140,8 +191,12 @@ func (s *State) SetModDescriptor(mod *ModDescriptor) error {
 	return nil
 }
 
-// SetGameInstallation sets the game installation and updates config
+// SetGameInstallation sets the game installation and updates config; it is
+// refused while ideas or decisions have unsaved changes
 func (s *State) SetGameInstallation(game *GameInstallation) error {
+	if err := s.resetGameData(); err != nil {
+		return err
+	}
 	s.GameInstallation = game
 
 	// Save to config
@@ -176,11 +231,26 @@ func (s *State) SetCountryContext(country *domain.BookmarkCountry) {
 	modPath := s.GetModPath()
 	gamePath := s.GetGamePath()
 
-	s.CountryContext = NewCountryContext(country, modPath, gamePath)
+	s.UseCountryContext(NewCountryContext(country, modPath, gamePath, s.Cache))
+}
+
+// StartCountryContext loads the country context in the background; hand
+// the result to UseCountryContext once the job is done
+func (s *State) StartCountryContext(country *domain.BookmarkCountry) *LoadJob[*CountryContext] {
+	modPath, gamePath, cache := s.GetModPath(), s.GetGamePath(), s.Cache
+	return StartLoadJob(func(progress *Progress) (*CountryContext, error) {
+		return LoadCountryContext(country, modPath, gamePath, cache, progress)
+	})
+}
+
+// UseCountryContext makes a loaded country context current (UI goroutine only)
+func (s *State) UseCountryContext(ctx *CountryContext) {
+	s.CountryContext = ctx
+	s.Research = nil
 
 	// Save to config
 	if s.Config != nil {
-		s.Config.UpdateLastCountry(country.Tag)
+		s.Config.UpdateLastCountry(ctx.Country.Tag)
 	}
 }
 
@@ -188,3 +258,139 @@ func (s *State) SetCountryContext(country *domain.BookmarkCountry) {
 func (s *State) GetCountryContext() *CountryContext {
 	return s.CountryContext
 }
+
+// resetGameData drops the data loaded for the current mod and game so it
+// is reloaded for a new one; unsaved idea or decision edits are an error
+// instead of being dropped
+func (s *State) resetGameData() error {
+	for _, key := range []string{IdeaDocumentKey, DecisionDocumentKey} {
+		if doc := s.Workspace.Tracked(key); doc != nil && doc.Dirty() {
+			return fmt.Errorf("%s have unsaved changes - save them in their editor first", strings.ToLower(doc.Title()))
+		}
+	}
+	s.Workspace.Untrack(IdeaDocumentKey)
+	s.Workspace.Untrack(DecisionDocumentKey)
+
+	s.SpriteRegistry = nil
+	s.Ideas = nil
+	s.Decisions = nil
+	s.Events = nil
+	s.Characters = nil
+	s.Scripted = nil
+	s.Modifiers = nil
+	s.Equipment = nil
+	s.Research = nil
+	s.Provenance = nil
+	return nil
+}
+
+// GetSpriteRegistry returns the sprite registry, loading it on first use
+func (s *State) GetSpriteRegistry() *SpriteRegistry {
+	if s.SpriteRegistry == nil {
+		s.SpriteRegistry = NewSpriteRegistry(s.GetModPath(), s.GetGamePath())
+		s.SpriteRegistry.Load()
+	}
+	return s.SpriteRegistry
+}
+
+// GetIdeas returns the idea set, loading it on first use
+func (s *State) GetIdeas() *domain.IdeaSet {
+	if s.Ideas == nil {
+		loader := NewIdeaLoader(s.GetModPath(), s.GetGamePath())
+		ideas, err := loader.LoadAll()
+		if err != nil {
+			println("Warning: Failed to load ideas:", err.Error())
+		}
+		s.Ideas = ideas
+		s.Workspace.Track(NewIdeaDocument(ideas, loader))
+	}
+	return s.Ideas
+}
+
+// GetDecisions returns the decision set, loading it on first use
+func (s *State) GetDecisions() *domain.DecisionSet {
+	if s.Decisions == nil {
+		loader := NewDecisionLoader(s.GetModPath(), s.GetGamePath())
+		decisions, err := loader.LoadAll()
+		if err != nil {
+			println("Warning: Failed to load decisions:", err.Error())
+		}
+		s.Decisions = decisions
+		s.Workspace.Track(NewDecisionDocument(decisions, loader))
+	}
+	return s.Decisions
+}
+
+// GetEvents returns the event set, loading it on first use
+func (s *State) GetEvents() *domain.EventSet {
+	if s.Events == nil {
+		events, err := NewEventLoader(s.GetModPath(), s.GetGamePath()).LoadAll()
+		if err != nil {
+			println("Warning: Failed to load events:", err.Error())
+		}
+		s.Events = events
+	}
+	return s.Events
+}
+
+// GetCharacters returns the character set, loading it on first use
+func (s *State) GetCharacters() *domain.CharacterSet {
+	if s.Characters == nil {
+		characters, err := NewCharacterLoader(s.GetModPath(), s.GetGamePath()).LoadAll()
+		if err != nil {
+			println("Warning: Failed to load characters:", err.Error())
+		}
+		s.Characters = characters
+	}
+	return s.Characters
+}
+
+// GetScripted returns the scripted trigger/effect library, loading it on first use
+func (s *State) GetScripted() *parser.ScriptedLibrary {
+	if s.Scripted == nil {
+		if s.CountryContext != nil && s.CountryContext.Scripted != nil {
+			s.Scripted = s.CountryContext.Scripted
+		} else {
+			s.Scripted = LoadScriptedLibrary(s.GetModPath(), s.GetGamePath())
+		}
+	}
+	return s.Scripted
+}
+
+// GetModifiers returns the modifier catalogue, loading it on first use
+func (s *State) GetModifiers() *domain.ModifierCatalogue {
+	if s.Modifiers == nil {
+		s.Modifiers = LoadModifierCatalogue(s.GetGamePath())
+	}
+	return s.Modifiers
+}
+
+// GetEquipment returns the equipment and sub-unit set, loading it on first use
+func (s *State) GetEquipment() *domain.EquipmentSet {
+	if s.Equipment == nil {
+		equipment, err := NewEquipmentLoader(s.GetModPath(), s.GetGamePath()).LoadAll()
+		if err != nil {
+			println("Warning: Failed to load equipment:", err.Error())
+		}
+		s.Equipment = equipment
+	}
+	return s.Equipment
+}
+
+// GetResearch returns the research context of the selected country
+// (nil without a country), building it on first use
+func (s *State) GetResearch() *ResearchSetup {
+	if s.Research == nil && s.CountryContext != nil {
+		s.Research = NewResearchSetup(s.CountryContext, s.GetIdeas())
+	}
+	return s.Research
+}
+
+// GetProvenance returns the game/override/mod origin of technologies and
+// focuses, loading it on first use
+func (s *State) GetProvenance() *domain.ProvenanceReport {
+	if s.Provenance == nil {
+		s.Provenance = LoadProvenance(s.GetModPath(), s.GetGamePath(), s.Cache)
+	}
+	return s.Provenance
+}
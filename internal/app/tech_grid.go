@@ -0,0 +1,104 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/serializer"
+)
+
+// LoadTechGrid collects the year row and column variables of the files the
+// technologies come from (vanilla rows if the files declare none)
+func LoadTechGrid(technologies []*domain.Technology) (*domain.TechGrid, error) {
+	rows := make(map[string]int)
+	columns := make(map[string]int)
+	seen := make(map[string]bool)
+
+	for _, tech := range technologies {
+		if tech.File == "" || seen[tech.File] {
+			continue
+		}
+		seen[tech.File] = true
+
+		content, err := os.ReadFile(tech.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", tech.File, err)
+		}
+		program, err := parser.NewParser(string(content)).Parse()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", tech.File, err)
+		}
+		techParser := parser.NewTechParser()
+		if _, err := techParser.ParseTechnologies(program); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", tech.File, err)
+		}
+		for name, value := range techParser.GetVerticalVariables() {
+			rows[name] = value
+		}
+		for name, value := range techParser.GetHorizontalVariables() {
+			columns[name] = value
+		}
+	}
+
+	return domain.NewTechGrid(rows, columns), nil
+}
+
+// SaveTechnologies writes the folder positions, paths and xor of the
+// technologies back into their files under modPath (.bak backup); new column
+// variables are declared in every file that uses them. Game files are never
+// written
+func SaveTechnologies(modPath string, technologies []*domain.Technology, columns map[string]int) error {
+	byFile := make(map[string][]*domain.Technology)
+	for _, tech := range technologies {
+		if tech.File == "" {
+			continue
+		}
+		if !inModPath(modPath, tech.File) {
+			println("Warning: not saving", tech.ID, "- defined outside the mod in", tech.File)
+			continue
+		}
+		byFile[tech.File] = append(byFile[tech.File], tech)
+	}
+
+	for file, techs := range byFile {
+		used := make(map[string]int)
+		for _, tech := range techs {
+			if value, ok := columns[tech.Position.XVar]; ok {
+				used[tech.Position.XVar] = value
+			}
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+		patched, err := parser.PatchTechPositions(string(content), techs, used)
+		if err != nil {
+			return fmt.Errorf("failed to patch positions in %s: %w", file, err)
+		}
+		patched, err = parser.PatchTechLinks(patched, techs)
+		if err != nil {
+			return fmt.Errorf("failed to patch links in %s: %w", file, err)
+		}
+		if patched == string(content) {
+			continue
+		}
+		if err := serializer.NewTechWriter().WriteSource(file, patched); err != nil {
+			return fmt.Errorf("failed to save technologies: %w", err)
+		}
+	}
+	return nil
+}
+
+// inModPath reports whether a file is inside the mod folder
+func inModPath(modPath, file string) bool {
+	if modPath == "" {
+		return false
+	}
+	rel, err := filepath.Rel(modPath, file)
+	return err == nil && !strings.HasPrefix(rel, "..")
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+)
+
+// techSharingDir holds technology sharing groups relative to the mod/game root
+const techSharingDir = "common/technology_sharing"
+
+// TechSharingStatus is a sharing group evaluated for the selected country
+type TechSharingStatus struct {
+	Group     *parser.TechSharingGroup
+	Available bool     // available triggers hold for the country
+	Unknown   []string // Triggers that could not be checked (treated as met)
+	Joined    bool     // Counted in the research time calculation
+}
+
+// LoadTechSharingGroups loads sharing groups from game and mod
+// A mod file with the same name replaces the game file; a group ID declared
+// again in a later file replaces the earlier group
+func LoadTechSharingGroups(modPath, gamePath string, scripted *parser.ScriptedLibrary) []*parser.TechSharingGroup {
+	files := make(map[string]string) // file name -> path
+	sources := make(map[string]string)
+	for _, layer := range []struct{ path, source string }{{gamePath, "game"}, {modPath, "mod"}} {
+		if layer.path == "" {
+			continue
+		}
+		paths, err := filepath.Glob(filepath.Join(layer.path, filepath.FromSlash(techSharingDir), "*.txt"))
+		if err != nil {
+			continue
+		}
+		for _, path := range paths {
+			files[filepath.Base(path)] = path
+			sources[filepath.Base(path)] = layer.source
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if sources[names[i]] != sources[names[j]] {
+			return sources[names[i]] == "game"
+		}
+		return names[i] < names[j]
+	})
+
+	groups := make([]*parser.TechSharingGroup, 0)
+	index := make(map[string]int)
+	for _, name := range names {
+		program, err := parseScriptFile(files[name])
+		if err != nil {
+			println("Warning: Failed to parse", files[name], ":", err.Error())
+			continue
+		}
+		for _, group := range parser.ParseTechSharingGroups(program, scripted) {
+			group.Source = sources[name]
+			if i, ok := index[group.ID]; ok {
+				groups[i] = group
+				continue
+			}
+			index[group.ID] = len(groups)
+			groups = append(groups, group)
+		}
+	}
+
+	println("Loaded", len(groups), "technology sharing groups")
+	return groups
+}
+
+// EvaluateTechSharing checks which sharing groups the country can join
+func EvaluateTechSharing(groups []*parser.TechSharingGroup, evaluator *ConditionEvaluator) []*TechSharingStatus {
+	statuses := make([]*TechSharingStatus, 0, len(groups))
+	for _, group := range groups {
+		status := &TechSharingStatus{Group: group, Available: evaluator.Evaluate(group.Available)}
+		if group.Available != nil {
+			status.Unknown = group.Available.Unknown
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
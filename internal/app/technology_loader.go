@@ -1,6 +1,7 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -15,6 +16,8 @@ import (
 type TechnologyLoader struct {
 	modPath  string
 	gamePath string
+	cache    *ParseCache // Parsed files (nil: always parse)
+	progress *Progress   // Reports parsed files (nil: none)
 }
 
 // NewTechnologyLoader creates a new technology loader
@@ -25,6 +28,19 @@ func NewTechnologyLoader(modPath, gamePath string) *TechnologyLoader {
 	}
 }
 
+// SetCache makes the loader reuse parsed files from a cache
+func (tl *TechnologyLoader) SetCache(cache *ParseCache) *TechnologyLoader {
+	tl.cache = cache
+	return tl
+}
+
+// SetProgress makes the loader report parsed files and stop parsing when
+// the progress is cancelled
+func (tl *TechnologyLoader) SetProgress(progress *Progress) *TechnologyLoader {
+	tl.progress = progress
+	return tl
+}
+
 // LoadTechnologiesForFolder loads all technologies that belong to a specific folder
 func (tl *TechnologyLoader) LoadTechnologiesForFolder(folderName string) ([]*domain.Technology, error) {
 	// Load all technologies from mod and game
@@ -109,21 +125,43 @@ func (tl *TechnologyLoader) loadTechnologiesFromPath(basePath string) (map[strin
 	// Map: filename -> technologies
 	fileMap := make(map[string][]*domain.Technology)
 
-	// Parse each file
-	for _, file := range files {
-		techs, err := tl.parseTechnologyFile(file)
-		if err != nil {
+	// Parse the files concurrently (unchanged files come from the cache)
+	tl.progress.AddFiles(len(files))
+	parsed, errs := parser.ParseFiles(files, trackFile(tl.progress, tl.loadTechnologyFile))
+	for i, file := range files {
+		if errors.Is(errs[i], ErrCancelled) {
+			continue
+		}
+		if errs[i] != nil {
 			// Skip files with errors
-			println("Warning: Failed to parse", file, ":", err.Error())
+			println("Warning: Failed to parse", file, ":", errs[i].Error())
 			continue
 		}
 		fileName := filepath.Base(file)
-		fileMap[fileName] = techs
+		fileMap[fileName] = parsed[i]
 	}
 
 	return fileMap, nil
 }
 
+// loadTechnologyFile returns the technologies of a file from the cache, or
+// parses it
+func (tl *TechnologyLoader) loadTechnologyFile(filePath string) ([]*domain.Technology, error) {
+	technologies, err := cachedParse(tl.cache, cacheTechnologies, filePath, tl.parseTechnologyFile)
+	if err != nil {
+		return nil, err
+	}
+	for _, tech := range technologies {
+		if tech.Effects == nil { // Empty maps are not stored in the cache
+			tech.Effects = make(map[string]map[string]float64)
+		}
+		if tech.AIResearchWeights == nil {
+			tech.AIResearchWeights = make(map[string]float64)
+		}
+	}
+	return technologies, nil
+}
+
 // parseTechnologyFile parses a single technology file
 func (tl *TechnologyLoader) parseTechnologyFile(filePath string) ([]*domain.Technology, error) {
 	// Read file
@@ -146,35 +184,8 @@ func (tl *TechnologyLoader) parseTechnologyFile(filePath string) ([]*domain.Tech
 		return nil, err
 	}
 
-	// Debug: print file name and tech count only for electronics
-	fileName := filepath.Base(filePath)
-	if len(technologies) > 0 {
-		isElectronics := false
-		for _, tech := range technologies {
-			if tech.Folder == "electronics_folder" {
-				isElectronics = true
-				break
-			}
-		}
-
-		if isElectronics {
-			println("Parsed", fileName, ":", len(technologies), "technologies")
-			// Show first few techs with their positions
-			for i, tech := range technologies {
-				if i >= 3 {
-					break
-				}
-				xStr := fmt.Sprintf("%d", tech.Position.X)
-				if tech.Position.XVar != "" {
-					xStr = fmt.Sprintf("%s=%d", tech.Position.XVar, tech.Position.X)
-				}
-				yStr := fmt.Sprintf("%d", tech.Position.Y)
-				if tech.Position.YVar != "" {
-					yStr = fmt.Sprintf("%s=%d", tech.Position.YVar, tech.Position.Y)
-				}
-				println("  -", tech.ID, "X:", xStr, "Y:", yStr)
-			}
-		}
+	for _, tech := range technologies {
+		tech.File = filePath
 	}
 
 	return technologies, nil
@@ -192,6 +203,7 @@ func (tl *TechnologyLoader) techBelongsToFolder(tech *domain.Technology, folderN
 }
 
 // DetectSubTrees groups technologies into sub-trees by X-coordinate gaps
+// (doctrine folders are split by domain.BuildDoctrineTree instead)
 func (tl *TechnologyLoader) DetectSubTrees(folderName string, technologies []*domain.Technology) []*domain.SubTree {
 	if len(technologies) == 0 {
 		return nil
@@ -200,10 +212,8 @@ func (tl *TechnologyLoader) DetectSubTrees(folderName string, technologies []*do
 	println("\n=== DetectSubTrees for folder:", folderName, "===")
 	println("Total technologies:", len(technologies))
 
-	// Step 1: Collect unique X values
-	uniqueXValues := make(map[int]bool)
+	// Step 1: Show coordinates
 	for _, tech := range technologies {
-		uniqueXValues[tech.Position.X] = true
 		// Show coordinates with aliases
 		xStr := fmt.Sprintf("%d", tech.Position.X)
 		if tech.Position.XVar != "" {
@@ -216,46 +226,10 @@ func (tl *TechnologyLoader) DetectSubTrees(folderName string, technologies []*do
 		println("  Tech:", tech.ID, "| X:", xStr, "| Y:", yStr)
 	}
 
-	// Step 2: Sort unique X values
-	xValues := make([]int, 0, len(uniqueXValues))
-	for x := range uniqueXValues {
-		xValues = append(xValues, x)
-	}
-	sort.Ints(xValues)
-
-	// Step 3: Find gaps and create sub-trees
+	// Step 2: Split columns into ranges separated by gaps
 	subTrees := make([]*domain.SubTree, 0)
-	currentRange := []int{xValues[0]}
-
-	for i := 1; i < len(xValues); i++ {
-		gap := xValues[i] - xValues[i-1]
-
-		if gap > 5 {
-			// Gap found - create sub-tree for current range
-			subTree := createSubTreeForRange(
-				currentRange[0],
-				currentRange[len(currentRange)-1],
-				technologies,
-				folderName,
-			)
-			subTrees = append(subTrees, subTree)
-
-			// Start new range
-			currentRange = []int{xValues[i]}
-		} else {
-			currentRange = append(currentRange, xValues[i])
-		}
-	}
-
-	// Add last range
-	if len(currentRange) > 0 {
-		subTree := createSubTreeForRange(
-			currentRange[0],
-			currentRange[len(currentRange)-1],
-			technologies,
-			folderName,
-		)
-		subTrees = append(subTrees, subTree)
+	for _, r := range domain.SubTreeRanges(technologies) {
+		subTrees = append(subTrees, createSubTreeForRange(r[0], r[1], technologies, folderName))
 	}
 
 	println("\nDetected", len(subTrees), "sub-trees:")
@@ -327,9 +301,6 @@ func identifySubTreeName(categories map[string]bool, folderName string) string {
 		"jet_technology": "Jets & Aircraft Engines",
 		"jet_engine":     "Jets & Aircraft Engines",
 		"nuclear":        "Atomic Research",
-		"land_doctrine":  "Land Doctrine",
-		"air_doctrine":   "Air Doctrine",
-		"naval_doctrine": "Naval Doctrine",
 	}
 
 	for cat := range categories {
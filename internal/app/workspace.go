@@ -0,0 +1,193 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DocumentKind identifies what an open document edits
+type DocumentKind int
+
+const (
+	DocumentFocusTree DocumentKind = iota
+	DocumentTechFolder
+	DocumentLocalization
+	DocumentIdeas
+	DocumentDecisions
+)
+
+// String returns a short name of the kind for tabs and messages
+func (k DocumentKind) String() string {
+	switch k {
+	case DocumentFocusTree:
+		return "focus"
+	case DocumentTechFolder:
+		return "tech"
+	case DocumentLocalization:
+		return "loc"
+	case DocumentIdeas:
+		return "ideas"
+	case DocumentDecisions:
+		return "decisions"
+	}
+	return "unknown"
+}
+
+// Document is something open for editing in the workspace
+type Document interface {
+	// Key identifies the document; opening the same key again reuses it
+	Key() string
+	Title() string
+	Kind() DocumentKind
+	// Dirty reports unsaved changes
+	Dirty() bool
+	// Save writes the changes to disk and clears the dirty state
+	Save() error
+}
+
+// Workspace holds the documents open in the editor, in the order they were
+// opened, and which of them is active. Tracked documents are edited in
+// scenes without a tab (ideas, decisions) but are saved and listed as
+// unsaved like the open ones
+type Workspace struct {
+	documents []Document
+	tracked   []Document
+	active    int
+}
+
+// NewWorkspace creates an empty workspace
+func NewWorkspace() *Workspace {
+	return &Workspace{active: -1}
+}
+
+// Open adds a document and makes it active; if a document with the same
+// key is already open, that one is activated and returned instead
+func (w *Workspace) Open(doc Document) Document {
+	if i := w.index(doc.Key()); i >= 0 {
+		w.active = i
+		return w.documents[i]
+	}
+	w.documents = append(w.documents, doc)
+	w.active = len(w.documents) - 1
+	return doc
+}
+
+// Get returns the open document with the key, or nil
+func (w *Workspace) Get(key string) Document {
+	if i := w.index(key); i >= 0 {
+		return w.documents[i]
+	}
+	return nil
+}
+
+// Close removes a document (unsaved changes are dropped); the document
+// opened before it becomes active
+func (w *Workspace) Close(key string) {
+	i := w.index(key)
+	if i < 0 {
+		return
+	}
+	w.documents = append(w.documents[:i], w.documents[i+1:]...)
+	if w.active >= i {
+		w.active--
+	}
+	if w.active < 0 && len(w.documents) > 0 {
+		w.active = 0
+	}
+}
+
+// Documents returns the open documents in opening order
+func (w *Workspace) Documents() []Document {
+	return w.documents
+}
+
+// Active returns the active document, or nil when nothing is open
+func (w *Workspace) Active() Document {
+	if w.active < 0 || w.active >= len(w.documents) {
+		return nil
+	}
+	return w.documents[w.active]
+}
+
+// SetActive activates the open document with the key
+func (w *Workspace) SetActive(key string) bool {
+	i := w.index(key)
+	if i < 0 {
+		return false
+	}
+	w.active = i
+	return true
+}
+
+// Next returns the document after the active one (wrapping around), or nil
+func (w *Workspace) Next() Document {
+	if len(w.documents) == 0 {
+		return nil
+	}
+	return w.documents[(w.active+1)%len(w.documents)]
+}
+
+// Track adds a document edited without a tab, replacing a tracked document
+// with the same key
+func (w *Workspace) Track(doc Document) {
+	w.Untrack(doc.Key())
+	w.tracked = append(w.tracked, doc)
+}
+
+// Untrack removes a tracked document (unsaved changes are dropped)
+func (w *Workspace) Untrack(key string) {
+	for i, doc := range w.tracked {
+		if doc.Key() == key {
+			w.tracked = append(w.tracked[:i], w.tracked[i+1:]...)
+			return
+		}
+	}
+}
+
+// Tracked returns the tracked document with the key, or nil
+func (w *Workspace) Tracked(key string) Document {
+	for _, doc := range w.tracked {
+		if doc.Key() == key {
+			return doc
+		}
+	}
+	return nil
+}
+
+// DirtyDocuments returns the open and tracked documents with unsaved changes
+func (w *Workspace) DirtyDocuments() []Document {
+	dirty := make([]Document, 0)
+	for _, docs := range [][]Document{w.documents, w.tracked} {
+		for _, doc := range docs {
+			if doc.Dirty() {
+				dirty = append(dirty, doc)
+			}
+		}
+	}
+	return dirty
+}
+
+// SaveAll saves every dirty document; documents that fail stay dirty and
+// their errors are joined
+func (w *Workspace) SaveAll() (int, error) {
+	saved := 0
+	var errs []error
+	for _, doc := range w.DirtyDocuments() {
+		if err := doc.Save(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", doc.Title(), err))
+			continue
+		}
+		saved++
+	}
+	return saved, errors.Join(errs...)
+}
+
+// index returns the position of the document with the key, or -1
+func (w *Workspace) index(key string) int {
+	for i, doc := range w.documents {
+		if doc.Key() == key {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,212 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/lint"
+)
+
+// fakeDocument counts saves and can be made to fail
+type fakeDocument struct {
+	key     string
+	dirty   bool
+	saves   int
+	saveErr error
+}
+
+func (d *fakeDocument) Key() string        { return d.key }
+func (d *fakeDocument) Title() string      { return d.key }
+func (d *fakeDocument) Kind() DocumentKind { return DocumentFocusTree }
+func (d *fakeDocument) Dirty() bool        { return d.dirty }
+
+func (d *fakeDocument) Save() error {
+	if d.saveErr != nil {
+		return d.saveErr
+	}
+	d.saves++
+	d.dirty = false
+	return nil
+}
+
+func TestWorkspace(t *testing.T) {
+	w := NewWorkspace()
+	if w.Active() != nil || w.Next() != nil {
+		t.Fatal("Empty workspace has an active document")
+	}
+
+	a := &fakeDocument{key: "a"}
+	b := &fakeDocument{key: "b", dirty: true}
+	c := &fakeDocument{key: "c", dirty: true, saveErr: errors.New("read-only")}
+	w.Open(a)
+	w.Open(b)
+	w.Open(c)
+	if w.Active() != c || w.Next() != a {
+		t.Errorf("Active = %v, Next = %v", w.Active(), w.Next())
+	}
+
+	// Opening a key again activates the open document
+	if doc := w.Open(&fakeDocument{key: "a"}); doc != a || w.Active() != a || len(w.Documents()) != 3 {
+		t.Errorf("Reopen returned %v, active %v, %d documents", doc, w.Active(), len(w.Documents()))
+	}
+
+	if dirty := w.DirtyDocuments(); len(dirty) != 2 {
+		t.Errorf("DirtyDocuments() = %d, want 2", len(dirty))
+	}
+	saved, err := w.SaveAll()
+	if saved != 1 || err == nil || b.saves != 1 || !c.Dirty() {
+		t.Errorf("SaveAll() = %d, %v (b saved %d, c dirty %v)", saved, err, b.saves, c.Dirty())
+	}
+
+	w.SetActive("b")
+	w.Close("b")
+	if w.Active() != a || w.Get("b") != nil || len(w.Documents()) != 2 {
+		t.Errorf("After close: active %v, %d documents", w.Active(), len(w.Documents()))
+	}
+	w.Close("a")
+	if w.Active() != c {
+		t.Errorf("Closing the first document activated %v", w.Active())
+	}
+}
+
+func TestLocalizationDocument(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test_l_russian.yml")
+	source := "\ufeffl_russian:\r\n # Comment\r\n tech_a:0 \"Old\"\r\n tech_b:1 \"Keep\" # note\r\n"
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := LoadLocalizationDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Language != "russian" || len(doc.Keys()) != 2 || doc.Values["tech_a"] != "Old" {
+		t.Fatalf("Loaded %s with %v", doc.Language, doc.Values)
+	}
+
+	doc.Set("tech_b", "Keep")
+	if doc.Dirty() {
+		t.Error("Setting an unchanged value made the document dirty")
+	}
+	doc.Set("tech_a", "New")
+	doc.Set("tech_new", "Added")
+	if !doc.Dirty() || !doc.Changed("tech_a") || doc.Changed("tech_b") {
+		t.Error("Edits not tracked")
+	}
+
+	if err := doc.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Dirty() {
+		t.Error("Document still dirty after save")
+	}
+
+	content, _ := os.ReadFile(path)
+	want := "\ufeffl_russian:\r\n # Comment\r\n tech_a:0 \"New\"\r\n tech_b:1 \"Keep\" # note\r\n tech_new:0 \"Added\"\r\n"
+	if string(content) != want {
+		t.Errorf("Saved file:\n%q\nwant:\n%q", content, want)
+	}
+	if backup, _ := os.ReadFile(path + ".bak"); string(backup) != source {
+		t.Error("Backup does not hold the previous version")
+	}
+
+	reloaded, err := LoadLocalizationDocument(path)
+	if err != nil || len(reloaded.Values) != 3 || reloaded.Values["tech_new"] != "Added" {
+		t.Errorf("Reloaded %v, %v", reloaded.Values, err)
+	}
+}
+
+func TestFocusDocumentSavesLinks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test_focus.txt")
+	source := `focus_tree = {
+	id = test_tree
+	focus = {
+		id = TST_a
+		icon = GFX_goal_generic_radar # Radar
+		x = 0
+		y = 0
+	}
+	focus = {
+		id = TST_b
+		prerequisite = { focus = TST_a focus = TST_removed }
+		mutually_exclusive = { focus = TST_c }
+		x = 0
+		y = 1
+	}
+	focus = {
+		id = TST_c
+		prerequisite = { focus = TST_a }
+		x = 2
+		y = 1
+	}
+}`
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc := NewFocusDocument(path, "test_tree")
+	if err := doc.Load(); err != nil {
+		t.Fatal(err)
+	}
+	issues := lint.NewEngine().Run(&lint.Input{FocusTree: doc.Tree, FocusFile: path})
+	if applied := lint.ApplyFixes(issues); applied != 2 {
+		t.Fatalf("Applied %d fixes of %v", applied, issues)
+	}
+	doc.Tree.Focuses["TST_a"].Icon = "GFX_goal_generic_electronics"
+	doc.Modified = true
+	if err := doc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := NewFocusDocument(path, "test_tree")
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	b, c := reloaded.Tree.Focuses["TST_b"], reloaded.Tree.Focuses["TST_c"]
+	if len(b.Prerequisites) != 1 || len(b.Prerequisites[0]) != 1 || b.Prerequisites[0][0] != "TST_a" {
+		t.Errorf("TST_b prerequisites = %v", b.Prerequisites)
+	}
+	if !c.IsMutuallyExclusiveWith("TST_b") || !b.IsMutuallyExclusiveWith("TST_c") {
+		t.Errorf("Exclusions: TST_b %v, TST_c %v", b.MutuallyExclusive, c.MutuallyExclusive)
+	}
+	if icon := reloaded.Tree.Focuses["TST_a"].Icon; icon != "GFX_goal_generic_electronics" {
+		t.Errorf("TST_a icon = %q", icon)
+	}
+	if issues := lint.NewEngine().Run(&lint.Input{FocusTree: reloaded.Tree, FocusFile: path}); len(issues) != 0 {
+		t.Errorf("Issues after reload: %v", issues)
+	}
+	if content, _ := os.ReadFile(path); !strings.Contains(string(content), "icon = GFX_goal_generic_electronics # Radar") {
+		t.Errorf("Saved file lost its formatting:\n%s", content)
+	}
+}
+
+func TestStateTracksIdeaEdits(t *testing.T) {
+	modPath := t.TempDir()
+	writeTestFile(t, filepath.Join(modPath, "common", "ideas", "GER.txt"), "ideas = {\n\tcountry = {\n\t\tGER_autarky = {\n\t\t\tremoval_cost = 10\n\t\t}\n\t}\n}\n")
+	s := &State{Workspace: NewWorkspace(), BasePath: modPath}
+
+	ideas := s.GetIdeas()
+	if len(ideas.Files) != 1 {
+		t.Fatalf("Loaded %d idea files", len(ideas.Files))
+	}
+	ideas.Files[0].Dirty = true
+	if dirty := s.Workspace.DirtyDocuments(); len(dirty) != 1 || dirty[0].Key() != IdeaDocumentKey {
+		t.Fatalf("DirtyDocuments() = %v", dirty)
+	}
+
+	// Switching the mod would drop the edits, so it is refused
+	other := &ModDescriptor{ModFolderPath: t.TempDir()}
+	if err := s.SetModDescriptor(other); err == nil || s.Ideas != ideas || s.ModDescriptor != nil {
+		t.Fatalf("SetModDescriptor() = %v with unsaved ideas", err)
+	}
+
+	if saved, err := s.Workspace.SaveAll(); saved != 1 || err != nil || ideas.Files[0].Dirty {
+		t.Fatalf("SaveAll() = %d, %v", saved, err)
+	}
+	if err := s.SetModDescriptor(other); err != nil || s.Ideas != nil || s.Workspace.Tracked(IdeaDocumentKey) != nil {
+		t.Errorf("SetModDescriptor() = %v after saving", err)
+	}
+}
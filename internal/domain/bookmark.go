@@ -18,6 +18,7 @@ type BookmarkCountry struct {
 	IsMajor  bool     // Major power flag
 	Ideas    []string // Starting national ideas
 	Focuses  []string // Starting focuses
+	Date     string   // Start date of the bookmark the country was taken from
 }
 
 // GetDisplayName returns the display name or tag
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// CharacterRoleTypes are the role blocks a character may have
+var CharacterRoleTypes = []string{"country_leader", "advisor", "corps_commander", "field_marshal", "navy_leader", "operative", "scientist"}
+
+// CharacterRole is one role block (country_leader = { ... }, advisor = { ... })
+type CharacterRole struct {
+	Type      string // country_leader, advisor, corps_commander, ...
+	Ideology  string // Sub-ideology (country leaders)
+	Slot      string // Advisor slot (political_advisor, army_chief, theorist, ...)
+	IdeaToken string // Advisor idea token
+	Cost      string // Advisor cost
+	Skill     string // Commander skill
+	Traits    []string
+}
+
+// Character is a character declared in common/characters
+type Character struct {
+	ID        string
+	Name      string            // Localisation key; the ID when unset
+	Portraits map[string]string // "civilian.large", "army.small", ... -> sprite name or texture path
+	Roles     []*CharacterRole
+	File      *CharacterFile
+}
+
+// NewCharacter creates a character with no roles
+func NewCharacter(id string) *Character {
+	return &Character{
+		ID:        id,
+		Portraits: make(map[string]string),
+		Roles:     make([]*CharacterRole, 0),
+	}
+}
+
+// Portrait returns the preferred large portrait (civilian, then army, then navy)
+func (c *Character) Portrait() string {
+	for _, key := range []string{"civilian.large", "army.large", "navy.large", "civilian.small", "army.small", "navy.small"} {
+		if portrait := c.Portraits[key]; portrait != "" {
+			return portrait
+		}
+	}
+	return ""
+}
+
+// RoleTypes returns the character's role types in declaration order
+func (c *Character) RoleTypes() []string {
+	types := make([]string, len(c.Roles))
+	for i, role := range c.Roles {
+		types[i] = role.Type
+	}
+	return types
+}
+
+// Traits returns the traits of all roles, deduplicated
+func (c *Character) Traits() []string {
+	traits := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, role := range c.Roles {
+		for _, trait := range role.Traits {
+			if !seen[trait] {
+				seen[trait] = true
+				traits = append(traits, trait)
+			}
+		}
+	}
+	return traits
+}
+
+// CharacterFile is a common/characters/*.txt file
+type CharacterFile struct {
+	Path       string
+	RelPath    string
+	Source     string // "mod" or "game"
+	Characters []*Character
+}
+
+// CharacterReference is a character ID used by a focus or history file
+type CharacterReference struct {
+	ID     string
+	Source string // "focus GER_x" or a history file path
+}
+
+// CharacterSet indexes characters from several files by ID (later files win)
+type CharacterSet struct {
+	Files      []*CharacterFile
+	characters map[string]*Character
+}
+
+// NewCharacterSet creates an index over the files
+func NewCharacterSet(files []*CharacterFile) *CharacterSet {
+	set := &CharacterSet{
+		Files:      files,
+		characters: make(map[string]*Character),
+	}
+	for _, file := range files {
+		for _, character := range file.Characters {
+			set.characters[character.ID] = character
+		}
+	}
+	return set
+}
+
+// Get returns a character by ID
+func (s *CharacterSet) Get(id string) (*Character, bool) {
+	character, ok := s.characters[id]
+	return character, ok
+}
+
+// Count returns the number of distinct character IDs
+func (s *CharacterSet) Count() int {
+	return len(s.characters)
+}
+
+// ForTag returns the characters of a country, sorted by ID: those whose
+// ID starts with TAG_ or that are declared in a file named after the tag
+func (s *CharacterSet) ForTag(tag string) []*Character {
+	characters := make([]*Character, 0)
+	for _, character := range s.characters {
+		fileTag := ""
+		if character.File != nil {
+			base := character.File.RelPath[strings.LastIndex(character.File.RelPath, "/")+1:]
+			fileTag = strings.TrimSuffix(base, ".txt")
+		}
+		if strings.HasPrefix(character.ID, tag+"_") || strings.EqualFold(fileTag, tag) {
+			characters = append(characters, character)
+		}
+	}
+	sort.Slice(characters, func(i, j int) bool { return characters[i].ID < characters[j].ID })
+	return characters
+}
+
+// Missing returns the references whose character is not declared
+func (s *CharacterSet) Missing(refs []CharacterReference) []CharacterReference {
+	missing := make([]CharacterReference, 0)
+	for _, ref := range refs {
+		if _, ok := s.characters[ref.ID]; !ok {
+			missing = append(missing, ref)
+		}
+	}
+	return missing
+}
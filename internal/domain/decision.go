@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+)
+
+// DecisionCategory is a category declared in common/decisions/categories
+type DecisionCategory struct {
+	ID       string
+	Icon     string
+	Picture  string
+	Priority string
+	Allowed  string // Raw trigger block
+	Visible  string
+	Extra    []ScriptField
+	File     *DecisionCategoryFile
+}
+
+// Decision is a single decision or mission declared in common/decisions
+type Decision struct {
+	ID             string
+	Category       string // Category ID of the enclosing block
+	Icon           string
+	Cost           string // Political power cost; "" if unset
+	DaysRemove     string // Days until remove_effect fires; "" if unset
+	DaysReEnable   string
+	FireOnlyOnce   bool
+	Allowed        string // Raw trigger blocks
+	Visible        string
+	Available      string
+	CompleteEffect string // Raw effect blocks
+	RemoveEffect   string
+	AIWillDo       string
+	Extra          []ScriptField // days_mission_timeout, target_trigger, modifier, ...
+
+	// Cross-reference data extracted at parse time
+	VisibleFlags   []string // has_country_flag checks in visible
+	VisibleFocuses []string // has_completed_focus checks in visible
+
+	File *DecisionFile
+}
+
+// NewDecision creates a decision with no extra fields
+func NewDecision(id, category string) *Decision {
+	return &Decision{
+		ID:       id,
+		Category: category,
+		Extra:    make([]ScriptField, 0),
+	}
+}
+
+// SpriteName returns the sprite the game shows for the decision
+func (d *Decision) SpriteName() string {
+	if d.Icon == "" {
+		return "GFX_decision_generic_decision"
+	}
+	return "GFX_decision_" + d.Icon
+}
+
+// UnlockedBy reports whether completing the focus makes the decision
+// visible: via an unlock tooltip, a flag the focus sets, or has_completed_focus
+func (d *Decision) UnlockedBy(focus *Focus) bool {
+	for _, id := range focus.RewardDecisions {
+		if id == d.ID || id == d.Category {
+			return true
+		}
+	}
+	for _, flag := range focus.RewardFlags {
+		for _, visible := range d.VisibleFlags {
+			if flag == visible {
+				return true
+			}
+		}
+	}
+	for _, id := range d.VisibleFocuses {
+		if id == focus.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the editable fields
+func (d *Decision) Validate() []string {
+	errors := make([]string, 0)
+
+	if d.ID == "" {
+		errors = append(errors, "decision ID is required")
+	}
+	for _, field := range []struct{ key, value string }{
+		{"cost", d.Cost},
+		{"days_remove", d.DaysRemove},
+		{"days_re_enable", d.DaysReEnable},
+	} {
+		if field.value == "" {
+			continue
+		}
+		if _, err := strconv.ParseFloat(field.value, 64); err != nil {
+			errors = append(errors, "decision "+d.ID+": "+field.key+" is not a number")
+		}
+	}
+
+	return errors
+}
+
+// DecisionBlock is one category_id = { ... } block of a decision file
+type DecisionBlock struct {
+	Category  string
+	Decisions []*Decision
+}
+
+// DecisionFile is a common/decisions/*.txt file
+type DecisionFile struct {
+	Path    string // Absolute path the file was loaded from
+	RelPath string // Path relative to the mod/game root
+	Source  string // "mod" or "game"
+	Blocks  []*DecisionBlock
+	Dirty   bool // Edited since load
+}
+
+// Decisions returns all decisions of the file in declaration order
+func (f *DecisionFile) Decisions() []*Decision {
+	decisions := make([]*Decision, 0)
+	for _, block := range f.Blocks {
+		decisions = append(decisions, block.Decisions...)
+	}
+	return decisions
+}
+
+// DecisionCategoryFile is a common/decisions/categories/*.txt file
+type DecisionCategoryFile struct {
+	Path       string
+	RelPath    string
+	Source     string
+	Categories []*DecisionCategory
+	Dirty      bool
+}
+
+// DecisionSet indexes decisions and categories by ID (later files win)
+type DecisionSet struct {
+	Files         []*DecisionFile
+	CategoryFiles []*DecisionCategoryFile
+	decisions     map[string]*Decision
+	categories    map[string]*DecisionCategory
+}
+
+// NewDecisionSet creates an index over the files
+func NewDecisionSet(files []*DecisionFile, categoryFiles []*DecisionCategoryFile) *DecisionSet {
+	set := &DecisionSet{
+		Files:         files,
+		CategoryFiles: categoryFiles,
+		decisions:     make(map[string]*Decision),
+		categories:    make(map[string]*DecisionCategory),
+	}
+	for _, file := range categoryFiles {
+		for _, category := range file.Categories {
+			set.categories[category.ID] = category
+		}
+	}
+	for _, file := range files {
+		for _, decision := range file.Decisions() {
+			set.decisions[decision.ID] = decision
+		}
+	}
+	return set
+}
+
+// Get returns a decision by ID
+func (s *DecisionSet) Get(id string) (*Decision, bool) {
+	decision, ok := s.decisions[id]
+	return decision, ok
+}
+
+// Category returns a category by ID
+func (s *DecisionSet) Category(id string) (*DecisionCategory, bool) {
+	category, ok := s.categories[id]
+	return category, ok
+}
+
+// Count returns the number of distinct decision IDs
+func (s *DecisionSet) Count() int {
+	return len(s.decisions)
+}
+
+// All returns the decisions sorted by category then ID
+func (s *DecisionSet) All() []*Decision {
+	decisions := make([]*Decision, 0, len(s.decisions))
+	for _, decision := range s.decisions {
+		decisions = append(decisions, decision)
+	}
+	sort.Slice(decisions, func(a, b int) bool {
+		if decisions[a].Category != decisions[b].Category {
+			return decisions[a].Category < decisions[b].Category
+		}
+		return decisions[a].ID < decisions[b].ID
+	})
+	return decisions
+}
+
+// UnlockedBy returns the decisions a focus unlocks, in All() order
+func (s *DecisionSet) UnlockedBy(focus *Focus) []*Decision {
+	decisions := make([]*Decision, 0)
+	for _, decision := range s.All() {
+		if decision.UnlockedBy(focus) {
+			decisions = append(decisions, decision)
+		}
+	}
+	return decisions
+}
+
+// DirtyFiles returns decision files edited since load
+func (s *DecisionSet) DirtyFiles() []*DecisionFile {
+	files := make([]*DecisionFile, 0)
+	for _, file := range s.Files {
+		if file.Dirty {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// DirtyCategoryFiles returns category files edited since load
+func (s *DecisionSet) DirtyCategoryFiles() []*DecisionCategoryFile {
+	files := make([]*DecisionCategoryFile, 0)
+	for _, file := range s.CategoryFiles {
+		if file.Dirty {
+			files = append(files, file)
+		}
+	}
+	return files
+}
@@ -0,0 +1,282 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChangeKind is how a node differs between two versions of a tree
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// Field groups of a change
+const (
+	FieldPosition = "position" // x, y, relative_position_id, folder
+	FieldLink     = "link"     // prerequisite, mutually_exclusive, path, xor
+	FieldValue    = "value"    // Everything else
+)
+
+// FieldChange is a field with different values in the two versions
+type FieldChange struct {
+	Field string `json:"field"`
+	Group string `json:"group"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// NodeChange is a focus or technology that differs between two versions
+type NodeChange struct {
+	ID     string        `json:"id"`
+	Kind   ChangeKind    `json:"kind"`
+	Fields []FieldChange `json:"fields,omitempty"` // Modified nodes only
+}
+
+// Moved reports whether the position of a modified node changed
+func (c NodeChange) Moved() bool {
+	return c.hasGroup(FieldPosition)
+}
+
+// LinksChanged reports whether the links of a modified node changed
+func (c NodeChange) LinksChanged() bool {
+	return c.hasGroup(FieldLink)
+}
+
+// hasGroup reports whether a field of the group changed
+func (c NodeChange) hasGroup(group string) bool {
+	for _, field := range c.Fields {
+		if field.Group == group {
+			return true
+		}
+	}
+	return false
+}
+
+// Lines describes the change, one line per field
+func (c NodeChange) Lines() []string {
+	if c.Kind != ChangeModified {
+		return []string{string(c.Kind)}
+	}
+	lines := make([]string, len(c.Fields))
+	for i, field := range c.Fields {
+		lines[i] = fmt.Sprintf("%s: %s -> %s", field.Field, diffValue(field.Old), diffValue(field.New))
+	}
+	return lines
+}
+
+// diffValue shows empty values as "-"
+func diffValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// TreeDiff is the semantic difference between two versions of a tree:
+// nodes are matched by ID, so moving a block in the file is no change
+type TreeDiff struct {
+	Changes []NodeChange `json:"changes"` // Sorted by ID
+}
+
+// Change returns the change of a node
+func (d *TreeDiff) Change(id string) (NodeChange, bool) {
+	for _, change := range d.Changes {
+		if change.ID == id {
+			return change, true
+		}
+	}
+	return NodeChange{}, false
+}
+
+// Count returns the number of changes of a kind
+func (d *TreeDiff) Count(kind ChangeKind) int {
+	count := 0
+	for _, change := range d.Changes {
+		if change.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// Summary returns "N added, N removed, N modified"
+func (d *TreeDiff) Summary() string {
+	return fmt.Sprintf("%d added, %d removed, %d modified", d.Count(ChangeAdded), d.Count(ChangeRemoved), d.Count(ChangeModified))
+}
+
+// diffField is a comparable field of a node
+type diffField struct {
+	name, group, value string
+}
+
+// DiffFocusTrees compares two versions of a focus tree
+func DiffFocusTrees(old, new *FocusTree) *TreeDiff {
+	oldFields := make(map[string][]diffField, len(old.Focuses))
+	for id, focus := range old.Focuses {
+		oldFields[id] = focusDiffFields(focus)
+	}
+	newFields := make(map[string][]diffField, len(new.Focuses))
+	for id, focus := range new.Focuses {
+		newFields[id] = focusDiffFields(focus)
+	}
+	return diffNodes(oldFields, newFields)
+}
+
+// DiffTechnologyTrees compares two versions of a technology tree
+func DiffTechnologyTrees(old, new *TechnologyTree) *TreeDiff {
+	oldFields := make(map[string][]diffField, len(old.Technologies))
+	for id, tech := range old.Technologies {
+		oldFields[id] = techDiffFields(tech)
+	}
+	newFields := make(map[string][]diffField, len(new.Technologies))
+	for id, tech := range new.Technologies {
+		newFields[id] = techDiffFields(tech)
+	}
+	return diffNodes(oldFields, newFields)
+}
+
+// CompareFocuses returns the fields that differ between two versions of a focus
+func CompareFocuses(old, new *Focus) []FieldChange {
+	return compareFields(focusDiffFields(old), focusDiffFields(new))
+}
+
+// CompareTechnologies returns the fields that differ between two versions of a technology
+func CompareTechnologies(old, new *Technology) []FieldChange {
+	return compareFields(techDiffFields(old), techDiffFields(new))
+}
+
+// compareFields returns the changed fields of two field lists of one node type
+func compareFields(old, new []diffField) []FieldChange {
+	changed := make([]FieldChange, 0)
+	for i, field := range new {
+		if old[i].value != field.value {
+			changed = append(changed, FieldChange{Field: field.name, Group: field.group, Old: old[i].value, New: field.value})
+		}
+	}
+	return changed
+}
+
+// diffNodes compares the fields of nodes matched by ID
+func diffNodes(old, new map[string][]diffField) *TreeDiff {
+	diff := &TreeDiff{Changes: make([]NodeChange, 0)}
+	for id, fields := range new {
+		oldFields, exists := old[id]
+		if !exists {
+			diff.Changes = append(diff.Changes, NodeChange{ID: id, Kind: ChangeAdded})
+			continue
+		}
+		if changed := compareFields(oldFields, fields); len(changed) > 0 {
+			diff.Changes = append(diff.Changes, NodeChange{ID: id, Kind: ChangeModified, Fields: changed})
+		}
+	}
+	for id := range old {
+		if _, exists := new[id]; !exists {
+			diff.Changes = append(diff.Changes, NodeChange{ID: id, Kind: ChangeRemoved})
+		}
+	}
+	sort.Slice(diff.Changes, func(i, j int) bool { return diff.Changes[i].ID < diff.Changes[j].ID })
+	return diff
+}
+
+// focusDiffFields returns the compared fields of a focus (always in the same order)
+func focusDiffFields(f *Focus) []diffField {
+	prerequisites := make([]string, len(f.Prerequisites))
+	for i, group := range f.Prerequisites {
+		prerequisites[i] = strings.Join(sortedCopy(group), " | ")
+	}
+	sort.Strings(prerequisites)
+
+	return []diffField{
+		{"x", FieldPosition, strconv.Itoa(f.Position.X)},
+		{"y", FieldPosition, strconv.Itoa(f.Position.Y)},
+		{"relative_position_id", FieldPosition, f.RelativePositionID},
+		{"prerequisite", FieldLink, strings.Join(prerequisites, ", ")},
+		{"mutually_exclusive", FieldLink, strings.Join(sortedCopy(f.MutuallyExclusive), ", ")},
+		{"icon", FieldValue, f.Icon},
+		{"cost", FieldValue, strconv.Itoa(f.Cost)},
+		{"available", FieldValue, compactValue(f.Available)},
+		{"bypass", FieldValue, compactValue(f.Bypass)},
+		{"cancel_if_invalid", FieldValue, strconv.FormatBool(f.CancelIfInvalid)},
+		{"continue_if_invalid", FieldValue, strconv.FormatBool(f.ContinueIfInvalid)},
+		{"available_if_capitulated", FieldValue, strconv.FormatBool(f.AvailableIfCapitulated)},
+		{"completion_reward", FieldValue, compactValue(f.CompletionReward)},
+		{"ai_will_do", FieldValue, compactValue(f.AIWillDo)},
+		{"search_filters", FieldValue, strings.Join(sortedCopy(f.SearchFilters), ", ")},
+	}
+}
+
+// techDiffFields returns the compared fields of a technology (always in the same order)
+func techDiffFields(t *Technology) []diffField {
+	paths := make([]string, len(t.Paths))
+	for i, path := range t.Paths {
+		paths[i] = fmt.Sprintf("%s (x%g)", path.LeadsToTech, path.ResearchCostCoeff)
+	}
+	sort.Strings(paths)
+
+	effects := make([]string, 0)
+	for category, modifiers := range t.Effects {
+		for name, value := range modifiers {
+			effects = append(effects, fmt.Sprintf("%s.%s = %g", category, name, value))
+		}
+	}
+	sort.Strings(effects)
+
+	weights := make([]string, 0, len(t.AIResearchWeights))
+	for name, value := range t.AIResearchWeights {
+		weights = append(weights, fmt.Sprintf("%s = %g", name, value))
+	}
+	sort.Strings(weights)
+
+	return []diffField{
+		{"x", FieldPosition, positionValue(t.Position.XVar, t.Position.X)},
+		{"y", FieldPosition, positionValue(t.Position.YVar, t.Position.Y)},
+		{"folder", FieldPosition, t.Folder},
+		{"path", FieldLink, strings.Join(paths, ", ")},
+		{"xor", FieldLink, strings.Join(sortedCopy(t.XOR), ", ")},
+		{"research_cost", FieldValue, strconv.FormatFloat(t.ResearchCost, 'g', -1, 64)},
+		{"start_year", FieldValue, strconv.Itoa(t.StartYear)},
+		{"allow", FieldValue, compactValue(t.Allow)},
+		{"categories", FieldValue, strings.Join(sortedCopy(t.Categories), ", ")},
+		{"effects", FieldValue, strings.Join(effects, ", ")},
+		{"xp_research_type", FieldValue, t.XPResearchType},
+		{"xp_boost_cost", FieldValue, strconv.Itoa(t.XPBoostCost)},
+		{"xp_research_bonus", FieldValue, strconv.FormatFloat(t.XPResearchBonus, 'g', -1, 64)},
+		{"xp_unlock_cost", FieldValue, strconv.Itoa(t.XPUnlockCost)},
+		{"doctrine", FieldValue, strconv.FormatBool(t.Doctrine)},
+		{"doctrine_name", FieldValue, t.DoctrineName},
+		{"on_research_complete", FieldValue, compactValue(t.OnResearchComplete)},
+		{"ai_will_do", FieldValue, compactValue(t.AIWillDo)},
+		{"ai_research_weights", FieldValue, strings.Join(weights, ", ")},
+		{"enable_tactic", FieldValue, t.EnableTactic},
+		{"enable_building", FieldValue, t.EnableBuilding},
+		{"enable_equipments", FieldValue, strings.Join(sortedCopy(t.EnableEquipments), ", ")},
+		{"enable_subunits", FieldValue, strings.Join(sortedCopy(t.EnableSubunits), ", ")},
+	}
+}
+
+// positionValue shows a coordinate with its variable ("@1940 (10)")
+func positionValue(variable string, value int) string {
+	if variable == "" {
+		return strconv.Itoa(value)
+	}
+	return fmt.Sprintf("%s (%d)", variable, value)
+}
+
+// compactValue collapses whitespace of raw script blocks, so reindenting
+// is no change
+func compactValue(raw string) string {
+	return strings.Join(strings.Fields(raw), " ")
+}
+
+// sortedCopy returns a sorted copy of values
+func sortedCopy(values []string) []string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
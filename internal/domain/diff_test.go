@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffFocusTrees(t *testing.T) {
+	old, changed := newLayoutTree(), newLayoutTree()
+	old.Focuses["TST_a"].Available = "{ always = yes }"
+
+	// TST_mid removed, TST_new added, TST_b moved, TST_left relinked,
+	// TST_root costs more, TST_a only reindented
+	delete(changed.Focuses, "TST_mid")
+	changed.AddFocus(&Focus{ID: "TST_new", Position: NewPosition(20, 0)})
+	changed.Focuses["TST_b"].Position.X = 9
+	changed.Focuses["TST_left"].MutuallyExclusive = []string{"TST_b"}
+	changed.Focuses["TST_root"].Cost = 10
+	changed.Focuses["TST_a"].Available = "{\n\talways = yes\n}"
+
+	diff := DiffFocusTrees(old, changed)
+	if diff.Summary() != "1 added, 1 removed, 3 modified" {
+		t.Fatalf("Unexpected diff: %s %+v", diff.Summary(), diff.Changes)
+	}
+	if change, _ := diff.Change("TST_new"); change.Kind != ChangeAdded {
+		t.Errorf("TST_new not added: %+v", change)
+	}
+	if change, _ := diff.Change("TST_mid"); change.Kind != ChangeRemoved {
+		t.Errorf("TST_mid not removed: %+v", change)
+	}
+	if _, ok := diff.Change("TST_a"); ok {
+		t.Error("Reindented TST_a reported as changed")
+	}
+
+	b, _ := diff.Change("TST_b")
+	if !b.Moved() || b.LinksChanged() || strings.Join(b.Lines(), "; ") != "x: 8 -> 9" {
+		t.Errorf("Unexpected TST_b change: %v", b.Lines())
+	}
+	left, _ := diff.Change("TST_left")
+	if !left.LinksChanged() || left.Moved() || left.Fields[0].Old != "TST_right" || left.Fields[0].New != "TST_b" {
+		t.Errorf("Unexpected TST_left change: %+v", left)
+	}
+	root, _ := diff.Change("TST_root")
+	if len(root.Fields) != 1 || root.Fields[0].Field != "cost" || root.Fields[0].Group != FieldValue {
+		t.Errorf("Unexpected TST_root change: %+v", root)
+	}
+}
+
+func TestDiffTechnologyTrees(t *testing.T) {
+	tree := func(change func(techs []*Technology)) *TechnologyTree {
+		techs, _ := newTechGrid()
+		change(techs)
+		tree := NewTechnologyTree()
+		for _, tech := range techs {
+			tree.AddTechnology(tech)
+		}
+		return tree
+	}
+
+	old := tree(func([]*Technology) {})
+	changed := tree(func(techs []*Technology) {
+		radar := techByID(techs, "tech_radar")
+		radar.Position.YVar = "@1938"
+		radar.Paths[0].ResearchCostCoeff = 2
+	})
+
+	diff := DiffTechnologyTrees(old, changed)
+	radar, ok := diff.Change("tech_radar")
+	if len(diff.Changes) != 1 || !ok {
+		t.Fatalf("Unexpected diff: %+v", diff.Changes)
+	}
+	want := "y: 6 -> @1938 (6); path: tech_rocket (x1) -> tech_rocket (x2)"
+	if got := strings.Join(radar.Lines(), "; "); got != want {
+		t.Errorf("Got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,263 @@
+package domain
+
+import "sort"
+
+// Doctrine layout in canvas grid cells
+const (
+	doctrineColumnWidth = 3 // Cells per node column
+	doctrineRowHeight   = 2 // Cells per depth row
+	doctrineGrandGap    = 2 // Empty cells between grand doctrines
+)
+
+// DoctrineTree is a doctrine folder split into grand doctrines (the roots,
+// usually mutually exclusive) and their sub-doctrine tracks
+type DoctrineTree struct {
+	Folder string
+	Grand  []*GrandDoctrine
+}
+
+// GrandDoctrine is a doctrine root and everything researched after it
+type GrandDoctrine struct {
+	Root      *Technology
+	Exclusive []string      // xor: grand doctrines locked by researching this one
+	Core      []*Technology // Technologies before the first branch choice
+	Tracks    []*DoctrineTrack
+
+	depth map[string]int // Steps from the root
+}
+
+// DoctrineTrack is a sub-doctrine: a branch starting at a technology
+// that excludes (xor) another technology of the same grand doctrine
+type DoctrineTrack struct {
+	Start        *Technology
+	Exclusive    []string // xor: tracks locked by researching this one
+	Technologies []*Technology
+}
+
+// Name returns the doctrine_name of the root, or its ID
+func (g *GrandDoctrine) Name() string {
+	return doctrineName(g.Root)
+}
+
+// Name returns the doctrine_name of the first technology, or its ID
+func (t *DoctrineTrack) Name() string {
+	return doctrineName(t.Start)
+}
+
+// doctrineName returns a technology's doctrine_name, or its ID
+func doctrineName(tech *Technology) string {
+	if tech.DoctrineName != "" {
+		return tech.DoctrineName
+	}
+	return tech.ID
+}
+
+// DoctrineCost is the total cost of a set of doctrine technologies
+type DoctrineCost struct {
+	Research float64 // Sum of research_cost
+	XPBoost  int     // XP to boost every technology (xp_boost_cost)
+	XPUnlock int     // XP to unlock (xp_unlock_cost, doctrine mastery)
+}
+
+// doctrineCost sums the costs of technologies
+func doctrineCost(technologies []*Technology) DoctrineCost {
+	cost := DoctrineCost{}
+	for _, tech := range technologies {
+		cost.Research += tech.ResearchCost
+		cost.XPBoost += tech.XPBoostCost
+		cost.XPUnlock += tech.XPUnlockCost
+	}
+	return cost
+}
+
+// Cost returns the cost of the core technologies (root included)
+func (g *GrandDoctrine) Cost() DoctrineCost {
+	return doctrineCost(g.Core)
+}
+
+// Cost returns the cost of the track's technologies
+func (t *DoctrineTrack) Cost() DoctrineCost {
+	return doctrineCost(t.Technologies)
+}
+
+// IsDoctrineFolder reports whether most technologies of a folder are
+// doctrines (doctrine = yes or researched with XP)
+func IsDoctrineFolder(technologies []*Technology) bool {
+	doctrines := 0
+	for _, tech := range technologies {
+		if tech.Doctrine || tech.XPResearchType != "" {
+			doctrines++
+		}
+	}
+	return len(technologies) > 0 && doctrines*2 > len(technologies)
+}
+
+// BuildDoctrineTree splits a doctrine folder into grand doctrines: every
+// technology without an incoming path in the folder is a root, and the
+// technologies reachable from it belong to it (first root by position wins).
+// Inside a grand doctrine a technology whose xor names another technology of
+// the same grand doctrine starts a sub-doctrine track
+func BuildDoctrineTree(folder string, technologies []*Technology) *DoctrineTree {
+	byID := make(map[string]*Technology, len(technologies))
+	for _, tech := range technologies {
+		byID[tech.ID] = tech
+	}
+	incoming := make(map[string]bool)
+	for _, tech := range technologies {
+		for _, path := range tech.Paths {
+			if _, ok := byID[path.LeadsToTech]; ok && path.LeadsToTech != tech.ID {
+				incoming[path.LeadsToTech] = true
+			}
+		}
+	}
+
+	roots := make([]*Technology, 0)
+	for _, tech := range technologies {
+		if !incoming[tech.ID] {
+			roots = append(roots, tech)
+		}
+	}
+	sortByPosition(roots)
+
+	tree := &DoctrineTree{Folder: folder, Grand: make([]*GrandDoctrine, 0, len(roots))}
+	owner := make(map[string]*GrandDoctrine)
+	for _, root := range roots {
+		grand := &GrandDoctrine{Root: root, Exclusive: root.XOR, depth: map[string]int{root.ID: 0}}
+		queue := []*Technology{root}
+		owner[root.ID] = grand
+		members := []*Technology{root}
+		for len(queue) > 0 {
+			tech := queue[0]
+			queue = queue[1:]
+			for _, path := range tech.Paths {
+				next, ok := byID[path.LeadsToTech]
+				if !ok || owner[next.ID] != nil {
+					continue
+				}
+				owner[next.ID] = grand
+				grand.depth[next.ID] = grand.depth[tech.ID] + 1
+				members = append(members, next)
+				queue = append(queue, next)
+			}
+		}
+		grand.split(members, byID)
+		tree.Grand = append(tree.Grand, grand)
+	}
+
+	return tree
+}
+
+// split assigns the members of a grand doctrine to the core or to tracks
+func (g *GrandDoctrine) split(members []*Technology, byID map[string]*Technology) {
+	inGrand := make(map[string]bool, len(members))
+	for _, tech := range members {
+		inGrand[tech.ID] = true
+	}
+
+	// Track starts: xor with another member
+	track := make(map[string]*DoctrineTrack)
+	for _, tech := range members {
+		for _, other := range tech.XOR {
+			if inGrand[other] && tech != g.Root {
+				g.Tracks = append(g.Tracks, &DoctrineTrack{Start: tech, Exclusive: tech.XOR})
+				break
+			}
+		}
+	}
+	sort.SliceStable(g.Tracks, func(i, j int) bool {
+		a, b := g.Tracks[i].Start, g.Tracks[j].Start
+		if g.depth[a.ID] != g.depth[b.ID] {
+			return g.depth[a.ID] < g.depth[b.ID]
+		}
+		return lessByPosition(a, b)
+	})
+
+	// Breadth-first from each track start, stopping at other track starts
+	starts := make(map[string]bool, len(g.Tracks))
+	for _, t := range g.Tracks {
+		starts[t.Start.ID] = true
+	}
+	for _, t := range g.Tracks {
+		queue := []*Technology{t.Start}
+		for len(queue) > 0 {
+			tech := queue[0]
+			queue = queue[1:]
+			if track[tech.ID] != nil {
+				continue
+			}
+			track[tech.ID] = t
+			t.Technologies = append(t.Technologies, tech)
+			for _, path := range tech.Paths {
+				if next, ok := byID[path.LeadsToTech]; ok && inGrand[next.ID] && !starts[next.ID] {
+					queue = append(queue, next)
+				}
+			}
+		}
+	}
+
+	for _, tech := range members {
+		if track[tech.ID] == nil {
+			g.Core = append(g.Core, tech)
+		}
+	}
+}
+
+// Layout places the doctrine tree in grid cells: grand doctrines side by
+// side, each with its core on top and its tracks in columns below; rows
+// follow the steps from the grand doctrine root
+func (d *DoctrineTree) Layout() map[string]Position {
+	positions := make(map[string]Position)
+
+	x := 0
+	for _, grand := range d.Grand {
+		width := grand.placeRows(grand.Core, x, positions)
+		trackX := x
+		for _, track := range grand.Tracks {
+			trackX += grand.placeRows(track.Technologies, trackX, positions)
+		}
+		if trackX-x > width {
+			width = trackX - x
+		}
+		x += width + doctrineGrandGap
+	}
+
+	return positions
+}
+
+// placeRows places technologies one row per depth starting at column x and
+// returns the width used (in cells)
+func (g *GrandDoctrine) placeRows(technologies []*Technology, x int, positions map[string]Position) int {
+	ordered := append([]*Technology{}, technologies...)
+	sortByPosition(ordered)
+
+	columns := make(map[int]int) // depth -> technologies placed
+	width := 0
+	for _, tech := range ordered {
+		depth := g.depth[tech.ID]
+		column := columns[depth]
+		columns[depth]++
+		positions[tech.ID] = NewPosition(x+column*doctrineColumnWidth, 1+depth*doctrineRowHeight)
+		if (column+1)*doctrineColumnWidth > width {
+			width = (column + 1) * doctrineColumnWidth
+		}
+	}
+	return width
+}
+
+// sortByPosition orders technologies by their folder position
+func sortByPosition(technologies []*Technology) {
+	sort.SliceStable(technologies, func(i, j int) bool {
+		return lessByPosition(technologies[i], technologies[j])
+	})
+}
+
+// lessByPosition orders by X, then Y, then ID
+func lessByPosition(a, b *Technology) bool {
+	if a.Position.X != b.Position.X {
+		return a.Position.X < b.Position.X
+	}
+	if a.Position.Y != b.Position.Y {
+		return a.Position.Y < b.Position.Y
+	}
+	return a.ID < b.ID
+}
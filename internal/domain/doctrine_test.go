@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"testing"
+)
+
+// newDoctrineFolder builds two exclusive grand doctrines; mobile_warfare
+// branches into the exclusive delay (with mass_motorization) and
+// elastic_defence tracks
+func newDoctrineFolder() []*Technology {
+	tech := func(id string, cost float64, x, y int, leadsTo ...string) *Technology {
+		t := NewTechnology(id, x, y, "land_doctrine_folder")
+		t.XPResearchType = "army"
+		t.ResearchCost = cost
+		for _, target := range leadsTo {
+			t.AddPath(target, 1)
+		}
+		return t
+	}
+
+	mobile := tech("mobile_warfare", 3, 0, 0, "delay", "elastic_defence")
+	mobile.Doctrine, mobile.DoctrineName, mobile.XPBoostCost = true, "MOBILE_WARFARE", 50
+	mobile.XOR = []string{"superior_firepower"}
+	delay := tech("delay", 2, -2, 2, "mass_motorization")
+	delay.XOR, delay.XPUnlockCost = []string{"elastic_defence"}, 100
+	elastic := tech("elastic_defence", 2, 2, 2)
+	elastic.XOR = []string{"delay"}
+	firepower := tech("superior_firepower", 3, 10, 0)
+	firepower.Doctrine, firepower.XOR = true, []string{"mobile_warfare"}
+
+	return []*Technology{mobile, delay, elastic, tech("mass_motorization", 2, -2, 4), firepower}
+}
+
+func TestDoctrineTree(t *testing.T) {
+	techs := newDoctrineFolder()
+	if !IsDoctrineFolder(techs) {
+		t.Fatal("Expected a doctrine folder")
+	}
+
+	tree := BuildDoctrineTree("land_doctrine_folder", techs)
+	if len(tree.Grand) != 2 {
+		t.Fatalf("Expected 2 grand doctrines, got %d", len(tree.Grand))
+	}
+
+	mobile := tree.Grand[0]
+	if mobile.Name() != "MOBILE_WARFARE" || len(mobile.Exclusive) != 1 || mobile.Exclusive[0] != "superior_firepower" {
+		t.Errorf("Unexpected grand doctrine: %s xor %v", mobile.Name(), mobile.Exclusive)
+	}
+	if len(mobile.Core) != 1 || mobile.Core[0].ID != "mobile_warfare" {
+		t.Errorf("Unexpected core: %v", mobile.Core)
+	}
+	if len(mobile.Tracks) != 2 {
+		t.Fatalf("Expected 2 tracks, got %d", len(mobile.Tracks))
+	}
+	delay := mobile.Tracks[0]
+	if delay.Name() != "delay" || len(delay.Technologies) != 2 || delay.Technologies[1].ID != "mass_motorization" {
+		t.Errorf("Unexpected track: %s %v", delay.Name(), delay.Technologies)
+	}
+	if cost := delay.Cost(); cost.Research != 4 || cost.XPUnlock != 100 {
+		t.Errorf("Unexpected track cost: %+v", cost)
+	}
+	if cost := mobile.Cost(); cost.XPBoost != 50 {
+		t.Errorf("Unexpected core cost: %+v", cost)
+	}
+
+	layout := tree.Layout()
+	if pos := layout["mobile_warfare"]; pos.X != 0 || pos.Y != 1 {
+		t.Errorf("mobile_warfare at %+v", pos)
+	}
+	if pos := layout["mass_motorization"]; pos.X != 0 || pos.Y != 5 {
+		t.Errorf("mass_motorization at %+v", pos)
+	}
+	if pos := layout["elastic_defence"]; pos.X != 3 || pos.Y != 3 {
+		t.Errorf("elastic_defence at %+v", pos)
+	}
+	if pos := layout["superior_firepower"]; pos.X != 8 || pos.Y != 1 {
+		t.Errorf("superior_firepower at %+v", pos)
+	}
+}
@@ -0,0 +1,207 @@
+package domain
+
+import "sort"
+
+// Equipment is an equipment type declared in common/units/equipment
+type Equipment struct {
+	ID          string
+	IsArchetype bool
+	Archetype   string             // Archetype the equipment inherits stats from
+	Parent      string             // Previous equipment in the upgrade line
+	Year        int                // Year shown in the production view
+	Stats       map[string]float64 // Numeric stats (soft_attack, build_cost_ic, ...)
+	Resources   map[string]int     // resources = { steel = 2 }
+	File        *EquipmentFile
+}
+
+// NewEquipment creates an equipment with no stats
+func NewEquipment(id string) *Equipment {
+	return &Equipment{
+		ID:        id,
+		Stats:     make(map[string]float64),
+		Resources: make(map[string]int),
+	}
+}
+
+// EquipmentFile is a common/units/equipment/*.txt file
+type EquipmentFile struct {
+	Path      string
+	RelPath   string
+	Source    string // "mod" or "game"
+	Equipment []*Equipment
+}
+
+// SubUnit is a battalion/company type declared in common/units
+type SubUnit struct {
+	ID           string
+	Abbreviation string
+	Group        string             // infantry, mobile, armor, support, ...
+	Categories   []string           // category_front_line, ...
+	Stats        map[string]float64 // Numeric stats (combat_width, max_strength, ...)
+	Need         map[string]int     // Equipment archetype -> amount
+	File         *SubUnitFile
+}
+
+// NewSubUnit creates a sub-unit with no stats
+func NewSubUnit(id string) *SubUnit {
+	return &SubUnit{
+		ID:         id,
+		Categories: make([]string, 0),
+		Stats:      make(map[string]float64),
+		Need:       make(map[string]int),
+	}
+}
+
+// SubUnitFile is a common/units/*.txt file
+type SubUnitFile struct {
+	Path     string
+	RelPath  string
+	Source   string // "mod" or "game"
+	SubUnits []*SubUnit
+}
+
+// EquipmentSet indexes equipment and sub-units by ID (later files win)
+type EquipmentSet struct {
+	Files     []*EquipmentFile
+	UnitFiles []*SubUnitFile
+	equipment map[string]*Equipment
+	units     map[string]*SubUnit
+}
+
+// NewEquipmentSet creates an index over the files
+func NewEquipmentSet(files []*EquipmentFile, unitFiles []*SubUnitFile) *EquipmentSet {
+	set := &EquipmentSet{
+		Files:     files,
+		UnitFiles: unitFiles,
+		equipment: make(map[string]*Equipment),
+		units:     make(map[string]*SubUnit),
+	}
+	for _, file := range files {
+		for _, equipment := range file.Equipment {
+			set.equipment[equipment.ID] = equipment
+		}
+	}
+	for _, file := range unitFiles {
+		for _, unit := range file.SubUnits {
+			set.units[unit.ID] = unit
+		}
+	}
+	return set
+}
+
+// Get returns an equipment by ID
+func (s *EquipmentSet) Get(id string) (*Equipment, bool) {
+	equipment, ok := s.equipment[id]
+	return equipment, ok
+}
+
+// SubUnit returns a sub-unit by ID
+func (s *EquipmentSet) SubUnit(id string) (*SubUnit, bool) {
+	unit, ok := s.units[id]
+	return unit, ok
+}
+
+// Count returns the number of equipment and sub-unit IDs
+func (s *EquipmentSet) Count() (equipment, units int) {
+	return len(s.equipment), len(s.units)
+}
+
+// Stats returns an equipment's effective stats: the archetype's stats
+// overridden by the equipment's own
+func (s *EquipmentSet) Stats(id string) map[string]float64 {
+	stats := make(map[string]float64)
+	equipment, ok := s.equipment[id]
+	if !ok {
+		return stats
+	}
+	if archetype, ok := s.equipment[equipment.Archetype]; ok && archetype != equipment {
+		for key, value := range archetype.Stats {
+			stats[key] = value
+		}
+	}
+	for key, value := range equipment.Stats {
+		stats[key] = value
+	}
+	return stats
+}
+
+// EquipmentLineageNode is an equipment in a lineage
+type EquipmentLineageNode struct {
+	Equipment *Equipment
+	Depth     int // Upgrade steps from the first equipment of the line
+	Column    int // Position among equipment of the same depth
+}
+
+// Lineage returns the archetype of an equipment (nil if undeclared) and every
+// equipment sharing it, ordered by parent links: each equipment is placed one
+// step after its parent; equipment without a known parent start a line
+func (s *EquipmentSet) Lineage(id string) (*Equipment, []*EquipmentLineageNode) {
+	equipment, ok := s.equipment[id]
+	if !ok {
+		return nil, nil
+	}
+	archetypeID := equipment.Archetype
+	if equipment.IsArchetype {
+		archetypeID = equipment.ID
+	}
+	archetype := s.equipment[archetypeID]
+
+	members := make([]*Equipment, 0)
+	for _, candidate := range s.equipment {
+		if !candidate.IsArchetype && (candidate.Archetype == archetypeID || candidate.ID == id) {
+			members = append(members, candidate)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].Year != members[j].Year {
+			return members[i].Year < members[j].Year
+		}
+		return members[i].ID < members[j].ID
+	})
+
+	inLine := make(map[string]*Equipment, len(members))
+	children := make(map[string][]*Equipment)
+	for _, member := range members {
+		inLine[member.ID] = member
+	}
+	roots := make([]*Equipment, 0)
+	for _, member := range members {
+		if _, ok := inLine[member.Parent]; ok && member.Parent != member.ID {
+			children[member.Parent] = append(children[member.Parent], member)
+		} else {
+			roots = append(roots, member)
+		}
+	}
+
+	// Breadth-first from the roots, then from members of parent cycles
+	nodes := make([]*EquipmentLineageNode, 0, len(members))
+	placed := make(map[string]bool)
+	columns := make(map[int]int)
+	walk := func(start *Equipment) {
+		queue := []*EquipmentLineageNode{{Equipment: start}}
+		for len(queue) > 0 {
+			node := queue[0]
+			queue = queue[1:]
+			if placed[node.Equipment.ID] {
+				continue
+			}
+			placed[node.Equipment.ID] = true
+			node.Column = columns[node.Depth]
+			columns[node.Depth]++
+			nodes = append(nodes, node)
+			for _, child := range children[node.Equipment.ID] {
+				queue = append(queue, &EquipmentLineageNode{Equipment: child, Depth: node.Depth + 1})
+			}
+		}
+	}
+	for _, root := range roots {
+		walk(root)
+	}
+	for _, member := range members {
+		if !placed[member.ID] {
+			walk(member)
+		}
+	}
+
+	return archetype, nodes
+}
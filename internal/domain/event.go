@@ -0,0 +1,171 @@
+package domain
+
+import "sort"
+
+// EventTypes are the top-level keys that declare events and the effects
+// that fire them
+var EventTypes = []string{"country_event", "news_event", "state_event", "unit_leader_event", "operative_leader_event"}
+
+// EventOption is a single option of an event
+type EventOption struct {
+	Name   string   // Localisation key
+	Body   string   // Raw option block
+	Events []string // Events fired by the option
+}
+
+// Event is an event declared in events/*.txt
+type Event struct {
+	ID              string
+	Type            string // country_event, news_event, ...
+	Title           string // Localisation key
+	Desc            string
+	Picture         string
+	IsTriggeredOnly bool
+	FireOnlyOnce    bool
+	Hidden          bool
+	Trigger         string // Raw trigger block
+	Immediate       string // Raw immediate block
+	ImmediateEvents []string
+	Options         []*EventOption
+	File            *EventFile
+}
+
+// EventFile is an events/*.txt file
+type EventFile struct {
+	Path       string
+	RelPath    string
+	Source     string // "mod" or "game"
+	Namespaces []string
+	Events     []*Event
+}
+
+// EventSet indexes events from several files by ID (later files win)
+type EventSet struct {
+	Files  []*EventFile
+	events map[string]*Event
+}
+
+// NewEventSet creates an index over the files
+func NewEventSet(files []*EventFile) *EventSet {
+	set := &EventSet{
+		Files:  files,
+		events: make(map[string]*Event),
+	}
+	for _, file := range files {
+		for _, event := range file.Events {
+			set.events[event.ID] = event
+		}
+	}
+	return set
+}
+
+// Get returns an event by ID
+func (s *EventSet) Get(id string) (*Event, bool) {
+	event, ok := s.events[id]
+	return event, ok
+}
+
+// Count returns the number of distinct event IDs
+func (s *EventSet) Count() int {
+	return len(s.events)
+}
+
+// EventChainNode is an event in a chain; Event is nil for dangling IDs
+type EventChainNode struct {
+	ID     string
+	Event  *Event
+	Layer  int // Distance from the chain roots (roots are layer 0)
+	Column int // Position within the layer
+}
+
+// EventChainEdge links an event to an event it fires
+type EventChainEdge struct {
+	From   string
+	To     string
+	Source string // Option name, or "immediate"
+}
+
+// EventChain is the graph of events reachable from a set of roots
+type EventChain struct {
+	Nodes []*EventChainNode // In breadth-first order
+	Edges []EventChainEdge
+	index map[string]*EventChainNode
+}
+
+// Node returns a chain node by event ID
+func (c *EventChain) Node(id string) (*EventChainNode, bool) {
+	node, ok := c.index[id]
+	return node, ok
+}
+
+// Layers returns the number of layers in the chain
+func (c *EventChain) Layers() int {
+	layers := 0
+	for _, node := range c.Nodes {
+		if node.Layer+1 > layers {
+			layers = node.Layer + 1
+		}
+	}
+	return layers
+}
+
+// Missing returns referenced event IDs that are not declared, sorted
+func (c *EventChain) Missing() []string {
+	missing := make([]string, 0)
+	for _, node := range c.Nodes {
+		if node.Event == nil {
+			missing = append(missing, node.ID)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// Chain walks fired events breadth-first from the roots; each event is
+// placed once, on the layer where it is first reached
+func (s *EventSet) Chain(roots []string) *EventChain {
+	chain := &EventChain{
+		Nodes: make([]*EventChainNode, 0),
+		Edges: make([]EventChainEdge, 0),
+		index: make(map[string]*EventChainNode),
+	}
+	columns := make(map[int]int) // layer -> next column
+
+	add := func(id string, layer int) {
+		if _, ok := chain.index[id]; ok {
+			return
+		}
+		node := &EventChainNode{ID: id, Layer: layer, Column: columns[layer]}
+		node.Event, _ = s.Get(id)
+		columns[layer]++
+		chain.index[id] = node
+		chain.Nodes = append(chain.Nodes, node)
+	}
+
+	for _, id := range roots {
+		add(id, 0)
+	}
+
+	// Nodes grows while iterating: this is the BFS queue
+	for i := 0; i < len(chain.Nodes); i++ {
+		node := chain.Nodes[i]
+		if node.Event == nil {
+			continue
+		}
+
+		link := func(to, source string) {
+			chain.Edges = append(chain.Edges, EventChainEdge{From: node.ID, To: to, Source: source})
+			add(to, node.Layer+1)
+		}
+		for _, id := range node.Event.ImmediateEvents {
+			link(id, "immediate")
+		}
+		for _, option := range node.Event.Options {
+			for _, id := range option.Events {
+				link(id, option.Name)
+			}
+		}
+	}
+
+	return chain
+}
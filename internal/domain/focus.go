@@ -5,6 +5,7 @@ type Focus struct {
 	// Identification
 	ID   string
 	Icon string
+	Line int // Line of the focus = { ... } block in its file (0 if unknown)
 
 	// Position
 	Position             Position
@@ -23,9 +24,15 @@ type Focus struct {
 	AvailableIfCapitulated  bool
 	
 	// Rewards and AI
-	CompletionReward string   // Raw reward block
-	AIWillDo         string   // AI priority block
-	SearchFilters    []string // UI search filters
+	CompletionReward string     // Raw reward block
+	RewardIdeas      []string   // Ideas granted by add_ideas / add_timed_idea in the reward
+	RewardDecisions  []string   // Decisions/categories shown by unlock_decision(_category)_tooltip
+	RewardFlags      []string   // Country flags set by set_country_flag in the reward
+	RewardEvents     []string   // Events fired by country_event / news_event in the reward
+	RewardCharacters []string   // Characters recruited/promoted/given roles in the reward
+	RewardModifiers  []Modifier // Lines of modifier = { ... } blocks in the reward
+	AIWillDo         string     // AI priority block
+	SearchFilters    []string   // UI search filters
 }
 
 // NewFocus creates a new Focus with required fields
@@ -38,21 +45,6 @@ func NewFocus(id string, x, y int) *Focus {
 	}
 }
 
-// Validate checks if the focus is valid
-func (f *Focus) Validate() []string {
-	errors := make([]string, 0)
-	
-	if f.ID == "" {
-		errors = append(errors, "focus ID cannot be empty")
-	}
-	
-	if f.Cost <= 0 {
-		errors = append(errors, "focus cost must be positive")
-	}
-	
-	return errors
-}
-
 // HasPrerequisite checks if this focus has any prerequisites
 func (f *Focus) HasPrerequisite() bool {
 	return len(f.Prerequisites) > 0
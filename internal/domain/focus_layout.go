@@ -0,0 +1,412 @@
+package domain
+
+import (
+	"math"
+	"sort"
+)
+
+// focusLayoutSweeps is the number of down/up barycenter sweeps
+const focusLayoutSweeps = 4
+
+// FocusLayoutMode selects how a layout is written to the focuses
+type FocusLayoutMode int
+
+const (
+	FocusLayoutAbsolute FocusLayoutMode = iota // x/y on every focus
+	FocusLayoutRelative                        // Offsets from the first prerequisite (relative_position_id)
+)
+
+// FocusLayoutOptions configures LayoutFocusTree
+type FocusLayoutOptions struct {
+	Mode    FocusLayoutMode
+	Locked  map[string]bool // Focuses that keep their current absolute position
+	Spacing int             // Columns between neighbours (0 = 2)
+}
+
+// FocusLayout is an arrangement of a focus tree in absolute positions
+type FocusLayout struct {
+	Positions map[string]Position
+	Layers    [][]string // Focus IDs per row, left to right
+
+	layer map[string]int
+}
+
+// LayoutFocusTree arranges a focus tree Sugiyama-style: focuses are layered
+// by prerequisite depth, layers are ordered by barycenter sweeps to reduce
+// edge crossings (mutually exclusive focuses stay adjacent) and columns are
+// assigned under the prerequisites. Locked focuses keep their position
+func LayoutFocusTree(tree *FocusTree, options FocusLayoutOptions) *FocusLayout {
+	spacing := options.Spacing
+	if spacing <= 0 {
+		spacing = 2
+	}
+	current := tree.AbsolutePositions()
+
+	ids := make([]string, 0, len(tree.Focuses))
+	for id := range tree.Focuses {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := current[ids[i]], current[ids[j]]
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		return ids[i] < ids[j]
+	})
+
+	parents := make(map[string][]string, len(ids))
+	children := make(map[string][]string, len(ids))
+	for _, id := range ids {
+		for _, group := range tree.Focuses[id].Prerequisites {
+			for _, parentID := range group {
+				if _, ok := tree.Focuses[parentID]; ok && parentID != id && !containsID(parents[id], parentID) {
+					parents[id] = append(parents[id], parentID)
+					children[parentID] = append(children[parentID], id)
+				}
+			}
+		}
+	}
+
+	layout := &FocusLayout{Positions: make(map[string]Position, len(ids)), layer: make(map[string]int, len(ids))}
+	layout.assignLayers(ids, parents, current, options.Locked)
+
+	// Layers in current left-to-right order
+	maxLayer := 0
+	for _, id := range ids {
+		if layout.layer[id] > maxLayer {
+			maxLayer = layout.layer[id]
+		}
+	}
+	layout.Layers = make([][]string, maxLayer+1)
+	for _, id := range ids {
+		layout.Layers[layout.layer[id]] = append(layout.Layers[layout.layer[id]], id)
+	}
+
+	// Crossing reduction: order by the mean rank of neighbours in other layers
+	rank := make(map[string]float64, len(ids))
+	updateRanks := func(layer []string) {
+		for i, id := range layer {
+			rank[id] = float64(i)
+		}
+	}
+	for _, layer := range layout.Layers {
+		updateRanks(layer)
+	}
+	for sweep := 0; sweep < focusLayoutSweeps; sweep++ {
+		for i := 1; i < len(layout.Layers); i++ {
+			layout.Layers[i] = orderLayer(tree, layout.Layers[i], parents, rank, current, options.Locked, spacing)
+			updateRanks(layout.Layers[i])
+		}
+		for i := len(layout.Layers) - 2; i >= 0; i-- {
+			layout.Layers[i] = orderLayer(tree, layout.Layers[i], children, rank, current, options.Locked, spacing)
+			updateRanks(layout.Layers[i])
+		}
+	}
+
+	layout.assignColumns(parents, children, current, options.Locked, spacing)
+	return layout
+}
+
+// assignLayers sets each focus's row: one below its deepest prerequisite,
+// or its current row when locked
+func (l *FocusLayout) assignLayers(ids []string, parents map[string][]string, current map[string]Position, locked map[string]bool) {
+	visiting := make(map[string]bool)
+	var visit func(id string) int
+	visit = func(id string) int {
+		if layer, ok := l.layer[id]; ok {
+			return layer
+		}
+		if locked[id] {
+			l.layer[id] = max(current[id].Y, 0)
+			return l.layer[id]
+		}
+		visiting[id] = true
+		layer := 0
+		for _, parentID := range parents[id] {
+			if !visiting[parentID] { // Cycles are cut
+				layer = max(layer, visit(parentID)+1)
+			}
+		}
+		visiting[id] = false
+		l.layer[id] = layer
+		return layer
+	}
+	for _, id := range ids {
+		visit(id)
+	}
+}
+
+// orderLayer sorts a layer by the barycenter of its neighbours' ranks,
+// keeping mutually exclusive focuses together as one block. Focuses without
+// neighbours keep their rank; locked focuses sort by their fixed column
+func orderLayer(tree *FocusTree, layer []string, neighbours map[string][]string, rank map[string]float64, current map[string]Position, locked map[string]bool, spacing int) []string {
+	key := make(map[string]float64, len(layer))
+	for _, id := range layer {
+		switch {
+		case locked[id]:
+			key[id] = float64(current[id].X) / float64(spacing)
+		case len(neighbours[id]) > 0:
+			sum := 0.0
+			for _, other := range neighbours[id] {
+				sum += rank[other]
+			}
+			key[id] = sum / float64(len(neighbours[id]))
+		default:
+			key[id] = rank[id]
+		}
+	}
+
+	// Blocks of mutually exclusive focuses (union-find over the layer)
+	block := make(map[string]string, len(layer))
+	var find func(id string) string
+	find = func(id string) string {
+		if block[id] == id {
+			return id
+		}
+		block[id] = find(block[id])
+		return block[id]
+	}
+	inLayer := make(map[string]bool, len(layer))
+	for _, id := range layer {
+		block[id] = id
+		inLayer[id] = true
+	}
+	for _, id := range layer {
+		for _, other := range tree.Focuses[id].MutuallyExclusive {
+			if inLayer[other] {
+				block[find(other)] = find(id)
+			}
+		}
+	}
+	blockKey := make(map[string]float64)
+	blockSize := make(map[string]int)
+	for _, id := range layer {
+		root := find(id)
+		blockKey[root] += key[id]
+		blockSize[root]++
+	}
+
+	ordered := append([]string{}, layer...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := find(ordered[i]), find(ordered[j])
+		if a != b {
+			ka, kb := blockKey[a]/float64(blockSize[a]), blockKey[b]/float64(blockSize[b])
+			if ka != kb {
+				return ka < kb
+			}
+			return a < b
+		}
+		return key[ordered[i]] < key[ordered[j]]
+	})
+	return ordered
+}
+
+// assignColumns places each layer left to right, every focus as close as
+// possible to the mean column of its prerequisites and at least spacing
+// apart; then focuses without prerequisites are centred over their children
+func (l *FocusLayout) assignColumns(parents, children map[string][]string, current map[string]Position, locked map[string]bool, spacing int) {
+	for y, layer := range l.Layers {
+		desired := make(map[string]int, len(layer))
+		for _, id := range layer {
+			if !locked[id] && len(parents[id]) > 0 {
+				desired[id] = l.meanColumn(parents[id])
+			}
+		}
+		l.placeLayer(y, layer, desired, current, locked, spacing, true)
+	}
+
+	for y := len(l.Layers) - 1; y >= 0; y-- {
+		layer := l.Layers[y]
+		desired := make(map[string]int, len(layer))
+		roots := 0
+		for _, id := range layer {
+			if len(parents[id]) == 0 && len(children[id]) > 0 {
+				desired[id] = l.meanColumn(children[id])
+				roots++
+			} else {
+				desired[id] = l.Positions[id].X
+			}
+		}
+		if roots > 0 {
+			l.placeLayer(y, layer, desired, current, locked, spacing, false)
+		}
+	}
+
+	for id := range l.Positions {
+		if locked[id] {
+			return
+		}
+	}
+	minX := math.MaxInt
+	for _, pos := range l.Positions {
+		minX = min(minX, pos.X)
+	}
+	for id, pos := range l.Positions {
+		pos.X -= minX
+		l.Positions[id] = pos
+	}
+}
+
+// placeLayer assigns the columns of a layer in order: each free focus at its
+// desired column (or right after its left neighbour) without coming closer
+// than spacing to a locked focus. With balance, a layer without locked
+// focuses is shifted so it is centred on the desired columns
+func (l *FocusLayout) placeLayer(y int, layer []string, desired map[string]int, current map[string]Position, locked map[string]bool, spacing int, balance bool) {
+	lockedColumns := make([]int, 0)
+	for _, id := range layer {
+		if locked[id] {
+			lockedColumns = append(lockedColumns, current[id].X)
+			l.Positions[id] = current[id]
+		}
+	}
+	conflicts := func(x int) bool {
+		for _, column := range lockedColumns {
+			if x > column-spacing && x < column+spacing {
+				return true
+			}
+		}
+		return false
+	}
+
+	next := math.MinInt
+	for _, id := range layer {
+		if locked[id] {
+			next = max(next, current[id].X+spacing)
+			continue
+		}
+		x, ok := desired[id]
+		if !ok {
+			x = 0
+			if next != math.MinInt {
+				x = next
+			}
+		}
+		if next != math.MinInt {
+			x = max(x, next)
+		}
+		for conflicts(x) {
+			x++
+		}
+		l.Positions[id] = NewPosition(x, y)
+		next = x + spacing
+	}
+
+	if !balance || len(lockedColumns) > 0 || len(desired) == 0 {
+		return
+	}
+	offset := 0
+	for id, x := range desired {
+		offset += x - l.Positions[id].X
+	}
+	shift := int(math.Round(float64(offset) / float64(len(desired))))
+	for _, id := range layer {
+		pos := l.Positions[id]
+		pos.X += shift
+		l.Positions[id] = pos
+	}
+}
+
+// meanColumn returns the rounded mean column of placed focuses
+func (l *FocusLayout) meanColumn(ids []string) int {
+	sum := 0
+	for _, id := range ids {
+		sum += l.Positions[id].X
+	}
+	return int(math.Round(float64(sum) / float64(len(ids))))
+}
+
+// Apply writes the layout to the focuses: absolute x/y, or offsets from the
+// first prerequisite in an earlier row. Locked focuses are left as they are
+// unless they were relative to a focus that moved
+func (l *FocusLayout) Apply(tree *FocusTree, options FocusLayoutOptions) {
+	for id, focus := range tree.Focuses {
+		pos, ok := l.Positions[id]
+		if !ok {
+			continue
+		}
+		if options.Locked[id] {
+			if anchor := focus.RelativePositionID; anchor != "" && !options.Locked[anchor] {
+				focus.Position = NewPosition(pos.X, pos.Y)
+				focus.RelativePositionID = ""
+			}
+			continue
+		}
+
+		focus.RelativePositionID = ""
+		focus.Position = NewPosition(pos.X, pos.Y)
+		if options.Mode != FocusLayoutRelative {
+			continue
+		}
+		if anchor := l.anchor(tree, focus); anchor != "" {
+			anchorPos := l.Positions[anchor]
+			focus.RelativePositionID = anchor
+			focus.Position = NewPosition(pos.X-anchorPos.X, pos.Y-anchorPos.Y)
+		}
+	}
+}
+
+// anchor returns the first prerequisite of a focus in an earlier row, or ""
+func (l *FocusLayout) anchor(tree *FocusTree, focus *Focus) string {
+	for _, group := range focus.Prerequisites {
+		for _, parentID := range group {
+			if _, ok := tree.Focuses[parentID]; ok && l.layer[parentID] < l.layer[focus.ID] {
+				return parentID
+			}
+		}
+	}
+	return ""
+}
+
+// AbsolutePositions resolves the absolute position of every focus
+func (ft *FocusTree) AbsolutePositions() map[string]Position {
+	positions := make(map[string]Position, len(ft.Focuses))
+	for id := range ft.Focuses {
+		positions[id] = ft.AbsolutePosition(id)
+	}
+	return positions
+}
+
+// CountFocusCrossings counts crossing prerequisite lines between focuses
+// whose parents share a row and whose children share a row
+func CountFocusCrossings(tree *FocusTree, positions map[string]Position) int {
+	type edge struct{ from, to Position }
+	edges := make([]edge, 0)
+	for id, focus := range tree.Focuses {
+		seen := make(map[string]bool)
+		for _, group := range focus.Prerequisites {
+			for _, parentID := range group {
+				if _, ok := tree.Focuses[parentID]; ok && !seen[parentID] {
+					seen[parentID] = true
+					edges = append(edges, edge{from: positions[parentID], to: positions[id]})
+				}
+			}
+		}
+	}
+
+	crossings := 0
+	for i := range edges {
+		for j := i + 1; j < len(edges); j++ {
+			a, b := edges[i], edges[j]
+			if a.from.Y != b.from.Y || a.to.Y != b.to.Y {
+				continue
+			}
+			if (a.from.X-b.from.X)*(a.to.X-b.to.X) < 0 {
+				crossings++
+			}
+		}
+	}
+	return crossings
+}
+
+// containsID reports whether ids contains id
+func containsID(ids []string, id string) bool {
+	for _, other := range ids {
+		if other == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+// newLayoutTree builds a tree with a crossing under TST_a_child (which is
+// placed relative to TST_a) and a mutually exclusive pair split by TST_mid
+func newLayoutTree() *FocusTree {
+	focus := func(id string, x, y int, prerequisite string) *Focus {
+		f := &Focus{ID: id, Position: NewPosition(x, y)}
+		if prerequisite != "" {
+			f.Prerequisites = [][]string{{prerequisite}}
+		}
+		return f
+	}
+
+	aChild := focus("TST_a_child", 12, 1, "TST_a")
+	aChild.RelativePositionID = "TST_a"
+	left := focus("TST_left", -3, 3, "TST_a_child")
+	left.MutuallyExclusive = []string{"TST_right"}
+	right := focus("TST_right", 3, 3, "TST_a_child")
+	right.MutuallyExclusive = []string{"TST_left"}
+
+	tree := NewFocusTree("test_tree")
+	for _, f := range []*Focus{
+		focus("TST_root", 5, 0, ""),
+		focus("TST_a", 0, 1, "TST_root"),
+		focus("TST_b", 8, 1, "TST_root"),
+		focus("TST_b_child", 0, 2, "TST_b"),
+		aChild,
+		left,
+		focus("TST_mid", 0, 3, "TST_a_child"),
+		right,
+	} {
+		tree.AddFocus(f)
+	}
+	return tree
+}
+
+func TestFocusLayout(t *testing.T) {
+	tree := newLayoutTree()
+	if crossings := CountFocusCrossings(tree, tree.AbsolutePositions()); crossings != 1 {
+		t.Fatalf("Expected 1 crossing before layout, got %d", crossings)
+	}
+
+	layout := LayoutFocusTree(tree, FocusLayoutOptions{})
+	if len(layout.Layers) != 4 {
+		t.Fatalf("Expected 4 rows, got %d", len(layout.Layers))
+	}
+	if crossings := CountFocusCrossings(tree, layout.Positions); crossings != 0 {
+		t.Errorf("Expected no crossings after layout, got %d", crossings)
+	}
+
+	// Mutually exclusive focuses are neighbours in their row
+	row := strings.Join(layout.Layers[3], " ")
+	if !strings.Contains(row, "TST_left TST_right") && !strings.Contains(row, "TST_right TST_left") {
+		t.Errorf("Mutually exclusive focuses not adjacent: %s", row)
+	}
+
+	// No overlaps, and the root is centred over its children
+	seen := make(map[Position]string)
+	for id, pos := range layout.Positions {
+		if other, ok := seen[pos]; ok {
+			t.Errorf("%s overlaps %s at %+v", id, other, pos)
+		}
+		seen[pos] = id
+	}
+	root, a, b := layout.Positions["TST_root"], layout.Positions["TST_a"], layout.Positions["TST_b"]
+	if root.X*2 != a.X+b.X || root.Y != 0 || a.Y != 1 {
+		t.Errorf("Unexpected root/children positions: %+v %+v %+v", root, a, b)
+	}
+}
+
+func TestFocusLayoutLockedRelative(t *testing.T) {
+	tree := newLayoutTree()
+	options := FocusLayoutOptions{Mode: FocusLayoutRelative, Locked: map[string]bool{"TST_b": true}}
+
+	layout := LayoutFocusTree(tree, options)
+	if pos := layout.Positions["TST_b"]; pos.X != 8 || pos.Y != 1 {
+		t.Errorf("Locked focus moved to %+v", pos)
+	}
+	layout.Apply(tree, options)
+
+	if b := tree.Focuses["TST_b"]; b.Position.X != 8 || b.RelativePositionID != "" {
+		t.Errorf("Locked focus rewritten: %+v", b)
+	}
+	child := tree.Focuses["TST_b_child"]
+	if child.RelativePositionID != "TST_b" || child.Position.X != 0 || child.Position.Y != 1 {
+		t.Errorf("Unexpected relative position: %s %+v", child.RelativePositionID, child.Position)
+	}
+	for id, pos := range layout.Positions {
+		if abs := tree.AbsolutePosition(id); abs.X != pos.X || abs.Y != pos.Y {
+			t.Errorf("%s resolves to %+v, layout has %+v", id, abs, pos)
+		}
+	}
+}
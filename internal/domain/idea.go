@@ -0,0 +1,260 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+)
+
+// IdeaKind classifies an idea category
+type IdeaKind int
+
+const (
+	IdeaKindCountry IdeaKind = iota // National spirits (country = { ... })
+	IdeaKindHidden                  // hidden_ideas
+	IdeaKindLaw                     // Categories with law = yes (economy, trade_laws, ...)
+	IdeaKindAdvisor                 // Advisor / designer slots
+)
+
+// String returns the display name of the kind
+func (k IdeaKind) String() string {
+	switch k {
+	case IdeaKindHidden:
+		return "hidden"
+	case IdeaKindLaw:
+		return "law"
+	case IdeaKindAdvisor:
+		return "advisor"
+	default:
+		return "country"
+	}
+}
+
+// advisorCategories are the vanilla idea-based advisor slots
+var advisorCategories = map[string]bool{
+	"political_advisor": true,
+	"army_chief":        true,
+	"navy_chief":        true,
+	"air_chief":         true,
+	"high_command":      true,
+	"theorist":          true,
+}
+
+// ScriptField is an unmodelled key/value kept verbatim so files round-trip
+type ScriptField struct {
+	Key      string
+	Operator string // "=" (default), "<" or ">"
+	Value    string // Script text; blocks are multi-line
+}
+
+// Modifier is a single modifier line (stability_factor = 0.1)
+type Modifier struct {
+	Key   string
+	Value string
+}
+
+// Number returns the modifier value as a float, if numeric
+func (m Modifier) Number() (float64, bool) {
+	v, err := strconv.ParseFloat(m.Value, 64)
+	return v, err == nil
+}
+
+// IdeaCategory is one key of an ideas = { } block
+type IdeaCategory struct {
+	Name       string
+	Law        bool          // law = yes: only one idea of the category is active
+	Designer   bool          // designer = yes (equipment / industrial designers)
+	Properties []ScriptField // Other category-level fields (use_list_view, cost, ...)
+	Ideas      []*Idea
+}
+
+// Kind classifies the category
+func (c *IdeaCategory) Kind() IdeaKind {
+	switch {
+	case c.Name == "hidden_ideas":
+		return IdeaKindHidden
+	case c.Law:
+		return IdeaKindLaw
+	case c.Designer || advisorCategories[c.Name]:
+		return IdeaKindAdvisor
+	default:
+		return IdeaKindCountry
+	}
+}
+
+// Idea is a national spirit, law, or advisor declared in common/ideas
+type Idea struct {
+	ID              string
+	Category        *IdeaCategory
+	Name            string // Optional localisation key override
+	Picture         string // Sprite suffix: GFX_idea_<picture>
+	Cost            string // Political power cost (laws, advisors); "" if unset
+	RemovalCost     string // "-1" means it cannot be removed; "" if unset
+	Default         bool   // default = yes (laws)
+	Allowed         string // Raw trigger block
+	AllowedCivilWar string
+	Available       string
+	Visible         string
+	Modifiers       []Modifier
+	Extra           []ScriptField // on_add, research_bonus, equipment_bonus, ai_will_do, ...
+	File            *IdeaFile
+}
+
+// NewIdea creates an idea with no modifiers
+func NewIdea(id string) *Idea {
+	return &Idea{
+		ID:        id,
+		Modifiers: make([]Modifier, 0),
+		Extra:     make([]ScriptField, 0),
+	}
+}
+
+// Kind returns the category kind (country if the idea has no category)
+func (i *Idea) Kind() IdeaKind {
+	if i.Category == nil {
+		return IdeaKindCountry
+	}
+	return i.Category.Kind()
+}
+
+// CategoryName returns the category key or ""
+func (i *Idea) CategoryName() string {
+	if i.Category == nil {
+		return ""
+	}
+	return i.Category.Name
+}
+
+// SpriteName returns the sprite the game shows for the idea
+func (i *Idea) SpriteName() string {
+	if i.Picture != "" {
+		return "GFX_idea_" + i.Picture
+	}
+	return "GFX_idea_" + i.ID
+}
+
+// GetModifier returns the modifier value by key
+func (i *Idea) GetModifier(key string) (Modifier, bool) {
+	for _, m := range i.Modifiers {
+		if m.Key == key {
+			return m, true
+		}
+	}
+	return Modifier{}, false
+}
+
+// SetModifier updates a modifier or appends it
+func (i *Idea) SetModifier(key, value string) {
+	for idx := range i.Modifiers {
+		if i.Modifiers[idx].Key == key {
+			i.Modifiers[idx].Value = value
+			return
+		}
+	}
+	i.Modifiers = append(i.Modifiers, Modifier{Key: key, Value: value})
+}
+
+// RemoveModifier deletes a modifier by key
+func (i *Idea) RemoveModifier(key string) {
+	for idx, m := range i.Modifiers {
+		if m.Key == key {
+			i.Modifiers = append(i.Modifiers[:idx], i.Modifiers[idx+1:]...)
+			return
+		}
+	}
+}
+
+// Validate checks the editable fields
+func (i *Idea) Validate() []string {
+	errors := make([]string, 0)
+
+	if i.ID == "" {
+		errors = append(errors, "idea ID is required")
+	}
+	if i.Cost != "" {
+		if _, err := strconv.ParseFloat(i.Cost, 64); err != nil {
+			errors = append(errors, "idea "+i.ID+": cost is not a number")
+		}
+	}
+	if i.RemovalCost != "" {
+		if _, err := strconv.ParseFloat(i.RemovalCost, 64); err != nil {
+			errors = append(errors, "idea "+i.ID+": removal_cost is not a number")
+		}
+	}
+
+	return errors
+}
+
+// IdeaFile is a common/ideas/*.txt file
+type IdeaFile struct {
+	Path       string // Absolute path the file was loaded from
+	RelPath    string // Path relative to the mod/game root (common/ideas/x.txt)
+	Source     string // "mod" or "game"
+	Categories []*IdeaCategory
+	Dirty      bool // Edited since load
+}
+
+// Ideas returns all ideas of the file in declaration order
+func (f *IdeaFile) Ideas() []*Idea {
+	ideas := make([]*Idea, 0)
+	for _, category := range f.Categories {
+		ideas = append(ideas, category.Ideas...)
+	}
+	return ideas
+}
+
+// IdeaSet indexes ideas from several files by ID (later files win)
+type IdeaSet struct {
+	Files []*IdeaFile
+	ideas map[string]*Idea
+}
+
+// NewIdeaSet creates an index over the files
+func NewIdeaSet(files []*IdeaFile) *IdeaSet {
+	set := &IdeaSet{
+		Files: files,
+		ideas: make(map[string]*Idea),
+	}
+	for _, file := range files {
+		for _, idea := range file.Ideas() {
+			set.ideas[idea.ID] = idea
+		}
+	}
+	return set
+}
+
+// Get returns an idea by ID
+func (s *IdeaSet) Get(id string) (*Idea, bool) {
+	idea, ok := s.ideas[id]
+	return idea, ok
+}
+
+// Count returns the number of distinct idea IDs
+func (s *IdeaSet) Count() int {
+	return len(s.ideas)
+}
+
+// All returns the ideas sorted by category then ID
+func (s *IdeaSet) All() []*Idea {
+	ideas := make([]*Idea, 0, len(s.ideas))
+	for _, idea := range s.ideas {
+		ideas = append(ideas, idea)
+	}
+	sort.Slice(ideas, func(a, b int) bool {
+		if ideas[a].CategoryName() != ideas[b].CategoryName() {
+			return ideas[a].CategoryName() < ideas[b].CategoryName()
+		}
+		return ideas[a].ID < ideas[b].ID
+	})
+	return ideas
+}
+
+// DirtyFiles returns files edited since load
+func (s *IdeaSet) DirtyFiles() []*IdeaFile {
+	files := make([]*IdeaFile, 0)
+	for _, file := range s.Files {
+		if file.Dirty {
+			files = append(files, file)
+		}
+	}
+	return files
+}
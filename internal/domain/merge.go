@@ -0,0 +1,147 @@
+package domain
+
+import "fmt"
+
+// MergeStatus is how a block changed between the old game version (base),
+// the mod copy (ours) and the new game version (theirs)
+type MergeStatus string
+
+const (
+	MergeUnchanged MergeStatus = "unchanged" // Same in all versions
+	MergeOurs      MergeStatus = "ours"      // Changed by the mod only
+	MergeTheirs    MergeStatus = "theirs"    // Changed by the game update only
+	MergeBoth      MergeStatus = "both"      // Same change on both sides
+	MergeConflict  MergeStatus = "conflict"  // Different changes, needs a resolution
+)
+
+// MergeSide is the version a conflict is resolved with
+type MergeSide string
+
+const (
+	MergeSideNone   MergeSide = ""       // Unresolved
+	MergeSideOurs   MergeSide = "ours"   // Keep the mod block
+	MergeSideTheirs MergeSide = "theirs" // Take the new game block
+)
+
+// MergeBlock is a block of a three-way merge: a technology, a focus, a
+// variable or another statement, matched by key across the versions.
+// Container blocks (technologies = { }, focus_tree = { }) are merged per child
+type MergeBlock struct {
+	Key  string // Technology ID, focus:<id>, @VARIABLE, ...
+	Name string // Statement name (container blocks)
+
+	// Script of the statement in each version ("" if absent)
+	Base, Ours, Theirs string
+
+	Status     MergeStatus
+	Resolution MergeSide     // Conflicts only
+	Children   []*MergeBlock // Container blocks only
+
+	// Where the block is in the mod source, and its statement as written in
+	// the new game file (indented for the mod file), to splice the result
+	// into the mod file keeping its formatting and comments
+	Span         MergeSpan
+	TheirsSource string
+}
+
+// MergeSpan is the bytes of a statement in the mod source (MergeFile.Source),
+// or where a block the mod copy does not have is inserted (Start == End)
+type MergeSpan struct {
+	Start, End int
+	Indent     string // Indentation of the statement's line
+}
+
+// MergeStatusOf compares the versions of a block
+func MergeStatusOf(base, ours, theirs string) MergeStatus {
+	switch {
+	case ours == theirs && ours == base:
+		return MergeUnchanged
+	case ours == theirs:
+		return MergeBoth
+	case ours == base:
+		return MergeTheirs
+	case theirs == base:
+		return MergeOurs
+	default:
+		return MergeConflict
+	}
+}
+
+// IsContainer reports whether the block is merged per child
+func (b *MergeBlock) IsContainer() bool {
+	return b.Children != nil
+}
+
+// Result returns the merged script of the block ("" if it is deleted);
+// unresolved conflicts keep the mod version
+func (b *MergeBlock) Result() string {
+	switch b.Status {
+	case MergeTheirs:
+		return b.Theirs
+	case MergeConflict:
+		if b.Resolution == MergeSideTheirs {
+			return b.Theirs
+		}
+	}
+	return b.Ours
+}
+
+// MergeFile is the three-way merge of a script file
+type MergeFile struct {
+	Blocks []*MergeBlock // In mod file order, new game blocks after their predecessor
+	Source string        // The mod copy the results are spliced into
+}
+
+// Walk calls fn for every block that is not a container, in file order
+func (f *MergeFile) Walk(fn func(block *MergeBlock)) {
+	var walk func(blocks []*MergeBlock)
+	walk = func(blocks []*MergeBlock) {
+		for _, block := range blocks {
+			if block.IsContainer() {
+				walk(block.Children)
+			} else {
+				fn(block)
+			}
+		}
+	}
+	walk(f.Blocks)
+}
+
+// Conflicts returns the conflicting blocks
+func (f *MergeFile) Conflicts() []*MergeBlock {
+	conflicts := make([]*MergeBlock, 0)
+	f.Walk(func(block *MergeBlock) {
+		if block.Status == MergeConflict {
+			conflicts = append(conflicts, block)
+		}
+	})
+	return conflicts
+}
+
+// Unresolved returns the number of conflicts without a resolution
+func (f *MergeFile) Unresolved() int {
+	count := 0
+	for _, block := range f.Conflicts() {
+		if block.Resolution == MergeSideNone {
+			count++
+		}
+	}
+	return count
+}
+
+// ResolveAll resolves every unresolved conflict with one side
+func (f *MergeFile) ResolveAll(side MergeSide) {
+	for _, block := range f.Conflicts() {
+		if block.Resolution == MergeSideNone {
+			block.Resolution = side
+		}
+	}
+}
+
+// Summary returns the number of blocks per status
+func (f *MergeFile) Summary() string {
+	counts := make(map[MergeStatus]int)
+	f.Walk(func(block *MergeBlock) { counts[block.Status]++ })
+	return fmt.Sprintf("%d unchanged, %d mod, %d game update, %d both, %d conflicts (%d unresolved)",
+		counts[MergeUnchanged], counts[MergeOurs], counts[MergeTheirs], counts[MergeBoth], counts[MergeConflict], f.Unresolved())
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ModifierFormat is how a modifier value is displayed
+type ModifierFormat int
+
+const (
+	ModifierPercent ModifierFormat = iota // 0.05 -> +5%
+	ModifierFlat                          // 2 -> +2
+)
+
+// ModifierDefinition is a known modifier from the modifier catalogue
+type ModifierDefinition struct {
+	Name     string
+	Category string // country, army, navy, air, unit, state, ...
+	Format   ModifierFormat
+	Display  string // Human-readable name; derived from Name when empty
+}
+
+// DisplayName returns the human-readable name of the modifier
+func (d *ModifierDefinition) DisplayName() string {
+	if d.Display != "" {
+		return d.Display
+	}
+	return HumanizeModifier(d.Name)
+}
+
+// ModifierIssue is a modifier name not found in the catalogue
+type ModifierIssue struct {
+	Modifier   string
+	Source     string // "technology x", "idea y", "focus z"
+	Suggestion string // Closest known modifier, if any
+}
+
+// ModifierCatalogue indexes known modifiers by name
+type ModifierCatalogue struct {
+	modifiers map[string]*ModifierDefinition
+}
+
+// NewModifierCatalogue creates a catalogue over the definitions (later ones win)
+func NewModifierCatalogue(definitions []*ModifierDefinition) *ModifierCatalogue {
+	catalogue := &ModifierCatalogue{modifiers: make(map[string]*ModifierDefinition)}
+	for _, definition := range definitions {
+		catalogue.Add(definition)
+	}
+	return catalogue
+}
+
+// Add registers or replaces a definition
+func (c *ModifierCatalogue) Add(definition *ModifierDefinition) {
+	c.modifiers[definition.Name] = definition
+}
+
+// Get returns a definition by name
+func (c *ModifierCatalogue) Get(name string) (*ModifierDefinition, bool) {
+	definition, ok := c.modifiers[name]
+	return definition, ok
+}
+
+// Count returns the number of known modifiers
+func (c *ModifierCatalogue) Count() int {
+	return len(c.modifiers)
+}
+
+// Check returns an issue for an unknown modifier, nil when it is known
+func (c *ModifierCatalogue) Check(name, source string) *ModifierIssue {
+	if _, ok := c.modifiers[name]; ok {
+		return nil
+	}
+	return &ModifierIssue{Modifier: name, Source: source, Suggestion: c.Suggest(name)}
+}
+
+// Suggest returns the closest known modifier name (at most a third of the
+// name's length in edits away), or "" when nothing is close
+func (c *ModifierCatalogue) Suggest(name string) string {
+	best, bestDistance := "", len(name)/3+1
+	for known := range c.modifiers {
+		if d := editDistance(name, known); d < bestDistance || (d == bestDistance && best != "" && known < best) {
+			best, bestDistance = known, d
+		}
+	}
+	return best
+}
+
+// Tooltip renders a modifier line as shown in game ("+5% Soft Attack");
+// unknown modifiers are formatted by name (percent for *_factor)
+func (c *ModifierCatalogue) Tooltip(name string, value float64) string {
+	definition, ok := c.modifiers[name]
+	if !ok {
+		definition = &ModifierDefinition{Name: name, Format: ModifierFlat}
+		if strings.HasSuffix(name, "_factor") {
+			definition.Format = ModifierPercent
+		}
+	}
+	return FormatModifierValue(value, definition.Format) + " " + definition.DisplayName()
+}
+
+// TooltipText is Tooltip for a script value; non-numeric values are shown as-is
+func (c *ModifierCatalogue) TooltipText(name, value string) string {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		display := HumanizeModifier(name)
+		if definition, ok := c.modifiers[name]; ok {
+			display = definition.DisplayName()
+		}
+		return display + ": " + value
+	}
+	return c.Tooltip(name, number)
+}
+
+// Names returns all known modifier names, sorted
+func (c *ModifierCatalogue) Names() []string {
+	names := make([]string, 0, len(c.modifiers))
+	for name := range c.modifiers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FormatModifierValue formats a value with an explicit sign
+func FormatModifierValue(value float64, format ModifierFormat) string {
+	if format == ModifierPercent {
+		value *= 100
+	}
+	text := strconv.FormatFloat(value, 'f', -1, 64)
+	if rounded := fmt.Sprintf("%.2f", value); len(rounded) < len(text) {
+		text = strings.TrimRight(strings.TrimRight(rounded, "0"), ".")
+	}
+	if value >= 0 {
+		text = "+" + text
+	}
+	if format == ModifierPercent {
+		text += "%"
+	}
+	return text
+}
+
+// HumanizeModifier turns army_org_factor into "Army Org"
+func HumanizeModifier(name string) string {
+	name = strings.TrimSuffix(name, "_factor")
+	words := strings.Split(name, "_")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// editDistance is the Levenshtein distance between two names
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,153 @@
+package domain
+
+import "sort"
+
+// Provenance is where the effective definition of a focus or technology
+// comes from when the mod is loaded over the game
+type Provenance string
+
+const (
+	ProvenanceGame     Provenance = "game"     // Vanilla, untouched by the mod
+	ProvenanceOverride Provenance = "override" // Vanilla ID redefined by the mod
+	ProvenanceMod      Provenance = "mod"      // New in the mod
+)
+
+// Kinds of content in a provenance report
+const (
+	ProvenanceTechnology = "technology"
+	ProvenanceFocus      = "focus"
+)
+
+// ProvenanceEntry records the origin of one focus or technology
+type ProvenanceEntry struct {
+	ID         string        `json:"id"`
+	Kind       string        `json:"kind"`
+	Provenance Provenance    `json:"provenance"`
+	File       string        `json:"file"`                // File of the effective definition
+	GameFile   string        `json:"game_file,omitempty"` // Vanilla file (overrides only)
+	Fields     []FieldChange `json:"fields,omitempty"`    // Vanilla -> mod values (overrides only)
+}
+
+// ProvenanceReport is the origin of every focus and technology of a mod
+type ProvenanceReport struct {
+	Entries []ProvenanceEntry `json:"entries"` // Sorted by kind and ID
+
+	index map[string]int // kind + "/" + ID -> entry
+}
+
+// NewProvenanceReport creates an empty report
+func NewProvenanceReport() *ProvenanceReport {
+	return &ProvenanceReport{Entries: make([]ProvenanceEntry, 0), index: make(map[string]int)}
+}
+
+// Get returns the entry of a focus or technology
+func (r *ProvenanceReport) Get(kind, id string) (ProvenanceEntry, bool) {
+	i, ok := r.index[kind+"/"+id]
+	if !ok {
+		return ProvenanceEntry{}, false
+	}
+	return r.Entries[i], true
+}
+
+// Overrides returns the vanilla content redefined by the mod
+func (r *ProvenanceReport) Overrides() []ProvenanceEntry {
+	overrides := make([]ProvenanceEntry, 0)
+	for _, entry := range r.Entries {
+		if entry.Provenance == ProvenanceOverride {
+			overrides = append(overrides, entry)
+		}
+	}
+	return overrides
+}
+
+// Count returns the number of entries of a kind with a provenance
+func (r *ProvenanceReport) Count(kind string, provenance Provenance) int {
+	count := 0
+	for _, entry := range r.Entries {
+		if entry.Kind == kind && entry.Provenance == provenance {
+			count++
+		}
+	}
+	return count
+}
+
+// AddTechnologies records technologies defined by the mod and by the game;
+// mod technologies with a vanilla ID are overrides
+func (r *ProvenanceReport) AddTechnologies(mod, game []*Technology) {
+	vanilla := make(map[string]*Technology, len(game))
+	for _, tech := range game {
+		vanilla[tech.ID] = tech
+	}
+	modded := make(map[string]bool, len(mod))
+	for _, tech := range mod {
+		modded[tech.ID] = true
+		entry := ProvenanceEntry{ID: tech.ID, Kind: ProvenanceTechnology, Provenance: ProvenanceMod, File: tech.File}
+		if original, ok := vanilla[tech.ID]; ok {
+			entry.Provenance = ProvenanceOverride
+			entry.GameFile = original.File
+			entry.Fields = CompareTechnologies(original, tech)
+		}
+		r.add(entry)
+	}
+	for _, tech := range game {
+		if !modded[tech.ID] {
+			r.add(ProvenanceEntry{ID: tech.ID, Kind: ProvenanceTechnology, Provenance: ProvenanceGame, File: tech.File})
+		}
+	}
+	r.sort()
+}
+
+// AddFocuses records focuses defined by the mod and by the game (focus
+// files of each side); mod focuses with a vanilla ID are overrides
+func (r *ProvenanceReport) AddFocuses(mod, game map[string][]*Focus) {
+	vanilla := make(map[string]*Focus)
+	vanillaFile := make(map[string]string)
+	for file, focuses := range game {
+		for _, focus := range focuses {
+			vanilla[focus.ID] = focus
+			vanillaFile[focus.ID] = file
+		}
+	}
+	modded := make(map[string]bool)
+	for file, focuses := range mod {
+		for _, focus := range focuses {
+			modded[focus.ID] = true
+			entry := ProvenanceEntry{ID: focus.ID, Kind: ProvenanceFocus, Provenance: ProvenanceMod, File: file}
+			if original, ok := vanilla[focus.ID]; ok {
+				entry.Provenance = ProvenanceOverride
+				entry.GameFile = vanillaFile[focus.ID]
+				entry.Fields = CompareFocuses(original, focus)
+			}
+			r.add(entry)
+		}
+	}
+	for id, file := range vanillaFile {
+		if !modded[id] {
+			r.add(ProvenanceEntry{ID: id, Kind: ProvenanceFocus, Provenance: ProvenanceGame, File: file})
+		}
+	}
+	r.sort()
+}
+
+// add appends or replaces an entry
+func (r *ProvenanceReport) add(entry ProvenanceEntry) {
+	if i, ok := r.index[entry.Kind+"/"+entry.ID]; ok {
+		r.Entries[i] = entry
+		return
+	}
+	r.index[entry.Kind+"/"+entry.ID] = len(r.Entries)
+	r.Entries = append(r.Entries, entry)
+}
+
+// sort orders entries by kind and ID and rebuilds the index
+func (r *ProvenanceReport) sort() {
+	sort.Slice(r.Entries, func(i, j int) bool {
+		if r.Entries[i].Kind != r.Entries[j].Kind {
+			return r.Entries[i].Kind < r.Entries[j].Kind
+		}
+		return r.Entries[i].ID < r.Entries[j].ID
+	})
+	for i, entry := range r.Entries {
+		r.index[entry.Kind+"/"+entry.ID] = i
+	}
+}
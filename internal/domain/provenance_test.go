@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestProvenanceReport(t *testing.T) {
+	game := []*Technology{
+		{ID: "tech_a", File: "game/a.txt", ResearchCost: 1},
+		{ID: "tech_b", File: "game/a.txt", ResearchCost: 1},
+	}
+	mod := []*Technology{
+		{ID: "tech_a", File: "mod/a.txt", ResearchCost: 2, Paths: []TechPath{{LeadsToTech: "tech_c", ResearchCostCoeff: 1}}},
+		{ID: "tech_c", File: "mod/c.txt", ResearchCost: 1},
+	}
+	report := NewProvenanceReport()
+	report.AddTechnologies(mod, game)
+	report.AddFocuses(
+		map[string][]*Focus{"mod/ger.txt": {{ID: "GER_a", Cost: 10}}},
+		map[string][]*Focus{"game/ger.txt": {{ID: "GER_a", Cost: 10}, {ID: "GER_b", Cost: 10}}},
+	)
+
+	want := map[string]Provenance{
+		"technology/tech_a": ProvenanceOverride,
+		"technology/tech_b": ProvenanceGame,
+		"technology/tech_c": ProvenanceMod,
+		"focus/GER_a":       ProvenanceOverride,
+		"focus/GER_b":       ProvenanceGame,
+	}
+	if len(report.Entries) != len(want) {
+		t.Fatalf("Expected %d entries, got %+v", len(want), report.Entries)
+	}
+	for _, entry := range report.Entries {
+		if want[entry.Kind+"/"+entry.ID] != entry.Provenance {
+			t.Errorf("%s %s: got %s", entry.Kind, entry.ID, entry.Provenance)
+		}
+	}
+
+	techA, _ := report.Get(ProvenanceTechnology, "tech_a")
+	if techA.GameFile != "game/a.txt" || techA.File != "mod/a.txt" || len(techA.Fields) != 2 {
+		t.Fatalf("Unexpected override: %+v", techA)
+	}
+	if techA.Fields[0].Field != "path" || techA.Fields[1].Field != "research_cost" || techA.Fields[1].Old != "1" || techA.Fields[1].New != "2" {
+		t.Errorf("Unexpected override fields: %+v", techA.Fields)
+	}
+	if focus, _ := report.Get(ProvenanceFocus, "GER_a"); len(focus.Fields) != 0 {
+		t.Errorf("Identical focus override has changes: %+v", focus.Fields)
+	}
+	if overrides := report.Overrides(); len(overrides) != 2 || overrides[0].ID != "GER_a" {
+		t.Errorf("Unexpected overrides: %+v", overrides)
+	}
+	if report.Count(ProvenanceTechnology, ProvenanceMod) != 1 {
+		t.Error("Expected one new technology")
+	}
+}
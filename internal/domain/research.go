@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ResearchDefines are the NDefines.NTechnology values used for research time
+type ResearchDefines struct {
+	BaseTechCost        float64 // BASE_TECH_COST: days per research_cost point
+	AheadPenaltyPerYear float64 // BASE_YEAR_AHEAD_PENALTY_FACTOR: extra time per year ahead
+	MinResearchSpeed    float64 // MIN_RESEARCH_SPEED: lower bound of the speed factor
+}
+
+// DefaultResearchDefines returns the vanilla defines
+func DefaultResearchDefines() ResearchDefines {
+	return ResearchDefines{
+		BaseTechCost:        100,
+		AheadPenaltyPerYear: 2,
+		MinResearchSpeed:    0.1,
+	}
+}
+
+// ResearchInput is the country-specific part of the research time
+type ResearchInput struct {
+	Date         float64 // Current date as a fractional year (1936.0 for 1936.1.1)
+	SpeedBonus   float64 // Sum of research_speed_factor from ideas and technologies
+	SharingBonus float64 // Technology sharing bonus (per-country bonus × sharing countries)
+}
+
+// ResearchTime is the breakdown of a technology's research time
+type ResearchTime struct {
+	TechID       string
+	BaseCost     float64 // research_cost
+	PathCoeff    float64 // Lowest research_cost_coeff of the incoming paths (1 without paths)
+	StartYear    int     // 0 when the technology has no start_year
+	YearsAhead   float64 // Years between the date and start_year, 0 when not ahead
+	AheadPenalty float64 // Extra time from researching ahead of time (2 = +200%)
+	Speed        float64 // Research speed factor (1 + bonuses, bounded by the minimum)
+	Days         float64
+}
+
+// CalculateResearchTime computes the research days of a technology:
+// BASE_TECH_COST × research_cost × path coefficient × (1 + ahead penalty) / speed
+func CalculateResearchTime(tech *Technology, pathCoeff float64, input ResearchInput, defines ResearchDefines) ResearchTime {
+	result := ResearchTime{
+		TechID:    tech.ID,
+		BaseCost:  tech.ResearchCost,
+		PathCoeff: pathCoeff,
+		StartYear: tech.StartYear,
+	}
+
+	if tech.StartYear > 0 && input.Date > 0 && float64(tech.StartYear) > input.Date {
+		result.YearsAhead = float64(tech.StartYear) - input.Date
+		result.AheadPenalty = result.YearsAhead * defines.AheadPenaltyPerYear
+	}
+
+	result.Speed = math.Max(1+input.SpeedBonus+input.SharingBonus, defines.MinResearchSpeed)
+	result.Days = defines.BaseTechCost * tech.ResearchCost * pathCoeff * (1 + result.AheadPenalty) / result.Speed
+	return result
+}
+
+// IncomingPathCoeff returns the research_cost_coeff of the paths leading to
+// a technology. A path only discounts (or raises) the cost once the
+// technology it starts from is researched, so only paths from completed
+// technologies count; of those the lowest coefficient applies. Returns 1
+// when no completed technology leads to it
+func IncomingPathCoeff(technologies []*Technology, techID string, completed map[string]bool) float64 {
+	coeff, found := 1.0, false
+	for _, tech := range technologies {
+		if !completed[tech.ID] {
+			continue
+		}
+		for _, path := range tech.Paths {
+			if path.LeadsToTech == techID && (!found || path.ResearchCostCoeff < coeff) {
+				coeff, found = path.ResearchCostCoeff, true
+			}
+		}
+	}
+	return coeff
+}
+
+// ParseScriptDate converts a script date (1936.1.1 or 1936.1.1.12) to a fractional year
+func ParseScriptDate(date string) float64 {
+	parts := strings.Split(date, ".")
+	year, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0
+	}
+	month, day := 1, 1
+	if len(parts) > 1 {
+		if m, err := strconv.Atoi(parts[1]); err == nil && m >= 1 && m <= 12 {
+			month = m
+		}
+	}
+	if len(parts) > 2 {
+		if d, err := strconv.Atoi(parts[2]); err == nil && d >= 1 && d <= 31 {
+			day = d
+		}
+	}
+	dayOfYear := day - 1
+	for _, length := range monthDays[:month-1] {
+		dayOfYear += length
+	}
+	return float64(year) + float64(dayOfYear)/365
+}
+
+// monthDays are the month lengths of the game calendar (no leap years)
+var monthDays = []int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
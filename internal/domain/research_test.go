@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseScriptDate(t *testing.T) {
+	tests := []struct {
+		date string
+		want float64
+	}{
+		{"1936.1.1", 1936},
+		{"1939.8.14.12", 1939 + 225.0/365},
+		{"1936", 1936},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		if got := ParseScriptDate(tt.date); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("ParseScriptDate(%q) = %v, want %v", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestCalculateResearchTime(t *testing.T) {
+	defines := DefaultResearchDefines()
+	tech := &Technology{ID: "infantry_weapons1", ResearchCost: 1.5, StartYear: 1938}
+
+	// Two years ahead: +400% time; +10% speed
+	result := CalculateResearchTime(tech, 1, ResearchInput{Date: 1936, SpeedBonus: 0.1}, defines)
+	if result.YearsAhead != 2 || result.AheadPenalty != 4 {
+		t.Errorf("ahead = %v years, penalty %v; want 2, 4", result.YearsAhead, result.AheadPenalty)
+	}
+	if want := 100 * 1.5 * 5 / 1.1; math.Abs(result.Days-want) > 1e-9 {
+		t.Errorf("Days = %v, want %v", result.Days, want)
+	}
+
+	// Not ahead, discounted path, speed bounded by the minimum
+	result = CalculateResearchTime(tech, 0.5, ResearchInput{Date: 1940, SpeedBonus: -5}, defines)
+	if result.AheadPenalty != 0 || result.Speed != defines.MinResearchSpeed {
+		t.Errorf("penalty = %v, speed = %v; want 0, %v", result.AheadPenalty, result.Speed, defines.MinResearchSpeed)
+	}
+	if want := 100 * 1.5 * 0.5 / 0.1; math.Abs(result.Days-want) > 1e-9 {
+		t.Errorf("Days = %v, want %v", result.Days, want)
+	}
+}
+
+func TestIncomingPathCoeff(t *testing.T) {
+	techs := []*Technology{
+		{ID: "a", Paths: []TechPath{{LeadsToTech: "c", ResearchCostCoeff: 1}}},
+		{ID: "b", Paths: []TechPath{{LeadsToTech: "c", ResearchCostCoeff: 0.5}}},
+	}
+	for _, tc := range []struct {
+		techID    string
+		completed []string
+		want      float64
+	}{
+		{"c", []string{"a", "b"}, 0.5},
+		{"c", []string{"a"}, 1},   // The discounted path starts from b, not researched
+		{"c", []string{"b"}, 0.5}, // Only b researched
+		{"c", nil, 1},
+		{"a", []string{"a", "b"}, 1},
+	} {
+		completed := make(map[string]bool)
+		for _, id := range tc.completed {
+			completed[id] = true
+		}
+		if got := IncomingPathCoeff(techs, tc.techID, completed); got != tc.want {
+			t.Errorf("IncomingPathCoeff(%s, %v) = %v, want %v", tc.techID, tc.completed, got, tc.want)
+		}
+	}
+}
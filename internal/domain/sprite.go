@@ -0,0 +1,54 @@
+package domain
+
+// SpriteKind identifies the kind of sprite declaration in a .gfx file
+type SpriteKind int
+
+const (
+	SpriteKindSimple        SpriteKind = iota // spriteType
+	SpriteKindFrameAnimated                   // frameAnimatedSpriteType
+	SpriteKindCorneredTile                    // corneredTileSpriteType
+)
+
+// String returns the .gfx block name for the sprite kind
+func (k SpriteKind) String() string {
+	switch k {
+	case SpriteKindFrameAnimated:
+		return "frameAnimatedSpriteType"
+	case SpriteKindCorneredTile:
+		return "corneredTileSpriteType"
+	default:
+		return "spriteType"
+	}
+}
+
+// Sprite represents a sprite declared in interface/*.gfx
+type Sprite struct {
+	Name        string     // "GFX_goal_generic_production"
+	TextureFile string     // Texture path relative to game/mod root ("gfx/interface/goals/goal_generic_production.dds")
+	Kind        SpriteKind // Declaration kind
+	NoOfFrames  int        // Number of horizontal frames in the texture (1 if not set)
+	BorderSize  Position   // Border size for cornered tile sprites
+	Size        Position   // Size for cornered tile sprites
+
+	// Provenance
+	Source  string // "mod" or "game"
+	GfxFile string // Full path of the .gfx file that declared the sprite
+}
+
+// NewSprite creates a new Sprite with a single frame
+func NewSprite(name, textureFile string) *Sprite {
+	return &Sprite{
+		Name:        name,
+		TextureFile: textureFile,
+		Kind:        SpriteKindSimple,
+		NoOfFrames:  1,
+	}
+}
+
+// FrameCount returns the number of frames, never less than 1
+func (s *Sprite) FrameCount() int {
+	if s.NoOfFrames < 1 {
+		return 1
+	}
+	return s.NoOfFrames
+}
@@ -0,0 +1,415 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SubTreeGap is the number of empty columns that separates two sub-trees
+const SubTreeGap = 5
+
+// Technology grid issue kinds
+const (
+	TechGridUpwardPath      = "upward_path"      // Path to a technology in an earlier row
+	TechGridSubTreeCrossing = "subtree_crossing" // Path into another sub-tree
+	TechGridOverlap         = "tech_overlap"     // Two technologies in one cell
+	TechGridYearRow         = "year_row"         // Row does not match start_year
+)
+
+// yearRowPattern matches year row variables (@1940, @1936_1)
+var yearRowPattern = regexp.MustCompile(`^@(\d{4})(_\d+)?$`)
+
+// TechGrid holds the coordinate variables of a technology folder: year
+// rows (@1940 = 10, @1940_1 = 11) and columns (@RADAR = 3)
+type TechGrid struct {
+	Rows    map[string]int
+	Columns map[string]int
+}
+
+// NewTechGrid creates a grid from a file's variables; without year rows the
+// vanilla rows are used
+func NewTechGrid(rows, columns map[string]int) *TechGrid {
+	grid := &TechGrid{Rows: make(map[string]int), Columns: make(map[string]int)}
+	for name, value := range rows {
+		grid.Rows[name] = value
+	}
+	for name, value := range columns {
+		grid.Columns[name] = value
+	}
+	if len(grid.Rows) == 0 {
+		grid.Rows = DefaultTechRows()
+	}
+	return grid
+}
+
+// DefaultTechRows returns the vanilla year rows: two rows per year (@1936,
+// @1936_1) up to 1942, then one row every two cells up to 1951
+func DefaultTechRows() map[string]int {
+	rows := map[string]int{"@1930": 0, "@1930_1": 1}
+	for year := 1936; year <= 1942; year++ {
+		row := 2 + (year-1936)*2
+		rows["@"+strconv.Itoa(year)] = row
+		rows["@"+strconv.Itoa(year)+"_1"] = row + 1
+	}
+	for year := 1943; year <= 1951; year++ {
+		rows["@"+strconv.Itoa(year)] = 16 + (year-1943)*2
+	}
+	return rows
+}
+
+// YearRow returns the row of a year (@<year>)
+func (g *TechGrid) YearRow(year int) (int, bool) {
+	row, ok := g.Rows["@"+strconv.Itoa(year)]
+	return row, ok
+}
+
+// RowYear returns the year of the last year row at or above y (0 if none)
+func (g *TechGrid) RowYear(y int) int {
+	bestRow, bestYear := 0, 0
+	for name, row := range g.Rows {
+		match := yearRowPattern.FindStringSubmatch(name)
+		if match == nil || row > y {
+			continue
+		}
+		year, _ := strconv.Atoi(match[1])
+		if bestYear == 0 || row > bestRow || row == bestRow && year < bestYear {
+			bestRow, bestYear = row, year
+		}
+	}
+	return bestYear
+}
+
+// RowName returns the variable of a row, preferring full years over _1
+// rows ("" if the row has no variable)
+func (g *TechGrid) RowName(y int) string {
+	return variableFor(g.Rows, y)
+}
+
+// ColumnName returns the variable of a column ("" if none)
+func (g *TechGrid) ColumnName(x int) string {
+	return variableFor(g.Columns, x)
+}
+
+// variableFor returns the shortest, then alphabetically first, variable with a value
+func variableFor(variables map[string]int, value int) string {
+	best := ""
+	for name, v := range variables {
+		if v != value {
+			continue
+		}
+		if best == "" || len(name) < len(best) || len(name) == len(best) && name < best {
+			best = name
+		}
+	}
+	return best
+}
+
+// SubTreeRanges splits the columns used by technologies into sub-tree
+// ranges separated by more than SubTreeGap empty columns
+func SubTreeRanges(technologies []*Technology) [][2]int {
+	unique := make(map[int]bool)
+	for _, tech := range technologies {
+		unique[tech.Position.X] = true
+	}
+	columns := make([]int, 0, len(unique))
+	for x := range unique {
+		columns = append(columns, x)
+	}
+	sort.Ints(columns)
+
+	ranges := make([][2]int, 0)
+	for i, x := range columns {
+		if i == 0 || x-columns[i-1] > SubTreeGap {
+			ranges = append(ranges, [2]int{x, x})
+		} else {
+			ranges[len(ranges)-1][1] = x
+		}
+	}
+	return ranges
+}
+
+// subTreeIndex returns the index of the range containing x (-1 if none)
+func subTreeIndex(ranges [][2]int, x int) int {
+	for i, r := range ranges {
+		if x >= r[0] && x <= r[1] {
+			return i
+		}
+	}
+	return -1
+}
+
+// TechGridIssue is a violation of the technology grid rules
+type TechGridIssue struct {
+	Kind    string
+	Tech    *Technology
+	Message string
+}
+
+// TechGridLayout arranges the technologies of a folder on a grid
+type TechGridLayout struct {
+	Grid   *TechGrid
+	Folder string
+
+	// Column variables created by AssignColumns (name -> column)
+	NewColumns map[string]int
+}
+
+// NewTechGridLayout creates a layout engine for a folder
+func NewTechGridLayout(grid *TechGrid, folder string) *TechGridLayout {
+	if grid == nil {
+		grid = NewTechGrid(nil, nil)
+	}
+	return &TechGridLayout{Grid: grid, Folder: folder, NewColumns: make(map[string]int)}
+}
+
+// Layout places every technology on the row of its start_year, then moves
+// it down below its predecessors (one row below a predecessor in the same
+// column) and out of occupied cells; columns keep their X and get variables
+func (l *TechGridLayout) Layout(technologies []*Technology) {
+	for _, tech := range technologies {
+		if row, ok := l.Grid.YearRow(tech.StartYear); ok && tech.StartYear > 0 {
+			tech.Position.Y = row
+		}
+	}
+	l.Reflow(technologies)
+	l.AssignColumns(technologies)
+}
+
+// Reflow moves technologies down until every path leads down (or sideways)
+// and no two technologies share a cell; rows get their year variables
+func (l *TechGridLayout) Reflow(technologies []*Technology) {
+	predecessors := make(map[string][]*Technology)
+	for _, tech := range technologies {
+		for _, path := range tech.Paths {
+			predecessors[path.LeadsToTech] = append(predecessors[path.LeadsToTech], tech)
+		}
+	}
+
+	occupied := make(map[Position]bool)
+	for _, tech := range topologicalTechnologies(technologies) {
+		y := tech.Position.Y
+		for _, pred := range predecessors[tech.ID] {
+			minimum := pred.Position.Y
+			if pred.Position.X == tech.Position.X {
+				minimum++
+			}
+			y = max(y, minimum)
+		}
+		for occupied[NewPosition(tech.Position.X, y)] {
+			y++
+		}
+		occupied[NewPosition(tech.Position.X, y)] = true
+
+		if y != tech.Position.Y || tech.Position.YVar != "" && l.Grid.Rows[tech.Position.YVar] != y {
+			tech.Position.Y = y
+			tech.Position.YVar = l.Grid.RowName(y)
+		} else if tech.Position.YVar == "" {
+			tech.Position.YVar = l.Grid.RowName(y)
+		}
+	}
+}
+
+// AssignColumns gives every column without a variable a new one named
+// after its sub-tree (@<FOLDER>_<n>_COL<k>, or after a variable already used
+// in the sub-tree: @RADAR_COL<k>) and records it in NewColumns
+func (l *TechGridLayout) AssignColumns(technologies []*Technology) {
+	ranges := SubTreeRanges(technologies)
+	for i, r := range ranges {
+		prefix := l.subTreePrefix(technologies, r, i, len(ranges))
+		for x := r[0]; x <= r[1]; x++ {
+			name := l.Grid.ColumnName(x)
+			used := false
+			for _, tech := range technologies {
+				if tech.Position.X != x {
+					continue
+				}
+				used = true
+				if tech.Position.XVar != "" && l.Grid.Columns[tech.Position.XVar] == x {
+					name = tech.Position.XVar
+				}
+			}
+			if !used {
+				continue
+			}
+			if name == "" {
+				name = fmt.Sprintf("%s_COL%d", prefix, x-r[0]+1)
+				l.Grid.Columns[name] = x
+				l.NewColumns[name] = x
+			}
+			for _, tech := range technologies {
+				if tech.Position.X == x && (tech.Position.XVar == "" || l.Grid.Columns[tech.Position.XVar] != x) {
+					tech.Position.XVar = name
+				}
+			}
+		}
+	}
+}
+
+// subTreePrefix names the columns of a sub-tree
+func (l *TechGridLayout) subTreePrefix(technologies []*Technology, r [2]int, index, count int) string {
+	vars := make([]string, 0)
+	for _, tech := range technologies {
+		if x := tech.Position.X; x >= r[0] && x <= r[1] && tech.Position.XVar != "" {
+			vars = append(vars, tech.Position.XVar)
+		}
+	}
+	if len(vars) > 0 {
+		sort.Strings(vars)
+		return vars[0]
+	}
+
+	prefix := "@" + strings.ToUpper(strings.TrimSuffix(l.Folder, "_folder"))
+	if count > 1 {
+		prefix += "_" + strconv.Itoa(index+1)
+	}
+	return prefix
+}
+
+// Insert adds a technology after another one of its chain: it takes the
+// column of afterID one row below, inherits the paths of afterID that
+// continue in that column, and the technologies below are reflowed
+func (l *TechGridLayout) Insert(technologies []*Technology, tech *Technology, afterID string) ([]*Technology, error) {
+	var after *Technology
+	byID := make(map[string]*Technology, len(technologies))
+	for _, existing := range technologies {
+		byID[existing.ID] = existing
+		if existing.ID == afterID {
+			after = existing
+		}
+	}
+	if after == nil {
+		return technologies, fmt.Errorf("technology %s not found", afterID)
+	}
+	if _, exists := byID[tech.ID]; exists {
+		return technologies, fmt.Errorf("technology %s already exists", tech.ID)
+	}
+
+	tech.Folder = after.Folder
+	tech.Position = Position{X: after.Position.X, Y: after.Position.Y + 1, XVar: after.Position.XVar}
+	tech.Position.YVar = l.Grid.RowName(tech.Position.Y)
+
+	paths := make([]TechPath, 0, len(after.Paths)+1)
+	for _, path := range after.Paths {
+		if next, ok := byID[path.LeadsToTech]; ok && next.Position.X == after.Position.X {
+			tech.Paths = append(tech.Paths, path)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	after.Paths = append(paths, TechPath{LeadsToTech: tech.ID, ResearchCostCoeff: 1})
+
+	technologies = append(technologies, tech)
+	l.Reflow(technologies)
+	return technologies, nil
+}
+
+// Check reports paths leading up or into another sub-tree, technologies
+// sharing a cell and technologies whose row is not the year of start_year
+func (l *TechGridLayout) Check(technologies []*Technology) []TechGridIssue {
+	issues := make([]TechGridIssue, 0)
+	byID := make(map[string]*Technology, len(technologies))
+	for _, tech := range technologies {
+		byID[tech.ID] = tech
+	}
+	ranges := SubTreeRanges(technologies)
+	occupied := make(map[Position]*Technology)
+
+	for _, tech := range sortedTechnologies(technologies) {
+		cell := NewPosition(tech.Position.X, tech.Position.Y)
+		if other, ok := occupied[cell]; ok {
+			issues = append(issues, TechGridIssue{Kind: TechGridOverlap, Tech: tech,
+				Message: fmt.Sprintf("shares cell (%d, %d) with %s", cell.X, cell.Y, other.ID)})
+		} else {
+			occupied[cell] = tech
+		}
+
+		for _, path := range tech.Paths {
+			target, ok := byID[path.LeadsToTech]
+			if !ok {
+				continue
+			}
+			if target.Position.Y < tech.Position.Y {
+				issues = append(issues, TechGridIssue{Kind: TechGridUpwardPath, Tech: tech,
+					Message: fmt.Sprintf("path to %s goes up from row %d to %d", target.ID, tech.Position.Y, target.Position.Y)})
+			}
+			if from, to := subTreeIndex(ranges, tech.Position.X), subTreeIndex(ranges, target.Position.X); from != to {
+				issues = append(issues, TechGridIssue{Kind: TechGridSubTreeCrossing, Tech: tech,
+					Message: fmt.Sprintf("path to %s crosses from sub-tree %d to %d", target.ID, from+1, to+1)})
+			}
+		}
+
+		if tech.StartYear > 0 {
+			if row, ok := l.Grid.YearRow(tech.StartYear); ok && l.Grid.RowYear(tech.Position.Y) != tech.StartYear {
+				issues = append(issues, TechGridIssue{Kind: TechGridYearRow, Tech: tech,
+					Message: fmt.Sprintf("start_year %d is row %d (@%d), placed on row %d", tech.StartYear, row, tech.StartYear, tech.Position.Y)})
+			}
+		}
+	}
+	return issues
+}
+
+// topologicalTechnologies orders technologies so that path sources come
+// before their targets (ties and cycles by position)
+func topologicalTechnologies(technologies []*Technology) []*Technology {
+	sorted := sortedTechnologies(technologies)
+	inFolder := make(map[string]bool, len(sorted))
+	for _, tech := range sorted {
+		inFolder[tech.ID] = true
+	}
+	incoming := make(map[string]int, len(sorted))
+	for _, tech := range sorted {
+		for _, path := range tech.Paths {
+			if inFolder[path.LeadsToTech] {
+				incoming[path.LeadsToTech]++
+			}
+		}
+	}
+	ordered := make([]*Technology, 0, len(sorted))
+	done := make(map[string]bool, len(sorted))
+	for len(ordered) < len(sorted) {
+		progress := false
+		for _, tech := range sorted {
+			if done[tech.ID] || incoming[tech.ID] > 0 {
+				continue
+			}
+			done[tech.ID] = true
+			ordered = append(ordered, tech)
+			progress = true
+			for _, path := range tech.Paths {
+				if inFolder[path.LeadsToTech] {
+					incoming[path.LeadsToTech]--
+				}
+			}
+			break // Restart to keep position order among ready technologies
+		}
+		if !progress {
+			// Cycle: take the first remaining technology
+			for _, tech := range sorted {
+				if !done[tech.ID] {
+					incoming[tech.ID] = 0
+					break
+				}
+			}
+		}
+	}
+	return ordered
+}
+
+// sortedTechnologies orders technologies by row, column and ID
+func sortedTechnologies(technologies []*Technology) []*Technology {
+	sorted := append([]*Technology{}, technologies...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].Position, sorted[j].Position
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
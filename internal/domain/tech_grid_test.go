@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"testing"
+)
+
+// newTechGrid builds a folder with a radio chain (tech_radio2 off its year
+// row, tech_radio above it) and a radar sub-tree whose path crosses into a
+// rocket sub-tree
+func newTechGrid() ([]*Technology, *TechGrid) {
+	tech := func(id string, year int, position Position, leadsTo ...string) *Technology {
+		t := &Technology{ID: id, StartYear: year, Position: position, Folder: "test_folder"}
+		for _, target := range leadsTo {
+			t.Paths = append(t.Paths, TechPath{LeadsToTech: target, ResearchCostCoeff: 1})
+		}
+		return t
+	}
+
+	techs := []*Technology{
+		tech("tech_radio", 1936, NewPositionWithVars(0, 2, "@RADIO", "@1936"), "tech_radio2", "tech_radar"),
+		tech("tech_radio2", 1938, NewPositionWithVars(0, 1, "@RADIO", ""), "tech_radio3"),
+		tech("tech_radio3", 1940, NewPositionWithVars(0, 10, "@RADIO", "@1940")),
+		tech("tech_radar", 1938, NewPosition(2, 6), "tech_rocket"),
+		tech("tech_rocket", 1940, NewPosition(12, 10)),
+	}
+	return techs, NewTechGrid(map[string]int{"@1936": 2, "@1938": 6, "@1940": 10}, map[string]int{"@RADIO": 0})
+}
+
+// techByID returns a technology of techs
+func techByID(techs []*Technology, id string) *Technology {
+	for _, tech := range techs {
+		if tech.ID == id {
+			return tech
+		}
+	}
+	return nil
+}
+
+func TestTechGridCheck(t *testing.T) {
+	techs, grid := newTechGrid()
+	if ranges := SubTreeRanges(techs); len(ranges) != 2 || ranges[1] != [2]int{12, 12} {
+		t.Fatalf("Unexpected sub-trees: %v", ranges)
+	}
+
+	kinds := make(map[string][]string)
+	for _, issue := range NewTechGridLayout(grid, "test_folder").Check(techs) {
+		kinds[issue.Kind] = append(kinds[issue.Kind], issue.Tech.ID)
+	}
+	if ids := kinds[TechGridUpwardPath]; len(ids) != 1 || ids[0] != "tech_radio" {
+		t.Errorf("Unexpected upward paths: %v", ids)
+	}
+	if ids := kinds[TechGridSubTreeCrossing]; len(ids) != 1 || ids[0] != "tech_radar" {
+		t.Errorf("Unexpected sub-tree crossings: %v", ids)
+	}
+	if ids := kinds[TechGridYearRow]; len(ids) != 1 || ids[0] != "tech_radio2" {
+		t.Errorf("Unexpected year row issues: %v", ids)
+	}
+}
+
+func TestTechGridLayout(t *testing.T) {
+	techs, grid := newTechGrid()
+	layout := NewTechGridLayout(grid, "test_folder")
+	layout.Layout(techs)
+
+	radio2 := techByID(techs, "tech_radio2")
+	if radio2.Position.Y != 6 || radio2.Position.YVar != "@1938" {
+		t.Errorf("tech_radio2 not on @1938: %+v", radio2.Position)
+	}
+	for _, issue := range layout.Check(techs) {
+		if issue.Kind != TechGridSubTreeCrossing {
+			t.Errorf("Issue left after layout: %s %s", issue.Tech.ID, issue.Message)
+		}
+	}
+
+	// Existing column variables are kept, new ones are named per sub-tree
+	if radar := techByID(techs, "tech_radar"); radar.Position.XVar != "@RADIO_COL3" {
+		t.Errorf("Unexpected radar column: %+v", radar.Position)
+	}
+	if rocket := techByID(techs, "tech_rocket"); rocket.Position.XVar != "@TEST_2_COL1" {
+		t.Errorf("Unexpected rocket column: %+v", rocket.Position)
+	}
+	if len(layout.NewColumns) != 2 || techByID(techs, "tech_radio").Position.XVar != "@RADIO" {
+		t.Errorf("Unexpected new columns: %v", layout.NewColumns)
+	}
+}
+
+func TestTechGridInsert(t *testing.T) {
+	techs, grid := newTechGrid()
+	layout := NewTechGridLayout(grid, "test_folder")
+	layout.Layout(techs)
+
+	inserted := &Technology{ID: "tech_radio_mid", StartYear: 1938}
+	techs, err := layout.Insert(techs, inserted, "tech_radio2")
+	if err != nil {
+		t.Fatalf("Insert() error: %v", err)
+	}
+	if inserted.Position.X != 0 || inserted.Position.Y != 7 || len(inserted.Paths) != 1 || inserted.Paths[0].LeadsToTech != "tech_radio3" {
+		t.Errorf("Unexpected inserted technology: %+v %v", inserted.Position, inserted.Paths)
+	}
+	if radio2 := techByID(techs, "tech_radio2"); len(radio2.Paths) != 1 || radio2.Paths[0].LeadsToTech != "tech_radio_mid" {
+		t.Errorf("Chain not rewired: %v", radio2.Paths)
+	}
+	// tech_radio3 is already below, the chain needs no reflow
+	if radio3 := techByID(techs, "tech_radio3"); radio3.Position.Y != 10 {
+		t.Errorf("tech_radio3 moved to %+v", radio3.Position)
+	}
+
+	// Inserting right above a technology pushes it down
+	after := inserted.ID
+	for _, id := range []string{"tech_radio_a", "tech_radio_b", "tech_radio_c"} {
+		if techs, err = layout.Insert(techs, &Technology{ID: id}, after); err != nil {
+			t.Fatalf("Insert() error: %v", err)
+		}
+		after = id
+	}
+	if radio3 := techByID(techs, "tech_radio3"); radio3.Position.Y != 11 || radio3.Position.YVar != "" {
+		t.Errorf("tech_radio3 not pushed below the chain: %+v", radio3.Position)
+	}
+	if _, err := layout.Insert(techs, &Technology{ID: "tech_x"}, "tech_none"); err == nil {
+		t.Error("Expected an error for an unknown technology")
+	}
+}
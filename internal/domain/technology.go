@@ -3,7 +3,9 @@ package domain
 // Technology represents a technology in HOI4
 type Technology struct {
 	// Identification
-	ID string
+	ID   string
+	File string // Source file (set by loaders)
+	Line int    // Line of the technology block in its file (0 if unknown)
 	
 	// Position
 	Position Position
@@ -21,9 +23,15 @@ type Technology struct {
 	
 	// Research Properties
 	ResearchCost      float64
+	StartYear         int     // start_year: researching earlier is ahead of time
 	XPResearchType    string  // army/navy/air
 	XPBoostCost       int
 	XPResearchBonus   float64
+	XPUnlockCost      int     // xp_unlock_cost: XP needed to unlock (doctrine mastery)
+
+	// Doctrines
+	Doctrine     bool   // doctrine = yes
+	DoctrineName string // doctrine_name: name of the doctrine line the tech starts
 	
 	// Completion
 	OnResearchComplete string // Effects on completion
@@ -36,6 +44,10 @@ type Technology struct {
 	XOR            []string // Mutually exclusive technologies
 	EnableTactic   string
 	EnableBuilding string
+
+	// Unlocks
+	EnableEquipments []string // Equipment unlocked by enable_equipments
+	EnableSubunits   []string // Sub-units unlocked by enable_subunits
 }
 
 // TechPath represents a connection to another technology
@@ -59,25 +71,6 @@ func NewTechnology(id string, x, y int, folder string) *Technology {
 	}
 }
 
-// Validate checks if the technology is valid
-func (t *Technology) Validate() []string {
-	errors := make([]string, 0)
-	
-	if t.ID == "" {
-		errors = append(errors, "technology ID cannot be empty")
-	}
-	
-	if t.ResearchCost <= 0 {
-		errors = append(errors, "research cost must be positive")
-	}
-	
-	if t.Folder == "" {
-		errors = append(errors, "technology must have a folder")
-	}
-	
-	return errors
-}
-
 // AddPath adds a path to another technology
 func (t *Technology) AddPath(targetID string, costCoeff float64) {
 	t.Paths = append(t.Paths, TechPath{
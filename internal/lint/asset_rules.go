@@ -0,0 +1,42 @@
+package lint
+
+// checkMissingLocalisation reports focuses and technologies whose ID has no
+// localisation key (the in-game name)
+func checkMissingLocalisation(input *Input, report func(Issue)) {
+	if input.Localisation == nil {
+		return
+	}
+	if input.FocusTree != nil {
+		for _, focus := range sortedFocuses(input.FocusTree) {
+			if _, ok := input.Localisation[focus.ID]; !ok && focus.ID != "" {
+				report(Issue{Location: focusLocation(input, focus), Message: "no localisation for focus name " + focus.ID})
+			}
+		}
+	}
+	for _, tech := range input.Technologies {
+		if _, ok := input.Localisation[tech.ID]; !ok {
+			report(Issue{Location: techLocation(tech), Message: "no localisation for technology name " + tech.ID})
+		}
+	}
+}
+
+// checkMissingIcons reports focus icons and technologies without a sprite
+func checkMissingIcons(input *Input, report func(Issue)) {
+	if input.FocusTree != nil && input.FocusIcon != nil {
+		for _, focus := range sortedFocuses(input.FocusTree) {
+			switch {
+			case focus.Icon == "":
+				report(Issue{Location: focusLocation(input, focus), Message: "focus has no icon"})
+			case !input.FocusIcon(focus.Icon):
+				report(Issue{Location: focusLocation(input, focus), Message: "icon " + focus.Icon + " is not a known sprite"})
+			}
+		}
+	}
+	if input.TechIcon != nil {
+		for _, tech := range input.Technologies {
+			if !input.TechIcon(tech.ID) {
+				report(Issue{Location: techLocation(tech), Message: "no GFX_" + tech.ID + "_medium sprite"})
+			}
+		}
+	}
+}
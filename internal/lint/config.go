@@ -0,0 +1,87 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+)
+
+// ConfigFile is the rules file looked up in the mod root
+const ConfigFile = "hoi4lint.txt"
+
+// Config holds per-project rule settings:
+//
+//	rules = {
+//		missing_icon = off
+//		focus_overlap = error
+//	}
+//	ignore = { GER_secret_focus }
+type Config struct {
+	Severities map[string]Severity // Rule ID -> severity
+	Ignore     map[string]bool     // Focus/technology IDs never reported
+}
+
+// NewConfig creates a config that keeps the default severities
+func NewConfig() *Config {
+	return &Config{
+		Severities: make(map[string]Severity),
+		Ignore:     make(map[string]bool),
+	}
+}
+
+// ParseConfig parses a rules file
+func ParseConfig(content string) (*Config, error) {
+	program, err := parser.NewParser(content).Parse()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rules: %w", err)
+	}
+
+	config := NewConfig()
+	for _, stmt := range program.Statements {
+		assign, ok := stmt.(*parser.AssignmentStatement)
+		if !ok {
+			continue
+		}
+		block, ok := assign.Value.(*parser.BlockStatement)
+		if !ok {
+			continue
+		}
+
+		switch assign.Name.Value {
+		case "rules":
+			for _, inner := range block.Statements {
+				rule, ok := inner.(*parser.AssignmentStatement)
+				if !ok {
+					continue
+				}
+				value := parser.FormatExpression(rule.Value, 0)
+				severity, ok := ParseSeverity(value)
+				if !ok {
+					return nil, fmt.Errorf("line %d: unknown severity %q for rule %s", rule.Name.Token.Line, value, rule.Name.Value)
+				}
+				config.Severities[rule.Name.Value] = severity
+			}
+		case "ignore":
+			for _, inner := range block.Statements {
+				if value, ok := inner.(*parser.ValueStatement); ok {
+					config.Ignore[parser.FormatExpression(value.Value, 0)] = true
+				}
+			}
+		}
+	}
+
+	return config, nil
+}
+
+// LoadConfig reads a rules file; a missing file gives the default config
+func LoadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewConfig(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules: %w", err)
+	}
+	return ParseConfig(string(content))
+}
@@ -0,0 +1,350 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// DefaultRules returns the built-in rules
+func DefaultRules() []Rule {
+	return []Rule{
+		{ID: "missing_id", Severity: SeverityError, Description: "focus without an id", Check: checkMissingID},
+		{ID: "invalid_cost", Severity: SeverityError, Description: "focus cost or research_cost that is not positive", Check: checkInvalidCost},
+		{ID: "circular_prerequisite", Severity: SeverityError, Description: "focus prerequisites that form a cycle", Check: checkCircularPrerequisites},
+		{ID: "missing_target", Severity: SeverityError, Description: "prerequisite, mutually_exclusive, relative_position_id, path or xor naming an unknown ID", Check: checkMissingTargets},
+		{ID: "focus_overlap", Severity: SeverityWarning, Description: "focuses at the same position after relative positioning", Check: checkFocusOverlap},
+		{ID: "unreachable_focus", Severity: SeverityWarning, Description: "focus whose prerequisites can never be completed", Check: checkUnreachableFocuses},
+		{ID: "asymmetric_exclusion", Severity: SeverityWarning, Description: "mutually_exclusive listed on one focus only", Check: checkAsymmetricExclusions},
+		{ID: "asymmetric_xor", Severity: SeverityWarning, Description: "xor listed on one technology only", Check: checkAsymmetricXOR},
+		{ID: "upward_path", Severity: SeverityWarning, Description: "technology path leading to an earlier row", Check: techGridCheck(domain.TechGridUpwardPath)},
+		{ID: "subtree_crossing", Severity: SeverityWarning, Description: "technology path leading into another sub-tree of the folder", Check: techGridCheck(domain.TechGridSubTreeCrossing)},
+		{ID: "tech_overlap", Severity: SeverityWarning, Description: "technologies in the same cell of a folder", Check: techGridCheck(domain.TechGridOverlap)},
+		{ID: "year_row", Severity: SeverityInfo, Description: "technology not on the year row of its start_year", Check: techGridCheck(domain.TechGridYearRow)},
+		{ID: "missing_folder", Severity: SeverityError, Description: "technology without a folder", Check: checkMissingFolder},
+		{ID: "missing_localisation", Severity: SeverityWarning, Description: "focus or technology name without localisation", Check: checkMissingLocalisation},
+		{ID: "missing_icon", Severity: SeverityWarning, Description: "focus or technology icon without a sprite", Check: checkMissingIcons},
+	}
+}
+
+// checkMissingID reports focuses without an id
+func checkMissingID(input *Input, report func(Issue)) {
+	if input.FocusTree == nil {
+		return
+	}
+	for _, focus := range sortedFocuses(input.FocusTree) {
+		if focus.ID == "" {
+			report(Issue{Location: focusLocation(input, focus), Message: "focus has no id"})
+		}
+	}
+}
+
+// checkInvalidCost reports focus costs and research costs that are not positive
+func checkInvalidCost(input *Input, report func(Issue)) {
+	if input.FocusTree != nil {
+		for _, focus := range sortedFocuses(input.FocusTree) {
+			if focus.Cost <= 0 {
+				report(Issue{Location: focusLocation(input, focus), Message: fmt.Sprintf("focus cost must be positive, got %d", focus.Cost)})
+			}
+		}
+	}
+	for _, tech := range input.Technologies {
+		if tech.ResearchCost <= 0 {
+			report(Issue{Location: techLocation(tech), Message: fmt.Sprintf("research_cost must be positive, got %g", tech.ResearchCost)})
+		}
+	}
+}
+
+// checkCircularPrerequisites reports each prerequisite cycle once, at the
+// focus where the walk closes it
+func checkCircularPrerequisites(input *Input, report func(Issue)) {
+	tree := input.FocusTree
+	if tree == nil {
+		return
+	}
+
+	visited := make(map[string]bool)
+	stack := make([]string, 0)
+	onStack := make(map[string]bool)
+
+	var visit func(id string)
+	visit = func(id string) {
+		visited[id] = true
+		onStack[id] = true
+		stack = append(stack, id)
+
+		focus := tree.Focuses[id]
+		for _, group := range focus.Prerequisites {
+			for _, prereqID := range group {
+				if _, exists := tree.Focuses[prereqID]; !exists {
+					continue
+				}
+				if onStack[prereqID] {
+					cycle := []string{prereqID}
+					for i := len(stack) - 1; i >= 0 && stack[i] != prereqID; i-- {
+						cycle = append([]string{stack[i]}, cycle...)
+					}
+					cycle = append([]string{prereqID}, cycle...)
+					report(Issue{Location: focusLocation(input, focus), Message: "circular prerequisite: " + strings.Join(cycle, " -> ")})
+				} else if !visited[prereqID] {
+					visit(prereqID)
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		onStack[id] = false
+	}
+
+	for _, focus := range sortedFocuses(tree) {
+		if !visited[focus.ID] {
+			visit(focus.ID)
+		}
+	}
+}
+
+// checkMissingTargets reports references to unknown focuses and technologies;
+// the fix removes the reference
+func checkMissingTargets(input *Input, report func(Issue)) {
+	if tree := input.FocusTree; tree != nil {
+		for _, focus := range sortedFocuses(tree) {
+			for _, group := range focus.Prerequisites {
+				for _, prereqID := range group {
+					if _, exists := tree.Focuses[prereqID]; !exists {
+						report(Issue{
+							Location: focusLocation(input, focus),
+							Message:  "prerequisite " + prereqID + " does not exist",
+							Fix: &Fix{Description: "remove prerequisite " + prereqID, Apply: func() {
+								focus.Prerequisites = removeFromGroups(focus.Prerequisites, prereqID)
+							}},
+						})
+					}
+				}
+			}
+			for _, exclusiveID := range focus.MutuallyExclusive {
+				if _, exists := tree.Focuses[exclusiveID]; !exists {
+					report(Issue{
+						Location: focusLocation(input, focus),
+						Message:  "mutually exclusive focus " + exclusiveID + " does not exist",
+						Fix: &Fix{Description: "remove mutually_exclusive " + exclusiveID, Apply: func() {
+							focus.MutuallyExclusive = removeString(focus.MutuallyExclusive, exclusiveID)
+						}},
+					})
+				}
+			}
+			if id := focus.RelativePositionID; id != "" {
+				if _, exists := tree.Focuses[id]; !exists {
+					report(Issue{
+						Location: focusLocation(input, focus),
+						Message:  "relative_position_id " + id + " does not exist",
+						Fix: &Fix{Description: "remove relative_position_id", Apply: func() {
+							focus.RelativePositionID = ""
+						}},
+					})
+				}
+			}
+		}
+	}
+
+	known := knownTechnologies(input)
+	for _, tech := range input.Technologies {
+		for _, path := range tech.Paths {
+			if !known[path.LeadsToTech] {
+				target := path.LeadsToTech
+				report(Issue{
+					Location: techLocation(tech),
+					Message:  "path leads to unknown technology " + target,
+					Fix: &Fix{Description: "remove path to " + target, Apply: func() {
+						paths := make([]domain.TechPath, 0, len(tech.Paths))
+						for _, path := range tech.Paths {
+							if path.LeadsToTech != target {
+								paths = append(paths, path)
+							}
+						}
+						tech.Paths = paths
+					}},
+				})
+			}
+		}
+		for _, exclusiveID := range tech.XOR {
+			if !known[exclusiveID] {
+				report(Issue{
+					Location: techLocation(tech),
+					Message:  "xor names unknown technology " + exclusiveID,
+					Fix: &Fix{Description: "remove xor " + exclusiveID, Apply: func() {
+						tech.XOR = removeString(tech.XOR, exclusiveID)
+					}},
+				})
+			}
+		}
+	}
+}
+
+// checkFocusOverlap reports focuses sharing an absolute position with an
+// earlier focus; the fix moves the focus right to the first free column
+func checkFocusOverlap(input *Input, report func(Issue)) {
+	tree := input.FocusTree
+	if tree == nil {
+		return
+	}
+
+	occupied := make(map[domain.Position]string)
+	for _, focus := range sortedFocuses(tree) {
+		pos := tree.AbsolutePosition(focus.ID)
+		other, taken := occupied[pos]
+		if !taken {
+			occupied[pos] = focus.ID
+			continue
+		}
+
+		free := pos
+		for {
+			free.X++
+			if _, taken := occupied[free]; !taken {
+				break
+			}
+		}
+		shift := free.X - pos.X
+		occupied[free] = focus.ID
+		report(Issue{
+			Location: focusLocation(input, focus),
+			Message:  fmt.Sprintf("overlaps %s at (%d, %d)", other, pos.X, pos.Y),
+			Fix: &Fix{Description: fmt.Sprintf("move right by %d", shift), Apply: func() {
+				focus.Position.X += shift
+				focus.Position.XVar = ""
+			}},
+		})
+	}
+}
+
+// checkUnreachableFocuses reports focuses that can never be taken: a
+// prerequisite group has no takeable focus, or two required prerequisites
+// are mutually exclusive
+func checkUnreachableFocuses(input *Input, report func(Issue)) {
+	tree := input.FocusTree
+	if tree == nil {
+		return
+	}
+
+	exclusive := func(a, b *domain.Focus) bool {
+		return a.IsMutuallyExclusiveWith(b.ID) || b.IsMutuallyExclusiveWith(a.ID)
+	}
+	conflict := func(focus *domain.Focus) string {
+		required := make([]*domain.Focus, 0)
+		for _, group := range focus.Prerequisites {
+			if len(group) == 1 {
+				if prereq, ok := tree.Focuses[group[0]]; ok {
+					required = append(required, prereq)
+				}
+			}
+		}
+		for i := range required {
+			for j := i + 1; j < len(required); j++ {
+				if exclusive(required[i], required[j]) {
+					return required[i].ID + " and " + required[j].ID + " are mutually exclusive"
+				}
+			}
+		}
+		return ""
+	}
+
+	// Fixpoint: a focus is takeable when every prerequisite group has a
+	// takeable focus and no required prerequisites exclude each other
+	takeable := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for id, focus := range tree.Focuses {
+			if takeable[id] || conflict(focus) != "" {
+				continue
+			}
+			ok := true
+			for _, group := range focus.Prerequisites {
+				groupOK := false
+				for _, prereqID := range group {
+					if takeable[prereqID] {
+						groupOK = true
+						break
+					}
+				}
+				if !groupOK {
+					ok = false
+					break
+				}
+			}
+			if ok {
+				takeable[id] = true
+				changed = true
+			}
+		}
+	}
+
+	for _, focus := range sortedFocuses(tree) {
+		if takeable[focus.ID] {
+			continue
+		}
+		reason := conflict(focus)
+		if reason == "" {
+			for _, group := range focus.Prerequisites {
+				groupOK := false
+				for _, prereqID := range group {
+					groupOK = groupOK || takeable[prereqID]
+				}
+				if !groupOK {
+					reason = "no prerequisite of {" + strings.Join(group, " ") + "} can be taken"
+					break
+				}
+			}
+		}
+		report(Issue{Location: focusLocation(input, focus), Message: "focus can never be taken: " + reason})
+	}
+}
+
+// checkAsymmetricExclusions reports mutually_exclusive entries missing on
+// the other focus; the fix adds the reverse entry
+func checkAsymmetricExclusions(input *Input, report func(Issue)) {
+	tree := input.FocusTree
+	if tree == nil {
+		return
+	}
+	for _, focus := range sortedFocuses(tree) {
+		for _, otherID := range focus.MutuallyExclusive {
+			other, exists := tree.Focuses[otherID]
+			if !exists || other.IsMutuallyExclusiveWith(focus.ID) {
+				continue
+			}
+			id := focus.ID
+			report(Issue{
+				Location: focusLocation(input, focus),
+				Message:  "mutually exclusive with " + otherID + ", but " + otherID + " does not list " + id,
+				Fix: &Fix{Description: "add " + id + " to mutually_exclusive of " + otherID, Apply: func() {
+					if !other.IsMutuallyExclusiveWith(id) {
+						other.MutuallyExclusive = append(other.MutuallyExclusive, id)
+					}
+				}},
+			})
+		}
+	}
+}
+
+// removeFromGroups removes an ID from prerequisite groups, dropping empty groups
+func removeFromGroups(groups [][]string, id string) [][]string {
+	result := make([][]string, 0, len(groups))
+	for _, group := range groups {
+		if group = removeString(group, id); len(group) > 0 {
+			result = append(result, group)
+		}
+	}
+	return result
+}
+
+// removeString returns the values without id
+func removeString(values []string, id string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != id {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,269 @@
+// Package lint checks focus trees and technologies with configurable rules
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// Severity is the importance of an issue
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+	SeverityOff // Disables a rule (rules file only)
+)
+
+// String returns the name used in reports and rules files
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "off"
+	}
+}
+
+// ParseSeverity parses info, warning, error or off
+func ParseSeverity(name string) (Severity, bool) {
+	for _, severity := range []Severity{SeverityInfo, SeverityWarning, SeverityError, SeverityOff} {
+		if severity.String() == name {
+			return severity, true
+		}
+	}
+	return SeverityOff, false
+}
+
+// Location is where an issue was found
+type Location struct {
+	File   string // Source file ("" if unknown)
+	Line   int    // 1-based line (0 if unknown)
+	Object string // Focus or technology ID
+}
+
+// String formats the location as file:line (object)
+func (l Location) String() string {
+	text := l.File
+	if text == "" {
+		text = "<unknown>"
+	}
+	if l.Line > 0 {
+		text = fmt.Sprintf("%s:%d", text, l.Line)
+	}
+	if l.Object != "" {
+		text += " (" + l.Object + ")"
+	}
+	return text
+}
+
+// Fix is an automatic correction of an issue, applied to the loaded model
+type Fix struct {
+	Description string
+	Apply       func()
+}
+
+// Issue is a problem reported by a rule
+type Issue struct {
+	Rule     string
+	Severity Severity
+	Location Location
+	Message  string
+	Fix      *Fix // nil when the issue has no automatic fix
+}
+
+// String formats the issue as "file:line (object): severity rule: message"
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s %s: %s", i.Location, i.Severity, i.Rule, i.Message)
+}
+
+// Input is the data checked by the rules; rules skip missing parts
+type Input struct {
+	FocusTree    *domain.FocusTree
+	FocusFile    string
+	Technologies []*domain.Technology
+
+	// Known technologies that are not checked, e.g. other folders that
+	// paths and xor of the checked technologies may name
+	OtherTechnologies []*domain.Technology
+
+	TechGrid *domain.TechGrid // Year rows of the grid rules (nil = vanilla rows)
+
+	Localisation map[string]string        // nil skips localisation checks
+	FocusIcon    func(icon string) bool   // nil skips focus icon checks
+	TechIcon     func(techID string) bool // nil skips technology icon checks
+}
+
+// Rule is a named check with a default severity
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+	Check       func(input *Input, report func(Issue))
+}
+
+// Engine runs registered rules with the severities of a rules file
+type Engine struct {
+	rules  []Rule
+	config *Config
+}
+
+// NewEngine creates an engine with the built-in rules
+func NewEngine() *Engine {
+	engine := &Engine{config: NewConfig()}
+	for _, rule := range DefaultRules() {
+		engine.Register(rule)
+	}
+	return engine
+}
+
+// Register adds a rule; a rule with the same ID is replaced
+func (e *Engine) Register(rule Rule) {
+	for i, existing := range e.rules {
+		if existing.ID == rule.ID {
+			e.rules[i] = rule
+			return
+		}
+	}
+	e.rules = append(e.rules, rule)
+}
+
+// Rules returns the registered rules
+func (e *Engine) Rules() []Rule {
+	return e.rules
+}
+
+// SetConfig sets the severities and ignored objects of a rules file; rule
+// IDs that are not registered are reported (the rest of the config applies)
+func (e *Engine) SetConfig(config *Config) error {
+	if config == nil {
+		config = NewConfig()
+	}
+	e.config = config
+
+	unknown := make([]string, 0)
+	for id := range config.Severities {
+		if !e.hasRule(id) {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown rules: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// hasRule reports whether a rule ID is registered
+func (e *Engine) hasRule(id string) bool {
+	for _, rule := range e.rules {
+		if rule.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Run checks the input with every enabled rule; issues are sorted by
+// severity (errors first), then by location
+func (e *Engine) Run(input *Input) []Issue {
+	issues := make([]Issue, 0)
+	for _, rule := range e.rules {
+		severity := rule.Severity
+		if configured, ok := e.config.Severities[rule.ID]; ok {
+			severity = configured
+		}
+		if severity == SeverityOff {
+			continue
+		}
+		rule.Check(input, func(issue Issue) {
+			if e.config.Ignore[issue.Location.Object] {
+				return
+			}
+			issue.Rule = rule.ID
+			issue.Severity = severity
+			issues = append(issues, issue)
+		})
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.Severity != b.Severity {
+			return a.Severity > b.Severity
+		}
+		if a.Location.File != b.Location.File {
+			return a.Location.File < b.Location.File
+		}
+		if a.Location.Line != b.Location.Line {
+			return a.Location.Line < b.Location.Line
+		}
+		return a.Location.Object < b.Location.Object
+	})
+	return issues
+}
+
+// ApplyFixes applies the fixes of the issues and returns how many were applied
+func ApplyFixes(issues []Issue) int {
+	applied := 0
+	for _, issue := range issues {
+		if issue.Fix != nil {
+			issue.Fix.Apply()
+			applied++
+		}
+	}
+	return applied
+}
+
+// Count returns the number of issues of each severity
+func Count(issues []Issue) map[Severity]int {
+	counts := make(map[Severity]int)
+	for _, issue := range issues {
+		counts[issue.Severity]++
+	}
+	return counts
+}
+
+// focusLocation returns the location of a focus
+func focusLocation(input *Input, focus *domain.Focus) Location {
+	return Location{File: input.FocusFile, Line: focus.Line, Object: focus.ID}
+}
+
+// techLocation returns the location of a technology
+func techLocation(tech *domain.Technology) Location {
+	return Location{File: tech.File, Line: tech.Line, Object: tech.ID}
+}
+
+// knownTechnologies returns the IDs of the checked and other technologies
+func knownTechnologies(input *Input) map[string]bool {
+	known := make(map[string]bool, len(input.Technologies)+len(input.OtherTechnologies))
+	for _, tech := range input.Technologies {
+		known[tech.ID] = true
+	}
+	for _, tech := range input.OtherTechnologies {
+		known[tech.ID] = true
+	}
+	return known
+}
+
+// sortedFocuses returns the focuses of a tree ordered by line, then ID
+func sortedFocuses(tree *domain.FocusTree) []*domain.Focus {
+	focuses := make([]*domain.Focus, 0, len(tree.Focuses))
+	for _, focus := range tree.Focuses {
+		focuses = append(focuses, focus)
+	}
+	sort.Slice(focuses, func(i, j int) bool {
+		if focuses[i].Line != focuses[j].Line {
+			return focuses[i].Line < focuses[j].Line
+		}
+		return focuses[i].ID < focuses[j].ID
+	})
+	return focuses
+}
@@ -0,0 +1,299 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+)
+
+const testFocusTree = `focus_tree = {
+	id = test_tree
+	focus = {
+		id = TST_root
+		icon = GFX_goal_generic
+		x = 5
+		y = 0
+		cost = 10
+		mutually_exclusive = { focus = TST_right }
+	}
+	focus = {
+		id = TST_left
+		icon = GFX_goal_generic
+		prerequisite = { focus = TST_root }
+		mutually_exclusive = { focus = TST_right }
+		x = 4
+		y = 1
+		cost = 10
+	}
+	focus = {
+		id = TST_right
+		icon = GFX_missing
+		prerequisite = { focus = TST_root }
+		x = 6
+		y = 1
+		cost = 10
+	}
+	focus = {
+		id = TST_both
+		icon = GFX_goal_generic
+		prerequisite = { focus = TST_left }
+		prerequisite = { focus = TST_right }
+		relative_position_id = TST_left
+		x = 0
+		y = 1
+		cost = 10
+	}
+	focus = {
+		id = TST_stacked
+		icon = GFX_goal_generic
+		prerequisite = { focus = TST_ghost }
+		x = 4
+		y = 2
+		cost = 10
+	}
+	focus = {
+		id = TST_loop_a
+		icon = GFX_goal_generic
+		prerequisite = { focus = TST_loop_b }
+		x = 10
+		y = 0
+		cost = 10
+	}
+	focus = {
+		id = TST_loop_b
+		icon = GFX_goal_generic
+		prerequisite = { focus = TST_loop_a }
+		x = 11
+		y = 0
+		cost = 10
+	}
+}`
+
+const testTechnologies = `technologies = {
+	tech_a = {
+		xor = { tech_b }
+		research_cost = 1
+		path = { leads_to_tech = tech_missing research_cost_coeff = 1 }
+		folder = { name = test_folder position = { x = 0 y = 0 } }
+	}
+	tech_b = {
+		research_cost = 1
+		path = { leads_to_tech = tech_other research_cost_coeff = 1 }
+		folder = { name = test_folder position = { x = 2 y = 0 } }
+	}
+}`
+
+// parseFocusTree parses testFocusTree into a tree
+func parseFocusTree(t *testing.T) *domain.FocusTree {
+	t.Helper()
+	program, err := parser.NewParser(testFocusTree).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	focuses, err := parser.NewFocusParser().ParseFocusTree(program)
+	if err != nil {
+		t.Fatalf("ParseFocusTree() error: %v", err)
+	}
+	tree := domain.NewFocusTree("test_tree")
+	for _, focus := range focuses {
+		tree.AddFocus(focus)
+	}
+	return tree
+}
+
+// issuesOf returns the issues reported by a rule
+func issuesOf(issues []Issue, rule string) []Issue {
+	result := make([]Issue, 0)
+	for _, issue := range issues {
+		if issue.Rule == rule {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+func TestFocusRules(t *testing.T) {
+	tree := parseFocusTree(t)
+	input := &Input{
+		FocusTree:    tree,
+		FocusFile:    "national_focus/test.txt",
+		Localisation: map[string]string{"TST_root": "Root"},
+		FocusIcon:    func(icon string) bool { return icon == "GFX_goal_generic" },
+	}
+	issues := NewEngine().Run(input)
+
+	circular := issuesOf(issues, "circular_prerequisite")
+	if len(circular) != 1 || !strings.Contains(circular[0].Message, "TST_loop_a -> TST_loop_b -> TST_loop_a") {
+		t.Errorf("Unexpected circular issues: %v", circular)
+	}
+
+	missing := issuesOf(issues, "missing_target")
+	if len(missing) != 1 || missing[0].Location.Object != "TST_stacked" || missing[0].Fix == nil {
+		t.Fatalf("Unexpected missing target issues: %v", missing)
+	}
+	if missing[0].Location.File != "national_focus/test.txt" || missing[0].Location.Line != 38 {
+		t.Errorf("Unexpected location: %s", missing[0].Location)
+	}
+	if missing[0].Severity != SeverityError {
+		t.Errorf("Expected error severity, got %s", missing[0].Severity)
+	}
+
+	// TST_both is at (4, 2) after relative positioning, like TST_stacked
+	overlap := issuesOf(issues, "focus_overlap")
+	if len(overlap) != 1 || overlap[0].Location.Object != "TST_stacked" || !strings.Contains(overlap[0].Message, "TST_both at (4, 2)") {
+		t.Errorf("Unexpected overlap issues: %v", overlap)
+	}
+
+	unreachable := issuesOf(issues, "unreachable_focus")
+	objects := make([]string, len(unreachable))
+	for i, issue := range unreachable {
+		objects[i] = issue.Location.Object
+	}
+	if strings.Join(objects, " ") != "TST_both TST_stacked TST_loop_a TST_loop_b" {
+		t.Errorf("Unexpected unreachable focuses: %v", objects)
+	}
+
+	exclusion := issuesOf(issues, "asymmetric_exclusion")
+	if len(exclusion) != 2 {
+		t.Fatalf("Expected 2 asymmetric exclusions, got %v", exclusion)
+	}
+
+	if loc := issuesOf(issues, "missing_localisation"); len(loc) != 6 {
+		t.Errorf("Expected 6 missing localisations, got %d", len(loc))
+	}
+	icons := issuesOf(issues, "missing_icon")
+	if len(icons) != 1 || icons[0].Location.Object != "TST_right" {
+		t.Errorf("Unexpected icon issues: %v", icons)
+	}
+
+	// Errors sort first
+	if issues[0].Severity != SeverityError || issues[len(issues)-1].Severity != SeverityWarning {
+		t.Errorf("Issues not sorted by severity: %v", issues)
+	}
+}
+
+func TestApplyFixes(t *testing.T) {
+	tree := parseFocusTree(t)
+	input := &Input{FocusTree: tree}
+	engine := NewEngine()
+
+	applied := ApplyFixes(engine.Run(input))
+	if applied != 4 {
+		t.Errorf("Expected 4 fixes, got %d", applied)
+	}
+
+	issues := engine.Run(input)
+	for _, rule := range []string{"missing_target", "focus_overlap", "asymmetric_exclusion"} {
+		if fixed := issuesOf(issues, rule); len(fixed) != 0 {
+			t.Errorf("%s still reported after fixes: %v", rule, fixed)
+		}
+	}
+	right := tree.Focuses["TST_right"]
+	if !right.IsMutuallyExclusiveWith("TST_root") || !right.IsMutuallyExclusiveWith("TST_left") {
+		t.Errorf("Reverse exclusions not added: %v", right.MutuallyExclusive)
+	}
+	if stacked := tree.Focuses["TST_stacked"]; len(stacked.Prerequisites) != 0 || stacked.Position.X != 5 {
+		t.Errorf("Unexpected fixed focus: %+v", stacked)
+	}
+}
+
+func TestTechnologyRules(t *testing.T) {
+	program, err := parser.NewParser(testTechnologies).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	techs, err := parser.NewTechParser().ParseTechnologies(program)
+	if err != nil {
+		t.Fatalf("ParseTechnologies() error: %v", err)
+	}
+	if techs[0].Line != 2 {
+		t.Errorf("Expected tech_a at line 2, got %d", techs[0].Line)
+	}
+
+	input := &Input{
+		Technologies:      techs,
+		OtherTechnologies: []*domain.Technology{{ID: "tech_other"}},
+		TechIcon:          func(id string) bool { return id == "tech_a" },
+	}
+	issues := NewEngine().Run(input)
+
+	missing := issuesOf(issues, "missing_target")
+	if len(missing) != 1 || !strings.Contains(missing[0].Message, "tech_missing") {
+		t.Errorf("Unexpected missing target issues: %v", missing)
+	}
+	xor := issuesOf(issues, "asymmetric_xor")
+	if len(xor) != 1 || xor[0].Location.Object != "tech_a" {
+		t.Fatalf("Unexpected xor issues: %v", xor)
+	}
+	xor[0].Fix.Apply()
+	if len(techs[1].XOR) != 1 || techs[1].XOR[0] != "tech_a" {
+		t.Errorf("Reverse xor not added: %v", techs[1].XOR)
+	}
+	if icons := issuesOf(issues, "missing_icon"); len(icons) != 1 || icons[0].Location.Object != "tech_b" {
+		t.Errorf("Unexpected icon issues: %v", icons)
+	}
+}
+
+func TestTechGridRules(t *testing.T) {
+	techs := []*domain.Technology{
+		{ID: "tech_a", Folder: "test_folder", StartYear: 1936, Position: domain.NewPosition(0, 2),
+			Paths: []domain.TechPath{{LeadsToTech: "tech_b"}, {LeadsToTech: "tech_c"}}},
+		{ID: "tech_b", Folder: "test_folder", StartYear: 1940, Position: domain.NewPosition(0, 1)},
+		{ID: "tech_c", Folder: "test_folder", Position: domain.NewPosition(10, 4)},
+	}
+	issues := NewEngine().Run(&Input{Technologies: techs})
+
+	if upward := issuesOf(issues, "upward_path"); len(upward) != 1 || !strings.Contains(upward[0].Message, "tech_b") {
+		t.Errorf("Unexpected upward path issues: %v", upward)
+	}
+	if crossing := issuesOf(issues, "subtree_crossing"); len(crossing) != 1 || !strings.Contains(crossing[0].Message, "tech_c") {
+		t.Errorf("Unexpected sub-tree crossing issues: %v", crossing)
+	}
+	rows := issuesOf(issues, "year_row")
+	if len(rows) != 1 || rows[0].Severity != SeverityInfo || rows[0].Fix == nil {
+		t.Fatalf("Unexpected year row issues: %v", rows)
+	}
+	rows[0].Fix.Apply()
+	if pos := techs[1].Position; pos.Y != 10 || pos.YVar != "@1940" {
+		t.Errorf("Year row fix moved tech_b to %+v", pos)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	config, err := ParseConfig(`rules = {
+	missing_icon = off
+	focus_overlap = error
+}
+ignore = { TST_loop_a TST_loop_b }`)
+	if err != nil {
+		t.Fatalf("ParseConfig() error: %v", err)
+	}
+
+	engine := NewEngine()
+	if err := engine.SetConfig(config); err != nil {
+		t.Fatalf("SetConfig() error: %v", err)
+	}
+	issues := engine.Run(&Input{FocusTree: parseFocusTree(t), FocusIcon: func(string) bool { return false }})
+
+	if icons := issuesOf(issues, "missing_icon"); len(icons) != 0 {
+		t.Errorf("Disabled rule reported %d issues", len(icons))
+	}
+	if overlap := issuesOf(issues, "focus_overlap"); len(overlap) != 1 || overlap[0].Severity != SeverityError {
+		t.Errorf("Severity override not applied: %v", overlap)
+	}
+	for _, issue := range issues {
+		if strings.HasPrefix(issue.Location.Object, "TST_loop") {
+			t.Errorf("Ignored object reported: %s", issue)
+		}
+	}
+
+	if _, err := ParseConfig(`rules = { missing_icon = loud }`); err == nil {
+		t.Error("Expected an error for an unknown severity")
+	}
+	config, _ = ParseConfig(`rules = { no_such_rule = off }`)
+	if err := NewEngine().SetConfig(config); err == nil || !strings.Contains(err.Error(), "no_such_rule") {
+		t.Errorf("Expected an unknown rule error, got %v", err)
+	}
+}
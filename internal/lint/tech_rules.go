@@ -0,0 +1,97 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// checkAsymmetricXOR reports xor entries missing on the other technology;
+// the fix adds the reverse entry
+func checkAsymmetricXOR(input *Input, report func(Issue)) {
+	byID := make(map[string]int, len(input.Technologies))
+	for i, tech := range input.Technologies {
+		byID[tech.ID] = i
+	}
+	for _, tech := range input.Technologies {
+		for _, otherID := range tech.XOR {
+			i, exists := byID[otherID]
+			if !exists {
+				continue
+			}
+			other := input.Technologies[i]
+			if containsString(other.XOR, tech.ID) {
+				continue
+			}
+			id := tech.ID
+			report(Issue{
+				Location: techLocation(tech),
+				Message:  "xor with " + otherID + ", but " + otherID + " does not list " + id,
+				Fix: &Fix{Description: "add " + id + " to xor of " + otherID, Apply: func() {
+					if !containsString(other.XOR, id) {
+						other.XOR = append(other.XOR, id)
+					}
+				}},
+			})
+		}
+	}
+}
+
+// checkMissingFolder reports technologies without a folder
+func checkMissingFolder(input *Input, report func(Issue)) {
+	for _, tech := range input.Technologies {
+		if tech.Folder == "" {
+			report(Issue{Location: techLocation(tech), Message: "technology has no folder"})
+		}
+	}
+}
+
+// techGridCheck returns a check reporting one kind of grid rule violation
+// per folder; year row issues are fixed by moving the technology to the
+// row of its start_year
+func techGridCheck(kind string) func(input *Input, report func(Issue)) {
+	return func(input *Input, report func(Issue)) {
+		folders := make(map[string][]*domain.Technology)
+		names := make([]string, 0)
+		for _, tech := range input.Technologies {
+			if tech.Folder == "" {
+				continue
+			}
+			if _, ok := folders[tech.Folder]; !ok {
+				names = append(names, tech.Folder)
+			}
+			folders[tech.Folder] = append(folders[tech.Folder], tech)
+		}
+		sort.Strings(names)
+
+		for _, folder := range names {
+			layout := domain.NewTechGridLayout(input.TechGrid, folder)
+			for _, issue := range layout.Check(folders[folder]) {
+				if issue.Kind != kind {
+					continue
+				}
+				result := Issue{Location: techLocation(issue.Tech), Message: issue.Message}
+				if kind == domain.TechGridYearRow {
+					tech, grid := issue.Tech, layout.Grid
+					row, _ := grid.YearRow(tech.StartYear)
+					result.Fix = &Fix{Description: fmt.Sprintf("move %s to row %d (@%d)", tech.ID, row, tech.StartYear), Apply: func() {
+						tech.Position.Y = row
+						tech.Position.YVar = grid.RowName(row)
+					}}
+				}
+				report(result)
+			}
+		}
+	}
+}
+
+// containsString reports whether values contains id
+func containsString(values []string, id string) bool {
+	for _, value := range values {
+		if value == id {
+			return true
+		}
+	}
+	return false
+}
@@ -30,8 +30,9 @@ func (p *Program) TokenLiteral() string {
 }
 
 // AssignmentStatement represents an assignment (key = value)
+// Comparisons (key < value, key > value) use the same node; Token holds the operator
 type AssignmentStatement struct {
-	Token Token // The '=' token
+	Token Token // The '=', '<' or '>' token
 	Name  *Identifier
 	Value Expression
 }
@@ -39,6 +40,27 @@ type AssignmentStatement struct {
 func (as *AssignmentStatement) statementNode()       {}
 func (as *AssignmentStatement) TokenLiteral() string { return as.Token.Value }
 
+// Operator returns "=", "<" or ">"
+func (as *AssignmentStatement) Operator() string {
+	if as.Token.Value == "" {
+		return "="
+	}
+	return as.Token.Value
+}
+
+// ValueStatement represents a bare value inside a block ({ GER ENG } or { 1.0 0.5 0.2 })
+type ValueStatement struct {
+	Value Expression
+}
+
+func (vs *ValueStatement) statementNode() {}
+func (vs *ValueStatement) TokenLiteral() string {
+	if vs.Value == nil {
+		return ""
+	}
+	return vs.Value.TokenLiteral()
+}
+
 // BlockStatement represents a block { ... }
 type BlockStatement struct {
 	Token      Token // The '{' token
@@ -169,6 +169,10 @@ func (bp *BookmarkParser) parseBookmark(block *BlockStatement) *domain.Bookmark
 		}
 	}
 
+	for _, country := range bookmark.Countries {
+		country.Date = bookmark.Date
+	}
+
 	return bookmark
 }
 
@@ -224,8 +228,13 @@ func (bp *BookmarkParser) extractArray(expr Expression) []string {
 
 	if block, ok := expr.(*BlockStatement); ok {
 		for _, stmt := range block.Statements {
-			if assign, ok := stmt.(*AssignmentStatement); ok {
-				result = append(result, assign.Name.Value)
+			switch v := stmt.(type) {
+			case *AssignmentStatement:
+				result = append(result, v.Name.Value)
+			case *ValueStatement:
+				if value := scalarValue(v.Value); value != "" {
+					result = append(result, value)
+				}
 			}
 		}
 	}
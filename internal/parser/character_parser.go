@@ -0,0 +1,156 @@
+package parser
+
+import (
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// characterRefKeys are the effects that reference a character by ID
+// (recruit_character = GER_x or add_advisor_role = { character = GER_x ... })
+var characterRefKeys = map[string]string{
+	"recruit_character":          "character",
+	"promote_character":          "character",
+	"retire_character":           "character",
+	"add_advisor_role":           "character",
+	"add_country_leader_role":    "character",
+	"add_corps_commander_role":   "character",
+	"add_field_marshal_role":     "character",
+	"add_naval_commander_role":   "character",
+	"remove_advisor_role":        "character",
+	"activate_advisor":           "character",
+	"deactivate_advisor":         "character",
+	"set_character_name":         "character",
+	"remove_country_leader_role": "character",
+}
+
+// CharacterParser converts common/characters AST to domain.Character models
+type CharacterParser struct{}
+
+// NewCharacterParser creates a new CharacterParser
+func NewCharacterParser() *CharacterParser {
+	return &CharacterParser{}
+}
+
+// ParseCharacters parses every characters = { ... } block of a file
+func (cp *CharacterParser) ParseCharacters(program *Program) []*domain.Character {
+	characters := make([]*domain.Character, 0)
+
+	for _, stmt := range program.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok || assign.Name.Value != "characters" {
+			continue
+		}
+		block, ok := assign.Value.(*BlockStatement)
+		if !ok {
+			continue
+		}
+
+		for _, characterStmt := range block.Statements {
+			characterAssign, ok := characterStmt.(*AssignmentStatement)
+			if !ok {
+				continue
+			}
+			if characterBlock, ok := characterAssign.Value.(*BlockStatement); ok {
+				characters = append(characters, cp.parseCharacter(characterAssign.Name.Value, characterBlock))
+			}
+		}
+	}
+
+	return characters
+}
+
+// parseCharacter parses a single character block
+func (cp *CharacterParser) parseCharacter(id string, block *BlockStatement) *domain.Character {
+	character := domain.NewCharacter(id)
+	roleTypes := make(map[string]bool, len(domain.CharacterRoleTypes))
+	for _, roleType := range domain.CharacterRoleTypes {
+		roleTypes[roleType] = true
+	}
+
+	for _, stmt := range block.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok {
+			continue
+		}
+
+		key := assign.Name.Value
+		switch {
+		case key == "name":
+			character.Name = scalarValue(assign.Value)
+		case key == "portraits":
+			cp.parsePortraits(character, assign.Value)
+		case roleTypes[key]:
+			if roleBlock, ok := assign.Value.(*BlockStatement); ok {
+				character.Roles = append(character.Roles, cp.parseRole(key, roleBlock))
+			}
+		}
+	}
+
+	return character
+}
+
+// parsePortraits reads portraits = { civilian = { large = X small = Y } ... }
+func (cp *CharacterParser) parsePortraits(character *domain.Character, expr Expression) {
+	block, ok := expr.(*BlockStatement)
+	if !ok {
+		return
+	}
+
+	for _, kindStmt := range block.Statements {
+		kind, ok := kindStmt.(*AssignmentStatement)
+		if !ok {
+			continue
+		}
+		sizes, ok := kind.Value.(*BlockStatement)
+		if !ok {
+			continue
+		}
+		for _, sizeStmt := range sizes.Statements {
+			if size, ok := sizeStmt.(*AssignmentStatement); ok {
+				character.Portraits[kind.Name.Value+"."+size.Name.Value] = scalarValue(size.Value)
+			}
+		}
+	}
+}
+
+// parseRole parses a role block
+func (cp *CharacterParser) parseRole(roleType string, block *BlockStatement) *domain.CharacterRole {
+	role := &domain.CharacterRole{
+		Type:   roleType,
+		Traits: make([]string, 0),
+	}
+
+	for _, stmt := range block.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok {
+			continue
+		}
+
+		switch assign.Name.Value {
+		case "ideology":
+			role.Ideology = scalarValue(assign.Value)
+		case "slot":
+			role.Slot = scalarValue(assign.Value)
+		case "idea_token":
+			role.IdeaToken = scalarValue(assign.Value)
+		case "cost":
+			role.Cost = scalarValue(assign.Value)
+		case "skill":
+			role.Skill = scalarValue(assign.Value)
+		case "traits":
+			if traits, ok := assign.Value.(*BlockStatement); ok {
+				for _, trait := range traits.Statements {
+					if value, ok := trait.(*ValueStatement); ok {
+						role.Traits = append(role.Traits, scalarValue(value.Value))
+					}
+				}
+			}
+		}
+	}
+
+	return role
+}
+
+// CollectCharacterReferences returns the character IDs used anywhere in a script block
+func CollectCharacterReferences(statements []Statement) []string {
+	return CollectReferences(statements, characterRefKeys)
+}
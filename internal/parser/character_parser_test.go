@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+const testCharacters = `characters = {
+	GER_adolf_hitler = {
+		name = GER_adolf_hitler
+		portraits = {
+			civilian = {
+				large = GFX_portrait_adolf_hitler
+			}
+			army = {
+				small = "gfx/interface/ideas/idea_GER_hitler.dds"
+			}
+		}
+		country_leader = {
+			ideology = nazism
+			expire = "1965.1.1.1"
+			traits = { dictator fascist_demagogue }
+		}
+	}
+	GER_hjalmar_schacht = {
+		name = GER_hjalmar_schacht
+		advisor = {
+			slot = political_advisor
+			idea_token = GER_hjalmar_schacht
+			cost = 150
+			allowed = { original_tag = GER }
+			traits = { captain_of_industry }
+		}
+		corps_commander = {
+			traits = { trickster }
+			skill = 2
+		}
+	}
+}
+`
+
+func TestCharacterParser_Characters(t *testing.T) {
+	characters := NewCharacterParser().ParseCharacters(parseTestProgram(t, testCharacters))
+	if len(characters) != 2 {
+		t.Fatalf("Expected 2 characters, got %d", len(characters))
+	}
+
+	leader := characters[0]
+	if leader.Portrait() != "GFX_portrait_adolf_hitler" {
+		t.Errorf("Unexpected portrait: %q", leader.Portrait())
+	}
+	if leader.Portraits["army.small"] != "gfx/interface/ideas/idea_GER_hitler.dds" {
+		t.Errorf("Unexpected army portrait: %v", leader.Portraits)
+	}
+	if len(leader.Roles) != 1 || leader.Roles[0].Type != "country_leader" || leader.Roles[0].Ideology != "nazism" {
+		t.Fatalf("Unexpected leader roles: %+v", leader.Roles)
+	}
+	if strings.Join(leader.Roles[0].Traits, ",") != "dictator,fascist_demagogue" {
+		t.Errorf("Unexpected traits: %v", leader.Roles[0].Traits)
+	}
+
+	advisor := characters[1]
+	if strings.Join(advisor.RoleTypes(), ",") != "advisor,corps_commander" {
+		t.Errorf("Unexpected roles: %v", advisor.RoleTypes())
+	}
+	role := advisor.Roles[0]
+	if role.Slot != "political_advisor" || role.IdeaToken != "GER_hjalmar_schacht" || role.Cost != "150" {
+		t.Errorf("Unexpected advisor role: %+v", role)
+	}
+	if advisor.Roles[1].Skill != "2" || strings.Join(advisor.Traits(), ",") != "captain_of_industry,trickster" {
+		t.Errorf("Unexpected commander role: %+v", advisor.Roles[1])
+	}
+}
+
+func TestCharacterSet_ForTagAndMissing(t *testing.T) {
+	file := &domain.CharacterFile{RelPath: "common/characters/GER.txt", Characters: NewCharacterParser().ParseCharacters(parseTestProgram(t, testCharacters))}
+	for _, character := range file.Characters {
+		character.File = file
+	}
+	other := &domain.CharacterFile{
+		RelPath:    "common/characters/ITA.txt",
+		Characters: []*domain.Character{domain.NewCharacter("ITA_benito_mussolini")},
+	}
+	other.Characters[0].File = other
+
+	set := domain.NewCharacterSet([]*domain.CharacterFile{file, other})
+	if got := len(set.ForTag("GER")); got != 2 {
+		t.Errorf("Expected 2 GER characters, got %d", got)
+	}
+
+	missing := set.Missing([]domain.CharacterReference{
+		{ID: "GER_adolf_hitler", Source: "focus GER_a"},
+		{ID: "GER_unknown", Source: "focus GER_b"},
+	})
+	if len(missing) != 1 || missing[0].ID != "GER_unknown" || missing[0].Source != "focus GER_b" {
+		t.Errorf("Unexpected missing references: %+v", missing)
+	}
+}
+
+func TestCollectCharacterReferences(t *testing.T) {
+	input := `completion_reward = {
+	recruit_character = GER_erwin_rommel
+	add_advisor_role = {
+		character = GER_hjalmar_schacht
+		advisor = { slot = political_advisor }
+	}
+	if = {
+		limit = { has_dlc = "No Step Back" }
+		promote_character = GER_erwin_rommel
+	}
+}`
+
+	program := parseTestProgram(t, input)
+
+	reward := program.Statements[0].(*AssignmentStatement).Value.(*BlockStatement)
+	refs := CollectCharacterReferences(reward.Statements)
+	if strings.Join(refs, ",") != "GER_erwin_rommel,GER_hjalmar_schacht" {
+		t.Errorf("Unexpected character references: %v", refs)
+	}
+}
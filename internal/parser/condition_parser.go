@@ -0,0 +1,124 @@
+package parser
+
+// AvailableCondition represents availability conditions for a folder
+type AvailableCondition struct {
+	Conditions []*Condition
+	Unknown    []string // Triggers the evaluator does not understand (treated as met)
+}
+
+// Condition represents a single condition (has_country_flag, NOT, etc.)
+type Condition struct {
+	Type     string // "has_country_flag", "NOT", "AND", "OR", "has_dlc", "major_country", "tag", "has_government", "has_idea"
+	Value    string
+	Negated  bool
+	Children []*Condition // for nested conditions like NOT { ... }
+}
+
+// ParseAvailableCondition parses an available = { ... } block, inlining
+// scripted triggers when a library is given
+func ParseAvailableCondition(block *BlockStatement, scripted *ScriptedLibrary) *AvailableCondition {
+	condition := &AvailableCondition{
+		Conditions: make([]*Condition, 0),
+		Unknown:    make([]string, 0),
+	}
+
+	statements := block.Statements
+	if scripted != nil {
+		statements, _ = scripted.Expand(statements, ScriptedTrigger)
+	}
+
+	for _, stmt := range statements {
+		if assign, ok := stmt.(*AssignmentStatement); ok {
+			cond := condition.parseCondition(assign)
+			if cond != nil {
+				condition.Conditions = append(condition.Conditions, cond)
+			}
+		}
+	}
+
+	return condition
+}
+
+// parseCondition parses a single condition; unknown triggers are recorded
+// and skipped
+func (c *AvailableCondition) parseCondition(assign *AssignmentStatement) *Condition {
+	condName := assign.Name.Value
+
+	switch condName {
+	case "has_country_flag", "has_dlc", "has_government", "has_idea":
+		// has_country_flag = FLAG_NAME / has_dlc = "DLC Name"
+		return &Condition{
+			Type:  condName,
+			Value: conditionValue(assign.Value),
+		}
+
+	case "tag", "original_tag":
+		// tag = SOV (the editor does not track tag switches)
+		return &Condition{
+			Type:  "tag",
+			Value: conditionValue(assign.Value),
+		}
+
+	case "NOT":
+		// NOT = { ... } holds when no child holds
+		if block, ok := assign.Value.(*BlockStatement); ok {
+			children := make([]*Condition, 0)
+			for _, stmt := range block.Statements {
+				if childAssign, ok := stmt.(*AssignmentStatement); ok {
+					childCond := c.parseCondition(childAssign)
+					if childCond != nil {
+						children = append(children, childCond)
+					}
+				}
+			}
+			return &Condition{
+				Type:     "NOT",
+				Children: children,
+			}
+		}
+
+	case "AND", "OR":
+		// AND = { ... } / OR = { ... } (also produced by scripted trigger expansion)
+		if block, ok := assign.Value.(*BlockStatement); ok {
+			children := make([]*Condition, 0)
+			for _, stmt := range block.Statements {
+				if childAssign, ok := stmt.(*AssignmentStatement); ok {
+					childCond := c.parseCondition(childAssign)
+					if childCond == nil && condName == "OR" {
+						return nil // An unknown alternative may hold: treat the OR as unknown
+					}
+					if childCond != nil {
+						children = append(children, childCond)
+					}
+				}
+			}
+			return &Condition{
+				Type:     condName,
+				Children: children,
+			}
+		}
+
+	case "major_country":
+		// major_country = yes / no
+		return &Condition{
+			Type:    "major_country",
+			Value:   "yes",
+			Negated: !isYes(assign.Value),
+		}
+	}
+
+	c.Unknown = append(c.Unknown, condName)
+	return nil
+}
+
+// conditionValue extracts string value from expression
+func conditionValue(expr Expression) string {
+	switch v := expr.(type) {
+	case *Identifier:
+		return v.Value
+	case *StringLiteral:
+		return v.Value
+	default:
+		return ""
+	}
+}
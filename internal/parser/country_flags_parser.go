@@ -11,14 +11,30 @@ import (
 type CountryFlagsParser struct {
 	gamePath string
 	modPath  string
+
+	// loadFile parses one history file (parseHistoryFile unless replaced)
+	loadFile func(filePath string) ([]string, error)
 }
 
 // NewCountryFlagsParser creates a new country flags parser
 func NewCountryFlagsParser(gamePath, modPath string) *CountryFlagsParser {
-	return &CountryFlagsParser{
+	p := &CountryFlagsParser{
 		gamePath: gamePath,
 		modPath:  modPath,
 	}
+	p.loadFile = p.parseHistoryFile
+	return p
+}
+
+// SetFileLoader replaces how history files are parsed (a cache in front of
+// ParseFile)
+func (p *CountryFlagsParser) SetFileLoader(loader func(filePath string) ([]string, error)) {
+	p.loadFile = loader
+}
+
+// ParseFile returns the flags a history file sets
+func (p *CountryFlagsParser) ParseFile(filePath string) ([]string, error) {
+	return p.parseHistoryFile(filePath)
 }
 
 // ParseCountryFlags loads flags from history/countries/<TAG>.txt or <TAG> - <Name>.txt
@@ -47,11 +63,22 @@ func (p *CountryFlagsParser) ParseCountryFlags(countryTag string) ([]string, err
 
 // parseFlagsFromPath tries to find and parse country history file
 func (p *CountryFlagsParser) parseFlagsFromPath(basePath, countryTag string) ([]string, error) {
+	filePath, err := FindCountryHistoryFile(basePath, countryTag)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the file
+	return p.loadFile(filePath)
+}
+
+// FindCountryHistoryFile finds the history/countries file of a country
+func FindCountryHistoryFile(basePath, countryTag string) (string, error) {
 	historyDir := filepath.Join(basePath, "history", "countries")
 
 	// Check if directory exists
 	if _, err := os.Stat(historyDir); os.IsNotExist(err) {
-		return nil, fmt.Errorf("history/countries directory not found: %s", historyDir)
+		return "", fmt.Errorf("history/countries directory not found: %s", historyDir)
 	}
 
 	// Try to find file matching the country tag
@@ -59,28 +86,22 @@ func (p *CountryFlagsParser) parseFlagsFromPath(basePath, countryTag string) ([]
 	// 1. TAG.txt (e.g., GER.txt)
 	// 2. TAG - Name.txt (e.g., GER - Germany.txt)
 
-	var filePath string
-
 	// Try exact match first
 	exactPath := filepath.Join(historyDir, countryTag+".txt")
 	if _, err := os.Stat(exactPath); err == nil {
-		filePath = exactPath
-	} else {
-		// Try to find file starting with TAG
-		files, err := filepath.Glob(filepath.Join(historyDir, countryTag+" - *.txt"))
-		if err != nil {
-			return nil, err
-		}
+		return exactPath, nil
+	}
 
-		if len(files) > 0 {
-			filePath = files[0] // Take first match
-		} else {
-			return nil, fmt.Errorf("history file not found for country: %s", countryTag)
-		}
+	// Try to find file starting with TAG
+	files, err := filepath.Glob(filepath.Join(historyDir, countryTag+" - *.txt"))
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return "", fmt.Errorf("history file not found for country: %s", countryTag)
 	}
 
-	// Parse the file
-	return p.parseHistoryFile(filePath)
+	return files[0], nil // Take first match
 }
 
 // parseHistoryFile parses a history file and extracts set_country_flag statements
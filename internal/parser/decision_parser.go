@@ -0,0 +1,197 @@
+package parser
+
+import (
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// Keys whose values reference decisions, flags or focuses; the map value is
+// the field holding the reference when the statement takes a block
+var (
+	decisionUnlockKeys = map[string]string{
+		"unlock_decision_tooltip":          "decision",
+		"unlock_decision_category_tooltip": "category",
+	}
+	setFlagKeys        = map[string]string{"set_country_flag": "flag"}
+	hasFlagKeys        = map[string]string{"has_country_flag": "flag"}
+	completedFocusKeys = map[string]string{"has_completed_focus": "focus"}
+)
+
+// DecisionParser converts common/decisions AST to domain models
+type DecisionParser struct{}
+
+// NewDecisionParser creates a new DecisionParser
+func NewDecisionParser() *DecisionParser {
+	return &DecisionParser{}
+}
+
+// ParseDecisions parses a decisions file: category_id = { decision_id = { ... } }
+func (dp *DecisionParser) ParseDecisions(program *Program) []*domain.DecisionBlock {
+	blocks := make([]*domain.DecisionBlock, 0)
+
+	for _, stmt := range program.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok {
+			continue
+		}
+		categoryBlock, ok := assign.Value.(*BlockStatement)
+		if !ok {
+			continue
+		}
+
+		block := &domain.DecisionBlock{
+			Category:  assign.Name.Value,
+			Decisions: make([]*domain.Decision, 0),
+		}
+		for _, decisionStmt := range categoryBlock.Statements {
+			decisionAssign, ok := decisionStmt.(*AssignmentStatement)
+			if !ok {
+				continue
+			}
+			decisionBlock, ok := decisionAssign.Value.(*BlockStatement)
+			if !ok {
+				continue
+			}
+			block.Decisions = append(block.Decisions, dp.parseDecision(decisionAssign.Name.Value, block.Category, decisionBlock))
+		}
+		blocks = append(blocks, block)
+	}
+
+	return blocks
+}
+
+// parseDecision parses a single decision block
+func (dp *DecisionParser) parseDecision(id, category string, block *BlockStatement) *domain.Decision {
+	decision := domain.NewDecision(id, category)
+
+	for _, stmt := range block.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok {
+			continue
+		}
+
+		switch assign.Name.Value {
+		case "icon":
+			decision.Icon = scalarValue(assign.Value)
+		case "cost":
+			// A non-scalar cost is kept verbatim
+			if decision.Cost = scalarValue(assign.Value); decision.Cost == "" {
+				decision.Extra = append(decision.Extra, scriptField(assign))
+			}
+		case "days_remove":
+			decision.DaysRemove = scalarValue(assign.Value)
+		case "days_re_enable":
+			decision.DaysReEnable = scalarValue(assign.Value)
+		case "fire_only_once":
+			decision.FireOnlyOnce = isYes(assign.Value)
+		case "allowed":
+			decision.Allowed = FormatExpression(assign.Value, 0)
+		case "visible":
+			decision.Visible = FormatExpression(assign.Value, 0)
+			if visibleBlock, ok := assign.Value.(*BlockStatement); ok {
+				decision.VisibleFlags = CollectReferences(visibleBlock.Statements, hasFlagKeys)
+				decision.VisibleFocuses = CollectReferences(visibleBlock.Statements, completedFocusKeys)
+			}
+		case "available":
+			decision.Available = FormatExpression(assign.Value, 0)
+		case "complete_effect":
+			decision.CompleteEffect = FormatExpression(assign.Value, 0)
+		case "remove_effect":
+			decision.RemoveEffect = FormatExpression(assign.Value, 0)
+		case "ai_will_do":
+			decision.AIWillDo = FormatExpression(assign.Value, 0)
+		default:
+			decision.Extra = append(decision.Extra, scriptField(assign))
+		}
+	}
+
+	return decision
+}
+
+// ParseCategories parses common/decisions/categories: category_id = { ... }
+func (dp *DecisionParser) ParseCategories(program *Program) []*domain.DecisionCategory {
+	categories := make([]*domain.DecisionCategory, 0)
+
+	for _, stmt := range program.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok {
+			continue
+		}
+		block, ok := assign.Value.(*BlockStatement)
+		if !ok {
+			continue
+		}
+
+		category := &domain.DecisionCategory{
+			ID:    assign.Name.Value,
+			Extra: make([]domain.ScriptField, 0),
+		}
+		for _, fieldStmt := range block.Statements {
+			field, ok := fieldStmt.(*AssignmentStatement)
+			if !ok {
+				continue
+			}
+			switch field.Name.Value {
+			case "icon":
+				category.Icon = scalarValue(field.Value)
+			case "picture":
+				category.Picture = scalarValue(field.Value)
+			case "priority":
+				category.Priority = scalarValue(field.Value)
+			case "allowed":
+				category.Allowed = FormatExpression(field.Value, 0)
+			case "visible":
+				category.Visible = FormatExpression(field.Value, 0)
+			default:
+				category.Extra = append(category.Extra, scriptField(field))
+			}
+		}
+		categories = append(categories, category)
+	}
+
+	return categories
+}
+
+// CollectReferences returns the values of the given keys anywhere in a
+// script block (nested if/limit/hidden_effect included, NOT skipped),
+// deduplicated. keys maps a statement name to the field read when its
+// value is a block
+func CollectReferences(statements []Statement, keys map[string]string) []string {
+	values := make([]string, 0)
+	seen := make(map[string]bool)
+
+	var walk func(statements []Statement)
+	walk = func(statements []Statement) {
+		for _, stmt := range statements {
+			assign, ok := stmt.(*AssignmentStatement)
+			if !ok {
+				continue
+			}
+
+			block, isBlock := assign.Value.(*BlockStatement)
+			field, wanted := keys[assign.Name.Value]
+			if !wanted {
+				if isBlock && assign.Name.Value != "NOT" {
+					walk(block.Statements)
+				}
+				continue
+			}
+
+			value := scalarValue(assign.Value)
+			if isBlock {
+				value = ""
+				for _, inner := range block.Statements {
+					if innerAssign, ok := inner.(*AssignmentStatement); ok && innerAssign.Name.Value == field {
+						value = scalarValue(innerAssign.Value)
+					}
+				}
+			}
+			if value != "" && !seen[value] {
+				seen[value] = true
+				values = append(values, value)
+			}
+		}
+	}
+	walk(statements)
+
+	return values
+}
@@ -0,0 +1,166 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/serializer"
+)
+
+const testDecisions = `GER_rhineland_category = {
+	GER_remilitarize_rhineland = {
+		icon = generic_army_support
+		cost = 25
+		fire_only_once = yes
+		days_remove = 30
+		allowed = { original_tag = GER }
+		visible = {
+			OR = {
+				has_country_flag = GER_rhineland_unlocked
+				has_completed_focus = GER_rhineland
+			}
+			NOT = { has_country_flag = GER_rhineland_done }
+		}
+		available = { has_army_manpower = { size = 100000 } }
+		complete_effect = {
+			set_country_flag = GER_rhineland_done
+		}
+		ai_will_do = { factor = 10 }
+		days_mission_timeout = 60
+	}
+}
+`
+
+const testDecisionCategories = `GER_rhineland_category = {
+	icon = generic_political_actions
+	picture = GFX_decision_cat_picture_rhineland
+	priority = 100
+	allowed = { original_tag = GER }
+}
+`
+
+func TestDecisionParser_Fields(t *testing.T) {
+	blocks := NewDecisionParser().ParseDecisions(parseTestProgram(t, testDecisions))
+	if len(blocks) != 1 || len(blocks[0].Decisions) != 1 {
+		t.Fatalf("Expected 1 block with 1 decision, got %d blocks", len(blocks))
+	}
+
+	decision := blocks[0].Decisions[0]
+	if decision.ID != "GER_remilitarize_rhineland" || decision.Category != "GER_rhineland_category" {
+		t.Errorf("Unexpected decision: %s in %s", decision.ID, decision.Category)
+	}
+	if decision.Cost != "25" || decision.DaysRemove != "30" || !decision.FireOnlyOnce {
+		t.Errorf("Unexpected scalars: cost=%q days_remove=%q fire_only_once=%v", decision.Cost, decision.DaysRemove, decision.FireOnlyOnce)
+	}
+	if decision.SpriteName() != "GFX_decision_generic_army_support" {
+		t.Errorf("Unexpected sprite: %s", decision.SpriteName())
+	}
+	if !strings.Contains(decision.CompleteEffect, "set_country_flag = GER_rhineland_done") {
+		t.Errorf("Expected complete_effect text, got %q", decision.CompleteEffect)
+	}
+	if len(decision.Extra) != 1 || decision.Extra[0].Key != "days_mission_timeout" {
+		t.Errorf("Expected days_mission_timeout as extra field, got %+v", decision.Extra)
+	}
+
+	// Flags inside NOT do not unlock the decision
+	if len(decision.VisibleFlags) != 1 || decision.VisibleFlags[0] != "GER_rhineland_unlocked" {
+		t.Errorf("Expected visible flag GER_rhineland_unlocked, got %v", decision.VisibleFlags)
+	}
+	if len(decision.VisibleFocuses) != 1 || decision.VisibleFocuses[0] != "GER_rhineland" {
+		t.Errorf("Expected visible focus GER_rhineland, got %v", decision.VisibleFocuses)
+	}
+}
+
+func TestDecisionParser_Categories(t *testing.T) {
+	program := parseTestProgram(t, testDecisionCategories)
+
+	categories := NewDecisionParser().ParseCategories(program)
+	if len(categories) != 1 {
+		t.Fatalf("Expected 1 category, got %d", len(categories))
+	}
+
+	category := categories[0]
+	if category.Icon != "generic_political_actions" || category.Priority != "100" {
+		t.Errorf("Unexpected category: %+v", category)
+	}
+	if !strings.Contains(category.Allowed, "original_tag = GER") {
+		t.Errorf("Expected allowed trigger, got %q", category.Allowed)
+	}
+}
+
+func TestDecision_UnlockedBy(t *testing.T) {
+	decision := NewDecisionParser().ParseDecisions(parseTestProgram(t, testDecisions))[0].Decisions[0]
+
+	tests := []struct {
+		name  string
+		focus *domain.Focus
+		want  bool
+	}{
+		{"tooltip", &domain.Focus{ID: "a", RewardDecisions: []string{"GER_remilitarize_rhineland"}}, true},
+		{"category tooltip", &domain.Focus{ID: "b", RewardDecisions: []string{"GER_rhineland_category"}}, true},
+		{"flag", &domain.Focus{ID: "c", RewardFlags: []string{"GER_rhineland_unlocked"}}, true},
+		{"completed focus", &domain.Focus{ID: "GER_rhineland"}, true},
+		{"negated flag", &domain.Focus{ID: "d", RewardFlags: []string{"GER_rhineland_done"}}, false},
+	}
+
+	for _, tt := range tests {
+		if got := decision.UnlockedBy(tt.focus); got != tt.want {
+			t.Errorf("%s: UnlockedBy() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFocusParser_RewardDecisions(t *testing.T) {
+	input := `focus_tree = {
+	id = test
+	focus = {
+		id = GER_rhineland
+		completion_reward = {
+			unlock_decision_tooltip = GER_remilitarize_rhineland
+			unlock_decision_category_tooltip = { category = GER_rhineland_category }
+			hidden_effect = {
+				set_country_flag = { flag = GER_rhineland_unlocked days = 30 }
+			}
+		}
+	}
+}`
+
+	program := parseTestProgram(t, input)
+	focuses, err := NewFocusParser().ParseFocusTree(program)
+	if err != nil {
+		t.Fatalf("ParseFocusTree() error: %v", err)
+	}
+
+	focus := focuses[0]
+	if strings.Join(focus.RewardDecisions, ",") != "GER_remilitarize_rhineland,GER_rhineland_category" {
+		t.Errorf("Unexpected reward decisions: %v", focus.RewardDecisions)
+	}
+	if len(focus.RewardFlags) != 1 || focus.RewardFlags[0] != "GER_rhineland_unlocked" {
+		t.Errorf("Unexpected reward flags: %v", focus.RewardFlags)
+	}
+}
+
+func TestDecisionWriter_RoundTrip(t *testing.T) {
+	file := &domain.DecisionFile{Blocks: NewDecisionParser().ParseDecisions(parseTestProgram(t, testDecisions))}
+	decision := file.Blocks[0].Decisions[0]
+	decision.Cost = "50"
+	decision.FireOnlyOnce = false
+
+	output := serializer.NewDecisionWriter().Write(file)
+	blocks := NewDecisionParser().ParseDecisions(parseTestProgram(t, output))
+	if len(blocks) != 1 || len(blocks[0].Decisions) != 1 {
+		t.Fatalf("Unexpected structure after round-trip:\n%s", output)
+	}
+
+	again := blocks[0].Decisions[0]
+	if again.Cost != "50" || again.FireOnlyOnce || again.DaysRemove != "30" {
+		t.Errorf("Scalar fields not preserved:\n%s", output)
+	}
+	if again.Visible != decision.Visible || again.CompleteEffect != decision.CompleteEffect || again.AIWillDo != decision.AIWillDo {
+		t.Errorf("Script blocks not preserved:\n%s", output)
+	}
+	if len(again.Extra) != 1 || again.Extra[0].Value != "60" {
+		t.Errorf("Extra fields not preserved: %+v", again.Extra)
+	}
+}
@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+const testDoctrines = `technologies = {
+	mobile_warfare = {
+		doctrine = yes
+		doctrine_name = MOBILE_WARFARE
+		xor = { superior_firepower }
+		xp_research_type = army
+		xp_boost_cost = 50
+		research_cost = 3
+		path = { leads_to_tech = delay research_cost_coeff = 1 }
+		path = { leads_to_tech = elastic_defence research_cost_coeff = 1 }
+		folder = { name = land_doctrine_folder position = { x = 0 y = 0 } }
+	}
+	delay = {
+		xor = { elastic_defence }
+		xp_research_type = army
+		xp_unlock_cost = 100
+		research_cost = 2
+		path = { leads_to_tech = mass_motorization research_cost_coeff = 1 }
+		folder = { name = land_doctrine_folder position = { x = -2 y = 2 } }
+	}
+	elastic_defence = {
+		xor = { delay }
+		xp_research_type = army
+		research_cost = 2
+		folder = { name = land_doctrine_folder position = { x = 2 y = 2 } }
+	}
+	mass_motorization = {
+		xp_research_type = army
+		research_cost = 2
+		folder = { name = land_doctrine_folder position = { x = -2 y = 4 } }
+	}
+	superior_firepower = {
+		doctrine = yes
+		xor = { mobile_warfare }
+		xp_research_type = army
+		research_cost = 3
+		folder = { name = land_doctrine_folder position = { x = 10 y = 0 } }
+	}
+}`
+
+func TestTechParser_Doctrines(t *testing.T) {
+	techs, err := NewTechParser().ParseTechnologies(parseTestProgram(t, testDoctrines))
+	if err != nil {
+		t.Fatalf("ParseTechnologies() error: %v", err)
+	}
+	if !domain.IsDoctrineFolder(techs) {
+		t.Fatal("Expected a doctrine folder")
+	}
+
+	mobile, delay := techs[0], techs[1]
+	if !mobile.Doctrine || mobile.DoctrineName != "MOBILE_WARFARE" || mobile.XPBoostCost != 50 || mobile.XPResearchType != "army" {
+		t.Errorf("Unexpected grand doctrine: %+v", mobile)
+	}
+	if len(mobile.XOR) != 1 || mobile.XOR[0] != "superior_firepower" {
+		t.Errorf("Unexpected xor: %v", mobile.XOR)
+	}
+	if delay.Doctrine || delay.XPUnlockCost != 100 || len(delay.Paths) != 1 {
+		t.Errorf("Unexpected track technology: %+v", delay)
+	}
+}
@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"strconv"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// EquipmentParser converts common/units AST to equipment and sub-unit models
+type EquipmentParser struct{}
+
+// NewEquipmentParser creates a new EquipmentParser
+func NewEquipmentParser() *EquipmentParser {
+	return &EquipmentParser{}
+}
+
+// ParseEquipment parses every equipments = { ... } block of a file
+func (ep *EquipmentParser) ParseEquipment(program *Program) []*domain.Equipment {
+	equipment := make([]*domain.Equipment, 0)
+
+	for _, block := range topLevelEntries(program, "equipments") {
+		item := domain.NewEquipment(block.Name.Value)
+		for _, stmt := range block.Value.(*BlockStatement).Statements {
+			assign, ok := stmt.(*AssignmentStatement)
+			if !ok {
+				continue
+			}
+
+			switch key := assign.Name.Value; key {
+			case "is_archetype":
+				item.IsArchetype = isYes(assign.Value)
+			case "archetype":
+				item.Archetype = scalarValue(assign.Value)
+			case "parent":
+				item.Parent = scalarValue(assign.Value)
+			case "year":
+				item.Year, _ = strconv.Atoi(scalarValue(assign.Value))
+			case "resources":
+				if resources, ok := assign.Value.(*BlockStatement); ok {
+					for resource, amount := range numericFields(resources.Statements) {
+						item.Resources[resource] = int(amount)
+					}
+				}
+			default:
+				if value, ok := numericValue(assign.Value); ok {
+					item.Stats[key] = value
+				}
+			}
+		}
+		equipment = append(equipment, item)
+	}
+
+	return equipment
+}
+
+// ParseSubUnits parses every sub_units = { ... } block of a file
+func (ep *EquipmentParser) ParseSubUnits(program *Program) []*domain.SubUnit {
+	units := make([]*domain.SubUnit, 0)
+
+	for _, block := range topLevelEntries(program, "sub_units") {
+		unit := domain.NewSubUnit(block.Name.Value)
+		for _, stmt := range block.Value.(*BlockStatement).Statements {
+			assign, ok := stmt.(*AssignmentStatement)
+			if !ok {
+				continue
+			}
+
+			switch key := assign.Name.Value; key {
+			case "abbreviation":
+				unit.Abbreviation = scalarValue(assign.Value)
+			case "group":
+				unit.Group = scalarValue(assign.Value)
+			case "categories":
+				if categories, ok := assign.Value.(*BlockStatement); ok {
+					for _, category := range categories.Statements {
+						if value, ok := category.(*ValueStatement); ok {
+							unit.Categories = append(unit.Categories, scalarValue(value.Value))
+						}
+					}
+				}
+			case "need":
+				if need, ok := assign.Value.(*BlockStatement); ok {
+					for archetype, amount := range numericFields(need.Statements) {
+						unit.Need[archetype] = int(amount)
+					}
+				}
+			default:
+				if value, ok := numericValue(assign.Value); ok {
+					unit.Stats[key] = value
+				}
+			}
+		}
+		units = append(units, unit)
+	}
+
+	return units
+}
+
+// topLevelEntries returns the id = { ... } entries of every key = { ... } block
+func topLevelEntries(program *Program, key string) []*AssignmentStatement {
+	entries := make([]*AssignmentStatement, 0)
+	for _, stmt := range program.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok || assign.Name.Value != key {
+			continue
+		}
+		block, ok := assign.Value.(*BlockStatement)
+		if !ok {
+			continue
+		}
+		for _, inner := range block.Statements {
+			if entry, ok := inner.(*AssignmentStatement); ok {
+				if _, ok := entry.Value.(*BlockStatement); ok {
+					entries = append(entries, entry)
+				}
+			}
+		}
+	}
+	return entries
+}
+
+// numericFields returns the numeric key = value lines of a block
+func numericFields(statements []Statement) map[string]float64 {
+	fields := make(map[string]float64)
+	for _, stmt := range statements {
+		if assign, ok := stmt.(*AssignmentStatement); ok {
+			if value, ok := numericValue(assign.Value); ok {
+				fields[assign.Name.Value] = value
+			}
+		}
+	}
+	return fields
+}
+
+// numericValue returns a number literal's value
+func numericValue(expr Expression) (float64, bool) {
+	num, ok := expr.(*NumberLiteral)
+	if !ok {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(num.Value, 64)
+	return value, err == nil
+}
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+const testEquipment = `equipments = {
+	infantry_equipment = {
+		year = 1918
+		is_archetype = yes
+		type = infantry
+		reliability = 0.9
+		soft_attack = 3
+		defense = 20
+		build_cost_ic = 0.43
+		resources = { steel = 2 }
+	}
+	infantry_equipment_0 = {
+		year = 1918
+		archetype = infantry_equipment
+		priority = 10
+	}
+	infantry_equipment_1 = {
+		year = 1936
+		archetype = infantry_equipment
+		parent = infantry_equipment_0
+		soft_attack = 6
+	}
+	infantry_equipment_2 = {
+		year = 1939
+		archetype = infantry_equipment
+		parent = infantry_equipment_1
+		soft_attack = 9
+	}
+	infantry_equipment_1b = {
+		year = 1938
+		archetype = infantry_equipment
+		parent = infantry_equipment_1
+	}
+}
+`
+
+const testSubUnits = `sub_units = {
+	infantry = {
+		abbreviation = "INF"
+		group = infantry
+		categories = { category_front_line category_light_infantry }
+		combat_width = 2
+		max_strength = 25
+		need = { infantry_equipment = 100 }
+	}
+}
+`
+
+func TestEquipmentParser(t *testing.T) {
+	program := parseTestProgram(t, testEquipment)
+
+	equipment := NewEquipmentParser().ParseEquipment(program)
+	if len(equipment) != 5 {
+		t.Fatalf("Expected 5 equipment, got %d", len(equipment))
+	}
+
+	archetype := equipment[0]
+	if !archetype.IsArchetype || archetype.Year != 1918 || archetype.Stats["soft_attack"] != 3 || archetype.Resources["steel"] != 2 {
+		t.Errorf("Unexpected archetype: %+v", archetype)
+	}
+	if _, ok := archetype.Stats["year"]; ok {
+		t.Error("year should not be a stat")
+	}
+
+	set := domain.NewEquipmentSet([]*domain.EquipmentFile{{Equipment: equipment}}, nil)
+	stats := set.Stats("infantry_equipment_1")
+	if stats["soft_attack"] != 6 || stats["defense"] != 20 {
+		t.Errorf("Expected archetype stats overridden by own, got %v", stats)
+	}
+
+	root, lineage := set.Lineage("infantry_equipment_2")
+	if root == nil || root.ID != "infantry_equipment" {
+		t.Fatalf("Expected archetype infantry_equipment, got %+v", root)
+	}
+	want := []struct {
+		id     string
+		depth  int
+		column int
+	}{
+		{"infantry_equipment_0", 0, 0},
+		{"infantry_equipment_1", 1, 0},
+		{"infantry_equipment_1b", 2, 0},
+		{"infantry_equipment_2", 2, 1},
+	}
+	if len(lineage) != len(want) {
+		t.Fatalf("Expected %d lineage nodes, got %d", len(want), len(lineage))
+	}
+	for i, w := range want {
+		node := lineage[i]
+		if node.Equipment.ID != w.id || node.Depth != w.depth || node.Column != w.column {
+			t.Errorf("lineage[%d] = %s depth %d column %d, want %+v", i, node.Equipment.ID, node.Depth, node.Column, w)
+		}
+	}
+}
+
+func TestEquipmentParser_SubUnits(t *testing.T) {
+	program := parseTestProgram(t, testSubUnits)
+
+	units := NewEquipmentParser().ParseSubUnits(program)
+	if len(units) != 1 {
+		t.Fatalf("Expected 1 sub-unit, got %d", len(units))
+	}
+
+	unit := units[0]
+	if unit.Abbreviation != "INF" || unit.Group != "infantry" || len(unit.Categories) != 2 {
+		t.Errorf("Unexpected sub-unit: %+v", unit)
+	}
+	if unit.Stats["combat_width"] != 2 || unit.Need["infantry_equipment"] != 100 {
+		t.Errorf("Unexpected stats/need: %v %v", unit.Stats, unit.Need)
+	}
+}
@@ -0,0 +1,135 @@
+package parser
+
+import (
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// eventFireKeys are the effects that fire events (country_event = ger.1
+// or country_event = { id = ger.1 days = 3 })
+var eventFireKeys = func() map[string]string {
+	keys := make(map[string]string, len(domain.EventTypes))
+	for _, eventType := range domain.EventTypes {
+		keys[eventType] = "id"
+	}
+	return keys
+}()
+
+// EventParser converts events/*.txt AST to domain.Event models
+type EventParser struct{}
+
+// NewEventParser creates a new EventParser
+func NewEventParser() *EventParser {
+	return &EventParser{}
+}
+
+// ParseEvents parses namespaces and events of a file
+func (ep *EventParser) ParseEvents(program *Program) ([]string, []*domain.Event) {
+	namespaces := make([]string, 0)
+	events := make([]*domain.Event, 0)
+
+	for _, stmt := range program.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok {
+			continue
+		}
+
+		if assign.Name.Value == "add_namespace" {
+			namespaces = append(namespaces, scalarValue(assign.Value))
+			continue
+		}
+		if _, isEvent := eventFireKeys[assign.Name.Value]; !isEvent {
+			continue
+		}
+
+		block, ok := assign.Value.(*BlockStatement)
+		if !ok {
+			continue
+		}
+		if event := ep.parseEvent(assign.Name.Value, block); event.ID != "" {
+			events = append(events, event)
+		}
+	}
+
+	return namespaces, events
+}
+
+// parseEvent parses a single event block
+func (ep *EventParser) parseEvent(eventType string, block *BlockStatement) *domain.Event {
+	event := &domain.Event{
+		Type:            eventType,
+		ImmediateEvents: make([]string, 0),
+		Options:         make([]*domain.EventOption, 0),
+	}
+
+	for _, stmt := range block.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok {
+			continue
+		}
+
+		switch assign.Name.Value {
+		case "id":
+			event.ID = scalarValue(assign.Value)
+		case "title":
+			if event.Title == "" {
+				event.Title = localisationKey(assign.Value)
+			}
+		case "desc":
+			if event.Desc == "" {
+				event.Desc = localisationKey(assign.Value)
+			}
+		case "picture":
+			event.Picture = scalarValue(assign.Value)
+		case "is_triggered_only":
+			event.IsTriggeredOnly = isYes(assign.Value)
+		case "fire_only_once":
+			event.FireOnlyOnce = isYes(assign.Value)
+		case "hidden":
+			event.Hidden = isYes(assign.Value)
+		case "trigger":
+			event.Trigger = FormatExpression(assign.Value, 0)
+		case "immediate":
+			event.Immediate = FormatExpression(assign.Value, 0)
+			if immediate, ok := assign.Value.(*BlockStatement); ok {
+				event.ImmediateEvents = CollectReferences(immediate.Statements, eventFireKeys)
+			}
+		case "option":
+			if optionBlock, ok := assign.Value.(*BlockStatement); ok {
+				event.Options = append(event.Options, ep.parseOption(optionBlock))
+			}
+		}
+	}
+
+	return event
+}
+
+// parseOption parses an option block
+func (ep *EventParser) parseOption(block *BlockStatement) *domain.EventOption {
+	option := &domain.EventOption{
+		Body:   FormatExpression(block, 0),
+		Events: CollectReferences(block.Statements, eventFireKeys),
+	}
+
+	for _, stmt := range block.Statements {
+		if assign, ok := stmt.(*AssignmentStatement); ok && assign.Name.Value == "name" {
+			option.Name = scalarValue(assign.Value)
+			break
+		}
+	}
+
+	return option
+}
+
+// localisationKey returns a title/desc key: the scalar value, or the
+// text of the first conditional block (title = { text = x trigger = { } })
+func localisationKey(expr Expression) string {
+	if block, ok := expr.(*BlockStatement); ok {
+		for _, stmt := range block.Statements {
+			if assign, ok := stmt.(*AssignmentStatement); ok && assign.Name.Value == "text" {
+				return scalarValue(assign.Value)
+			}
+		}
+		return ""
+	}
+	return scalarValue(expr)
+}
@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+const testEvents = `add_namespace = ger
+
+country_event = {
+	id = ger.1
+	title = ger.1.t
+	desc = {
+		text = ger.1.d_a
+		trigger = { has_war = yes }
+	}
+	picture = GFX_report_event_hitler
+	is_triggered_only = yes
+
+	immediate = {
+		hidden_effect = { news_event = { id = ger.2 days = 1 } }
+	}
+
+	option = {
+		name = ger.1.a
+		country_event = ger.3
+	}
+	option = {
+		name = ger.1.b
+		add_political_power = 50
+	}
+}
+
+news_event = {
+	id = ger.2
+	title = ger.2.t
+	is_triggered_only = yes
+	option = {
+		name = ger.2.a
+		country_event = { id = ger.1 }
+	}
+}
+
+country_event = {
+	id = ger.3
+	hidden = yes
+	is_triggered_only = yes
+	immediate = {
+		country_event = ger.99
+	}
+}
+`
+
+func TestEventParser_Events(t *testing.T) {
+	namespaces, events := NewEventParser().ParseEvents(parseTestProgram(t, testEvents))
+
+	if len(namespaces) != 1 || namespaces[0] != "ger" {
+		t.Errorf("Expected namespace ger, got %v", namespaces)
+	}
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+
+	first := events[0]
+	if first.ID != "ger.1" || first.Type != "country_event" || !first.IsTriggeredOnly {
+		t.Errorf("Unexpected event: %+v", first)
+	}
+	if first.Title != "ger.1.t" || first.Desc != "ger.1.d_a" {
+		t.Errorf("Unexpected title/desc: %q %q", first.Title, first.Desc)
+	}
+	if strings.Join(first.ImmediateEvents, ",") != "ger.2" {
+		t.Errorf("Expected immediate event ger.2, got %v", first.ImmediateEvents)
+	}
+	if len(first.Options) != 2 || first.Options[0].Name != "ger.1.a" || strings.Join(first.Options[0].Events, ",") != "ger.3" {
+		t.Errorf("Unexpected options: %+v", first.Options)
+	}
+	if len(first.Options[1].Events) != 0 {
+		t.Errorf("Expected no events from option b, got %v", first.Options[1].Events)
+	}
+
+	if events[1].Type != "news_event" || !events[2].Hidden {
+		t.Errorf("Unexpected event types/flags: %s hidden=%v", events[1].Type, events[2].Hidden)
+	}
+}
+
+func TestEventSet_Chain(t *testing.T) {
+	_, events := NewEventParser().ParseEvents(parseTestProgram(t, testEvents))
+	set := domain.NewEventSet([]*domain.EventFile{{Events: events}})
+
+	chain := set.Chain([]string{"ger.1"})
+
+	layers := map[string]int{"ger.1": 0, "ger.2": 1, "ger.3": 1, "ger.99": 2}
+	if len(chain.Nodes) != len(layers) {
+		t.Fatalf("Expected %d nodes, got %d", len(layers), len(chain.Nodes))
+	}
+	for id, layer := range layers {
+		node, ok := chain.Node(id)
+		if !ok {
+			t.Fatalf("Node %s missing from chain", id)
+		}
+		if node.Layer != layer {
+			t.Errorf("Node %s: expected layer %d, got %d", id, layer, node.Layer)
+		}
+	}
+
+	// ger.2 loops back to ger.1: the edge is kept, the node is not duplicated
+	if len(chain.Edges) != 4 {
+		t.Errorf("Expected 4 edges, got %d: %+v", len(chain.Edges), chain.Edges)
+	}
+
+	missing := chain.Missing()
+	if len(missing) != 1 || missing[0] != "ger.99" {
+		t.Errorf("Expected dangling ger.99, got %v", missing)
+	}
+}
+
+func TestFocusParser_RewardEvents(t *testing.T) {
+	input := `focus_tree = {
+	id = test
+	focus = {
+		id = GER_rhineland
+		completion_reward = {
+			country_event = ger.1
+			if = {
+				limit = { has_war = no }
+				news_event = { id = ger.2 hours = 6 }
+			}
+		}
+	}
+}`
+
+	program := parseTestProgram(t, input)
+	focuses, err := NewFocusParser().ParseFocusTree(program)
+	if err != nil {
+		t.Fatalf("ParseFocusTree() error: %v", err)
+	}
+
+	if got := strings.Join(focuses[0].RewardEvents, ","); got != "ger.1,ger.2" {
+		t.Errorf("Expected reward events ger.1,ger.2, got %s", got)
+	}
+}
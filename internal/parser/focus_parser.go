@@ -93,6 +93,7 @@ func (fp *FocusParser) ParseFocusTree(program *Program) ([]*domain.Focus, error)
 					if err != nil {
 						return nil, fmt.Errorf("failed to parse focus: %w", err)
 					}
+					focus.Line = focusAssign.Name.Token.Line
 					
 					focuses = append(focuses, focus)
 				}
@@ -220,6 +221,14 @@ func (fp *FocusParser) parseFocus(block *BlockStatement) (*domain.Focus, error)
 			
 		case "completion_reward":
 			focus.CompletionReward = fp.blockToString(assignStmt.Value)
+			if rewardBlock, ok := assignStmt.Value.(*BlockStatement); ok {
+				focus.RewardIdeas = CollectIdeaReferences(rewardBlock.Statements)
+				focus.RewardDecisions = CollectReferences(rewardBlock.Statements, decisionUnlockKeys)
+				focus.RewardFlags = CollectReferences(rewardBlock.Statements, setFlagKeys)
+				focus.RewardEvents = CollectReferences(rewardBlock.Statements, eventFireKeys)
+				focus.RewardCharacters = CollectCharacterReferences(rewardBlock.Statements)
+				focus.RewardModifiers = CollectModifierBlocks(rewardBlock.Statements)
+			}
 			
 		case "ai_will_do":
 			focus.AIWillDo = fp.blockToString(assignStmt.Value)
@@ -318,13 +327,11 @@ func (fp *FocusParser) parseSearchFilters(block *BlockStatement) []string {
 	return filters
 }
 
-// blockToString converts a block or expression to string (for raw storage)
+// blockToString converts a block or expression back to script text (for raw storage)
 func (fp *FocusParser) blockToString(expr Expression) string {
-	// For now, just return a placeholder
-	// In a full implementation, we would reconstruct the block syntax
 	switch v := expr.(type) {
 	case *BlockStatement:
-		return "{...}" // Placeholder
+		return FormatExpression(v, 0)
 	case *StringLiteral:
 		return v.Value
 	case *Identifier:
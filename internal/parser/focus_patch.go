@@ -0,0 +1,317 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// sourceEdit replaces source[line][start:end] (0-based line, byte offsets)
+type sourceEdit struct {
+	line, start, end int
+	text             string
+}
+
+// PatchFocusPositions rewrites x, y and relative_position_id of the given
+// focuses in the source of their focus file, keeping formatting and
+// everything else as it is
+func PatchFocusPositions(source string, focuses []*domain.Focus) (string, error) {
+	program, err := NewParser(source).Parse()
+	if err != nil {
+		return "", fmt.Errorf("failed to parse: %w", err)
+	}
+
+	byID := make(map[string]*domain.Focus, len(focuses))
+	for _, focus := range focuses {
+		byID[focus.ID] = focus
+	}
+
+	lines := strings.Split(source, "\n")
+	edits := make([]sourceEdit, 0)
+	inserts := make(map[int][]string) // Line -> lines inserted after it
+
+	for _, block := range focusBlocks(program) {
+		fields := make(map[string]*AssignmentStatement)
+		for _, stmt := range block.Statements {
+			if assign, ok := stmt.(*AssignmentStatement); ok {
+				if _, seen := fields[assign.Name.Value]; !seen {
+					fields[assign.Name.Value] = assign
+				}
+			}
+		}
+		idField := fields["id"]
+		if idField == nil {
+			continue
+		}
+		focus := byID[FormatExpression(idField.Value, 0)]
+		if focus == nil {
+			continue
+		}
+
+		idLine := idField.Name.Token.Line - 1
+		indent := lines[idLine][:len(lines[idLine])-len(strings.TrimLeft(lines[idLine], " \t"))]
+		values := []struct{ key, value string }{
+			{"relative_position_id", focus.RelativePositionID},
+			{"x", strconv.Itoa(focus.Position.X)},
+			{"y", strconv.Itoa(focus.Position.Y)},
+		}
+		for _, field := range values {
+			assign := fields[field.key]
+			switch {
+			case assign == nil && field.value != "":
+				inserts[idLine] = append(inserts[idLine], indent+field.key+" = "+field.value)
+			case assign != nil && field.value == "":
+				line := assign.Name.Token.Line - 1
+				if valueLine, _ := valueStart(assign); valueLine != line {
+					continue
+				}
+				edits = append(edits, sourceEdit{line: line, start: assign.Name.Token.Column - 1, end: valueEnd(lines[line], assign), text: ""})
+			case assign != nil:
+				line, start := valueStart(assign)
+				edits = append(edits, sourceEdit{line: line, start: start, end: valueEnd(lines[line], assign), text: field.value})
+			}
+		}
+	}
+
+	// Apply right to left so earlier offsets stay valid
+	sort.Slice(edits, func(i, j int) bool {
+		if edits[i].line != edits[j].line {
+			return edits[i].line < edits[j].line
+		}
+		return edits[i].start > edits[j].start
+	})
+	removed := make(map[int]bool)
+	for _, edit := range edits {
+		line := lines[edit.line]
+		if edit.end < len(line) && edit.text == "" && line[edit.end] == ' ' {
+			edit.end++ // Drop the separator after a removed field
+		}
+		lines[edit.line] = line[:edit.start] + edit.text + line[edit.end:]
+		if edit.text == "" && strings.TrimSpace(lines[edit.line]) == "" {
+			removed[edit.line] = true
+		}
+	}
+
+	var sb strings.Builder
+	for i, line := range lines {
+		if !removed[i] {
+			sb.WriteString(line)
+			if i < len(lines)-1 {
+				sb.WriteString("\n")
+			}
+		}
+		for _, inserted := range inserts[i] {
+			sb.WriteString(inserted + "\n")
+		}
+	}
+	return sb.String(), nil
+}
+
+// PatchFocusLinks rewrites the prerequisites, mutually exclusive focuses
+// and icon of the given focuses in the source of their focus file:
+// prerequisite groups no longer on a focus are removed and new ones added
+// after the last one, mutually_exclusive is rewritten when its focuses
+// changed. Everything else is kept as it is
+func PatchFocusLinks(source string, focuses []*domain.Focus) (string, error) {
+	program, err := NewParser(source).Parse()
+	if err != nil {
+		return "", fmt.Errorf("failed to parse: %w", err)
+	}
+
+	byID := make(map[string]*domain.Focus, len(focuses))
+	for _, focus := range focuses {
+		byID[focus.ID] = focus
+	}
+
+	index := newSourceIndex(source)
+	edits := make([]rangeEdit, 0)
+	fp := &FocusParser{}
+
+	for _, block := range focusBlocks(program) {
+		var idField, iconField, lastPrerequisite *AssignmentStatement
+		exclusive := make([]*AssignmentStatement, 0)
+		for _, stmt := range block.Statements {
+			if assign, ok := stmt.(*AssignmentStatement); ok {
+				switch assign.Name.Value {
+				case "id":
+					idField = assign
+				case "icon":
+					iconField = assign
+				case "prerequisite":
+					lastPrerequisite = assign
+				case "mutually_exclusive":
+					exclusive = append(exclusive, assign)
+				}
+			}
+		}
+		if idField == nil {
+			continue
+		}
+		focus := byID[FormatExpression(idField.Value, 0)]
+		if focus == nil {
+			continue
+		}
+
+		// Prerequisite groups, matched regardless of the order of their focuses
+		wanted := make(map[string]bool, len(focus.Prerequisites))
+		for _, group := range focus.Prerequisites {
+			wanted[groupKey(group)] = true
+		}
+		present := make(map[string]bool)
+		for _, stmt := range block.Statements {
+			assign, ok := stmt.(*AssignmentStatement)
+			if !ok || assign.Name.Value != "prerequisite" {
+				continue
+			}
+			groupBlock, ok := assign.Value.(*BlockStatement)
+			if !ok {
+				continue
+			}
+			group := fp.parsePrerequisite(groupBlock)
+			if len(group) == 0 {
+				continue
+			}
+			if key := groupKey(group); wanted[key] && !present[key] {
+				present[key] = true
+				continue
+			}
+			edits = append(edits, index.removal(assign))
+		}
+		added := make([]string, 0)
+		for _, group := range focus.Prerequisites {
+			if key := groupKey(group); !present[key] {
+				present[key] = true
+				added = append(added, "prerequisite = { focus = "+strings.Join(group, " focus = ")+" }")
+			}
+		}
+
+		// The last mutually_exclusive block is the one the game reads
+		var current []string
+		if len(exclusive) > 0 {
+			if exclusiveBlock, ok := exclusive[len(exclusive)-1].Value.(*BlockStatement); ok {
+				current = fp.parseMutuallyExclusive(exclusiveBlock)
+			}
+		}
+		after := lastPrerequisite
+		if !sameStrings(current, focus.MutuallyExclusive) {
+			for _, assign := range exclusive {
+				edits = append(edits, index.removal(assign))
+			}
+			if len(focus.MutuallyExclusive) > 0 {
+				added = append(added, "mutually_exclusive = { focus = "+strings.Join(focus.MutuallyExclusive, " focus = ")+" }")
+			}
+			if len(exclusive) > 0 {
+				after = exclusive[len(exclusive)-1]
+			}
+		}
+		if after == nil {
+			after = idField
+		}
+		if len(added) > 0 {
+			if edit, ok := index.insertInBlock(block, after, added); ok {
+				edits = append(edits, edit)
+			}
+		}
+
+		// Icon, keeping its quotes
+		switch {
+		case iconField != nil && focus.Icon != strings.Trim(FormatExpression(iconField.Value, 0), `"`):
+			start, end := index.statementRange(iconField)
+			valueLine, valueColumn := valueStart(iconField)
+			start = index.lineStarts[valueLine] + valueColumn
+			icon := focus.Icon
+			if _, quoted := iconField.Value.(*StringLiteral); quoted {
+				icon = `"` + icon + `"`
+			}
+			edits = append(edits, rangeEdit{start: start, end: end, text: icon})
+		case iconField == nil && focus.Icon != "":
+			if edit, ok := index.insertInBlock(block, idField, []string{"icon = " + focus.Icon}); ok {
+				edits = append(edits, edit)
+			}
+		}
+	}
+
+	return applyRangeEdits(source, edits), nil
+}
+
+// groupKey identifies a prerequisite group by its focuses, in any order
+func groupKey(group []string) string {
+	sorted := append([]string{}, group...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, " ")
+}
+
+// focusBlocks returns the focus blocks of the focus_tree
+func focusBlocks(program *Program) []*BlockStatement {
+	blocks := make([]*BlockStatement, 0)
+	for _, stmt := range program.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok {
+			continue
+		}
+		block, ok := assign.Value.(*BlockStatement)
+		if !ok {
+			continue
+		}
+		if assign.Name.Value != "focus_tree" {
+			continue
+		}
+		for _, inner := range block.Statements {
+			if focus, ok := inner.(*AssignmentStatement); ok && focus.Name.Value == "focus" {
+				if focusBlock, ok := focus.Value.(*BlockStatement); ok {
+					blocks = append(blocks, focusBlock)
+				}
+			}
+		}
+	}
+	return blocks
+}
+
+// valueStart returns the 0-based line and byte offset of a scalar value
+func valueStart(assign *AssignmentStatement) (int, int) {
+	token, ok := scalarToken(assign.Value)
+	if !ok {
+		token = assign.Token
+	}
+	return token.Line - 1, token.Column - 1
+}
+
+// scalarToken returns the token of a scalar expression
+func scalarToken(expr Expression) (Token, bool) {
+	switch value := expr.(type) {
+	case *Identifier:
+		return value.Token, true
+	case *NumberLiteral:
+		return value.Token, true
+	case *StringLiteral:
+		return value.Token, true
+	case *DateLiteral:
+		return value.Token, true
+	}
+	return Token{}, false
+}
+
+// valueEnd returns the byte offset after the scalar value of an assignment
+// on its line (the end of the next word after '=')
+func valueEnd(line string, assign *AssignmentStatement) int {
+	_, start := valueStart(assign)
+	return scalarEnd(line, start)
+}
+
+// scalarEnd returns the byte offset after the scalar starting at start on a
+// line: a quoted string, or a word
+func scalarEnd(line string, start int) int {
+	end := start
+	if end < len(line) && line[end] == '"' {
+		if closing := strings.IndexByte(line[end+1:], '"'); closing >= 0 {
+			return end + closing + 2
+		}
+	}
+	for end < len(line) && !strings.ContainsRune(" \t\r\n}#", rune(line[end])) {
+		end++
+	}
+	return end
+}
@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+const testLayoutTree = `focus_tree = {
+	id = test_tree
+	focus = {
+		id = TST_root
+		x = 5
+		y = 0
+	}
+	focus = {
+		id = TST_a
+		prerequisite = { focus = TST_root }
+		x = 0
+		y = 1
+	}
+	focus = {
+		id = TST_b
+		prerequisite = { focus = TST_root }
+		x = 8
+		y = 1
+	}
+	focus = {
+		id = TST_b_child
+		prerequisite = { focus = TST_b }
+		x = 0
+		y = 2
+	}
+	focus = {
+		id = TST_a_child
+		prerequisite = { focus = TST_a }
+		relative_position_id = TST_a
+		x = 12
+		y = 1
+	}
+	focus = {
+		id = TST_left
+		prerequisite = { focus = TST_a_child }
+		mutually_exclusive = { focus = TST_right }
+		x = -3 y = 3
+	}
+	focus = {
+		id = TST_mid
+		prerequisite = { focus = TST_a_child }
+		x = 0 y = 3
+	}
+	focus = {
+		id = TST_right
+		prerequisite = { focus = TST_a_child }
+		mutually_exclusive = { focus = TST_left }
+		x = 3 y = 3
+	}
+}`
+
+// parseLayoutTree parses testLayoutTree
+func parseLayoutTree(t *testing.T) ([]*domain.Focus, *domain.FocusTree) {
+	t.Helper()
+	program, err := NewParser(testLayoutTree).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	focuses, err := NewFocusParser().ParseFocusTree(program)
+	if err != nil {
+		t.Fatalf("ParseFocusTree() error: %v", err)
+	}
+	tree := domain.NewFocusTree("test_tree")
+	for _, focus := range focuses {
+		tree.AddFocus(focus)
+	}
+	return focuses, tree
+}
+
+func TestPatchFocusPositions(t *testing.T) {
+	focuses, tree := parseLayoutTree(t)
+	tree.Focuses["TST_a_child"].RelativePositionID = ""
+	tree.Focuses["TST_a_child"].Position = domain.NewPosition(2, 2)
+	tree.Focuses["TST_left"].Position = domain.NewPosition(-1, 1)
+	tree.Focuses["TST_left"].RelativePositionID = "TST_a_child"
+	tree.Focuses["TST_root"].Position = domain.NewPosition(10, 0)
+
+	patched, err := PatchFocusPositions(testLayoutTree, focuses)
+	if err != nil {
+		t.Fatalf("PatchFocusPositions() error: %v", err)
+	}
+	for _, want := range []string{
+		"id = TST_root\n\t\tx = 10\n\t\ty = 0\n",
+		"id = TST_a_child\n\t\tprerequisite = { focus = TST_a }\n\t\tx = 2\n\t\ty = 2\n",
+		"id = TST_left\n\t\trelative_position_id = TST_a_child\n",
+		"x = -1 y = 1\n",
+	} {
+		if !strings.Contains(patched, want) {
+			t.Errorf("Patched source misses %q:\n%s", want, patched)
+		}
+	}
+
+	// The patched file parses to the same positions
+	program, err := NewParser(patched).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	reparsed, err := NewFocusParser().ParseFocusTree(program)
+	if err != nil {
+		t.Fatalf("ParseFocusTree() error: %v", err)
+	}
+	for i, focus := range reparsed {
+		if focus.Position.X != focuses[i].Position.X || focus.Position.Y != focuses[i].Position.Y || focus.RelativePositionID != focuses[i].RelativePositionID {
+			t.Errorf("%s: got %+v %q, want %+v %q", focus.ID, focus.Position, focus.RelativePositionID, focuses[i].Position, focuses[i].RelativePositionID)
+		}
+	}
+}
+
+func TestPatchFocusLinks(t *testing.T) {
+	focuses, tree := parseLayoutTree(t)
+	tree.Focuses["TST_b_child"].Prerequisites = [][]string{{"TST_b", "TST_a"}}
+	tree.Focuses["TST_left"].MutuallyExclusive = []string{"TST_right", "TST_mid"}
+	tree.Focuses["TST_mid"].MutuallyExclusive = []string{"TST_left"}
+	tree.Focuses["TST_right"].MutuallyExclusive = nil
+	tree.Focuses["TST_root"].Icon = "GFX_goal_generic_radar"
+
+	patched, err := PatchFocusLinks(testLayoutTree, focuses)
+	if err != nil {
+		t.Fatalf("PatchFocusLinks() error: %v", err)
+	}
+	for _, want := range []string{
+		"id = TST_root\n\t\ticon = GFX_goal_generic_radar\n\t\tx = 5\n",
+		"id = TST_b_child\n\t\tprerequisite = { focus = TST_b focus = TST_a }\n\t\tx = 0\n",
+		"prerequisite = { focus = TST_a_child }\n\t\tmutually_exclusive = { focus = TST_right focus = TST_mid }\n\t\tx = -3 y = 3\n",
+		"prerequisite = { focus = TST_a_child }\n\t\tmutually_exclusive = { focus = TST_left }\n\t\tx = 0 y = 3\n",
+		"id = TST_right\n\t\tprerequisite = { focus = TST_a_child }\n\t\tx = 3 y = 3\n",
+	} {
+		if !strings.Contains(patched, want) {
+			t.Errorf("Patched source misses %q:\n%s", want, patched)
+		}
+	}
+
+	program, err := NewParser(patched).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	reparsed, err := NewFocusParser().ParseFocusTree(program)
+	if err != nil {
+		t.Fatalf("ParseFocusTree() error: %v", err)
+	}
+	for i, focus := range reparsed {
+		want := focuses[i]
+		if focus.Icon != want.Icon || !sameStrings(focus.MutuallyExclusive, want.MutuallyExclusive) || len(focus.Prerequisites) != len(want.Prerequisites) {
+			t.Errorf("%s: got %q %v %v, want %q %v %v", focus.ID, focus.Icon, focus.Prerequisites, focus.MutuallyExclusive, want.Icon, want.Prerequisites, want.MutuallyExclusive)
+		}
+	}
+
+	unchanged, err := PatchFocusLinks(patched, reparsed)
+	if err != nil || unchanged != patched {
+		t.Errorf("Patching unchanged links altered the source:\n%s", unchanged)
+	}
+}
@@ -0,0 +1,245 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// GfxParser parses interface/*.gfx files to extract sprite declarations
+type GfxParser struct {
+	modPath  string
+	gamePath string
+}
+
+// NewGfxParser creates a new GFX parser
+func NewGfxParser(modPath, gamePath string) *GfxParser {
+	return &GfxParser{
+		modPath:  modPath,
+		gamePath: gamePath,
+	}
+}
+
+// ParseSprites loads all sprites from game and mod .gfx files
+// Priority: mod overrides game by sprite name
+func (p *GfxParser) ParseSprites() (map[string]*domain.Sprite, error) {
+	sprites := make(map[string]*domain.Sprite)
+
+	// 1. Load from GAME first (base layer)
+	if p.gamePath != "" {
+		gameSprites, err := p.parseSpritesFromPath(p.gamePath, "game")
+		if err == nil {
+			for _, sprite := range gameSprites {
+				sprites[sprite.Name] = sprite
+			}
+		}
+	}
+
+	// 2. Load from MOD (overrides game)
+	if p.modPath != "" {
+		modSprites, err := p.parseSpritesFromPath(p.modPath, "mod")
+		if err == nil {
+			for _, sprite := range modSprites {
+				sprites[sprite.Name] = sprite
+			}
+		}
+	}
+
+	if len(sprites) == 0 {
+		return sprites, fmt.Errorf("no sprites found in mod or game interface folders")
+	}
+
+	return sprites, nil
+}
+
+// parseSpritesFromPath parses all .gfx files under <basePath>/interface
+func (p *GfxParser) parseSpritesFromPath(basePath, source string) ([]*domain.Sprite, error) {
+	interfaceDir := filepath.Join(basePath, "interface")
+
+	if _, err := os.Stat(interfaceDir); os.IsNotExist(err) {
+		return nil, err
+	}
+
+	sprites := make([]*domain.Sprite, 0)
+
+	err := filepath.Walk(interfaceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries
+		}
+		if info.IsDir() || !strings.EqualFold(filepath.Ext(path), ".gfx") {
+			return nil
+		}
+
+		fileSprites, err := p.ParseGfxFile(path, source)
+		if err != nil {
+			// Skip files with errors
+			println("Warning: Failed to parse", path, ":", err.Error())
+			return nil
+		}
+		sprites = append(sprites, fileSprites...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sprites, nil
+}
+
+// ParseGfxFile parses a single .gfx file
+func (p *GfxParser) ParseGfxFile(filePath, source string) ([]*domain.Sprite, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	parser := NewParser(string(content))
+	program, err := parser.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	sprites := p.ParseSpriteTypes(program)
+	for _, sprite := range sprites {
+		sprite.Source = source
+		sprite.GfxFile = filePath
+	}
+
+	return sprites, nil
+}
+
+// ParseSpriteTypes extracts sprites from all spriteTypes blocks of a program
+func (p *GfxParser) ParseSpriteTypes(program *Program) []*domain.Sprite {
+	sprites := make([]*domain.Sprite, 0)
+
+	for _, stmt := range program.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok || !strings.EqualFold(assign.Name.Value, "spriteTypes") {
+			continue
+		}
+
+		block, ok := assign.Value.(*BlockStatement)
+		if !ok {
+			continue
+		}
+
+		for _, spriteStmt := range block.Statements {
+			spriteAssign, ok := spriteStmt.(*AssignmentStatement)
+			if !ok {
+				continue
+			}
+
+			spriteBlock, ok := spriteAssign.Value.(*BlockStatement)
+			if !ok {
+				continue
+			}
+
+			var kind domain.SpriteKind
+			switch strings.ToLower(spriteAssign.Name.Value) {
+			case "spritetype", "textspritetype":
+				kind = domain.SpriteKindSimple
+			case "frameanimatedspritetype":
+				kind = domain.SpriteKindFrameAnimated
+			case "corneredtilespritetype":
+				kind = domain.SpriteKindCorneredTile
+			default:
+				continue // progressbartype, maskedShieldType, etc.
+			}
+
+			sprite := p.parseSprite(spriteBlock, kind)
+			if sprite.Name != "" && sprite.TextureFile != "" {
+				sprites = append(sprites, sprite)
+			}
+		}
+	}
+
+	return sprites
+}
+
+// parseSprite parses a single sprite declaration block
+func (p *GfxParser) parseSprite(block *BlockStatement, kind domain.SpriteKind) *domain.Sprite {
+	sprite := domain.NewSprite("", "")
+	sprite.Kind = kind
+
+	for _, stmt := range block.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok {
+			continue
+		}
+
+		switch strings.ToLower(assign.Name.Value) {
+		case "name":
+			sprite.Name = p.extractValue(assign.Value)
+		case "texturefile":
+			sprite.TextureFile = normalizeTexturePath(p.extractValue(assign.Value))
+		case "noofframes":
+			if frames, err := strconv.Atoi(p.extractValue(assign.Value)); err == nil && frames > 0 {
+				sprite.NoOfFrames = frames
+			}
+		case "bordersize":
+			if posBlock, ok := assign.Value.(*BlockStatement); ok {
+				sprite.BorderSize = p.parseXY(posBlock)
+			}
+		case "size":
+			if posBlock, ok := assign.Value.(*BlockStatement); ok {
+				sprite.Size = p.parseXY(posBlock)
+			}
+		}
+	}
+
+	return sprite
+}
+
+// parseXY parses a { x = 1 y = 2 } block
+func (p *GfxParser) parseXY(block *BlockStatement) domain.Position {
+	pos := domain.Position{}
+
+	for _, stmt := range block.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok {
+			continue
+		}
+
+		val, err := strconv.Atoi(p.extractValue(assign.Value))
+		if err != nil {
+			continue
+		}
+
+		switch assign.Name.Value {
+		case "x":
+			pos.X = val
+		case "y":
+			pos.Y = val
+		}
+	}
+
+	return pos
+}
+
+// extractValue extracts string value from expression
+func (p *GfxParser) extractValue(expr Expression) string {
+	switch v := expr.(type) {
+	case *StringLiteral:
+		return v.Value
+	case *Identifier:
+		return v.Value
+	case *NumberLiteral:
+		return v.Value
+	default:
+		return ""
+	}
+}
+
+// normalizeTexturePath converts backslashes and strips leading slashes
+// Example: "gfx\\interface\\goals\\x.dds" -> "gfx/interface/goals/x.dds"
+func normalizeTexturePath(path string) string {
+	path = strings.ReplaceAll(path, "\\", "/")
+	for strings.Contains(path, "//") {
+		path = strings.ReplaceAll(path, "//", "/")
+	}
+	return strings.TrimLeft(path, "/")
+}
@@ -0,0 +1,190 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/serializer"
+)
+
+func TestGfxParser_SpriteTypes(t *testing.T) {
+	input := `spriteTypes = {
+	spriteType = {
+		name = "GFX_goal_generic_production"
+		texturefile = "gfx/interface/goals/goal_generic_production.dds"
+	}
+	SpriteType = {
+		name = "GFX_infantry_weapons_medium"
+		textureFile = "gfx//interface/technologies/infantry_weapons.dds"
+	}
+	frameAnimatedSpriteType = {
+		name = "GFX_focus_unavailable"
+		texturefile = "gfx/interface/goals/focus_unavailable.dds"
+		noOfFrames = 4
+		animation_rate_fps = 5
+	}
+	corneredTileSpriteType = {
+		name = "GFX_tiled_window"
+		texturefile = "gfx/interface/tiled_window.dds"
+		size = { x = 100 y = 200 }
+		borderSize = { x = 8 y = 8 }
+	}
+	progressbartype = {
+		name = "GFX_progress"
+		color = { 1.0 1.0 1.0 }
+	}
+	spriteType = {
+		name = "GFX_no_texture"
+	}
+}`
+
+	parser := NewParser(input)
+	program, err := parser.Parse()
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	sprites := NewGfxParser("", "").ParseSpriteTypes(program)
+	if len(sprites) != 4 {
+		t.Fatalf("Expected 4 sprites, got %d", len(sprites))
+	}
+
+	byName := make(map[string]*domain.Sprite)
+	for _, sprite := range sprites {
+		byName[sprite.Name] = sprite
+	}
+
+	goal := byName["GFX_goal_generic_production"]
+	if goal == nil || goal.TextureFile != "gfx/interface/goals/goal_generic_production.dds" {
+		t.Errorf("Unexpected goal sprite: %+v", goal)
+	}
+	if goal != nil && goal.FrameCount() != 1 {
+		t.Errorf("Expected 1 frame, got %d", goal.FrameCount())
+	}
+
+	tech := byName["GFX_infantry_weapons_medium"]
+	if tech == nil || tech.TextureFile != "gfx/interface/technologies/infantry_weapons.dds" {
+		t.Errorf("Expected normalized texture path, got %+v", tech)
+	}
+
+	animated := byName["GFX_focus_unavailable"]
+	if animated == nil || animated.Kind != domain.SpriteKindFrameAnimated || animated.NoOfFrames != 4 {
+		t.Errorf("Unexpected animated sprite: %+v", animated)
+	}
+
+	tiled := byName["GFX_tiled_window"]
+	if tiled == nil || tiled.Kind != domain.SpriteKindCorneredTile {
+		t.Fatalf("Unexpected cornered tile sprite: %+v", tiled)
+	}
+	if tiled.Size.X != 100 || tiled.Size.Y != 200 || tiled.BorderSize.X != 8 {
+		t.Errorf("Unexpected cornered tile dimensions: size=%+v border=%+v", tiled.Size, tiled.BorderSize)
+	}
+}
+
+func TestGfxParser_ModOverridesGame(t *testing.T) {
+	gameDir := t.TempDir()
+	modDir := t.TempDir()
+
+	writeGfx := func(base, name, content string) {
+		dir := filepath.Join(base, "interface")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeGfx(gameDir, "goals.gfx", `spriteTypes = {
+	spriteType = { name = "GFX_a" texturefile = "gfx/interface/goals/game_a.dds" }
+	spriteType = { name = "GFX_b" texturefile = "gfx/interface/goals/game_b.dds" }
+}`)
+	writeGfx(modDir, "my_goals.gfx", `spriteTypes = {
+	spriteType = { name = "GFX_a" texturefile = "gfx/interface/goals/mod_a.dds" }
+}`)
+
+	sprites, err := NewGfxParser(modDir, gameDir).ParseSprites()
+	if err != nil {
+		t.Fatalf("ParseSprites() error: %v", err)
+	}
+
+	if sprites["GFX_a"].Source != "mod" || sprites["GFX_a"].TextureFile != "gfx/interface/goals/mod_a.dds" {
+		t.Errorf("Expected mod override for GFX_a, got %+v", sprites["GFX_a"])
+	}
+	if sprites["GFX_b"].Source != "game" {
+		t.Errorf("Expected GFX_b from game, got %+v", sprites["GFX_b"])
+	}
+}
+
+func TestGfxParser_ReadsWriterOutput(t *testing.T) {
+	writer := serializer.NewGfxWriter()
+	path := filepath.Join(t.TempDir(), "interface", "goals_imported.gfx")
+
+	sprite := domain.NewSprite("GFX_my_goal", "gfx/interface/goals/my_goal.dds")
+	source, _, err := AppendSprite("", sprite.Name, writer.WriteSprite(sprite))
+	if err != nil {
+		t.Fatalf("AppendSprite() error: %v", err)
+	}
+	if source, _, err = AppendSprite(source, sprite.Name+"_shine", writer.WriteShineSprite(sprite)); err != nil {
+		t.Fatalf("AppendSprite() error: %v", err)
+	}
+
+	// Appending an already declared sprite is a no-op
+	unchanged, appended, err := AppendSprite(source, sprite.Name, writer.WriteSprite(sprite))
+	if err != nil || appended || unchanged != source {
+		t.Errorf("Expected duplicate sprite to be skipped, appended=%v err=%v", appended, err)
+	}
+
+	if err := writer.WriteSource(path, source); err != nil {
+		t.Fatalf("WriteSource() error: %v", err)
+	}
+	sprites, err := NewGfxParser("", "").ParseGfxFile(path, "mod")
+	if err != nil {
+		t.Fatalf("ParseGfxFile() error: %v", err)
+	}
+	if len(sprites) != 2 {
+		t.Fatalf("Expected 2 sprites, got %d", len(sprites))
+	}
+	if sprites[0].Name != "GFX_my_goal" || sprites[0].TextureFile != sprite.TextureFile {
+		t.Errorf("Unexpected sprite: %+v", sprites[0])
+	}
+	if sprites[1].Name != "GFX_my_goal_shine" || sprites[1].TextureFile != sprite.TextureFile {
+		t.Errorf("Unexpected shine sprite: %+v", sprites[1])
+	}
+}
+
+func TestAppendSprite_KeepsSource(t *testing.T) {
+	source := `# name = "GFX_new" is only mentioned in this comment
+spriteTypes = {
+	spriteType = { name = "GFX_old" texturefile = "gfx/old.dds" }
+}
+
+# Trailing blocks stay after the sprite list
+objectTypes = {
+	x = { }
+}
+`
+	block := "\tspriteType = {\n\t\tname = \"GFX_new\"\n\t}\n"
+	patched, appended, err := AppendSprite(source, "GFX_new", block)
+	if err != nil || !appended {
+		t.Fatalf("AppendSprite() = %v, %v", appended, err)
+	}
+
+	want := strings.Replace(source, "gfx/old.dds\" }\n", "gfx/old.dds\" }\n"+block, 1)
+	if patched != want {
+		t.Errorf("Patched:\n%s\nwant:\n%s", patched, want)
+	}
+
+	// A spriteTypes block closed on the last sprite line
+	patched, _, err = AppendSprite(`spriteTypes = { spriteType = { name = "GFX_old" } }`, "GFX_new", block)
+	if err != nil || patched != "spriteTypes = { spriteType = { name = \"GFX_old\" } \n"+block+"}" {
+		t.Errorf("Patched %q, %v", patched, err)
+	}
+
+	if _, appended, _ := AppendSprite(source, "GFX_old", block); appended {
+		t.Error("Declared sprite appended again")
+	}
+}
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AppendSprite adds a sprite block at the end of the last spriteTypes block
+// of a .gfx source, keeping the rest of the file as it is; a source without
+// one gets a new spriteTypes block. Returns false and the source unchanged
+// if a sprite of any kind with that name is already declared
+func AppendSprite(source, name, block string) (string, bool, error) {
+	program, err := NewParser(source).Parse()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to parse: %w", err)
+	}
+
+	var last *BlockStatement
+	for _, stmt := range program.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok || !strings.EqualFold(assign.Name.Value, "spriteTypes") {
+			continue
+		}
+		spriteTypes, ok := assign.Value.(*BlockStatement)
+		if !ok {
+			continue
+		}
+		if spriteTypesDeclare(spriteTypes, name) {
+			return source, false, nil
+		}
+		last = spriteTypes
+	}
+
+	if last == nil {
+		if source != "" && !strings.HasSuffix(source, "\n") {
+			source += "\n"
+		}
+		return source + "spriteTypes = {\n" + block + "}\n", true, nil
+	}
+
+	closing, ok := newSourceIndex(source).closingBrace(last)
+	if !ok {
+		return "", false, fmt.Errorf("spriteTypes block is not closed")
+	}
+
+	// Put the block on its own lines before the closing brace
+	lineStart := strings.LastIndexByte(source[:closing], '\n') + 1
+	if strings.TrimSpace(source[lineStart:closing]) == "" {
+		return source[:lineStart] + block + source[lineStart:], true, nil
+	}
+	return source[:closing] + "\n" + block + source[closing:], true, nil
+}
+
+// spriteTypesDeclare reports whether a spriteTypes block has a sprite named name
+func spriteTypesDeclare(spriteTypes *BlockStatement, name string) bool {
+	for _, stmt := range spriteTypes.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok {
+			continue
+		}
+		sprite, ok := assign.Value.(*BlockStatement)
+		if !ok {
+			continue
+		}
+		for _, field := range sprite.Statements {
+			if nameField, ok := field.(*AssignmentStatement); ok && strings.EqualFold(nameField.Name.Value, "name") {
+				if (&GfxParser{}).extractValue(nameField.Value) == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
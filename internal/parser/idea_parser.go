@@ -0,0 +1,222 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// IdeaParser converts common/ideas AST to domain.IdeaCategory models
+type IdeaParser struct{}
+
+// NewIdeaParser creates a new IdeaParser
+func NewIdeaParser() *IdeaParser {
+	return &IdeaParser{}
+}
+
+// ParseIdeas parses every ideas = { ... } block of a file
+func (ip *IdeaParser) ParseIdeas(program *Program) ([]*domain.IdeaCategory, error) {
+	categories := make([]*domain.IdeaCategory, 0)
+
+	for _, stmt := range program.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok || assign.Name.Value != "ideas" {
+			continue
+		}
+
+		block, ok := assign.Value.(*BlockStatement)
+		if !ok {
+			return nil, fmt.Errorf("ideas value is not a block")
+		}
+
+		for _, categoryStmt := range block.Statements {
+			categoryAssign, ok := categoryStmt.(*AssignmentStatement)
+			if !ok {
+				continue
+			}
+			categoryBlock, ok := categoryAssign.Value.(*BlockStatement)
+			if !ok {
+				continue
+			}
+			categories = append(categories, ip.parseCategory(categoryAssign.Name.Value, categoryBlock))
+		}
+	}
+
+	return categories, nil
+}
+
+// parseCategory parses a category block; block-valued entries are ideas
+func (ip *IdeaParser) parseCategory(name string, block *BlockStatement) *domain.IdeaCategory {
+	category := &domain.IdeaCategory{
+		Name:       name,
+		Properties: make([]domain.ScriptField, 0),
+		Ideas:      make([]*domain.Idea, 0),
+	}
+
+	for _, stmt := range block.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok {
+			continue
+		}
+
+		key := assign.Name.Value
+		switch key {
+		case "law":
+			category.Law = isYes(assign.Value)
+			continue
+		case "designer":
+			category.Designer = isYes(assign.Value)
+			continue
+		}
+
+		ideaBlock, ok := assign.Value.(*BlockStatement)
+		if !ok {
+			category.Properties = append(category.Properties, scriptField(assign))
+			continue
+		}
+
+		idea := ip.parseIdea(key, ideaBlock)
+		idea.Category = category
+		category.Ideas = append(category.Ideas, idea)
+	}
+
+	return category
+}
+
+// parseIdea parses a single idea block
+func (ip *IdeaParser) parseIdea(id string, block *BlockStatement) *domain.Idea {
+	idea := domain.NewIdea(id)
+
+	for _, stmt := range block.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok {
+			continue
+		}
+
+		switch assign.Name.Value {
+		case "picture":
+			idea.Picture = scalarValue(assign.Value)
+		case "name":
+			idea.Name = scalarValue(assign.Value)
+		case "cost":
+			idea.Cost = scalarValue(assign.Value)
+		case "removal_cost":
+			idea.RemovalCost = scalarValue(assign.Value)
+		case "default":
+			idea.Default = isYes(assign.Value)
+		case "allowed":
+			idea.Allowed = FormatExpression(assign.Value, 0)
+		case "allowed_civil_war":
+			idea.AllowedCivilWar = FormatExpression(assign.Value, 0)
+		case "available":
+			idea.Available = FormatExpression(assign.Value, 0)
+		case "visible":
+			idea.Visible = FormatExpression(assign.Value, 0)
+		case "modifier":
+			modifierBlock, ok := assign.Value.(*BlockStatement)
+			if !ok {
+				idea.Extra = append(idea.Extra, scriptField(assign))
+				continue
+			}
+			for _, modStmt := range modifierBlock.Statements {
+				if modAssign, ok := modStmt.(*AssignmentStatement); ok {
+					idea.Modifiers = append(idea.Modifiers, domain.Modifier{
+						Key:   modAssign.Name.Value,
+						Value: FormatExpression(modAssign.Value, 0),
+					})
+				}
+			}
+		default:
+			idea.Extra = append(idea.Extra, scriptField(assign))
+		}
+	}
+
+	return idea
+}
+
+// CollectIdeaReferences returns ideas granted by add_ideas, add_timed_idea and
+// swap_ideas anywhere in an effect block (including nested if/hidden_effect)
+func CollectIdeaReferences(statements []Statement) []string {
+	ideas := make([]string, 0)
+	seen := make(map[string]bool)
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ideas = append(ideas, id)
+		}
+	}
+
+	var walk func(statements []Statement)
+	walk = func(statements []Statement) {
+		for _, stmt := range statements {
+			assign, ok := stmt.(*AssignmentStatement)
+			if !ok {
+				continue
+			}
+
+			switch assign.Name.Value {
+			case "add_ideas":
+				if block, ok := assign.Value.(*BlockStatement); ok {
+					for _, inner := range block.Statements {
+						switch v := inner.(type) {
+						case *ValueStatement:
+							add(scalarValue(v.Value))
+						case *AssignmentStatement:
+							add(v.Name.Value)
+						}
+					}
+				} else {
+					add(scalarValue(assign.Value))
+				}
+				continue
+			case "add_timed_idea", "swap_ideas":
+				if block, ok := assign.Value.(*BlockStatement); ok {
+					for _, inner := range block.Statements {
+						if field, ok := inner.(*AssignmentStatement); ok && (field.Name.Value == "idea" || field.Name.Value == "add_idea") {
+							add(scalarValue(field.Value))
+						}
+					}
+				}
+				continue
+			}
+
+			if block, ok := assign.Value.(*BlockStatement); ok {
+				walk(block.Statements)
+			}
+		}
+	}
+	walk(statements)
+
+	return ideas
+}
+
+// scriptField keeps an assignment verbatim
+func scriptField(assign *AssignmentStatement) domain.ScriptField {
+	return domain.ScriptField{
+		Key:      assign.Name.Value,
+		Operator: assign.Operator(),
+		Value:    FormatExpression(assign.Value, 0),
+	}
+}
+
+// scalarValue returns the text of a non-block value
+func scalarValue(expr Expression) string {
+	switch v := expr.(type) {
+	case *StringLiteral:
+		return v.Value
+	case *Identifier:
+		return v.Value
+	case *NumberLiteral:
+		return v.Value
+	case *DateLiteral:
+		return v.Value
+	default:
+		return ""
+	}
+}
+
+// isYes reports whether a value is yes/true
+func isYes(expr Expression) bool {
+	value := scalarValue(expr)
+	return value == "yes" || value == "true"
+}
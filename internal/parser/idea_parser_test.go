@@ -0,0 +1,199 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/serializer"
+)
+
+const testIdeas = `ideas = {
+	country = {
+		GER_autarky = {
+			picture = generic_production_bonus
+			allowed = {
+				original_tag = GER
+			}
+			removal_cost = -1
+			modifier = {
+				consumer_goods_factor = 0.05
+				stability_factor = -0.1
+			}
+			on_add = {
+				add_political_power = 50
+			}
+		}
+	}
+
+	economy = {
+		law = yes
+		use_list_view = yes
+
+		civilian_economy = {
+			default = yes
+			cost = 150
+			modifier = {
+				consumer_goods_factor = 0.35
+			}
+		}
+	}
+
+	political_advisor = {
+		GER_speer = {
+			picture = generic_political_advisor_europe_2
+			cost = 150
+		}
+	}
+}
+`
+
+func TestIdeaParser_Categories(t *testing.T) {
+	categories, err := NewIdeaParser().ParseIdeas(parseTestProgram(t, testIdeas))
+	if err != nil {
+		t.Fatalf("ParseIdeas() error: %v", err)
+	}
+
+	if len(categories) != 3 {
+		t.Fatalf("Expected 3 categories, got %d", len(categories))
+	}
+
+	kinds := []domain.IdeaKind{domain.IdeaKindCountry, domain.IdeaKindLaw, domain.IdeaKindAdvisor}
+	for i, kind := range kinds {
+		if categories[i].Kind() != kind {
+			t.Errorf("Category %s: expected kind %s, got %s", categories[i].Name, kind, categories[i].Kind())
+		}
+	}
+
+	economy := categories[1]
+	if len(economy.Properties) != 1 || economy.Properties[0].Key != "use_list_view" {
+		t.Errorf("Expected use_list_view property, got %+v", economy.Properties)
+	}
+	if len(economy.Ideas) != 1 || !economy.Ideas[0].Default || economy.Ideas[0].Cost != "150" {
+		t.Errorf("Unexpected law: %+v", economy.Ideas[0])
+	}
+}
+
+func TestIdeaParser_IdeaFields(t *testing.T) {
+	categories, err := NewIdeaParser().ParseIdeas(parseTestProgram(t, testIdeas))
+	if err != nil {
+		t.Fatalf("ParseIdeas() error: %v", err)
+	}
+	idea := categories[0].Ideas[0]
+
+	if idea.ID != "GER_autarky" || idea.CategoryName() != "country" {
+		t.Errorf("Unexpected idea: %s in %s", idea.ID, idea.CategoryName())
+	}
+	if idea.Picture != "generic_production_bonus" || idea.SpriteName() != "GFX_idea_generic_production_bonus" {
+		t.Errorf("Unexpected picture: %s", idea.Picture)
+	}
+	if idea.RemovalCost != "-1" {
+		t.Errorf("Expected removal_cost -1, got %q", idea.RemovalCost)
+	}
+	if !strings.Contains(idea.Allowed, "original_tag = GER") {
+		t.Errorf("Expected allowed trigger text, got %q", idea.Allowed)
+	}
+
+	if len(idea.Modifiers) != 2 {
+		t.Fatalf("Expected 2 modifiers, got %d", len(idea.Modifiers))
+	}
+	if value, ok := idea.Modifiers[1].Number(); !ok || value != -0.1 {
+		t.Errorf("Expected stability_factor -0.1, got %v", idea.Modifiers[1])
+	}
+
+	if len(idea.Extra) != 1 || idea.Extra[0].Key != "on_add" {
+		t.Errorf("Expected on_add kept as extra field, got %+v", idea.Extra)
+	}
+}
+
+func TestIdeaWriter_RoundTrip(t *testing.T) {
+	categories, err := NewIdeaParser().ParseIdeas(parseTestProgram(t, testIdeas))
+	if err != nil {
+		t.Fatalf("ParseIdeas() error: %v", err)
+	}
+	file := &domain.IdeaFile{Categories: categories}
+
+	idea := file.Categories[0].Ideas[0]
+	idea.SetModifier("stability_factor", "0.2")
+	idea.SetModifier("war_support_factor", "0.05")
+	idea.RemovalCost = ""
+
+	output := serializer.NewIdeaWriter().Write(file)
+	reparsed, err := NewIdeaParser().ParseIdeas(parseTestProgram(t, output))
+	if err != nil {
+		t.Fatalf("ParseIdeas() error: %v", err)
+	}
+
+	if len(reparsed) != 3 {
+		t.Fatalf("Expected 3 categories after round-trip, got %d\n%s", len(reparsed), output)
+	}
+	if !reparsed[1].Law || len(reparsed[1].Properties) != 1 {
+		t.Errorf("Category flags/properties lost: %+v", reparsed[1])
+	}
+
+	again := reparsed[0].Ideas[0]
+	if again.RemovalCost != "" || again.Picture != idea.Picture || again.Allowed != idea.Allowed {
+		t.Errorf("Scalar fields not preserved:\n%s", output)
+	}
+	if m, _ := again.GetModifier("stability_factor"); m.Value != "0.2" {
+		t.Errorf("Edited modifier not written: %+v", again.Modifiers)
+	}
+	if _, ok := again.GetModifier("war_support_factor"); !ok {
+		t.Errorf("Added modifier not written: %+v", again.Modifiers)
+	}
+	if len(again.Extra) != 1 || again.Extra[0].Value != idea.Extra[0].Value {
+		t.Errorf("on_add block not preserved:\n%s", output)
+	}
+}
+
+func TestCollectIdeaReferences(t *testing.T) {
+	input := `completion_reward = {
+	add_ideas = GER_autarky
+	add_ideas = { idea_a idea_b }
+	if = {
+		limit = { has_war = yes }
+		hidden_effect = {
+			add_timed_idea = { idea = timed_idea days = 180 }
+		}
+	}
+	swap_ideas = { remove_idea = idea_a add_idea = swapped_idea }
+	add_ideas = GER_autarky
+}`
+
+	program := parseTestProgram(t, input)
+
+	reward := program.Statements[0].(*AssignmentStatement).Value.(*BlockStatement)
+	ideas := CollectIdeaReferences(reward.Statements)
+
+	want := []string{"GER_autarky", "idea_a", "idea_b", "timed_idea", "swapped_idea"}
+	if strings.Join(ideas, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected %v, got %v", want, ideas)
+	}
+}
+
+func TestFocusParser_RewardIdeas(t *testing.T) {
+	input := `focus_tree = {
+	id = test
+	focus = {
+		id = TST_focus
+		completion_reward = {
+			add_ideas = GER_autarky
+		}
+	}
+}`
+
+	program := parseTestProgram(t, input)
+
+	focuses, err := NewFocusParser().ParseFocusTree(program)
+	if err != nil {
+		t.Fatalf("ParseFocusTree() error: %v", err)
+	}
+
+	focus := focuses[0]
+	if len(focus.RewardIdeas) != 1 || focus.RewardIdeas[0] != "GER_autarky" {
+		t.Errorf("Expected reward idea GER_autarky, got %v", focus.RewardIdeas)
+	}
+	if !strings.Contains(focus.CompletionReward, "add_ideas = GER_autarky") {
+		t.Errorf("Expected raw completion reward text, got %q", focus.CompletionReward)
+	}
+}
@@ -163,8 +163,8 @@ func (l *Lexer) NextToken() Token {
 		return l.readNumber()
 	}
 	
-	// Identifiers and keywords
-	if isLetter(l.current) || l.current == '_' {
+	// Identifiers and keywords ($PARAM$ placeholders of scripted triggers/effects included)
+	if isLetter(l.current) || l.current == '_' || l.current == '$' {
 		return l.readIdentifier()
 	}
 	
@@ -335,7 +335,8 @@ func (l *Lexer) readIdentifier() Token {
 		l.readChar()
 	}
 	
-	for isLetter(l.current) || isDigit(l.current) || l.current == '_' {
+	// Dots join parts of event IDs and scope chains (ger.12, FROM.owner)
+	for isIdentifierPart(l.current) || l.current == '$' || (l.current == '.' && isIdentifierPart(l.peekChar())) {
 		value += string(l.current)
 		l.readChar()
 	}
@@ -355,6 +356,11 @@ func isDigit(ch rune) bool {
 	return ch >= '0' && ch <= '9'
 }
 
+// isIdentifierPart checks if a rune can continue an identifier
+func isIdentifierPart(ch rune) bool {
+	return isLetter(ch) || isDigit(ch) || ch == '_'
+}
+
 // isLetter checks if a rune is a letter
 func isLetter(ch rune) bool {
 	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
@@ -273,3 +273,55 @@ func TestLexer_Date(t *testing.T) {
 		}
 	}
 }
+
+func TestLexer_DottedIdentifiers(t *testing.T) {
+	input := `event = ger.12 FROM.owner 1.5`
+
+	lexer := NewLexer(input)
+
+	tests := []struct {
+		expectedType  TokenType
+		expectedValue string
+	}{
+		{TokenIdentifier, "event"},
+		{TokenEquals, "="},
+		{TokenIdentifier, "ger.12"},
+		{TokenIdentifier, "FROM.owner"},
+		{TokenNumber, "1.5"},
+		{TokenEOF, ""},
+	}
+
+	for i, tt := range tests {
+		token := lexer.NextToken()
+
+		if token.Type != tt.expectedType || token.Value != tt.expectedValue {
+			t.Fatalf("tests[%d] - expected %v %q, got %v %q",
+				i, tt.expectedType, tt.expectedValue, token.Type, token.Value)
+		}
+	}
+}
+
+func TestLexer_ScriptedParams(t *testing.T) {
+	input := `has_country_flag = $FLAG$_done`
+
+	lexer := NewLexer(input)
+
+	tests := []struct {
+		expectedType  TokenType
+		expectedValue string
+	}{
+		{TokenIdentifier, "has_country_flag"},
+		{TokenEquals, "="},
+		{TokenIdentifier, "$FLAG$_done"},
+		{TokenEOF, ""},
+	}
+
+	for i, tt := range tests {
+		token := lexer.NextToken()
+
+		if token.Type != tt.expectedType || token.Value != tt.expectedValue {
+			t.Fatalf("tests[%d] - expected %v %q, got %v %q",
+				i, tt.expectedType, tt.expectedValue, token.Type, token.Value)
+		}
+	}
+}
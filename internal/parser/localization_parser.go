@@ -12,6 +12,10 @@ type LocalizationParser struct {
 	modPath  string
 	gamePath string
 	language string // e.g., "english", "russian"
+
+	// loadFile parses one file (parseLocalizationFile unless replaced, e.g.
+	// by a cached loader)
+	loadFile func(filePath string) (map[string]string, error)
 }
 
 // NewLocalizationParser creates a new localization parser
@@ -19,11 +23,19 @@ func NewLocalizationParser(modPath, gamePath, language string) *LocalizationPars
 	if language == "" {
 		language = "english" // Default to English
 	}
-	return &LocalizationParser{
+	p := &LocalizationParser{
 		modPath:  modPath,
 		gamePath: gamePath,
 		language: language,
 	}
+	p.loadFile = p.parseLocalizationFile
+	return p
+}
+
+// SetFileLoader replaces how single files are parsed (a cache in front of
+// LoadFile); the loader is called from several goroutines
+func (p *LocalizationParser) SetFileLoader(loader func(filePath string) (map[string]string, error)) {
+	p.loadFile = loader
 }
 
 // LoadLocalizations loads all localizations for the specified language
@@ -54,8 +66,22 @@ func (p *LocalizationParser) LoadLocalizations() (map[string]string, error) {
 	return localizations, nil
 }
 
-// loadFromPath loads localizations from a specific base path
-func (p *LocalizationParser) loadFromPath(basePath string) (map[string]string, error) {
+// Files returns the localization files LoadLocalizations parses, game first
+func (p *LocalizationParser) Files() []string {
+	files := make([]string, 0)
+	for _, basePath := range []string{p.gamePath, p.modPath} {
+		if basePath == "" {
+			continue
+		}
+		if pathFiles, err := p.filesInPath(basePath); err == nil {
+			files = append(files, pathFiles...)
+		}
+	}
+	return files
+}
+
+// filesInPath finds the localization files of the language under a base path
+func (p *LocalizationParser) filesInPath(basePath string) ([]string, error) {
 	locDir := filepath.Join(basePath, "localisation", p.language)
 
 	// Check if directory exists
@@ -79,13 +105,22 @@ func (p *LocalizationParser) loadFromPath(basePath string) (map[string]string, e
 		pattern = filepath.Join(basePath, "localisation", "*_l_"+p.language+".yml")
 		files, _ = filepath.Glob(pattern)
 	}
+	return files, nil
+}
+
+// loadFromPath loads localizations from a specific base path
+func (p *LocalizationParser) loadFromPath(basePath string) (map[string]string, error) {
+	files, err := p.filesInPath(basePath)
+	if err != nil {
+		return nil, err
+	}
 
 	localizations := make(map[string]string)
 
-	// Parse each file
-	for _, file := range files {
-		fileLocalizations, err := p.parseLocalizationFile(file)
-		if err != nil {
+	// Parse the files concurrently, merge in file order
+	parsed, errs := ParseFiles(files, p.loadFile)
+	for i, fileLocalizations := range parsed {
+		if errs[i] != nil {
 			// Skip files with errors
 			continue
 		}
@@ -99,6 +134,12 @@ func (p *LocalizationParser) loadFromPath(basePath string) (map[string]string, e
 	return localizations, nil
 }
 
+// LoadFile parses a single localization file of the parser's language
+// (files of other languages yield no strings)
+func (p *LocalizationParser) LoadFile(filePath string) (map[string]string, error) {
+	return p.parseLocalizationFile(filePath)
+}
+
 // parseLocalizationFile parses a single .yml localization file
 func (p *LocalizationParser) parseLocalizationFile(filePath string) (map[string]string, error) {
 	file, err := os.Open(filePath)
@@ -114,7 +155,7 @@ func (p *LocalizationParser) parseLocalizationFile(filePath string) (map[string]
 	inLanguageBlock := false
 
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), byteOrderMark))
 
 		// Skip empty lines and comments
 		if line == "" || strings.HasPrefix(line, "#") {
@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+)
+
+// byteOrderMark starts HOI4 localisation files (the game requires it)
+const byteOrderMark = "\ufeff"
+
+// LocalizationLanguage returns the language of the first l_<language>:
+// block of a localisation file, or "" if there is none
+func LocalizationLanguage(source string) string {
+	for _, line := range strings.Split(source, "\n") {
+		if language, ok := localizationHeader(line); ok {
+			return language
+		}
+	}
+	return ""
+}
+
+// PatchLocalization replaces the values of the given keys in the language
+// block of a localisation file, keeping indentation, version numbers and
+// all other lines; keys missing from the file are appended to the block
+func PatchLocalization(source, language string, values map[string]string) string {
+	lines := strings.Split(source, "\n")
+	eol := ""
+	if strings.Contains(source, "\r\n") {
+		eol = "\r"
+	}
+
+	patched := make(map[string]bool, len(values))
+	inBlock := false
+	blockEnd := -1 // Line after the last line of the language block
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(strings.TrimPrefix(line, byteOrderMark))
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if header, ok := localizationHeader(line); ok {
+			inBlock = header == language
+			if inBlock {
+				blockEnd = i + 1
+			}
+			continue
+		}
+		if !inBlock {
+			continue
+		}
+		blockEnd = i + 1
+
+		key, _ := (&LocalizationParser{}).parseLocalizationLine(trimmed)
+		value, ok := values[key]
+		if key == "" || !ok || patched[key] {
+			continue
+		}
+		quoteStart := strings.Index(line, "\"")
+		quoteEnd := strings.LastIndex(line, "\"")
+		lines[i] = line[:quoteStart+1] + value + line[quoteEnd:]
+		patched[key] = true
+	}
+
+	missing := make([]string, 0)
+	for key := range values {
+		if !patched[key] {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return source
+	}
+	sort.Strings(missing)
+
+	added := make([]string, 0, len(missing)+1)
+	if blockEnd < 0 {
+		// No block of the language yet: start one at the end of the file
+		if source == "" {
+			added = append(added, byteOrderMark+"l_"+language+":"+eol)
+		} else {
+			added = append(added, "l_"+language+":"+eol)
+		}
+		blockEnd = len(lines)
+		if lines[blockEnd-1] == "" {
+			blockEnd-- // Keep the final newline last
+		}
+	}
+	for _, key := range missing {
+		added = append(added, " "+key+":0 \""+values[key]+"\""+eol)
+	}
+
+	result := make([]string, 0, len(lines)+len(added))
+	result = append(result, lines[:blockEnd]...)
+	result = append(result, added...)
+	result = append(result, lines[blockEnd:]...)
+	return strings.Join(result, "\n")
+}
+
+// localizationHeader parses an "l_<language>:" block header line
+func localizationHeader(line string) (string, bool) {
+	trimmed := strings.TrimSpace(strings.TrimPrefix(line, byteOrderMark))
+	if !strings.HasPrefix(trimmed, "l_") || !strings.HasSuffix(trimmed, ":") || strings.ContainsAny(trimmed, " \t\"") {
+		return "", false
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(trimmed, "l_"), ":"), true
+}
@@ -0,0 +1,286 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// mergeContainers are the blocks whose children are merged one by one
+var mergeContainers = map[string]bool{
+	"technologies": true,
+	"focus_tree":   true,
+}
+
+// mergeEntry is a statement with its merge key
+type mergeEntry struct {
+	key  string
+	name string
+	stmt Statement
+}
+
+// merger holds the mod and new game sources of a three-way merge
+type merger struct {
+	ours, theirs *sourceIndex
+}
+
+// MergeScripts merges the mod copy of a game file (ours) with the changes
+// the game made between two versions (base -> theirs), block by block:
+// technologies, focuses, variables and other statements are matched by
+// name or id, and a block changed on both sides differently is a conflict
+func MergeScripts(base, ours, theirs string) (*domain.MergeFile, error) {
+	versions := make([][]Statement, 3)
+	for i, source := range []string{base, ours, theirs} {
+		program, err := NewParser(source).Parse()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", []string{"base", "ours", "theirs"}[i], err)
+		}
+		versions[i] = program.Statements
+	}
+	m := &merger{ours: newSourceIndex(ours), theirs: newSourceIndex(theirs)}
+	return &domain.MergeFile{
+		Blocks: m.mergeStatements(versions[0], versions[1], versions[2], true, domain.MergeSpan{}),
+		Source: ours,
+	}, nil
+}
+
+// mergeStatements merges three versions of a statement list; blocks the mod
+// copy does not have are inserted after their predecessor in it, or at first
+func (m *merger) mergeStatements(base, ours, theirs []Statement, topLevel bool, first domain.MergeSpan) []*domain.MergeBlock {
+	baseEntries, ourEntries, theirEntries := mergeEntries(base), mergeEntries(ours), mergeEntries(theirs)
+	byKey := func(entries []mergeEntry) map[string]mergeEntry {
+		result := make(map[string]mergeEntry, len(entries))
+		for _, entry := range entries {
+			result[entry.key] = entry
+		}
+		return result
+	}
+	baseByKey, oursByKey, theirsByKey := byKey(baseEntries), byKey(ourEntries), byKey(theirEntries)
+
+	blocks := make([]*domain.MergeBlock, 0)
+	insert := first
+	for _, key := range mergeOrder(ourEntries, theirEntries) {
+		b, o, t := baseByKey[key], oursByKey[key], theirsByKey[key]
+		span := insert
+		if o.stmt != nil {
+			if start, end, ok := m.ours.span(o.stmt); ok {
+				span = domain.MergeSpan{Start: start, End: end, Indent: m.ours.indent(start)}
+				insert = m.after(span)
+			}
+		}
+
+		if topLevel && o.stmt != nil && isMergeContainer(o, t) {
+			blocks = append(blocks, &domain.MergeBlock{
+				Key:      key,
+				Name:     firstName(o, t),
+				Status:   domain.MergeUnchanged,
+				Children: m.mergeStatements(children(b), children(o), children(t), false, m.firstChild(o, span)),
+				Span:     span,
+			})
+			continue
+		}
+		block := &domain.MergeBlock{Key: key, Base: statementText(b), Ours: statementText(o), Theirs: statementText(t), Span: span}
+		block.Status = domain.MergeStatusOf(block.Base, block.Ours, block.Theirs)
+		if t.stmt != nil {
+			if start, end, ok := m.theirs.span(t.stmt); ok {
+				block.TheirsSource = reindent(m.theirs.source[start:end], m.theirs.indent(start), span.Indent)
+			}
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// after returns where blocks following a statement are inserted: on the
+// next line, or after it on its line when something else follows
+func (m *merger) after(span domain.MergeSpan) domain.MergeSpan {
+	lineEnd := m.ours.lineEnd(span.End)
+	if strings.TrimSpace(m.ours.source[span.End:lineEnd]) == "" {
+		return domain.MergeSpan{Start: lineEnd, End: lineEnd, Indent: span.Indent}
+	}
+	return domain.MergeSpan{Start: span.End, End: span.End, Indent: span.Indent}
+}
+
+// firstChild returns where blocks before every child of a container of the
+// mod copy are inserted: after its opening brace
+func (m *merger) firstChild(entry mergeEntry, span domain.MergeSpan) domain.MergeSpan {
+	assign := entry.stmt.(*AssignmentStatement)
+	brace := m.ours.offset(assign.Value.(*BlockStatement).Token) + 1
+	indent := span.Indent + "\t"
+	for _, stmt := range children(entry) {
+		if start, _, ok := m.ours.span(stmt); ok {
+			indent = m.ours.indent(start)
+			break
+		}
+	}
+	return m.after(domain.MergeSpan{Start: brace, End: brace, Indent: indent})
+}
+
+// reindent moves the lines after the first of a statement from one
+// indentation to another
+func reindent(text, from, to string) string {
+	lines := strings.Split(text, "\n")
+	for i := 1; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], from) {
+			lines[i] = to + lines[i][len(from):]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// MergeSource returns the merged file: the mod source with the blocks that
+// take the game version replaced, removed or inserted, everything else as
+// it is; unresolved conflicts are an error
+func MergeSource(file *domain.MergeFile) (string, error) {
+	if unresolved := file.Unresolved(); unresolved > 0 {
+		return "", fmt.Errorf("%d unresolved conflicts", unresolved)
+	}
+
+	index := newSourceIndex(file.Source)
+	edits := make([]rangeEdit, 0)
+	var err error
+	file.Walk(func(block *domain.MergeBlock) {
+		result := block.Result()
+		switch {
+		case result == block.Ours:
+			// The mod version, as written
+		case result != "" && block.TheirsSource == "":
+			err = fmt.Errorf("%s not found in the new game file", block.Key)
+		case block.Ours != "" && result == "":
+			edits = append(edits, index.removeRange(block.Span.Start, block.Span.End))
+		case block.Ours != "":
+			edits = append(edits, rangeEdit{start: block.Span.Start, end: block.Span.End, text: block.TheirsSource})
+		case block.Span.Start == 0 || file.Source[block.Span.Start-1] == '\n':
+			edits = append(edits, rangeEdit{start: block.Span.Start, end: block.Span.Start, text: block.Span.Indent + block.TheirsSource + "\n"})
+		default:
+			edits = append(edits, rangeEdit{start: block.Span.Start, end: block.Span.Start, text: " " + block.TheirsSource})
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+	return applyRangeEdits(file.Source, edits), nil
+}
+
+// mergeEntries keys statements: name:id for blocks with an id (focus,
+// shared_focus), the name otherwise; repeated keys get #2, #3, ...
+func mergeEntries(statements []Statement) []mergeEntry {
+	entries := make([]mergeEntry, 0, len(statements))
+	seen := make(map[string]int)
+	for _, stmt := range statements {
+		entry := mergeEntry{stmt: stmt}
+		switch s := stmt.(type) {
+		case *AssignmentStatement:
+			entry.name = s.Name.Value
+			entry.key = s.Name.Value
+			if block, ok := s.Value.(*BlockStatement); ok {
+				if id := blockID(block); id != "" {
+					entry.key += ":" + id
+				}
+			}
+		case *ValueStatement:
+			entry.key = "value:" + FormatExpression(s.Value, 0)
+		default:
+			continue
+		}
+		seen[entry.key]++
+		if n := seen[entry.key]; n > 1 {
+			entry.key = fmt.Sprintf("%s#%d", entry.key, n)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// blockID returns the id = ... value of a block ("" if none)
+func blockID(block *BlockStatement) string {
+	for _, stmt := range block.Statements {
+		if assign, ok := stmt.(*AssignmentStatement); ok && assign.Name.Value == "id" {
+			if _, isBlock := assign.Value.(*BlockStatement); !isBlock {
+				return strings.Trim(FormatExpression(assign.Value, 0), `"`)
+			}
+		}
+	}
+	return ""
+}
+
+// mergeOrder returns the keys of ours in order, with keys only in theirs
+// inserted after their predecessor in theirs
+func mergeOrder(ours, theirs []mergeEntry) []string {
+	order := make([]string, 0, len(ours)+len(theirs))
+	present := make(map[string]bool)
+	for _, entry := range ours {
+		order = append(order, entry.key)
+		present[entry.key] = true
+	}
+	for i, entry := range theirs {
+		if present[entry.key] {
+			continue
+		}
+		at := 0
+		for j := i - 1; j >= 0; j-- {
+			if present[theirs[j].key] {
+				for k, key := range order {
+					if key == theirs[j].key {
+						at = k + 1
+						break
+					}
+				}
+				break
+			}
+		}
+		order = append(order[:at], append([]string{entry.key}, order[at:]...)...)
+		present[entry.key] = true
+	}
+	return order
+}
+
+// isMergeContainer reports whether the present versions of an entry are a
+// container block
+func isMergeContainer(entries ...mergeEntry) bool {
+	found := false
+	for _, entry := range entries {
+		if entry.stmt == nil {
+			continue
+		}
+		assign, ok := entry.stmt.(*AssignmentStatement)
+		if !ok || !mergeContainers[assign.Name.Value] {
+			return false
+		}
+		if _, ok := assign.Value.(*BlockStatement); !ok {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// firstName returns the statement name of the first present entry
+func firstName(entries ...mergeEntry) string {
+	for _, entry := range entries {
+		if entry.stmt != nil {
+			return entry.name
+		}
+	}
+	return ""
+}
+
+// children returns the statements of a container entry (nil if absent)
+func children(entry mergeEntry) []Statement {
+	if assign, ok := entry.stmt.(*AssignmentStatement); ok {
+		if block, ok := assign.Value.(*BlockStatement); ok {
+			return block.Statements
+		}
+	}
+	return nil
+}
+
+// statementText formats a statement at depth 0 ("" if absent), so
+// formatting and comments do not count as changes (MergeSource keeps them)
+func statementText(entry mergeEntry) string {
+	if entry.stmt == nil {
+		return ""
+	}
+	return strings.TrimSuffix(Format([]Statement{entry.stmt}, 0), "\n")
+}
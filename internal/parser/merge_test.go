@@ -0,0 +1,166 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+const mergeBase = `@1936 = 2
+technologies = {
+	tech_a = {
+		research_cost = 1
+		folder = { name = test_folder position = { x = 0 y = @1936 } }
+	}
+	tech_b = {
+		research_cost = 1
+	}
+	tech_c = {
+		research_cost = 1
+	}
+	tech_d = {
+		research_cost = 1
+	}
+}
+focus_tree = {
+	id = test_tree
+	focus = { id = TST_a cost = 10 }
+	focus = { id = TST_b cost = 10 }
+}`
+
+// Mod: tech_a retuned, tech_c removed, tech_d changed, own tech and focus added,
+// blocks reformatted and commented
+const mergeOurs = `@1936 = 2
+technologies = {
+	# Mod balance
+	tech_a = { research_cost = 2 folder = { name = test_folder position = { x = 0 y = @1936 } } }
+	tech_b = { research_cost = 1 }
+	tech_d = { research_cost = 5 }
+	tech_mod = { research_cost = 1 }
+}
+focus_tree = {
+	id = test_tree
+	focus = { id = TST_a cost = 10 }
+	focus = { id = TST_b cost = 10 }
+	focus = { id = TST_mod cost = 5 }
+}`
+
+// Game update: tech_b and TST_b changed, tech_d changed differently,
+// tech_first, tech_new and @1940 added, TST_a removed
+const mergeTheirs = `@1936 = 2
+@1940 = 10
+technologies = {
+	tech_first = { research_cost = 1 }
+	tech_a = {
+		research_cost = 1
+		folder = { name = test_folder position = { x = 0 y = @1936 } }
+	}
+	tech_b = {
+		research_cost = 3
+	}
+	tech_new = {
+		research_cost = 1
+	}
+	tech_c = {
+		research_cost = 1
+	}
+	tech_d = {
+		research_cost = 4
+	}
+}
+focus_tree = {
+	id = test_tree
+	focus = { id = TST_b cost = 7 }
+}`
+
+func TestMergeScripts(t *testing.T) {
+	merged, err := MergeScripts(mergeBase, mergeOurs, mergeTheirs)
+	if err != nil {
+		t.Fatalf("MergeScripts() error: %v", err)
+	}
+
+	statuses := make(map[string]domain.MergeStatus)
+	keys := make([]string, 0)
+	merged.Walk(func(block *domain.MergeBlock) {
+		statuses[block.Key] = block.Status
+		keys = append(keys, block.Key)
+	})
+	want := map[string]domain.MergeStatus{
+		"@1936": domain.MergeUnchanged, "@1940": domain.MergeTheirs,
+		"tech_a": domain.MergeOurs, "tech_b": domain.MergeTheirs, "tech_c": domain.MergeOurs,
+		"tech_d": domain.MergeConflict, "tech_mod": domain.MergeOurs, "tech_new": domain.MergeTheirs,
+		"tech_first": domain.MergeTheirs, "id": domain.MergeUnchanged, "focus:TST_a": domain.MergeTheirs, "focus:TST_b": domain.MergeTheirs,
+		"focus:TST_mod": domain.MergeOurs,
+	}
+	for key, status := range want {
+		if statuses[key] != status {
+			t.Errorf("%s: got %q, want %q", key, statuses[key], status)
+		}
+	}
+	if order := strings.Join(keys, " "); order != "@1936 @1940 tech_first tech_a tech_b tech_new tech_c tech_d tech_mod id focus:TST_a focus:TST_b focus:TST_mod" {
+		t.Errorf("Unexpected order: %s", order)
+	}
+
+	if _, err := MergeSource(merged); err == nil {
+		t.Fatal("Expected an error for an unresolved conflict")
+	}
+	conflicts := merged.Conflicts()
+	if len(conflicts) != 1 || !strings.Contains(conflicts[0].Ours, "5") || !strings.Contains(conflicts[0].Theirs, "4") {
+		t.Fatalf("Unexpected conflicts: %+v", conflicts)
+	}
+	conflicts[0].Resolution = domain.MergeSideTheirs
+
+	output, err := MergeSource(merged)
+	if err != nil {
+		t.Fatalf("MergeSource() error: %v", err)
+	}
+	program, err := NewParser(output).Parse()
+	if err != nil {
+		t.Fatalf("Merged file does not parse: %v\n%s", err, output)
+	}
+	techs, err := NewTechParser().ParseTechnologies(program)
+	if err != nil {
+		t.Fatalf("ParseTechnologies() error: %v", err)
+	}
+	costs := make(map[string]float64)
+	for _, tech := range techs {
+		costs[tech.ID] = tech.ResearchCost
+	}
+	if len(costs) != 6 || costs["tech_a"] != 2 || costs["tech_b"] != 3 || costs["tech_d"] != 4 || costs["tech_new"] != 1 || costs["tech_mod"] != 1 {
+		t.Errorf("Unexpected merged technologies: %v\n%s", costs, output)
+	}
+	focuses, err := NewFocusParser().ParseFocusTree(program)
+	if err != nil {
+		t.Fatalf("ParseFocusTree() error: %v", err)
+	}
+	if len(focuses) != 2 || focuses[0].Cost != 7 || focuses[1].ID != "TST_mod" {
+		t.Errorf("Unexpected merged focuses: %+v", focuses)
+	}
+	// The mod formatting and comments are kept, game blocks are spliced in as written
+	wantOutput := `@1936 = 2
+@1940 = 10
+technologies = {
+	tech_first = { research_cost = 1 }
+	# Mod balance
+	tech_a = { research_cost = 2 folder = { name = test_folder position = { x = 0 y = @1936 } } }
+	tech_b = {
+		research_cost = 3
+	}
+	tech_new = {
+		research_cost = 1
+	}
+	tech_d = {
+		research_cost = 4
+	}
+	tech_mod = { research_cost = 1 }
+}
+focus_tree = {
+	id = test_tree
+	focus = { id = TST_b cost = 7 }
+	focus = { id = TST_mod cost = 5 }
+}`
+	if output != wantOutput {
+		t.Errorf("Unexpected merged file:\n%s", output)
+	}
+}
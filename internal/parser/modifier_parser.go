@@ -0,0 +1,167 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// ParseModifierList parses the bundled modifier list: one modifier per line
+// as "name | category | percent/flat | Display name", # starts a comment
+func ParseModifierList(content string) []*domain.ModifierDefinition {
+	definitions := make([]*domain.ModifierDefinition, 0)
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "|")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		definition := &domain.ModifierDefinition{Name: fields[0]}
+		if len(fields) > 1 {
+			definition.Category = fields[1]
+		}
+		definition.Format = modifierFormatFor(definition.Name)
+		if len(fields) > 2 {
+			switch fields[2] {
+			case "percent":
+				definition.Format = domain.ModifierPercent
+			case "flat":
+				definition.Format = domain.ModifierFlat
+			}
+		}
+		if len(fields) > 3 {
+			definition.Display = fields[3]
+		}
+		definitions = append(definitions, definition)
+	}
+
+	return definitions
+}
+
+// ParseModifierDocumentation parses documentation/modifiers_documentation.md.
+// Headings that are a single modifier name start a modifier and other
+// headings name the category of the modifiers below them; bullet items
+// and table rows starting with a modifier name are accepted as well.
+// "Category:" lines and mentions of percent set the category and format
+func ParseModifierDocumentation(content string) []*domain.ModifierDefinition {
+	definitions := make([]*domain.ModifierDefinition, 0)
+	var current *domain.ModifierDefinition
+	section := ""
+
+	start := func(name string) {
+		current = &domain.ModifierDefinition{Name: name, Category: section, Format: modifierFormatFor(name)}
+		definitions = append(definitions, current)
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		lower := strings.ToLower(line)
+
+		switch {
+		case line == "":
+			continue
+
+		case strings.HasPrefix(line, "#"):
+			heading := strings.TrimSpace(strings.TrimLeft(line, "#"))
+			if name := modifierName(heading); name != "" {
+				start(name)
+			} else {
+				section = strings.ToLower(heading)
+				current = nil
+			}
+			continue
+
+		case strings.HasPrefix(line, "- "), strings.HasPrefix(line, "* "):
+			fields := strings.Fields(line[2:])
+			if len(fields) > 0 {
+				if name := modifierName(strings.TrimSuffix(fields[0], ":")); name != "" {
+					start(name)
+				}
+			}
+
+		case strings.HasPrefix(line, "|"):
+			cells := strings.Split(strings.Trim(line, "|"), "|")
+			if name := modifierName(strings.TrimSpace(cells[0])); name != "" {
+				start(name)
+			}
+		}
+
+		if current == nil {
+			continue
+		}
+		if strings.HasPrefix(lower, "category:") || strings.HasPrefix(lower, "categories:") {
+			value := strings.TrimSpace(line[strings.Index(line, ":")+1:])
+			if comma := strings.Index(value, ","); comma >= 0 {
+				value = value[:comma]
+			}
+			current.Category = strings.ToLower(strings.Trim(value, "` "))
+		}
+		if strings.Contains(lower, "percent") {
+			current.Format = domain.ModifierPercent
+		}
+	}
+
+	return definitions
+}
+
+// CollectModifierBlocks returns the lines of modifier = { ... } blocks
+// anywhere in an effect block (timed modifiers of nested effects)
+func CollectModifierBlocks(statements []Statement) []domain.Modifier {
+	modifiers := make([]domain.Modifier, 0)
+
+	var walk func(statements []Statement)
+	walk = func(statements []Statement) {
+		for _, stmt := range statements {
+			assign, ok := stmt.(*AssignmentStatement)
+			if !ok {
+				continue
+			}
+			block, ok := assign.Value.(*BlockStatement)
+			if !ok {
+				continue
+			}
+			if assign.Name.Value != "modifier" {
+				walk(block.Statements)
+				continue
+			}
+			for _, inner := range block.Statements {
+				if line, ok := inner.(*AssignmentStatement); ok {
+					if value := scalarValue(line.Value); value != "" {
+						modifiers = append(modifiers, domain.Modifier{Key: line.Name.Value, Value: value})
+					}
+				}
+			}
+		}
+	}
+	walk(statements)
+
+	return modifiers
+}
+
+// modifierName returns text as a modifier name (backticks stripped) when it
+// is a single lowercase identifier, otherwise ""
+func modifierName(text string) string {
+	text = strings.Trim(text, "`*")
+	if text == "" || text[0] < 'a' || text[0] > 'z' {
+		return ""
+	}
+	for _, r := range text {
+		if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '_' {
+			return ""
+		}
+	}
+	return text
+}
+
+// modifierFormatFor guesses the format from the name: *_factor is a percentage
+func modifierFormatFor(name string) domain.ModifierFormat {
+	if strings.HasSuffix(name, "_factor") || strings.Contains(name, "_factor_") {
+		return domain.ModifierPercent
+	}
+	return domain.ModifierFlat
+}
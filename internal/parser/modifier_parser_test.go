@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+const testModifierDocs = `# Modifiers Documentation
+
+## Army
+
+### army_org_factor
+Categories: army
+Value is a percentage.
+
+### max_planning
+Categories: army
+
+## Country
+
+- ` + "`stability_factor`" + `: stability (percent)
+| political_power_gain | country | flat |
+`
+
+func TestParseModifierList(t *testing.T) {
+	definitions := ParseModifierList("# comment\nsoft_attack | unit | percent | Soft Attack\nmax_command_power | country\n")
+	if len(definitions) != 2 {
+		t.Fatalf("Expected 2 definitions, got %d", len(definitions))
+	}
+	if d := definitions[0]; d.Name != "soft_attack" || d.Category != "unit" || d.Format != domain.ModifierPercent || d.DisplayName() != "Soft Attack" {
+		t.Errorf("Unexpected definition: %+v", d)
+	}
+	if d := definitions[1]; d.Format != domain.ModifierFlat || d.DisplayName() != "Max Command Power" {
+		t.Errorf("Unexpected definition: %+v", d)
+	}
+}
+
+func TestParseModifierDocumentation(t *testing.T) {
+	definitions := ParseModifierDocumentation(testModifierDocs)
+
+	byName := make(map[string]*domain.ModifierDefinition)
+	for _, d := range definitions {
+		byName[d.Name] = d
+	}
+	if len(byName) != 4 {
+		t.Fatalf("Expected 4 modifiers, got %d: %+v", len(byName), definitions)
+	}
+	if d := byName["army_org_factor"]; d.Category != "army" || d.Format != domain.ModifierPercent {
+		t.Errorf("Unexpected army_org_factor: %+v", d)
+	}
+	if d := byName["max_planning"]; d.Category != "army" || d.Format != domain.ModifierFlat {
+		t.Errorf("Unexpected max_planning: %+v", d)
+	}
+	if d := byName["stability_factor"]; d.Category != "country" || d.Format != domain.ModifierPercent {
+		t.Errorf("Unexpected stability_factor: %+v", d)
+	}
+	if _, ok := byName["political_power_gain"]; !ok {
+		t.Error("Expected table row modifier political_power_gain")
+	}
+}
+
+func TestModifierCatalogueTooltipAndSuggest(t *testing.T) {
+	catalogue := domain.NewModifierCatalogue(ParseModifierList(`army_org_factor | army | percent | Division Organization
+soft_attack | unit | percent | Soft Attack
+political_power_gain | country | flat | Daily Political Power`))
+
+	tests := []struct {
+		name  string
+		value float64
+		want  string
+	}{
+		{"soft_attack", 0.05, "+5% Soft Attack"},
+		{"army_org_factor", -0.1, "-10% Division Organization"},
+		{"political_power_gain", 0.25, "+0.25 Daily Political Power"},
+		{"unlisted_factor", 0.2, "+20% Unlisted"},
+	}
+	for _, tt := range tests {
+		if got := catalogue.Tooltip(tt.name, tt.value); got != tt.want {
+			t.Errorf("Tooltip(%s, %v) = %q, want %q", tt.name, tt.value, got, tt.want)
+		}
+	}
+
+	issue := catalogue.Check("army_org_facto", "idea x")
+	if issue == nil || issue.Suggestion != "army_org_factor" {
+		t.Errorf("Expected typo suggestion army_org_factor, got %+v", issue)
+	}
+	if issue := catalogue.Check("soft_attack", "idea x"); issue != nil {
+		t.Errorf("Expected known modifier, got %+v", issue)
+	}
+	if suggestion := catalogue.Suggest("completely_different"); suggestion != "" {
+		t.Errorf("Expected no suggestion, got %q", suggestion)
+	}
+}
+
+func TestTechParser_Effects(t *testing.T) {
+	input := `technologies = {
+	infantry_weapons1 = {
+		enable_equipments = { infantry_equipment_1 }
+		land_reinforce_rate = 0.02
+		infantry = { soft_attack = 0.05 defense = 0.05 }
+		allow = { always = no }
+		research_cost = 1.5
+		folder = { name = infantry_folder position = { x = 0 y = 0 } }
+	}
+}`
+
+	program, err := NewParser(input).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	technologies, err := NewTechParser().ParseTechnologies(program)
+	if err != nil {
+		t.Fatalf("ParseTechnologies() error: %v", err)
+	}
+
+	effects := technologies[0].Effects
+	if len(effects) != 2 || effects[""]["land_reinforce_rate"] != 0.02 || effects["infantry"]["soft_attack"] != 0.05 || effects["infantry"]["defense"] != 0.05 {
+		t.Errorf("Unexpected effects: %v", effects)
+	}
+}
+
+func TestCollectModifierBlocks(t *testing.T) {
+	program, err := NewParser(`completion_reward = {
+	add_timed_idea = { idea = x days = 30 }
+	if = { limit = { tag = GER } modifier = { stability_factor = 0.05 army_org_facto = 0.1 } }
+}`).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	block := program.Statements[0].(*AssignmentStatement).Value.(*BlockStatement)
+	modifiers := CollectModifierBlocks(block.Statements)
+	if len(modifiers) != 2 || modifiers[0].Key != "stability_factor" || modifiers[1].Key != "army_org_facto" || modifiers[1].Value != "0.1" {
+		t.Errorf("Unexpected modifiers: %+v", modifiers)
+	}
+}
@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ParseFiles runs parse on every file with one worker per CPU; results and
+// errors are in the order of files, so later files can still override
+// earlier ones when the results are merged
+func ParseFiles[T any](files []string, parse func(path string) (T, error)) ([]T, []error) {
+	results := make([]T, len(files))
+	errs := make([]error, len(files))
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(files) {
+		workers = len(files)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = parse(files[i])
+			}
+		}()
+	}
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, errs
+}
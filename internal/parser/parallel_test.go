@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTechFiles writes files technology files of perFile technologies each
+func writeTechFiles(tb testing.TB, dir string, files, perFile int) []string {
+	tb.Helper()
+	paths := make([]string, files)
+	for f := 0; f < files; f++ {
+		var source strings.Builder
+		source.WriteString("technologies = {\n")
+		for t := 0; t < perFile; t++ {
+			fmt.Fprintf(&source, `	tech_%d_%d = {
+		research_cost = 1.5
+		start_year = 1936
+		allow = { has_country_flag = flag_%d }
+		path = { leads_to_tech = tech_%d_%d research_cost_coeff = 1 }
+		folder = { name = test_folder position = { x = %d y = %d } }
+		categories = { electronics radar_tech }
+		ai_will_do = { factor = 2 }
+	}
+`, f, t, t, f, t+1, t%10, t)
+		}
+		source.WriteString("}\n")
+		paths[f] = filepath.Join(dir, fmt.Sprintf("%02d_technologies.txt", f))
+		if err := os.WriteFile(paths[f], []byte(source.String()), 0644); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return paths
+}
+
+// parseTechFile parses the technologies of a file
+func parseTechFile(path string) (int, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	program, err := NewParser(string(content)).Parse()
+	if err != nil {
+		return 0, err
+	}
+	techs, err := NewTechParser().ParseTechnologies(program)
+	return len(techs), err
+}
+
+func TestParseFiles(t *testing.T) {
+	dir := t.TempDir()
+	paths := writeTechFiles(t, dir, 12, 5)
+	paths = append(paths, filepath.Join(dir, "missing.txt"))
+
+	counts, errs := ParseFiles(paths, parseTechFile)
+	for i := range paths[:12] {
+		if errs[i] != nil || counts[i] != 5 {
+			t.Errorf("File %d: got %d technologies, error %v", i, counts[i], errs[i])
+		}
+	}
+	if errs[12] == nil {
+		t.Error("Expected an error for a missing file")
+	}
+
+	// Results keep the file order, so later files still override earlier ones
+	names, _ := ParseFiles(paths[:12], func(path string) (string, error) { return filepath.Base(path), nil })
+	for i, name := range names {
+		if name != filepath.Base(paths[i]) {
+			t.Errorf("Result %d is %s, want %s", i, name, filepath.Base(paths[i]))
+		}
+	}
+}
+
+func TestLocalizationParserFileLoader(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "localisation", "english")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for i, value := range []string{"first", "second"} {
+		content := fmt.Sprintf("l_english:\n key:0 \"%s\"\n key_%d:0 \"only %d\"\n", value, i, i)
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("%02d_l_english.yml", i)), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p := NewLocalizationParser(filepath.Dir(filepath.Dir(dir)), "", "english")
+	loaded := 0
+	p.SetFileLoader(func(path string) (map[string]string, error) {
+		loaded++
+		return p.LoadFile(path)
+	})
+	localizations, err := p.LoadLocalizations()
+	if err != nil {
+		t.Fatalf("LoadLocalizations() error: %v", err)
+	}
+	if loaded != 2 || localizations["key"] != "second" || localizations["key_0"] != "only 0" || localizations["key_1"] != "only 1" {
+		t.Errorf("Unexpected localizations (%d files loaded): %v", loaded, localizations)
+	}
+}
+
+func BenchmarkParseTechnologyFiles(b *testing.B) {
+	paths := writeTechFiles(b, b.TempDir(), 40, 60)
+
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, path := range paths {
+				if _, err := parseTechFile(path); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, errs := ParseFiles(paths, parseTechFile); errs[0] != nil {
+				b.Fatal(errs[0])
+			}
+		}
+	})
+}
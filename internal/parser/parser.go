@@ -55,15 +55,29 @@ func (p *Parser) Parse() (*Program, error) {
 // parseStatement parses a statement
 func (p *Parser) parseStatement() Statement {
 	// Check if it's an assignment (identifier = value OR @1918 = value OR "string" = value)
-	if p.current.Type == TokenIdentifier || p.current.Type == TokenKeyword || p.current.Type == TokenNumber || p.current.Type == TokenString {
-		if p.peek.Type == TokenEquals {
+	// or a comparison (identifier < value)
+	if p.current.Type == TokenIdentifier || p.current.Type == TokenKeyword || p.current.Type == TokenNumber || p.current.Type == TokenString || p.current.Type == TokenDate {
+		if isOperator(p.peek.Type) {
 			return p.parseAssignmentStatement()
 		}
 	}
 
+	// Bare values inside lists: { GER ENG } or { 1.0 1.0 1.0 }
+	switch p.current.Type {
+	case TokenIdentifier, TokenKeyword, TokenNumber, TokenString, TokenDate, TokenLeftBrace:
+		if value := p.parseExpression(); value != nil {
+			return &ValueStatement{Value: value}
+		}
+	}
+
 	return nil
 }
 
+// isOperator reports whether a token separates a key from its value
+func isOperator(t TokenType) bool {
+	return t == TokenEquals || t == TokenLessThan || t == TokenGreaterThan
+}
+
 // parseAssignmentStatement parses an assignment statement
 func (p *Parser) parseAssignmentStatement() *AssignmentStatement {
 	// Name can be Identifier, Keyword, or Number (for @1918 = 0)
@@ -74,10 +88,12 @@ func (p *Parser) parseAssignmentStatement() *AssignmentStatement {
 		},
 	}
 
-	// Expect '='
-	if !p.expectPeek(TokenEquals) {
+	// Expect '=', '<' or '>'
+	if !isOperator(p.peek.Type) {
+		p.errors = append(p.errors, fmt.Sprintf("expected =, got %s at line %d", p.peek.Type, p.peek.Line))
 		return nil
 	}
+	p.nextToken()
 
 	stmt.Token = p.current
 
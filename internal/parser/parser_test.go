@@ -4,6 +4,16 @@ import (
 	"testing"
 )
 
+// parseTestProgram parses test input, failing the test on a syntax error
+func parseTestProgram(t *testing.T, input string) *Program {
+	t.Helper()
+	program, err := NewParser(input).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	return program
+}
+
 func TestParser_SimpleAssignment(t *testing.T) {
 	input := `research_cost = 1.0`
 	
@@ -243,3 +253,64 @@ func TestParser_ComplexTechnology(t *testing.T) {
 		t.Fatalf("supportBlock.Statements does not contain 3 statements. got=%d", len(supportBlock.Statements))
 	}
 }
+
+func TestParser_BareValuesAndComparisons(t *testing.T) {
+	input := `ideas = { GER_idea "quoted idea" 1.5 }
+limit = { has_stability < 0.5 num_of_factories > 10 }`
+
+	program, err := NewParser(input).Parse()
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+
+	ideas := program.Statements[0].(*AssignmentStatement).Value.(*BlockStatement)
+	if len(ideas.Statements) != 3 {
+		t.Fatalf("ideas block does not contain 3 values. got=%d", len(ideas.Statements))
+	}
+	for i, want := range []string{"GER_idea", "quoted idea", "1.5"} {
+		value, ok := ideas.Statements[i].(*ValueStatement)
+		if !ok {
+			t.Fatalf("ideas.Statements[%d] is not *ValueStatement. got=%T", i, ideas.Statements[i])
+		}
+		if value.TokenLiteral() != want {
+			t.Errorf("value %d not %q. got=%q", i, want, value.TokenLiteral())
+		}
+	}
+
+	limit := program.Statements[1].(*AssignmentStatement).Value.(*BlockStatement)
+	if len(limit.Statements) != 2 {
+		t.Fatalf("limit block does not contain 2 comparisons. got=%d", len(limit.Statements))
+	}
+	first := limit.Statements[0].(*AssignmentStatement)
+	second := limit.Statements[1].(*AssignmentStatement)
+	if first.Name.Value != "has_stability" || first.Operator() != "<" {
+		t.Errorf("Expected has_stability <, got %s %s", first.Name.Value, first.Operator())
+	}
+	if second.Operator() != ">" || second.Value.TokenLiteral() != "10" {
+		t.Errorf("Expected > 10, got %s %s", second.Operator(), second.Value.TokenLiteral())
+	}
+}
+
+func TestFormat_RoundTrip(t *testing.T) {
+	input := `completion_reward = {
+	add_ideas = { idea_a idea_b }
+	if = {
+		limit = {
+			has_stability < 0.5
+		}
+		add_stability = 0.1
+	}
+	set_country_flag = "flag with spaces"
+}
+`
+
+	program, err := NewParser(input).Parse()
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+
+	output := Format(program.Statements, 0)
+	if output != input {
+		t.Errorf("Format() did not round-trip.\nwant:\n%s\ngot:\n%s", input, output)
+	}
+}
@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"strings"
+)
+
+// Format serializes statements back to Paradox script, one statement per line,
+// indented with tabs starting at the given depth
+func Format(statements []Statement, depth int) string {
+	var sb strings.Builder
+	for _, stmt := range statements {
+		writeStatement(&sb, stmt, depth)
+	}
+	return sb.String()
+}
+
+// FormatExpression serializes a single value; blocks are written multi-line
+// with their closing brace at the given depth
+func FormatExpression(expr Expression, depth int) string {
+	var sb strings.Builder
+	writeExpression(&sb, expr, depth)
+	return sb.String()
+}
+
+// writeStatement writes one statement followed by a newline
+func writeStatement(sb *strings.Builder, stmt Statement, depth int) {
+	switch s := stmt.(type) {
+	case *AssignmentStatement:
+		sb.WriteString(strings.Repeat("\t", depth))
+		sb.WriteString(s.Name.Value)
+		sb.WriteString(" " + s.Operator() + " ")
+		writeExpression(sb, s.Value, depth)
+		sb.WriteString("\n")
+	case *ValueStatement:
+		sb.WriteString(strings.Repeat("\t", depth))
+		writeExpression(sb, s.Value, depth)
+		sb.WriteString("\n")
+	}
+}
+
+// writeExpression writes a value without a trailing newline
+func writeExpression(sb *strings.Builder, expr Expression, depth int) {
+	switch v := expr.(type) {
+	case *StringLiteral:
+		sb.WriteString(QuoteString(v.Value))
+	case *BlockStatement:
+		writeBlock(sb, v, depth)
+	case nil:
+		sb.WriteString("{ }")
+	default:
+		sb.WriteString(v.TokenLiteral())
+	}
+}
+
+// writeBlock writes { ... }; lists of plain values stay on one line
+func writeBlock(sb *strings.Builder, block *BlockStatement, depth int) {
+	if len(block.Statements) == 0 {
+		sb.WriteString("{ }")
+		return
+	}
+
+	if isInlineList(block) {
+		sb.WriteString("{")
+		for _, stmt := range block.Statements {
+			sb.WriteString(" ")
+			writeExpression(sb, stmt.(*ValueStatement).Value, depth)
+		}
+		sb.WriteString(" }")
+		return
+	}
+
+	sb.WriteString("{\n")
+	sb.WriteString(Format(block.Statements, depth+1))
+	sb.WriteString(strings.Repeat("\t", depth))
+	sb.WriteString("}")
+}
+
+// isInlineList reports whether a block only holds bare scalar values
+func isInlineList(block *BlockStatement) bool {
+	for _, stmt := range block.Statements {
+		vs, ok := stmt.(*ValueStatement)
+		if !ok {
+			return false
+		}
+		if _, isBlock := vs.Value.(*BlockStatement); isBlock {
+			return false
+		}
+	}
+	return true
+}
+
+// QuoteString wraps a value in double quotes, escaping quotes and backslashes
+func QuoteString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestCollectSetTechnologies(t *testing.T) {
+	input := `capital = 64
+set_technology = {
+	infantry_weapons = 1
+	infantry_weapons1 = 1
+	tech_support = 0
+}
+if = {
+	limit = { has_dlc = "Man the Guns" }
+	set_technology = { early_destroyer = 1 }
+}
+1939.1.1 = {
+	set_technology = { infantry_weapons2 = 1 }
+}`
+	program, err := NewParser(input).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	got := CollectSetTechnologies(program.Statements)
+	want := []string{"infantry_weapons", "infantry_weapons1", "early_destroyer"}
+	if len(got) != len(want) {
+		t.Fatalf("CollectSetTechnologies() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("technology %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTechParser_StartYear(t *testing.T) {
+	input := `@year = 1938
+technologies = {
+	infantry_weapons2 = {
+		research_cost = 1.5
+		start_year = @year
+	}
+}`
+	program, err := NewParser(input).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	techs, err := NewTechParser().ParseTechnologies(program)
+	if err != nil {
+		t.Fatalf("ParseTechnologies() error: %v", err)
+	}
+	if len(techs) != 1 || techs[0].StartYear != 1938 {
+		t.Fatalf("start_year not parsed: %+v", techs)
+	}
+}
@@ -0,0 +1,309 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxScriptedDepth bounds nested scripted trigger/effect expansion
+const maxScriptedDepth = 16
+
+// scriptedParamPattern matches $PARAM$ placeholders
+var scriptedParamPattern = regexp.MustCompile(`\$([A-Za-z0-9_]+)\$`)
+
+// ScriptedKind distinguishes scripted triggers from scripted effects
+type ScriptedKind int
+
+const (
+	ScriptedTrigger ScriptedKind = iota // common/scripted_triggers
+	ScriptedEffect                      // common/scripted_effects
+)
+
+// String returns the display name of the kind
+func (k ScriptedKind) String() string {
+	if k == ScriptedEffect {
+		return "scripted effect"
+	}
+	return "scripted trigger"
+}
+
+// ScriptedDefinition is one scripted trigger or effect
+type ScriptedDefinition struct {
+	Name   string
+	Kind   ScriptedKind
+	Body   *BlockStatement
+	Params []string // $PARAM$ names used in the body, sorted
+	File   string   // Path the definition was loaded from
+}
+
+// ScriptedLibrary indexes scripted triggers and effects by name (later files win)
+type ScriptedLibrary struct {
+	triggers map[string]*ScriptedDefinition
+	effects  map[string]*ScriptedDefinition
+}
+
+// NewScriptedLibrary creates an empty library
+func NewScriptedLibrary() *ScriptedLibrary {
+	return &ScriptedLibrary{
+		triggers: make(map[string]*ScriptedDefinition),
+		effects:  make(map[string]*ScriptedDefinition),
+	}
+}
+
+// AddProgram registers every name = { ... } definition of a parsed file
+func (l *ScriptedLibrary) AddProgram(program *Program, kind ScriptedKind, file string) int {
+	count := 0
+	for _, stmt := range program.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok {
+			continue
+		}
+		body, ok := assign.Value.(*BlockStatement)
+		if !ok {
+			continue
+		}
+
+		definition := &ScriptedDefinition{
+			Name:   assign.Name.Value,
+			Kind:   kind,
+			Body:   body,
+			Params: scriptedParams(body),
+			File:   file,
+		}
+		if kind == ScriptedEffect {
+			l.effects[definition.Name] = definition
+		} else {
+			l.triggers[definition.Name] = definition
+		}
+		count++
+	}
+	return count
+}
+
+// Get returns a definition by name; triggers are checked before effects
+func (l *ScriptedLibrary) Get(name string) (*ScriptedDefinition, bool) {
+	if definition, ok := l.triggers[name]; ok {
+		return definition, true
+	}
+	definition, ok := l.effects[name]
+	return definition, ok
+}
+
+// Lookup returns a definition of the given kind by name
+func (l *ScriptedLibrary) Lookup(name string, kind ScriptedKind) (*ScriptedDefinition, bool) {
+	if kind == ScriptedEffect {
+		definition, ok := l.effects[name]
+		return definition, ok
+	}
+	definition, ok := l.triggers[name]
+	return definition, ok
+}
+
+// Count returns the number of triggers and effects
+func (l *ScriptedLibrary) Count() (triggers, effects int) {
+	return len(l.triggers), len(l.effects)
+}
+
+// Expand inlines scripted triggers/effects of the given kind into a copy
+// of the statements. name = yes is replaced by the body (wrapped in AND
+// for triggers with several conditions), name = no by NOT = { ... } and
+// name = { PARAM = value } substitutes $PARAM$ in the body. Recursive or
+// too deep references are left unexpanded and reported as warnings
+func (l *ScriptedLibrary) Expand(statements []Statement, kind ScriptedKind) ([]Statement, []string) {
+	expander := &scriptedExpander{library: l, kind: kind, active: make(map[string]bool)}
+	expanded := expander.expand(statements, 0)
+	return expanded, expander.warnings
+}
+
+// Preview returns the fully expanded body of a definition as script text
+func (l *ScriptedLibrary) Preview(name string) (string, bool) {
+	definition, ok := l.Get(name)
+	if !ok {
+		return "", false
+	}
+
+	expander := &scriptedExpander{library: l, kind: definition.Kind, active: map[string]bool{name: true}}
+	body := expander.expand(definition.Body.Statements, 1)
+
+	var sb strings.Builder
+	sb.WriteString(definition.Kind.String() + " " + name)
+	if len(definition.Params) > 0 {
+		sb.WriteString(" ($" + strings.Join(definition.Params, "$, $") + "$)")
+	}
+	sb.WriteString("\n" + Format(body, 0))
+	for _, warning := range expander.warnings {
+		sb.WriteString("# " + warning + "\n")
+	}
+	return sb.String(), true
+}
+
+// scriptedExpander carries the recursion guard through one expansion
+type scriptedExpander struct {
+	library  *ScriptedLibrary
+	kind     ScriptedKind
+	active   map[string]bool // Definitions currently being expanded
+	warnings []string
+}
+
+// expand returns a copy of statements with scripted references inlined
+func (e *scriptedExpander) expand(statements []Statement, depth int) []Statement {
+	result := make([]Statement, 0, len(statements))
+
+	for _, stmt := range statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok {
+			result = append(result, stmt)
+			continue
+		}
+
+		definition, isScripted := e.library.Lookup(assign.Name.Value, e.kind)
+		if !isScripted {
+			if block, ok := assign.Value.(*BlockStatement); ok {
+				copied := *assign
+				copied.Value = &BlockStatement{Token: block.Token, Statements: e.expand(block.Statements, depth)}
+				result = append(result, &copied)
+			} else {
+				result = append(result, assign)
+			}
+			continue
+		}
+
+		if e.active[definition.Name] {
+			e.warn(fmt.Sprintf("recursive %s %s not expanded", definition.Kind, definition.Name))
+			result = append(result, assign)
+			continue
+		}
+		if depth >= maxScriptedDepth {
+			e.warn(fmt.Sprintf("%s %s nested deeper than %d levels", definition.Kind, definition.Name, maxScriptedDepth))
+			result = append(result, assign)
+			continue
+		}
+
+		args := make(map[string]string)
+		negate := false
+		switch v := assign.Value.(type) {
+		case *BlockStatement:
+			for _, arg := range v.Statements {
+				if argAssign, ok := arg.(*AssignmentStatement); ok {
+					args[argAssign.Name.Value] = FormatExpression(argAssign.Value, 0)
+				}
+			}
+		default:
+			negate = scalarValue(v) == "no"
+		}
+
+		e.active[definition.Name] = true
+		body := e.expand(substituteParams(definition.Body.Statements, args), depth+1)
+		delete(e.active, definition.Name)
+
+		for _, param := range definition.Params {
+			if _, ok := args[param]; !ok && len(args) > 0 {
+				e.warn(fmt.Sprintf("%s %s: parameter $%s$ not set", definition.Kind, definition.Name, param))
+			}
+		}
+
+		result = append(result, e.wrap(body, negate)...)
+	}
+
+	return result
+}
+
+// wrap adapts an inlined body to its call site
+func (e *scriptedExpander) wrap(body []Statement, negate bool) []Statement {
+	if e.kind == ScriptedEffect {
+		return body
+	}
+	if len(body) > 1 {
+		body = []Statement{blockAssignment("AND", body)}
+	}
+	if negate {
+		body = []Statement{blockAssignment("NOT", body)}
+	}
+	return body
+}
+
+// warn records a warning once
+func (e *scriptedExpander) warn(warning string) {
+	for _, existing := range e.warnings {
+		if existing == warning {
+			return
+		}
+	}
+	e.warnings = append(e.warnings, warning)
+}
+
+// blockAssignment builds key = { statements }
+func blockAssignment(key string, statements []Statement) *AssignmentStatement {
+	return &AssignmentStatement{
+		Token: Token{Type: TokenEquals, Value: "="},
+		Name:  &Identifier{Token: Token{Type: TokenIdentifier, Value: key}, Value: key},
+		Value: &BlockStatement{Token: Token{Type: TokenLeftBrace, Value: "{"}, Statements: statements},
+	}
+}
+
+// substituteParams returns a deep copy of statements with $PARAM$ replaced
+func substituteParams(statements []Statement, args map[string]string) []Statement {
+	result := make([]Statement, 0, len(statements))
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *AssignmentStatement:
+			name := substituteText(s.Name.Value, args)
+			result = append(result, &AssignmentStatement{
+				Token: s.Token,
+				Name:  &Identifier{Token: Token{Type: s.Name.Token.Type, Value: name, Line: s.Name.Token.Line, Column: s.Name.Token.Column}, Value: name},
+				Value: substituteExpression(s.Value, args),
+			})
+		case *ValueStatement:
+			result = append(result, &ValueStatement{Value: substituteExpression(s.Value, args)})
+		default:
+			result = append(result, stmt)
+		}
+	}
+	return result
+}
+
+// substituteExpression copies a value with $PARAM$ replaced
+func substituteExpression(expr Expression, args map[string]string) Expression {
+	switch v := expr.(type) {
+	case *BlockStatement:
+		return &BlockStatement{Token: v.Token, Statements: substituteParams(v.Statements, args)}
+	case *Identifier:
+		value := substituteText(v.Value, args)
+		return &Identifier{Token: Token{Type: v.Token.Type, Value: value, Line: v.Token.Line, Column: v.Token.Column}, Value: value}
+	case *StringLiteral:
+		value := substituteText(v.Value, args)
+		return &StringLiteral{Token: Token{Type: v.Token.Type, Value: value, Line: v.Token.Line, Column: v.Token.Column}, Value: value}
+	default:
+		return expr
+	}
+}
+
+// substituteText replaces $PARAM$ placeholders that have an argument
+func substituteText(text string, args map[string]string) string {
+	if len(args) == 0 || !strings.Contains(text, "$") {
+		return text
+	}
+	return scriptedParamPattern.ReplaceAllStringFunc(text, func(match string) string {
+		if value, ok := args[match[1:len(match)-1]]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// scriptedParams returns the $PARAM$ names used in a body
+func scriptedParams(body *BlockStatement) []string {
+	seen := make(map[string]bool)
+	for _, match := range scriptedParamPattern.FindAllStringSubmatch(Format(body.Statements, 0), -1) {
+		seen[match[1]] = true
+	}
+
+	params := make([]string, 0, len(seen))
+	for param := range seen {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+	return params
+}
@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+const testScriptedTriggers = `is_fascist_ger = {
+	original_tag = GER
+	has_government = fascism
+}
+has_flag_param = {
+	has_country_flag = $FLAG$
+}
+loop_a = { loop_b = yes }
+loop_b = { loop_a = yes }
+`
+
+const testScriptedEffects = `give_fort = {
+	add_building_construction = { type = bunker level = $LEVEL$ province = $PROVINCE$ }
+}
+`
+
+func newTestLibrary(t *testing.T) *ScriptedLibrary {
+	t.Helper()
+	library := NewScriptedLibrary()
+	if n := library.AddProgram(parseTestProgram(t, testScriptedTriggers), ScriptedTrigger, "triggers.txt"); n != 4 {
+		t.Fatalf("Expected 4 triggers, got %d", n)
+	}
+	library.AddProgram(parseTestProgram(t, testScriptedEffects), ScriptedEffect, "effects.txt")
+	return library
+}
+
+func TestScriptedLibraryExpandYesNo(t *testing.T) {
+	library := newTestLibrary(t)
+
+	expanded, warnings := library.Expand(parseTestProgram(t, "is_fascist_ger = yes\nNOT = { is_fascist_ger = no }").Statements, ScriptedTrigger)
+	if len(warnings) != 0 {
+		t.Fatalf("Unexpected warnings: %v", warnings)
+	}
+
+	got := Format(expanded, 0)
+	for _, want := range []string{"AND = {", "original_tag = GER", "has_government = fascism", "NOT = {\n\t\tAND = {"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in expansion:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "is_fascist_ger") {
+		t.Errorf("Reference was not expanded:\n%s", got)
+	}
+}
+
+func TestScriptedLibraryExpandParams(t *testing.T) {
+	library := newTestLibrary(t)
+
+	expanded, _ := library.Expand(parseTestProgram(t, "has_flag_param = { FLAG = GER_ready }").Statements, ScriptedTrigger)
+	if got := strings.TrimSpace(Format(expanded, 0)); got != "has_country_flag = GER_ready" {
+		t.Errorf("Expected substituted flag, got %q", got)
+	}
+
+	effects, _ := library.Expand(parseTestProgram(t, "give_fort = { LEVEL = 2 PROVINCE = 6521 }").Statements, ScriptedEffect)
+	got := Format(effects, 0)
+	if !strings.Contains(got, "level = 2") || !strings.Contains(got, "province = 6521") {
+		t.Errorf("Expected substituted effect, got:\n%s", got)
+	}
+
+	if def, ok := library.Lookup("give_fort", ScriptedEffect); !ok || strings.Join(def.Params, ",") != "LEVEL,PROVINCE" {
+		t.Errorf("Expected params LEVEL,PROVINCE, got %+v", def)
+	}
+}
+
+func TestScriptedLibraryRecursion(t *testing.T) {
+	library := newTestLibrary(t)
+
+	_, warnings := library.Expand(parseTestProgram(t, "loop_a = yes").Statements, ScriptedTrigger)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "recursive scripted trigger loop_a") {
+		t.Errorf("Expected recursion warning, got %v", warnings)
+	}
+}
+
+func TestScriptedLibraryPreview(t *testing.T) {
+	library := newTestLibrary(t)
+
+	preview, ok := library.Preview("has_flag_param")
+	if !ok {
+		t.Fatal("Expected preview for has_flag_param")
+	}
+	if !strings.HasPrefix(preview, "scripted trigger has_flag_param ($FLAG$)\n") || !strings.Contains(preview, "has_country_flag = $FLAG$") {
+		t.Errorf("Unexpected preview:\n%s", preview)
+	}
+
+	if _, ok := library.Preview("missing"); ok {
+		t.Error("Expected no preview for an unknown name")
+	}
+}
+
+func TestTechnologyTagsScriptedCondition(t *testing.T) {
+	library := newTestLibrary(t)
+	p := NewTechnologyTagsParser("", "")
+	p.SetScriptedLibrary(library)
+
+	program := parseTestProgram(t, "available = { has_flag_param = { FLAG = has_naval_doctrines } }")
+	block := program.Statements[0].(*AssignmentStatement).Value.(*BlockStatement)
+
+	condition := p.parseAvailableCondition(block)
+	if len(condition.Conditions) != 1 {
+		t.Fatalf("Expected 1 condition, got %d", len(condition.Conditions))
+	}
+	if cond := condition.Conditions[0]; cond.Type != "has_country_flag" || cond.Value != "has_naval_doctrines" {
+		t.Errorf("Expected expanded has_country_flag, got %+v", cond)
+	}
+}
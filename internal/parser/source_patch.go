@@ -0,0 +1,214 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+)
+
+// rangeEdit replaces source[start:end] (byte offsets)
+type rangeEdit struct {
+	start, end int
+	text       string
+}
+
+// sourceIndex locates the statements of a parsed source by byte offset
+type sourceIndex struct {
+	source     string
+	lineStarts []int
+	closing    map[[2]int]int // '{' line and column -> offset of its '}'
+}
+
+// newSourceIndex lexes source once to match its braces
+func newSourceIndex(source string) *sourceIndex {
+	index := &sourceIndex{source: source, lineStarts: []int{0}, closing: make(map[[2]int]int)}
+	for i := 0; i < len(source); i++ {
+		if source[i] == '\n' {
+			index.lineStarts = append(index.lineStarts, i+1)
+		}
+	}
+
+	open := make([]Token, 0)
+	lexer := NewLexer(source)
+	for token := lexer.NextToken(); token.Type != TokenEOF; token = lexer.NextToken() {
+		switch token.Type {
+		case TokenLeftBrace:
+			open = append(open, token)
+		case TokenRightBrace:
+			if len(open) == 0 {
+				continue
+			}
+			last := open[len(open)-1]
+			open = open[:len(open)-1]
+			index.closing[[2]int{last.Line, last.Column}] = index.offset(token)
+		}
+	}
+	return index
+}
+
+// offset returns the byte offset of a token
+func (x *sourceIndex) offset(token Token) int {
+	if token.Line-1 >= len(x.lineStarts) {
+		return len(x.source)
+	}
+	return x.lineStarts[token.Line-1] + token.Column - 1
+}
+
+// closingBrace returns the offset of the '}' closing a block
+func (x *sourceIndex) closingBrace(block *BlockStatement) (int, bool) {
+	offset, ok := x.closing[[2]int{block.Token.Line, block.Token.Column}]
+	return offset, ok
+}
+
+// lineStart returns the offset of the start of the line holding offset
+func (x *sourceIndex) lineStart(offset int) int {
+	return strings.LastIndexByte(x.source[:offset], '\n') + 1
+}
+
+// lineEnd returns the offset after the newline of the line holding offset
+func (x *sourceIndex) lineEnd(offset int) int {
+	if next := strings.IndexByte(x.source[offset:], '\n'); next >= 0 {
+		return offset + next + 1
+	}
+	return len(x.source)
+}
+
+// indent returns the leading whitespace of the line holding offset
+func (x *sourceIndex) indent(offset int) string {
+	line := x.source[x.lineStart(offset):]
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
+
+// statementRange returns the offsets of an assignment, from its name to the
+// end of its value
+func (x *sourceIndex) statementRange(assign *AssignmentStatement) (int, int) {
+	start := x.offset(assign.Name.Token)
+	if block, ok := assign.Value.(*BlockStatement); ok {
+		if closing, ok := x.closingBrace(block); ok {
+			return start, closing + 1
+		}
+		return start, start
+	}
+	valueLine, _ := valueStart(assign)
+	lineOffset := x.lineStarts[valueLine]
+	return start, lineOffset + valueEnd(x.source[lineOffset:x.lineEnd(lineOffset)], assign)
+}
+
+// span returns the offsets of a statement, from its first token to the end
+// of its value
+func (x *sourceIndex) span(stmt Statement) (int, int, bool) {
+	switch s := stmt.(type) {
+	case *AssignmentStatement:
+		start, end := x.statementRange(s)
+		return start, end, true
+	case *ValueStatement:
+		if block, ok := s.Value.(*BlockStatement); ok {
+			closing, ok := x.closingBrace(block)
+			return x.offset(block.Token), closing + 1, ok
+		}
+		token, ok := scalarToken(s.Value)
+		if !ok {
+			return 0, 0, false
+		}
+		start := x.offset(token)
+		lineStart := x.lineStart(start)
+		return start, lineStart + scalarEnd(x.source[lineStart:x.lineEnd(start)], start-lineStart), true
+	}
+	return 0, 0, false
+}
+
+// removal returns the edit removing an assignment: its whole lines when
+// nothing else is on them, else the statement and the space after it
+func (x *sourceIndex) removal(assign *AssignmentStatement) rangeEdit {
+	start, end := x.statementRange(assign)
+	return x.removeRange(start, end)
+}
+
+// removeRange returns the edit removing source[start:end] as removal does
+func (x *sourceIndex) removeRange(start, end int) rangeEdit {
+	lineStart, lineEnd := x.lineStart(start), x.lineEnd(end)
+	if strings.TrimSpace(x.source[lineStart:start]) == "" && strings.TrimSpace(x.source[end:lineEnd]) == "" {
+		return rangeEdit{start: lineStart, end: lineEnd}
+	}
+	if end < len(x.source) && x.source[end] == ' ' {
+		end++
+	}
+	return rangeEdit{start: start, end: end}
+}
+
+// insertAfter returns the edit adding lines after the line on which an
+// assignment ends, with its indentation
+func (x *sourceIndex) insertAfter(assign *AssignmentStatement, lines []string) rangeEdit {
+	start, end := x.statementRange(assign)
+	at := x.lineEnd(end)
+	text := indentLines(lines, x.indent(start))
+	if at == len(x.source) && !strings.HasSuffix(x.source, "\n") {
+		text = "\n" + strings.TrimSuffix(text, "\n")
+	}
+	return rangeEdit{start: at, end: at, text: text}
+}
+
+// appendToBlock returns the edit adding lines at the end of a block: on
+// their own lines before a closing brace on its own line, else inline
+func (x *sourceIndex) appendToBlock(block *BlockStatement, lines []string) (rangeEdit, bool) {
+	closing, ok := x.closingBrace(block)
+	if !ok {
+		return rangeEdit{}, false
+	}
+	lineStart := x.lineStart(closing)
+	if strings.TrimSpace(x.source[lineStart:closing]) != "" {
+		return rangeEdit{start: closing, end: closing, text: strings.Join(lines, " ") + " "}, true
+	}
+	indent := x.indent(closing) + "\t"
+	if len(block.Statements) > 0 {
+		if first, ok := block.Statements[0].(*AssignmentStatement); ok {
+			indent = x.indent(x.offset(first.Name.Token))
+		}
+	}
+	return rangeEdit{start: lineStart, end: lineStart, text: indentLines(lines, indent)}, true
+}
+
+// insertInBlock returns the edit adding lines to a block after the line on
+// which after ends, or at the end of the block when after is nil or shares
+// its last line with the closing brace
+func (x *sourceIndex) insertInBlock(block *BlockStatement, after *AssignmentStatement, lines []string) (rangeEdit, bool) {
+	if after != nil {
+		closing, ok := x.closingBrace(block)
+		if _, end := x.statementRange(after); ok && closing >= x.lineEnd(end) {
+			return x.insertAfter(after, lines), true
+		}
+	}
+	return x.appendToBlock(block, lines)
+}
+
+// indentLines joins lines, each indented and ending with a newline
+func indentLines(lines []string, indent string) string {
+	var sb strings.Builder
+	for _, line := range lines {
+		sb.WriteString(indent + line + "\n")
+	}
+	return sb.String()
+}
+
+// applyRangeEdits applies non-overlapping edits, right to left so earlier
+// offsets stay valid; insertions at the same offset keep their order
+func applyRangeEdits(source string, edits []rangeEdit) string {
+	order := make([]int, len(edits))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := edits[order[i]], edits[order[j]]
+		if a.start != b.start {
+			return a.start > b.start
+		}
+		if a.end != b.end {
+			return a.end > b.end
+		}
+		return order[i] > order[j]
+	})
+	for _, i := range order {
+		edit := edits[i]
+		source = source[:edit.start] + edit.text + source[edit.end:]
+	}
+	return source
+}
@@ -117,6 +117,7 @@ func (tp *TechParser) ParseTechnologies(program *Program) ([]*domain.Technology,
 					continue
 				}
 
+				tech.Line = techAssign.Name.Token.Line
 				technologies = append(technologies, tech)
 				parsedTechs++
 			}
@@ -206,7 +207,9 @@ func (tp *TechParser) parseTechnology(id string, block *BlockStatement) (*domain
 			}
 
 		case "start_year":
-			// Store as metadata, not used in domain model currently
+			if year, err := strconv.Atoi(tp.resolveVariable(scalarValue(assignStmt.Value))); err == nil {
+				tech.StartYear = year
+			}
 
 		case "folder":
 			if folderBlock, ok := assignStmt.Value.(*BlockStatement); ok {
@@ -253,12 +256,85 @@ func (tp *TechParser) parseTechnology(id string, block *BlockStatement) (*domain
 					tech.XPResearchBonus = bonus
 				}
 			}
+
+		case "xp_unlock_cost":
+			if cost, err := strconv.Atoi(tp.resolveVariable(scalarValue(assignStmt.Value))); err == nil {
+				tech.XPUnlockCost = cost
+			}
+
+		case "doctrine":
+			tech.Doctrine = isYes(assignStmt.Value)
+
+		case "doctrine_name":
+			tech.DoctrineName = scalarValue(assignStmt.Value)
+
+		case "enable_equipments":
+			if block, ok := assignStmt.Value.(*BlockStatement); ok {
+				tech.EnableEquipments = tp.parseList(block)
+			}
+
+		case "enable_subunits":
+			if block, ok := assignStmt.Value.(*BlockStatement); ok {
+				tech.EnableSubunits = tp.parseList(block)
+			}
+
+		default:
+			tp.parseEffect(tech, assignStmt)
 		}
 	}
 
 	return tech, nil
 }
 
+// techNonEffectKeys are technology fields that are not modifiers
+var techNonEffectKeys = map[string]bool{
+	"allow": true, "allow_branch": true, "ai_will_do": true, "ai_research_weights": true,
+	"on_research_complete": true, "on_research_complete_limit": true,
+	"enable_equipment_modules": true, "enable_building": true, "enable_tactic": true, "enable_tactics": true,
+	"dependencies": true, "sub_technologies": true, "doctrine": true, "doctrine_name": true,
+	"desc": true, "show_equipment_icon": true, "show_effect_as_desc": true,
+	"is_special_project_tech": true, "special_project_specialization": true,
+	"force_use_small_tech_layout": true, "research_cost": true, "start_year": true, "xp_unlock_cost": true,
+}
+
+// parseEffect records a modifier: name = value at the top level of the
+// technology, or a category block (infantry = { soft_attack = 0.05 })
+// whose fields are all numeric
+func (tp *TechParser) parseEffect(tech *domain.Technology, stmt *AssignmentStatement) {
+	name := stmt.Name.Value
+	if techNonEffectKeys[name] {
+		return
+	}
+
+	switch v := stmt.Value.(type) {
+	case *NumberLiteral:
+		if value, err := strconv.ParseFloat(tp.resolveVariable(v.Value), 64); err == nil {
+			tech.AddEffect("", name, value)
+		}
+
+	case *BlockStatement:
+		effects := make(map[string]float64)
+		for _, inner := range v.Statements {
+			assign, ok := inner.(*AssignmentStatement)
+			if !ok {
+				return
+			}
+			num, ok := assign.Value.(*NumberLiteral)
+			if !ok {
+				return
+			}
+			value, err := strconv.ParseFloat(tp.resolveVariable(num.Value), 64)
+			if err != nil {
+				return
+			}
+			effects[assign.Name.Value] = value
+		}
+		for modifier, value := range effects {
+			tech.AddEffect(name, modifier, value)
+		}
+	}
+}
+
 // parseFolder parses a folder block
 func (tp *TechParser) parseFolder(tech *domain.Technology, block *BlockStatement) {
 	for _, stmt := range block.Statements {
@@ -337,31 +413,7 @@ func (tp *TechParser) parsePosition(block *BlockStatement) domain.Position {
 
 // parseCategories parses a categories block
 func (tp *TechParser) parseCategories(block *BlockStatement) []string {
-	categories := make([]string, 0)
-	seen := make(map[string]bool)
-
-	// In HOI4 files, categories are listed as: categories = { cat1 cat2 cat3 }
-	// Our parser sees them as assignments (cat1 = cat2, cat2 = cat3, etc.)
-	// So we extract both the name and value
-	for _, stmt := range block.Statements {
-		if assignStmt, ok := stmt.(*AssignmentStatement); ok {
-			// Add the assignment name (left side)
-			if !seen[assignStmt.Name.Value] {
-				categories = append(categories, assignStmt.Name.Value)
-				seen[assignStmt.Name.Value] = true
-			}
-
-			// Add the value if it's an identifier (right side)
-			if id, ok := assignStmt.Value.(*Identifier); ok {
-				if !seen[id.Value] {
-					categories = append(categories, id.Value)
-					seen[id.Value] = true
-				}
-			}
-		}
-	}
-
-	return categories
+	return tp.parseList(block)
 }
 
 // parsePath parses a path block
@@ -399,15 +451,29 @@ func (tp *TechParser) parsePath(block *BlockStatement) *domain.TechPath {
 
 // parseXOR parses an XOR block (mutually exclusive technologies)
 func (tp *TechParser) parseXOR(block *BlockStatement) []string {
-	xor := make([]string, 0)
+	return tp.parseList(block)
+}
+
+// parseList parses a list block ({ a b c }), deduplicated
+func (tp *TechParser) parseList(block *BlockStatement) []string {
+	values := make([]string, 0)
+	seen := make(map[string]bool)
 
 	for _, stmt := range block.Statements {
-		if assignStmt, ok := stmt.(*AssignmentStatement); ok {
-			xor = append(xor, assignStmt.Name.Value)
+		value := ""
+		switch v := stmt.(type) {
+		case *ValueStatement:
+			value = scalarValue(v.Value)
+		case *AssignmentStatement:
+			value = v.Name.Value
+		}
+		if value != "" && !seen[value] {
+			seen[value] = true
+			values = append(values, value)
 		}
 	}
 
-	return xor
+	return values
 }
 
 // resolveVariable resolves a variable reference (@VAR) to its value
@@ -475,3 +541,38 @@ func containsYear(s string) bool {
 	}
 	return false
 }
+
+// CollectSetTechnologies returns the technologies granted by set_technology
+// in a history file, skipping dated blocks (1939.1.1 = { ... })
+func CollectSetTechnologies(statements []Statement) []string {
+	technologies := make([]string, 0)
+	seen := make(map[string]bool)
+
+	var walk func(statements []Statement)
+	walk = func(statements []Statement) {
+		for _, stmt := range statements {
+			assign, ok := stmt.(*AssignmentStatement)
+			if !ok {
+				continue
+			}
+			block, ok := assign.Value.(*BlockStatement)
+			if !ok || containsYear(assign.Name.Value) {
+				continue
+			}
+			if assign.Name.Value != "set_technology" {
+				walk(block.Statements)
+				continue
+			}
+			for _, inner := range block.Statements {
+				tech, ok := inner.(*AssignmentStatement)
+				if ok && scalarValue(tech.Value) == "1" && !seen[tech.Name.Value] {
+					seen[tech.Name.Value] = true
+					technologies = append(technologies, tech.Name.Value)
+				}
+			}
+		}
+	}
+	walk(statements)
+
+	return technologies
+}
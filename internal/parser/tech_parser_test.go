@@ -97,10 +97,38 @@ technologies = {
 }
 
 func TestTechParser_Categories(t *testing.T) {
-	// Skip: Categories parsing works in real files (see TestTechParser_RealFile)
-	// but requires special handling for single identifiers in blocks
-	// which our current parser doesn't support yet
-	t.Skip("Categories parsing tested in RealFile test")
+	input := `technologies = {
+		infantry_weapons = {
+			categories = { infrastructure cat_inf weapons }
+			xor = { tech_a tech_b }
+			enable_equipments = { infantry_equipment_0 }
+			enable_subunits = { infantry }
+		}
+	}`
+
+	program, err := NewParser(input).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	technologies, err := NewTechParser().ParseTechnologies(program)
+	if err != nil {
+		t.Fatalf("ParseTechnologies() error: %v", err)
+	}
+
+	tech := technologies[0]
+	if len(tech.Categories) != 3 || tech.Categories[1] != "cat_inf" {
+		t.Errorf("Expected 3 categories, got %v", tech.Categories)
+	}
+	if len(tech.XOR) != 2 || tech.XOR[1] != "tech_b" {
+		t.Errorf("Expected 2 XOR techs, got %v", tech.XOR)
+	}
+	if len(tech.EnableEquipments) != 1 || tech.EnableEquipments[0] != "infantry_equipment_0" {
+		t.Errorf("Expected infantry_equipment_0, got %v", tech.EnableEquipments)
+	}
+	if len(tech.EnableSubunits) != 1 || tech.EnableSubunits[0] != "infantry" {
+		t.Errorf("Expected infantry sub-unit, got %v", tech.EnableSubunits)
+	}
 }
 
 func TestTechParser_RealFile(t *testing.T) {
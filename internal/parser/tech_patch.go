@@ -0,0 +1,281 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// PatchTechPositions rewrites the folder position of the given technologies
+// in the source of their technology file, using their x/y variables when
+// set; columns declares new variables (@NAME = value) after the last
+// variable of the file
+func PatchTechPositions(source string, technologies []*domain.Technology, columns map[string]int) (string, error) {
+	program, err := NewParser(source).Parse()
+	if err != nil {
+		return "", fmt.Errorf("failed to parse: %w", err)
+	}
+
+	byID := make(map[string]*domain.Technology, len(technologies))
+	for _, tech := range technologies {
+		byID[tech.ID] = tech
+	}
+
+	lines := strings.Split(source, "\n")
+	edits := make([]sourceEdit, 0)
+	declared := make(map[string]bool)
+	lastVariable, techLine := -1, -1
+
+	for _, stmt := range program.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok {
+			continue
+		}
+		if strings.HasPrefix(assign.Name.Value, "@") {
+			declared[assign.Name.Value] = true
+			lastVariable = assign.Name.Token.Line - 1
+			continue
+		}
+		block, ok := assign.Value.(*BlockStatement)
+		if !ok || assign.Name.Value != "technologies" {
+			continue
+		}
+		techLine = assign.Name.Token.Line - 1
+
+		for _, inner := range block.Statements {
+			techAssign, ok := inner.(*AssignmentStatement)
+			if !ok {
+				continue
+			}
+			if strings.HasPrefix(techAssign.Name.Value, "@") {
+				declared[techAssign.Name.Value] = true
+				lastVariable = techAssign.Name.Token.Line - 1
+				continue
+			}
+			tech := byID[techAssign.Name.Value]
+			techBlock, ok := techAssign.Value.(*BlockStatement)
+			if tech == nil || !ok {
+				continue
+			}
+			position := folderPosition(techBlock, tech.Folder)
+			if position == nil {
+				continue
+			}
+			values := map[string]string{
+				"x": coordinate(tech.Position.XVar, tech.Position.X),
+				"y": coordinate(tech.Position.YVar, tech.Position.Y),
+			}
+			for _, stmt := range position.Statements {
+				field, ok := stmt.(*AssignmentStatement)
+				if !ok || values[field.Name.Value] == "" {
+					continue
+				}
+				line, start := valueStart(field)
+				edits = append(edits, sourceEdit{line: line, start: start, end: valueEnd(lines[line], field), text: values[field.Name.Value]})
+			}
+		}
+	}
+
+	// Apply right to left so earlier offsets stay valid
+	sort.Slice(edits, func(i, j int) bool {
+		if edits[i].line != edits[j].line {
+			return edits[i].line < edits[j].line
+		}
+		return edits[i].start > edits[j].start
+	})
+	for _, edit := range edits {
+		line := lines[edit.line]
+		lines[edit.line] = line[:edit.start] + edit.text + line[edit.end:]
+	}
+
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		if !declared[name] {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return strings.Join(lines, "\n"), nil
+	}
+	sort.Strings(names)
+
+	// Declare after the last variable (same indentation), or before technologies
+	at, indent := techLine-1, ""
+	if lastVariable >= 0 {
+		at = lastVariable
+		indent = lines[at][:len(lines[at])-len(strings.TrimLeft(lines[at], " \t"))]
+	}
+	declarations := make([]string, len(names))
+	for i, name := range names {
+		declarations[i] = fmt.Sprintf("%s%s = %d", indent, name, columns[name])
+	}
+	patched := append([]string{}, lines[:at+1]...)
+	patched = append(patched, declarations...)
+	patched = append(patched, lines[at+1:]...)
+	return strings.Join(patched, "\n"), nil
+}
+
+// folderPosition returns the position block of the folder of a technology
+func folderPosition(techBlock *BlockStatement, folder string) *BlockStatement {
+	for _, stmt := range techBlock.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok || assign.Name.Value != "folder" {
+			continue
+		}
+		folderBlock, ok := assign.Value.(*BlockStatement)
+		if !ok {
+			continue
+		}
+		var name string
+		var position *BlockStatement
+		for _, inner := range folderBlock.Statements {
+			field, ok := inner.(*AssignmentStatement)
+			if !ok {
+				continue
+			}
+			switch field.Name.Value {
+			case "name":
+				name = strings.Trim(FormatExpression(field.Value, 0), `"`)
+			case "position":
+				position, _ = field.Value.(*BlockStatement)
+			}
+		}
+		if name == folder && position != nil {
+			return position
+		}
+	}
+	return nil
+}
+
+// coordinate returns the variable of a coordinate, or its value
+func coordinate(variable string, value int) string {
+	if variable != "" {
+		return variable
+	}
+	return strconv.Itoa(value)
+}
+
+// PatchTechLinks rewrites the paths and xor of the given technologies in the
+// source of their technology file: paths no longer on a technology are
+// removed and new ones added after the last path; the xor block is
+// rewritten when its technologies changed. Everything else is kept as it is
+func PatchTechLinks(source string, technologies []*domain.Technology) (string, error) {
+	program, err := NewParser(source).Parse()
+	if err != nil {
+		return "", fmt.Errorf("failed to parse: %w", err)
+	}
+
+	byID := make(map[string]*domain.Technology, len(technologies))
+	for _, tech := range technologies {
+		byID[tech.ID] = tech
+	}
+
+	index := newSourceIndex(source)
+	edits := make([]rangeEdit, 0)
+	tp := &TechParser{}
+
+	for _, stmt := range program.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok || assign.Name.Value != "technologies" {
+			continue
+		}
+		block, ok := assign.Value.(*BlockStatement)
+		if !ok {
+			continue
+		}
+		for _, inner := range block.Statements {
+			techAssign, ok := inner.(*AssignmentStatement)
+			if !ok {
+				continue
+			}
+			tech := byID[techAssign.Name.Value]
+			techBlock, ok := techAssign.Value.(*BlockStatement)
+			if tech == nil || !ok {
+				continue
+			}
+
+			wanted := make(map[domain.TechPath]bool, len(tech.Paths))
+			for _, path := range tech.Paths {
+				wanted[path] = true
+			}
+			present := make(map[domain.TechPath]bool)
+			var lastPath, xor *AssignmentStatement
+			for _, field := range techBlock.Statements {
+				fieldAssign, ok := field.(*AssignmentStatement)
+				if !ok {
+					continue
+				}
+				switch fieldAssign.Name.Value {
+				case "path":
+					lastPath = fieldAssign
+					pathBlock, ok := fieldAssign.Value.(*BlockStatement)
+					if !ok {
+						continue
+					}
+					path := *tp.parsePath(pathBlock)
+					if wanted[path] && !present[path] {
+						present[path] = true
+						continue
+					}
+					edits = append(edits, index.removal(fieldAssign))
+				case "xor":
+					xor = fieldAssign
+				}
+			}
+
+			added := make([]string, 0)
+			for _, path := range tech.Paths {
+				if !present[path] {
+					present[path] = true
+					added = append(added, fmt.Sprintf("path = { leads_to_tech = %s research_cost_coeff = %s }",
+						path.LeadsToTech, strconv.FormatFloat(path.ResearchCostCoeff, 'f', -1, 64)))
+				}
+			}
+
+			var current []string
+			if xor != nil {
+				if xorBlock, ok := xor.Value.(*BlockStatement); ok {
+					current = tp.parseXOR(xorBlock)
+				}
+			}
+			if !sameStrings(current, tech.XOR) {
+				if xor != nil {
+					edits = append(edits, index.removal(xor))
+				}
+				if len(tech.XOR) > 0 {
+					added = append(added, "xor = { "+strings.Join(tech.XOR, " ")+" }")
+				}
+			}
+
+			if len(added) == 0 {
+				continue
+			}
+			if edit, ok := index.insertInBlock(techBlock, lastPath, added); ok {
+				edits = append(edits, edit)
+			}
+		}
+	}
+
+	return applyRangeEdits(source, edits), nil
+}
+
+// sameStrings reports whether two lists hold the same values, in any order
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]int, len(a))
+	for _, value := range a {
+		seen[value]++
+	}
+	for _, value := range b {
+		if seen[value] == 0 {
+			return false
+		}
+		seen[value]--
+	}
+	return true
+}
@@ -0,0 +1,147 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+const testTechGrid = `technologies = {
+	@1936 = 2
+	@1938 = 6
+	@1940 = 10
+	@RADIO = 0
+
+	tech_radio = {
+		start_year = 1936
+		path = { leads_to_tech = tech_radio2 research_cost_coeff = 1 }
+		path = { leads_to_tech = tech_radar research_cost_coeff = 1 }
+		folder = { name = test_folder position = { x = @RADIO y = @1936 } }
+	}
+	tech_radio2 = {
+		start_year = 1938
+		path = { leads_to_tech = tech_radio3 research_cost_coeff = 1 }
+		folder = { name = test_folder position = { x = @RADIO y = 1 } }
+	}
+	tech_radio3 = {
+		start_year = 1940
+		folder = { name = test_folder position = { x = @RADIO y = @1940 } }
+	}
+	tech_radar = {
+		start_year = 1938
+		path = { leads_to_tech = tech_rocket research_cost_coeff = 1 }
+		folder = { name = test_folder position = { x = 2 y = 6 } }
+	}
+	tech_rocket = {
+		start_year = 1940
+		folder = { name = test_folder position = { x = 12 y = 10 } }
+	}
+}`
+
+// parseTechGrid parses testTechGrid into technologies and their grid
+func parseTechGrid(t *testing.T) ([]*domain.Technology, *domain.TechGrid) {
+	t.Helper()
+	program, err := NewParser(testTechGrid).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	techParser := NewTechParser()
+	techs, err := techParser.ParseTechnologies(program)
+	if err != nil {
+		t.Fatalf("ParseTechnologies() error: %v", err)
+	}
+	return techs, domain.NewTechGrid(techParser.GetVerticalVariables(), techParser.GetHorizontalVariables())
+}
+
+func TestPatchTechPositions(t *testing.T) {
+	techs, grid := parseTechGrid(t)
+	layout := domain.NewTechGridLayout(grid, "test_folder")
+	layout.Layout(techs)
+
+	patched, err := PatchTechPositions(testTechGrid, techs, layout.NewColumns)
+	if err != nil {
+		t.Fatalf("PatchTechPositions() error: %v", err)
+	}
+	for _, want := range []string{
+		"\t@RADIO = 0\n\t@RADIO_COL3 = 2\n\t@TEST_2_COL1 = 12\n",
+		"position = { x = @RADIO y = @1938 }",
+		"position = { x = @RADIO_COL3 y = @1938 }",
+	} {
+		if !strings.Contains(patched, want) {
+			t.Errorf("Patched source misses %q:\n%s", want, patched)
+		}
+	}
+
+	reparsed, reparsedGrid := func() ([]*domain.Technology, *domain.TechGrid) {
+		program, err := NewParser(patched).Parse()
+		if err != nil {
+			t.Fatalf("Parse() error: %v", err)
+		}
+		techParser := NewTechParser()
+		reparsed, err := techParser.ParseTechnologies(program)
+		if err != nil {
+			t.Fatalf("ParseTechnologies() error: %v", err)
+		}
+		return reparsed, domain.NewTechGrid(techParser.GetVerticalVariables(), techParser.GetHorizontalVariables())
+	}()
+	for i, tech := range reparsed {
+		if tech.Position != techs[i].Position {
+			t.Errorf("%s: got %+v, want %+v", tech.ID, tech.Position, techs[i].Position)
+		}
+	}
+	if reparsedGrid.Columns["@TEST_2_COL1"] != 12 {
+		t.Errorf("New column not declared: %v", reparsedGrid.Columns)
+	}
+}
+
+func TestPatchTechLinks(t *testing.T) {
+	techs, _ := parseTechGrid(t)
+	byID := make(map[string]*domain.Technology, len(techs))
+	for _, tech := range techs {
+		byID[tech.ID] = tech
+	}
+	byID["tech_radio"].Paths = byID["tech_radio"].Paths[:1]
+	byID["tech_radio2"].AddPath("tech_rocket", 0.5)
+	byID["tech_radio3"].XOR = []string{"tech_rocket"}
+	byID["tech_rocket"].XOR = []string{"tech_radio3"}
+
+	patched, err := PatchTechLinks(testTechGrid, techs)
+	if err != nil {
+		t.Fatalf("PatchTechLinks() error: %v", err)
+	}
+	for _, want := range []string{
+		"\t\tpath = { leads_to_tech = tech_radio2 research_cost_coeff = 1 }\n\t\tfolder",
+		"\t\tpath = { leads_to_tech = tech_radio3 research_cost_coeff = 1 }\n\t\tpath = { leads_to_tech = tech_rocket research_cost_coeff = 0.5 }\n",
+		"\t\tstart_year = 1940\n\t\tfolder = { name = test_folder position = { x = @RADIO y = @1940 } }\n\t\txor = { tech_rocket }\n\t}",
+	} {
+		if !strings.Contains(patched, want) {
+			t.Errorf("Patched source misses %q:\n%s", want, patched)
+		}
+	}
+
+	program, err := NewParser(patched).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	reparsed, err := NewTechParser().ParseTechnologies(program)
+	if err != nil {
+		t.Fatalf("ParseTechnologies() error: %v", err)
+	}
+	for i, tech := range reparsed {
+		if len(tech.Paths) != len(techs[i].Paths) || !sameStrings(tech.XOR, techs[i].XOR) {
+			t.Errorf("%s: got paths %v xor %v, want %v %v", tech.ID, tech.Paths, tech.XOR, techs[i].Paths, techs[i].XOR)
+			continue
+		}
+		for j, path := range tech.Paths {
+			if path != techs[i].Paths[j] {
+				t.Errorf("%s: path %d is %+v, want %+v", tech.ID, j, path, techs[i].Paths[j])
+			}
+		}
+	}
+
+	unchanged, err := PatchTechLinks(patched, reparsed)
+	if err != nil || unchanged != patched {
+		t.Errorf("Patching unchanged links altered the source:\n%s", unchanged)
+	}
+}
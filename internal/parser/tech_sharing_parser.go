@@ -0,0 +1,60 @@
+package parser
+
+import "strconv"
+
+// TechSharingGroup is a technology sharing group from common/technology_sharing
+type TechSharingGroup struct {
+	ID              string
+	Name            string // Localisation key
+	Description     string // Localisation key
+	Picture         string
+	PerCountryBonus float64 // research_sharing_per_country_bonus
+	Available       *AvailableCondition
+	Source          string // "mod" or "game"
+}
+
+// ParseTechSharingGroups parses every technology_sharing_group = { ... } of a
+// file; scripted triggers in available blocks are inlined when a library is given
+func ParseTechSharingGroups(program *Program, scripted *ScriptedLibrary) []*TechSharingGroup {
+	groups := make([]*TechSharingGroup, 0)
+
+	for _, stmt := range program.Statements {
+		assign, ok := stmt.(*AssignmentStatement)
+		if !ok || assign.Name.Value != "technology_sharing_group" {
+			continue
+		}
+		block, ok := assign.Value.(*BlockStatement)
+		if !ok {
+			continue
+		}
+
+		group := &TechSharingGroup{}
+		for _, inner := range block.Statements {
+			field, ok := inner.(*AssignmentStatement)
+			if !ok {
+				continue
+			}
+			switch field.Name.Value {
+			case "id":
+				group.ID = scalarValue(field.Value)
+			case "name":
+				group.Name = scalarValue(field.Value)
+			case "desc":
+				group.Description = scalarValue(field.Value)
+			case "picture":
+				group.Picture = scalarValue(field.Value)
+			case "research_sharing_per_country_bonus":
+				group.PerCountryBonus, _ = strconv.ParseFloat(scalarValue(field.Value), 64)
+			case "available":
+				if available, ok := field.Value.(*BlockStatement); ok {
+					group.Available = ParseAvailableCondition(available, scripted)
+				}
+			}
+		}
+		if group.ID != "" {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
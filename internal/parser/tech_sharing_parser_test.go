@@ -0,0 +1,57 @@
+package parser
+
+import "testing"
+
+func TestParseTechSharingGroups(t *testing.T) {
+	input := `technology_sharing_group = {
+	id = comintern_research
+	name = TECH_SHARING_COMINTERN_NAME
+	desc = TECH_SHARING_COMINTERN_DESC
+	picture = GFX_tech_sharing_comintern
+	research_sharing_per_country_bonus = 0.05
+	available = {
+		has_government = communism
+		NOT = { has_country_flag = left_comintern }
+		is_in_faction = yes
+	}
+}
+technology_sharing_group = {
+	id = commonwealth_research
+	research_sharing_per_country_bonus = 0.1
+	available = {
+		OR = {
+			tag = ENG
+			original_tag = CAN
+		}
+	}
+}`
+	program, err := NewParser(input).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	groups := ParseTechSharingGroups(program, nil)
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(groups))
+	}
+
+	comintern := groups[0]
+	if comintern.ID != "comintern_research" || comintern.Name != "TECH_SHARING_COMINTERN_NAME" || comintern.PerCountryBonus != 0.05 {
+		t.Errorf("Unexpected group: %+v", comintern)
+	}
+	conditions := comintern.Available.Conditions
+	if len(conditions) != 2 || conditions[0].Type != "has_government" || conditions[0].Value != "communism" {
+		t.Fatalf("Unexpected conditions: %+v", conditions)
+	}
+	if not := conditions[1]; not.Type != "NOT" || len(not.Children) != 1 || not.Children[0].Negated {
+		t.Errorf("NOT children should not be negated themselves: %+v", not)
+	}
+	if unknown := comintern.Available.Unknown; len(unknown) != 1 || unknown[0] != "is_in_faction" {
+		t.Errorf("Unknown = %v, want [is_in_faction]", unknown)
+	}
+
+	or := groups[1].Available.Conditions[0]
+	if or.Type != "OR" || len(or.Children) != 2 || or.Children[1].Type != "tag" || or.Children[1].Value != "CAN" {
+		t.Errorf("Unexpected OR condition: %+v", or)
+	}
+}
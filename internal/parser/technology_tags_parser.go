@@ -15,23 +15,11 @@ type TechFolder struct {
 	IsOverlay bool
 }
 
-// AvailableCondition represents availability conditions for a folder
-type AvailableCondition struct {
-	Conditions []*Condition
-}
-
-// Condition represents a single condition (has_country_flag, NOT, etc.)
-type Condition struct {
-	Type     string // "has_country_flag", "NOT", "has_dlc", "major_country"
-	Value    string
-	Negated  bool
-	Children []*Condition // for nested conditions like NOT { ... }
-}
-
 // TechnologyTagsParser parses technology_tags files to extract technology folders
 type TechnologyTagsParser struct {
 	gamePath string
 	modPath  string
+	scripted *ScriptedLibrary // Optional: scripted triggers expanded in conditions
 }
 
 // NewTechnologyTagsParser creates a new technology tags parser
@@ -42,6 +30,11 @@ func NewTechnologyTagsParser(gamePath, modPath string) *TechnologyTagsParser {
 	}
 }
 
+// SetScriptedLibrary enables inlining scripted triggers in available blocks
+func (p *TechnologyTagsParser) SetScriptedLibrary(library *ScriptedLibrary) {
+	p.scripted = library
+}
+
 // ParseTechnologyFolders extracts the list of technology folders
 // Priority: MOD first, then GAME (mod can override or add folders)
 func (p *TechnologyTagsParser) ParseTechnologyFolders() ([]string, error) {
@@ -298,81 +291,5 @@ func (p *TechnologyTagsParser) parseFolderBlock(folder *TechFolder, block *Block
 
 // parseAvailableCondition parses available = { ... } block
 func (p *TechnologyTagsParser) parseAvailableCondition(block *BlockStatement) *AvailableCondition {
-	condition := &AvailableCondition{
-		Conditions: make([]*Condition, 0),
-	}
-
-	for _, stmt := range block.Statements {
-		if assign, ok := stmt.(*AssignmentStatement); ok {
-			cond := p.parseCondition(assign)
-			if cond != nil {
-				condition.Conditions = append(condition.Conditions, cond)
-			}
-		}
-	}
-
-	return condition
-}
-
-// parseCondition parses a single condition
-func (p *TechnologyTagsParser) parseCondition(assign *AssignmentStatement) *Condition {
-	condName := assign.Name.Value
-
-	switch condName {
-	case "has_country_flag":
-		// has_country_flag = FLAG_NAME
-		flagName := p.extractValue(assign.Value)
-		return &Condition{
-			Type:  "has_country_flag",
-			Value: flagName,
-		}
-
-	case "NOT":
-		// NOT = { ... }
-		if block, ok := assign.Value.(*BlockStatement); ok {
-			children := make([]*Condition, 0)
-			for _, stmt := range block.Statements {
-				if childAssign, ok := stmt.(*AssignmentStatement); ok {
-					childCond := p.parseCondition(childAssign)
-					if childCond != nil {
-						childCond.Negated = true
-						children = append(children, childCond)
-					}
-				}
-			}
-			return &Condition{
-				Type:     "NOT",
-				Children: children,
-			}
-		}
-
-	case "has_dlc":
-		// has_dlc = "DLC Name"
-		dlcName := p.extractValue(assign.Value)
-		return &Condition{
-			Type:  "has_dlc",
-			Value: dlcName,
-		}
-
-	case "major_country":
-		// major_country = yes
-		return &Condition{
-			Type:  "major_country",
-			Value: "yes",
-		}
-	}
-
-	return nil
-}
-
-// extractValue extracts string value from expression
-func (p *TechnologyTagsParser) extractValue(expr Expression) string {
-	switch v := expr.(type) {
-	case *Identifier:
-		return v.Value
-	case *StringLiteral:
-		return v.Value
-	default:
-		return ""
-	}
+	return ParseAvailableCondition(block, p.scripted)
 }
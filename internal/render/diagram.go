@@ -0,0 +1,323 @@
+package render
+
+import (
+	"image"
+	"sort"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// EdgeKind describes how two nodes are connected
+type EdgeKind int
+
+const (
+	EdgePrerequisite EdgeKind = iota // Required parent (solid)
+	EdgeAlternative                  // One of several OR parents (dashed)
+	EdgeExclusive                    // Mutually exclusive pair (red, with marker)
+)
+
+// Diagram is a renderer-independent picture of a focus or technology tree
+// Coordinates are unscaled pixels with the origin at the top-left corner
+type Diagram struct {
+	Title  string
+	Nodes  []*Node
+	Edges  []Edge
+	Width  int
+	Height int
+
+	index map[string]*Node
+}
+
+// Node is a single focus or technology box
+type Node struct {
+	ID         string
+	Label      string
+	Icon       image.Image // Nil draws an empty icon area
+	X, Y       int
+	Width      int
+	Height     int
+	IconWidth  int
+	IconHeight int
+}
+
+// Edge connects two nodes by ID
+type Edge struct {
+	From string
+	To   string
+	Kind EdgeKind
+}
+
+// Options controls how domain objects are turned into a diagram
+type Options struct {
+	Title    string
+	Localize func(key string) string      // Display name for an ID; nil keeps the ID
+	Icon     func(key string) image.Image // Focus: icon sprite name, tech: tech ID
+}
+
+// nodeStyle holds the grid and box sizes for one tree type
+type nodeStyle struct {
+	cellWidth, cellHeight int
+	width, height         int
+	iconWidth, iconHeight int
+}
+
+// Vanilla-like spacing: focus x/y units are wide, tech units are compact
+var (
+	focusStyle = nodeStyle{cellWidth: 110, cellHeight: 140, width: 104, height: 120, iconWidth: 94, iconHeight: 86}
+	techStyle  = nodeStyle{cellWidth: 140, cellHeight: 100, width: 132, height: 92, iconWidth: 64, iconHeight: 64}
+)
+
+const (
+	diagramMargin = 40
+	titleHeight   = 30
+	maxLabelLines = 2
+)
+
+// NewFocusDiagram builds a diagram from a focus tree
+func NewFocusDiagram(tree *domain.FocusTree, opts Options) *Diagram {
+	ids := make([]string, 0, len(tree.Focuses))
+	for id := range tree.Focuses {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	d := newDiagram(opts.Title)
+	for _, id := range ids {
+		focus := tree.Focuses[id]
+		pos := tree.AbsolutePosition(id)
+		node := newNode(focusStyle, id, pos.X, pos.Y, opts)
+		if opts.Icon != nil && focus.Icon != "" {
+			node.Icon = opts.Icon(focus.Icon)
+		}
+		d.addNode(node)
+	}
+
+	for _, id := range ids {
+		focus := tree.Focuses[id]
+		for _, group := range focus.Prerequisites {
+			kind := EdgePrerequisite
+			if len(group) > 1 {
+				kind = EdgeAlternative
+			}
+			for _, parent := range group {
+				d.addEdge(parent, id, kind)
+			}
+		}
+		for _, other := range focus.MutuallyExclusive {
+			d.addEdge(id, other, EdgeExclusive)
+		}
+	}
+
+	d.normalize()
+	return d
+}
+
+// NewTechDiagram builds a diagram from the technologies of one folder
+func NewTechDiagram(techs []*domain.Technology, opts Options) *Diagram {
+	sorted := make([]*domain.Technology, len(techs))
+	copy(sorted, techs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	d := newDiagram(opts.Title)
+	for _, tech := range sorted {
+		node := newNode(techStyle, tech.ID, tech.Position.X, tech.Position.Y, opts)
+		if opts.Icon != nil {
+			node.Icon = opts.Icon(tech.ID)
+		}
+		d.addNode(node)
+	}
+
+	for _, tech := range sorted {
+		for _, path := range tech.Paths {
+			d.addEdge(tech.ID, path.LeadsToTech, EdgePrerequisite)
+		}
+		for _, other := range tech.XOR {
+			d.addEdge(tech.ID, other, EdgeExclusive)
+		}
+	}
+
+	d.normalize()
+	return d
+}
+
+// newDiagram creates an empty diagram
+func newDiagram(title string) *Diagram {
+	return &Diagram{
+		Title: title,
+		index: make(map[string]*Node),
+	}
+}
+
+// newNode places a node on the style grid
+func newNode(style nodeStyle, id string, x, y int, opts Options) *Node {
+	label := id
+	if opts.Localize != nil {
+		label = opts.Localize(id)
+	}
+	return &Node{
+		ID:         id,
+		Label:      label,
+		X:          x * style.cellWidth,
+		Y:          y * style.cellHeight,
+		Width:      style.width,
+		Height:     style.height,
+		IconWidth:  style.iconWidth,
+		IconHeight: style.iconHeight,
+	}
+}
+
+// addNode adds a node to the diagram
+func (d *Diagram) addNode(node *Node) {
+	d.Nodes = append(d.Nodes, node)
+	d.index[node.ID] = node
+}
+
+// addEdge adds an edge between existing nodes; exclusive pairs are added once
+func (d *Diagram) addEdge(from, to string, kind EdgeKind) {
+	if d.index[from] == nil || d.index[to] == nil || from == to {
+		return
+	}
+	for _, e := range d.Edges {
+		if e.From == from && e.To == to && e.Kind == kind {
+			return
+		}
+		if kind == EdgeExclusive && e.Kind == EdgeExclusive && e.From == to && e.To == from {
+			return
+		}
+	}
+	d.Edges = append(d.Edges, Edge{From: from, To: to, Kind: kind})
+}
+
+// normalize shifts nodes so the diagram starts at the margin and computes its size
+func (d *Diagram) normalize() {
+	top := diagramMargin
+	if d.Title != "" {
+		top += titleHeight
+	}
+
+	if len(d.Nodes) == 0 {
+		d.Width = 2 * diagramMargin
+		d.Height = top + diagramMargin
+		return
+	}
+
+	minX, minY := d.Nodes[0].X, d.Nodes[0].Y
+	maxX, maxY := minX, minY
+	for _, n := range d.Nodes {
+		minX = min(minX, n.X)
+		minY = min(minY, n.Y)
+		maxX = max(maxX, n.X+n.Width)
+		maxY = max(maxY, n.Y+n.Height)
+	}
+
+	for _, n := range d.Nodes {
+		n.X += diagramMargin - minX
+		n.Y += top - minY
+	}
+	d.Width = maxX - minX + 2*diagramMargin
+	d.Height = maxY - minY + top + diagramMargin
+}
+
+// Node returns the node with the given ID
+func (d *Diagram) Node(id string) *Node {
+	return d.index[id]
+}
+
+// EdgePoints returns the polyline for an edge
+// Parent-child edges run bottom-center to top-center with an elbow;
+// exclusive edges join the facing sides of the two boxes
+func (d *Diagram) EdgePoints(e Edge) []image.Point {
+	from, to := d.index[e.From], d.index[e.To]
+	if from == nil || to == nil {
+		return nil
+	}
+
+	if e.Kind == EdgeExclusive {
+		left, right := from, to
+		if right.X < left.X {
+			left, right = right, left
+		}
+		if left.Y == right.Y && left.X+left.Width <= right.X {
+			y := left.Y + left.IconHeight/2
+			return []image.Point{{left.X + left.Width, y}, {right.X, y}}
+		}
+		return []image.Point{from.Center(), to.Center()}
+	}
+
+	start := image.Point{from.X + from.Width/2, from.Y + from.Height}
+	end := image.Point{to.X + to.Width/2, to.Y}
+	if start.X == end.X {
+		return []image.Point{start, end}
+	}
+	midY := (start.Y + end.Y) / 2
+	return []image.Point{start, {start.X, midY}, {end.X, midY}, end}
+}
+
+// Center returns the node center point
+func (n *Node) Center() image.Point {
+	return image.Point{n.X + n.Width/2, n.Y + n.Height/2}
+}
+
+// IconRect returns the icon area at the top of the node
+func (n *Node) IconRect() image.Rectangle {
+	x := n.X + (n.Width-n.IconWidth)/2
+	y := n.Y + 4
+	return image.Rect(x, y, x+n.IconWidth, y+n.IconHeight)
+}
+
+// LabelLines wraps the label to the node width (at most two lines, truncated)
+func (n *Node) LabelLines() []string {
+	maxChars := (n.Width - 4) / glyphAdvance
+	return wrapText(n.Label, maxChars, maxLabelLines)
+}
+
+// wrapText breaks text on spaces into lines of at most maxChars runes
+func wrapText(text string, maxChars, maxLines int) []string {
+	if maxChars < 1 {
+		return nil
+	}
+
+	lines := make([]string, 0, maxLines)
+	current := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if len([]rune(candidate)) <= maxChars {
+			current = candidate
+			continue
+		}
+		if current != "" {
+			lines = append(lines, current)
+		}
+		current = word
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+
+	for i, line := range lines {
+		if len([]rune(line)) > maxChars {
+			lines[i] = truncate(line, maxChars)
+		}
+	}
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] = truncate(lines[maxLines-1]+"...", maxChars)
+	}
+	return lines
+}
+
+// truncate shortens s to maxChars runes, ending with "..." when cut
+func truncate(s string, maxChars int) string {
+	runes := []rune(s)
+	if len(runes) <= maxChars {
+		return s
+	}
+	if maxChars <= 3 {
+		return string(runes[:maxChars])
+	}
+	return string(runes[:maxChars-3]) + "..."
+}
@@ -0,0 +1,210 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/texture"
+)
+
+// Shared palette for PNG and SVG output
+var (
+	backgroundColor  = color.RGBA{28, 30, 36, 255}
+	nodeFillColor    = color.RGBA{52, 56, 66, 255}
+	nodeBorderColor  = color.RGBA{150, 155, 170, 255}
+	iconAreaColor    = color.RGBA{40, 43, 50, 255}
+	textColor        = color.RGBA{230, 230, 230, 255}
+	prereqColor      = color.RGBA{200, 200, 200, 255}
+	alternativeColor = color.RGBA{210, 190, 110, 255}
+	exclusiveColor   = color.RGBA{220, 60, 60, 255}
+)
+
+// Line styles in unscaled pixels
+const (
+	lineWidth   = 2
+	dashLength  = 8
+	gapLength   = 5
+	markerSize  = 6
+	maxPNGScale = 8
+)
+
+// WritePNG rasterizes the diagram as a PNG; scale multiplies every dimension
+func WritePNG(w io.Writer, d *Diagram, scale int) error {
+	if scale < 1 || scale > maxPNGScale {
+		return fmt.Errorf("scale must be between 1 and %d", maxPNGScale)
+	}
+
+	r := &rasterizer{
+		img:   image.NewRGBA(image.Rect(0, 0, d.Width*scale, d.Height*scale)),
+		scale: scale,
+	}
+	r.fillRect(image.Rect(0, 0, d.Width, d.Height), backgroundColor)
+
+	if d.Title != "" {
+		r.text(d.Title, diagramMargin, diagramMargin/2, 2, textColor)
+	}
+
+	for _, e := range d.Edges {
+		r.edge(d, e)
+	}
+	for _, n := range d.Nodes {
+		r.node(n)
+	}
+
+	if err := png.Encode(w, r.img); err != nil {
+		return fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	return nil
+}
+
+// rasterizer draws in unscaled diagram coordinates onto a scaled image
+type rasterizer struct {
+	img   *image.RGBA
+	scale int
+}
+
+// node draws a node box, its icon and label
+func (r *rasterizer) node(n *Node) {
+	box := image.Rect(n.X, n.Y, n.X+n.Width, n.Y+n.Height)
+	r.fillRect(box, nodeFillColor)
+	r.strokeRect(box, nodeBorderColor)
+
+	iconRect := n.IconRect()
+	if n.Icon != nil {
+		r.image(n.Icon, iconRect)
+	} else {
+		r.fillRect(iconRect, iconAreaColor)
+	}
+
+	y := iconRect.Max.Y + 4
+	for _, line := range n.LabelLines() {
+		x := n.X + (n.Width-textWidth(line))/2
+		r.text(line, x, y, 1, textColor)
+		y += lineHeight
+	}
+}
+
+// edge draws a connection line and, for exclusive pairs, the midpoint marker
+func (r *rasterizer) edge(d *Diagram, e Edge) {
+	points := d.EdgePoints(e)
+	if len(points) < 2 {
+		return
+	}
+
+	c := prereqColor
+	dashed := false
+	switch e.Kind {
+	case EdgeAlternative:
+		c, dashed = alternativeColor, true
+	case EdgeExclusive:
+		c = exclusiveColor
+	}
+
+	travelled := 0.0
+	for i := 1; i < len(points); i++ {
+		travelled = r.line(points[i-1], points[i], c, dashed, travelled)
+	}
+
+	if e.Kind == EdgeExclusive {
+		mid := image.Point{(points[0].X + points[len(points)-1].X) / 2, (points[0].Y + points[len(points)-1].Y) / 2}
+		r.marker(mid)
+	}
+}
+
+// fillRect fills a rectangle given in diagram coordinates
+func (r *rasterizer) fillRect(rect image.Rectangle, c color.RGBA) {
+	scaled := image.Rect(rect.Min.X*r.scale, rect.Min.Y*r.scale, rect.Max.X*r.scale, rect.Max.Y*r.scale)
+	draw.Draw(r.img, scaled, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// strokeRect draws a 1px (scaled) rectangle outline
+func (r *rasterizer) strokeRect(rect image.Rectangle, c color.RGBA) {
+	s := r.scale
+	outer := image.Rect(rect.Min.X*s, rect.Min.Y*s, rect.Max.X*s, rect.Max.Y*s)
+	u := image.NewUniform(c)
+	draw.Draw(r.img, image.Rect(outer.Min.X, outer.Min.Y, outer.Max.X, outer.Min.Y+s), u, image.Point{}, draw.Src)
+	draw.Draw(r.img, image.Rect(outer.Min.X, outer.Max.Y-s, outer.Max.X, outer.Max.Y), u, image.Point{}, draw.Src)
+	draw.Draw(r.img, image.Rect(outer.Min.X, outer.Min.Y, outer.Min.X+s, outer.Max.Y), u, image.Point{}, draw.Src)
+	draw.Draw(r.img, image.Rect(outer.Max.X-s, outer.Min.Y, outer.Max.X, outer.Max.Y), u, image.Point{}, draw.Src)
+}
+
+// line draws a thick (optionally dashed) segment and returns the distance
+// travelled so dash phase continues across polyline segments
+func (r *rasterizer) line(a, b image.Point, c color.RGBA, dashed bool, travelled float64) float64 {
+	s := float64(r.scale)
+	ax, ay := float64(a.X)*s, float64(a.Y)*s
+	bx, by := float64(b.X)*s, float64(b.Y)*s
+	length := math.Hypot(bx-ax, by-ay)
+	half := lineWidth * r.scale / 2
+	period := float64((dashLength + gapLength) * r.scale)
+
+	for step := 0.0; step <= length; step++ {
+		if dashed && math.Mod(travelled+step, period) >= float64(dashLength*r.scale) {
+			continue
+		}
+		t := 0.0
+		if length > 0 {
+			t = step / length
+		}
+		x := int(math.Round(ax + (bx-ax)*t))
+		y := int(math.Round(ay + (by-ay)*t))
+		draw.Draw(r.img, image.Rect(x-half, y-half, x+half+r.scale%2, y+half+r.scale%2), image.NewUniform(c), image.Point{}, draw.Src)
+	}
+
+	return travelled + length
+}
+
+// marker draws the mutually-exclusive marker: a red disc with a white cross
+func (r *rasterizer) marker(center image.Point) {
+	s := r.scale
+	cx, cy := center.X*s, center.Y*s
+	radius := markerSize * s
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if x*x+y*y <= radius*radius {
+				r.img.SetRGBA(cx+x, cy+y, exclusiveColor)
+			}
+		}
+	}
+
+	arm := radius / 2
+	for i := -arm; i <= arm; i++ {
+		for t := 0; t < s; t++ {
+			r.img.SetRGBA(cx+i+t, cy+i, textColor)
+			r.img.SetRGBA(cx+i+t, cy-i, textColor)
+		}
+	}
+}
+
+// text draws a string with the bitmap font; size multiplies the glyph pixels
+func (r *rasterizer) text(s string, x, y, size int, c color.RGBA) {
+	px := r.scale * size
+	cursor := x * r.scale
+	top := y * r.scale
+	for _, ch := range s {
+		g := glyph(ch)
+		for row := 0; row < glyphHeight; row++ {
+			for col := 0; col < glyphWidth; col++ {
+				if g[row]&(1<<(glyphWidth-1-col)) == 0 {
+					continue
+				}
+				rect := image.Rect(cursor+col*px, top+row*px, cursor+(col+1)*px, top+(row+1)*px)
+				draw.Draw(r.img, rect, image.NewUniform(c), image.Point{}, draw.Src)
+			}
+		}
+		cursor += glyphAdvance * px
+	}
+}
+
+// image scales an icon into a rectangle (aspect preserved) and blends it
+func (r *rasterizer) image(img image.Image, rect image.Rectangle) {
+	s := r.scale
+	fitted := texture.Fit(img, rect.Dx()*s, rect.Dy()*s)
+	dst := image.Rect(rect.Min.X*s, rect.Min.Y*s, rect.Max.X*s, rect.Max.Y*s)
+	draw.Draw(r.img, dst, fitted, image.Point{}, draw.Over)
+}
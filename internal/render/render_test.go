@@ -0,0 +1,190 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+func testFocusTree() *domain.FocusTree {
+	tree := domain.NewFocusTree("TST")
+
+	root := domain.NewFocus("TST_root", 5, 0)
+	root.Icon = "GFX_goal_generic"
+
+	left := domain.NewFocus("TST_left", -2, 1)
+	left.RelativePositionID = "TST_root"
+	left.Prerequisites = [][]string{{"TST_root"}}
+	left.MutuallyExclusive = []string{"TST_right"}
+
+	right := domain.NewFocus("TST_right", 2, 1)
+	right.RelativePositionID = "TST_root"
+	right.Prerequisites = [][]string{{"TST_root"}}
+	right.MutuallyExclusive = []string{"TST_left"}
+
+	end := domain.NewFocus("TST_end", 5, 2)
+	end.Prerequisites = [][]string{{"TST_left", "TST_right"}, {"TST_missing"}}
+
+	for _, f := range []*domain.Focus{root, left, right, end} {
+		tree.AddFocus(f)
+	}
+	return tree
+}
+
+func TestNewFocusDiagram(t *testing.T) {
+	icon := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	d := NewFocusDiagram(testFocusTree(), Options{
+		Localize: func(key string) string { return "Name " + key },
+		Icon: func(key string) image.Image {
+			if key == "GFX_goal_generic" {
+				return icon
+			}
+			return nil
+		},
+	})
+
+	if len(d.Nodes) != 4 {
+		t.Fatalf("Expected 4 nodes, got %d", len(d.Nodes))
+	}
+
+	counts := make(map[EdgeKind]int)
+	for _, e := range d.Edges {
+		counts[e.Kind]++
+	}
+	if counts[EdgePrerequisite] != 2 || counts[EdgeAlternative] != 2 || counts[EdgeExclusive] != 1 {
+		t.Errorf("Unexpected edge counts: %v", counts)
+	}
+
+	root, left, right := d.Node("TST_root"), d.Node("TST_left"), d.Node("TST_right")
+	if root.Icon != icon || left.Icon != nil {
+		t.Error("Icons not resolved by sprite name")
+	}
+	if root.Label != "Name TST_root" {
+		t.Errorf("Expected localized label, got %q", root.Label)
+	}
+
+	// Relative positions are resolved: left/right are 4 cells apart around root
+	if right.X-left.X != 4*focusStyle.cellWidth {
+		t.Errorf("Expected left/right 4 cells apart, got %d px", right.X-left.X)
+	}
+	if left.X != diagramMargin || root.Y != diagramMargin {
+		t.Errorf("Expected diagram normalized to margin, got left.X=%d root.Y=%d", left.X, root.Y)
+	}
+	if d.Width != right.X+right.Width+diagramMargin {
+		t.Errorf("Unexpected width %d", d.Width)
+	}
+}
+
+func TestNewTechDiagram(t *testing.T) {
+	a := domain.NewTechnology("tech_a", 0, 0, "infantry_folder")
+	a.Paths = []domain.TechPath{{LeadsToTech: "tech_b"}, {LeadsToTech: "tech_unknown"}}
+	a.XOR = []string{"tech_c"}
+	b := domain.NewTechnology("tech_b", 0, 2, "infantry_folder")
+	c := domain.NewTechnology("tech_c", 2, 0, "infantry_folder")
+	c.XOR = []string{"tech_a"}
+
+	d := NewTechDiagram([]*domain.Technology{c, b, a}, Options{Title: "Infantry"})
+
+	if len(d.Nodes) != 3 || d.Nodes[0].ID != "tech_a" {
+		t.Fatalf("Expected 3 nodes sorted by ID, got %d", len(d.Nodes))
+	}
+	if len(d.Edges) != 2 {
+		t.Fatalf("Expected 2 edges (path + one exclusive), got %d", len(d.Edges))
+	}
+	if d.Node("tech_a").Y != diagramMargin+titleHeight {
+		t.Errorf("Expected title space above nodes, got y=%d", d.Node("tech_a").Y)
+	}
+
+	points := d.EdgePoints(Edge{From: "tech_a", To: "tech_c", Kind: EdgeExclusive})
+	if len(points) != 2 || points[0].Y != points[1].Y {
+		t.Errorf("Expected horizontal exclusive line between side-by-side techs, got %v", points)
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	lines := wrapText("Expand the Civilian Industry Programme", 12, 2)
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %v", lines)
+	}
+	if lines[0] != "Expand the" || !strings.HasSuffix(lines[1], "...") {
+		t.Errorf("Unexpected wrap: %q", lines)
+	}
+	for _, line := range lines {
+		if len(line) > 12 {
+			t.Errorf("Line too long: %q", line)
+		}
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	icon := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for i := range icon.Pix {
+		icon.Pix[i] = 255
+	}
+	d := NewFocusDiagram(testFocusTree(), Options{
+		Icon: func(string) image.Image { return icon },
+	})
+
+	var buf bytes.Buffer
+	if err := WritePNG(&buf, d, 2); err != nil {
+		t.Fatalf("WritePNG failed: %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Output is not a PNG: %v", err)
+	}
+	if img.Bounds().Dx() != d.Width*2 || img.Bounds().Dy() != d.Height*2 {
+		t.Errorf("Expected %dx%d, got %v", d.Width*2, d.Height*2, img.Bounds())
+	}
+
+	// Icon area of the root node is white, background is untouched
+	// (the icon rect center at scale 2 is Min+Max)
+	iconRect := d.Node("TST_root").IconRect()
+	cx, cy := iconRect.Min.X+iconRect.Max.X, iconRect.Min.Y+iconRect.Max.Y
+	if c := color.RGBAModel.Convert(img.At(cx, cy)).(color.RGBA); c != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("Expected white icon pixel, got %v", c)
+	}
+	if c := color.RGBAModel.Convert(img.At(1, 1)).(color.RGBA); c != backgroundColor {
+		t.Errorf("Expected background color, got %v", c)
+	}
+
+	if err := WritePNG(&buf, d, 0); err == nil {
+		t.Error("Expected error for scale 0")
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	d := NewFocusDiagram(testFocusTree(), Options{
+		Localize: func(key string) string { return key + " & <co>" },
+		Icon: func(key string) image.Image {
+			return image.NewNRGBA(image.Rect(0, 0, 2, 2))
+		},
+	})
+
+	var buf bytes.Buffer
+	if err := WriteSVG(&buf, d); err != nil {
+		t.Fatalf("WriteSVG failed: %v", err)
+	}
+	svg := buf.String()
+
+	checks := []string{
+		`<svg xmlns="http://www.w3.org/2000/svg"`,
+		`<g id="TST_root">`,
+		`data-from="TST_root" data-to="TST_left"`,
+		`stroke-dasharray=`,
+		`class="exclusive"`,
+		`href="data:image/png;base64,`,
+		`TST_end &amp; &lt;co&gt;`,
+	}
+	for _, want := range checks {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package render
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// WriteSVG writes the diagram as a standalone SVG document
+// Icons are embedded as base64 PNG data URIs
+func WriteSVG(w io.Writer, d *Diagram) error {
+	var sb strings.Builder
+
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n",
+		d.Width, d.Height, d.Width, d.Height))
+	sb.WriteString(fmt.Sprintf(`<rect width="100%%" height="100%%" fill="%s"/>`+"\n", svgColor(backgroundColor)))
+
+	if d.Title != "" {
+		sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-size="18" fill="%s">%s</text>`+"\n",
+			diagramMargin, diagramMargin/2+14, svgColor(textColor), escape(d.Title)))
+	}
+
+	sb.WriteString(`<g class="edges" fill="none">` + "\n")
+	for _, e := range d.Edges {
+		writeSVGEdge(&sb, d, e)
+	}
+	sb.WriteString("</g>\n")
+
+	sb.WriteString(`<g class="nodes">` + "\n")
+	for _, n := range d.Nodes {
+		if err := writeSVGNode(&sb, n); err != nil {
+			return err
+		}
+	}
+	sb.WriteString("</g>\n</svg>\n")
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("failed to write SVG: %w", err)
+	}
+	return nil
+}
+
+// writeSVGEdge writes a polyline and, for exclusive pairs, the midpoint marker
+func writeSVGEdge(sb *strings.Builder, d *Diagram, e Edge) {
+	points := d.EdgePoints(e)
+	if len(points) < 2 {
+		return
+	}
+
+	coords := make([]string, len(points))
+	for i, p := range points {
+		coords[i] = fmt.Sprintf("%d,%d", p.X, p.Y)
+	}
+
+	c := prereqColor
+	extra := ""
+	switch e.Kind {
+	case EdgeAlternative:
+		c = alternativeColor
+		extra = fmt.Sprintf(` stroke-dasharray="%d %d"`, dashLength, gapLength)
+	case EdgeExclusive:
+		c = exclusiveColor
+	}
+
+	sb.WriteString(fmt.Sprintf(`<polyline data-from="%s" data-to="%s" points="%s" stroke="%s" stroke-width="%d"%s/>`+"\n",
+		escape(e.From), escape(e.To), strings.Join(coords, " "), svgColor(c), lineWidth, extra))
+
+	if e.Kind == EdgeExclusive {
+		first, last := points[0], points[len(points)-1]
+		mx, my := (first.X+last.X)/2, (first.Y+last.Y)/2
+		arm := markerSize / 2
+		sb.WriteString(fmt.Sprintf(`<circle class="exclusive" cx="%d" cy="%d" r="%d" fill="%s"/>`+"\n",
+			mx, my, markerSize, svgColor(exclusiveColor)))
+		sb.WriteString(fmt.Sprintf(`<path d="M%d %dL%d %dM%d %dL%d %d" stroke="%s" stroke-width="1.5"/>`+"\n",
+			mx-arm, my-arm, mx+arm, my+arm, mx-arm, my+arm, mx+arm, my-arm, svgColor(textColor)))
+	}
+}
+
+// writeSVGNode writes a node group: box, icon and label lines
+func writeSVGNode(sb *strings.Builder, n *Node) error {
+	sb.WriteString(fmt.Sprintf(`<g id="%s">`+"\n", escape(n.ID)))
+	sb.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="%s"/>`+"\n",
+		n.X, n.Y, n.Width, n.Height, svgColor(nodeFillColor), svgColor(nodeBorderColor)))
+
+	icon := n.IconRect()
+	if n.Icon != nil {
+		uri, err := pngDataURI(n.Icon)
+		if err != nil {
+			return fmt.Errorf("failed to embed icon for %s: %w", n.ID, err)
+		}
+		sb.WriteString(fmt.Sprintf(`<image x="%d" y="%d" width="%d" height="%d" href="%s"/>`+"\n",
+			icon.Min.X, icon.Min.Y, icon.Dx(), icon.Dy(), uri))
+	} else {
+		sb.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+			icon.Min.X, icon.Min.Y, icon.Dx(), icon.Dy(), svgColor(iconAreaColor)))
+	}
+
+	y := icon.Max.Y + 4 + glyphHeight
+	for _, line := range n.LabelLines() {
+		sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-size="10" text-anchor="middle" fill="%s">%s</text>`+"\n",
+			n.X+n.Width/2, y, svgColor(textColor), escape(line)))
+		y += lineHeight
+	}
+
+	sb.WriteString("</g>\n")
+	return nil
+}
+
+// pngDataURI encodes an image as a data:image/png;base64 URI
+func pngDataURI(img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// svgColor formats a color as #rrggbb
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// escape escapes text for XML content and attributes
+func escape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,121 @@
+package serializer
+
+import (
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// DecisionWriter serializes decision and decision category files
+type DecisionWriter struct{}
+
+// NewDecisionWriter creates a new DecisionWriter
+func NewDecisionWriter() *DecisionWriter {
+	return &DecisionWriter{}
+}
+
+// Write serializes all category blocks of a decision file
+func (dw *DecisionWriter) Write(file *domain.DecisionFile) string {
+	var sb strings.Builder
+
+	for i, block := range file.Blocks {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(block.Category + " = {\n")
+		for j, decision := range block.Decisions {
+			if j > 0 {
+				sb.WriteString("\n")
+			}
+			dw.writeDecision(&sb, decision)
+		}
+		sb.WriteString("}\n")
+	}
+
+	return sb.String()
+}
+
+// WriteToFile writes a decision file, keeping the previous version as <path>.bak
+func (dw *DecisionWriter) WriteToFile(file *domain.DecisionFile, path string) error {
+	return writeWithBackup(path, dw.Write(file))
+}
+
+// WriteCategories serializes a decision category file
+func (dw *DecisionWriter) WriteCategories(file *domain.DecisionCategoryFile) string {
+	var sb strings.Builder
+
+	for i, category := range file.Categories {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(category.ID + " = {\n")
+		writeScalars(&sb, 1, []struct{ key, value string }{
+			{"icon", category.Icon},
+			{"picture", category.Picture},
+			{"priority", category.Priority},
+		})
+		writeBlocks(&sb, 1, []struct{ key, value string }{
+			{"allowed", category.Allowed},
+			{"visible", category.Visible},
+		})
+		for _, field := range category.Extra {
+			writeField(&sb, field, 1)
+		}
+		sb.WriteString("}\n")
+	}
+
+	return sb.String()
+}
+
+// WriteCategoriesToFile writes a category file, keeping a .bak backup
+func (dw *DecisionWriter) WriteCategoriesToFile(file *domain.DecisionCategoryFile, path string) error {
+	return writeWithBackup(path, dw.WriteCategories(file))
+}
+
+// writeDecision writes a single decision block inside its category
+func (dw *DecisionWriter) writeDecision(sb *strings.Builder, decision *domain.Decision) {
+	sb.WriteString("\t" + decision.ID + " = {\n")
+
+	writeScalars(sb, 2, []struct{ key, value string }{
+		{"icon", decision.Icon},
+		{"cost", decision.Cost},
+		{"days_remove", decision.DaysRemove},
+		{"days_re_enable", decision.DaysReEnable},
+	})
+	if decision.FireOnlyOnce {
+		writeField(sb, domain.ScriptField{Key: "fire_only_once", Value: "yes"}, 2)
+	}
+	writeBlocks(sb, 2, []struct{ key, value string }{
+		{"allowed", decision.Allowed},
+		{"visible", decision.Visible},
+		{"available", decision.Available},
+	})
+	for _, field := range decision.Extra {
+		writeField(sb, field, 2)
+	}
+	writeBlocks(sb, 2, []struct{ key, value string }{
+		{"complete_effect", decision.CompleteEffect},
+		{"remove_effect", decision.RemoveEffect},
+		{"ai_will_do", decision.AIWillDo},
+	})
+
+	sb.WriteString("\t}\n")
+}
+
+// writeScalars writes the non-empty scalar fields, quoting where needed
+func writeScalars(sb *strings.Builder, depth int, fields []struct{ key, value string }) {
+	for _, f := range fields {
+		if f.value != "" {
+			writeField(sb, domain.ScriptField{Key: f.key, Value: scalar(f.value)}, depth)
+		}
+	}
+}
+
+// writeBlocks writes the non-empty raw script fields
+func writeBlocks(sb *strings.Builder, depth int, fields []struct{ key, value string }) {
+	for _, f := range fields {
+		if f.value != "" {
+			writeField(sb, domain.ScriptField{Key: f.key, Value: f.value}, depth)
+		}
+	}
+}
@@ -24,3 +24,9 @@ func (fw *FocusWriter) WriteToFile(tree *domain.FocusTree, path string) error {
 	// TODO: Implement file writing with .bak backup
 	return nil
 }
+
+// WriteSource writes edited focus file source (see parser.PatchFocusPositions
+// and parser.PatchFocusLinks), keeping the previous version as <path>.bak
+func (fw *FocusWriter) WriteSource(path, source string) error {
+	return writeWithBackup(path, source)
+}
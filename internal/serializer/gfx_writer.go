@@ -0,0 +1,72 @@
+package serializer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// ShineOverlayTexture is the vanilla texture scrolled over focus icons
+const ShineOverlayTexture = "gfx/interface/goals/shine_overlay.dds"
+
+// GfxWriter serializes sprite declarations to .gfx format
+type GfxWriter struct{}
+
+// NewGfxWriter creates a new GfxWriter
+func NewGfxWriter() *GfxWriter {
+	return &GfxWriter{}
+}
+
+// WriteSprite serializes a spriteType block
+func (gw *GfxWriter) WriteSprite(sprite *domain.Sprite) string {
+	var sb strings.Builder
+
+	sb.WriteString("\t" + sprite.Kind.String() + " = {\n")
+	sb.WriteString(fmt.Sprintf("\t\tname = %q\n", sprite.Name))
+	sb.WriteString(fmt.Sprintf("\t\ttexturefile = %q\n", sprite.TextureFile))
+	if sprite.FrameCount() > 1 {
+		sb.WriteString(fmt.Sprintf("\t\tnoOfFrames = %d\n", sprite.FrameCount()))
+	}
+	if sprite.Kind == domain.SpriteKindCorneredTile {
+		sb.WriteString(fmt.Sprintf("\t\tsize = { x = %d y = %d }\n", sprite.Size.X, sprite.Size.Y))
+		sb.WriteString(fmt.Sprintf("\t\tbordersize = { x = %d y = %d }\n", sprite.BorderSize.X, sprite.BorderSize.Y))
+	}
+	sb.WriteString("\t}\n")
+
+	return sb.String()
+}
+
+// WriteShineSprite serializes the "<name>_shine" sprite the focus tree uses
+// to animate a completed focus (two scrolling shine_overlay passes)
+func (gw *GfxWriter) WriteShineSprite(sprite *domain.Sprite) string {
+	var sb strings.Builder
+
+	sb.WriteString("\tSpriteType = {\n")
+	sb.WriteString(fmt.Sprintf("\t\tname = %q\n", sprite.Name+"_shine"))
+	sb.WriteString(fmt.Sprintf("\t\ttexturefile = %q\n", sprite.TextureFile))
+	sb.WriteString("\t\teffectFile = \"gfx/FX/buttonstate.lua\"\n")
+	for _, rotation := range []string{"-90.0", "90.0"} {
+		sb.WriteString("\t\tanimation = {\n")
+		sb.WriteString(fmt.Sprintf("\t\t\tanimationmaskfile = %q\n", sprite.TextureFile))
+		sb.WriteString(fmt.Sprintf("\t\t\tanimationtexturefile = %q\n", ShineOverlayTexture))
+		sb.WriteString("\t\t\tanimationrotation = " + rotation + "\n")
+		sb.WriteString("\t\t\tanimationlooping = no\n")
+		sb.WriteString("\t\t\tanimationtime = 0.75\n")
+		sb.WriteString("\t\t\tanimationdelay = 0\n")
+		sb.WriteString("\t\t\tanimationblendmode = \"add\"\n")
+		sb.WriteString("\t\t\tanimationtype = \"scrolling\"\n")
+		sb.WriteString("\t\t\tanimationrotationoffset = { x = 0.0 y = 0.0 }\n")
+		sb.WriteString("\t\t\tanimationtexturescale = { x = 1.0 y = 1.0 }\n")
+		sb.WriteString("\t\t}\n")
+	}
+	sb.WriteString("\t\tlegacy_lazy_load = no\n")
+	sb.WriteString("\t}\n")
+
+	return sb.String()
+}
+
+// WriteSource writes the source of a .gfx file (.bak backup)
+func (gw *GfxWriter) WriteSource(path, source string) error {
+	return writeWithBackup(path, source)
+}
@@ -0,0 +1,139 @@
+package serializer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// IdeaWriter serializes idea files to Paradox script format
+type IdeaWriter struct{}
+
+// NewIdeaWriter creates a new IdeaWriter
+func NewIdeaWriter() *IdeaWriter {
+	return &IdeaWriter{}
+}
+
+// Write serializes all categories of an idea file
+func (iw *IdeaWriter) Write(file *domain.IdeaFile) string {
+	var sb strings.Builder
+
+	sb.WriteString("ideas = {\n")
+	for i, category := range file.Categories {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		iw.writeCategory(&sb, category)
+	}
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// WriteToFile writes an idea file, keeping the previous version as <path>.bak
+func (iw *IdeaWriter) WriteToFile(file *domain.IdeaFile, path string) error {
+	return writeWithBackup(path, iw.Write(file))
+}
+
+// writeCategory writes one category block
+func (iw *IdeaWriter) writeCategory(sb *strings.Builder, category *domain.IdeaCategory) {
+	sb.WriteString("\t" + category.Name + " = {\n")
+	if category.Law {
+		sb.WriteString("\t\tlaw = yes\n")
+	}
+	if category.Designer {
+		sb.WriteString("\t\tdesigner = yes\n")
+	}
+	for _, field := range category.Properties {
+		writeField(sb, field, 2)
+	}
+
+	for _, idea := range category.Ideas {
+		sb.WriteString("\n")
+		iw.writeIdea(sb, idea, 2)
+	}
+	sb.WriteString("\t}\n")
+}
+
+// writeIdea writes a single idea block at the given indentation depth
+func (iw *IdeaWriter) writeIdea(sb *strings.Builder, idea *domain.Idea, depth int) {
+	indent := strings.Repeat("\t", depth)
+	inner := depth + 1
+
+	sb.WriteString(indent + idea.ID + " = {\n")
+
+	writeScalars(sb, inner, []struct{ key, value string }{
+		{"name", idea.Name},
+		{"picture", idea.Picture},
+		{"cost", idea.Cost},
+		{"removal_cost", idea.RemovalCost},
+	})
+	if idea.Default {
+		writeField(sb, domain.ScriptField{Key: "default", Value: "yes"}, inner)
+	}
+
+	writeBlocks(sb, inner, []struct{ key, value string }{
+		{"allowed", idea.Allowed},
+		{"allowed_civil_war", idea.AllowedCivilWar},
+		{"available", idea.Available},
+		{"visible", idea.Visible},
+	})
+
+	if len(idea.Modifiers) > 0 {
+		sb.WriteString(strings.Repeat("\t", inner) + "modifier = {\n")
+		for _, m := range idea.Modifiers {
+			writeField(sb, domain.ScriptField{Key: m.Key, Value: m.Value}, inner+1)
+		}
+		sb.WriteString(strings.Repeat("\t", inner) + "}\n")
+	}
+
+	for _, field := range idea.Extra {
+		writeField(sb, field, inner)
+	}
+
+	sb.WriteString(indent + "}\n")
+}
+
+// writeField writes key = value, re-indenting multi-line block values
+func writeField(sb *strings.Builder, field domain.ScriptField, depth int) {
+	indent := strings.Repeat("\t", depth)
+	operator := field.Operator
+	if operator == "" {
+		operator = "="
+	}
+	value := strings.ReplaceAll(field.Value, "\n", "\n"+indent)
+	sb.WriteString(fmt.Sprintf("%s%s %s %s\n", indent, field.Key, operator, value))
+}
+
+// scalar quotes values that are not plain identifiers or numbers
+func scalar(value string) string {
+	for _, r := range value {
+		plain := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+			r == '_' || r == '.' || r == '-' || r == '@'
+		if !plain {
+			return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+		}
+	}
+	return value
+}
+
+// writeWithBackup writes content to path, copying an existing file to <path>.bak first
+func writeWithBackup(path, content string) error {
+	if existing, err := os.ReadFile(path); err == nil {
+		if err := os.WriteFile(path+".bak", existing, 0644); err != nil {
+			return fmt.Errorf("failed to write backup: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package serializer
+
+// LocalizationWriter writes HOI4 localisation (.yml) files
+type LocalizationWriter struct{}
+
+// NewLocalizationWriter creates a new LocalizationWriter
+func NewLocalizationWriter() *LocalizationWriter {
+	return &LocalizationWriter{}
+}
+
+// WriteSource writes edited localisation source (see
+// parser.PatchLocalization), keeping the previous version as <path>.bak
+func (lw *LocalizationWriter) WriteSource(path, source string) error {
+	return writeWithBackup(path, source)
+}
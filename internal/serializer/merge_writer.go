@@ -0,0 +1,15 @@
+package serializer
+
+// MergeWriter writes the result of a three-way merge
+type MergeWriter struct{}
+
+// NewMergeWriter creates a new MergeWriter
+func NewMergeWriter() *MergeWriter {
+	return &MergeWriter{}
+}
+
+// WriteSource writes a merged file (see parser.MergeSource), keeping the
+// previous version as <path>.bak
+func (mw *MergeWriter) WriteSource(path, source string) error {
+	return writeWithBackup(path, source)
+}
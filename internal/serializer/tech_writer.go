@@ -23,3 +23,9 @@ func (tw *TechWriter) WriteToFile(tree *domain.TechnologyTree, path string) erro
 	// TODO: Implement file writing with .bak backup
 	return nil
 }
+
+// WriteSource writes edited technology file source (see
+// parser.PatchTechPositions), keeping the previous version as <path>.bak
+func (tw *TechWriter) WriteSource(path, source string) error {
+	return writeWithBackup(path, source)
+}
@@ -0,0 +1,143 @@
+package texture
+
+import "encoding/binary"
+
+// expand565 converts an RGB565 color to 8-bit channels
+func expand565(c uint16) [3]uint8 {
+	r := uint8(c>>11) & 0x1F
+	g := uint8(c>>5) & 0x3F
+	b := uint8(c) & 0x1F
+	return [3]uint8{r<<3 | r>>2, g<<2 | g>>4, b<<3 | b>>2}
+}
+
+// decodeBC1 decodes a BC1 (DXT1) color block.
+// allowTransparent enables the 3-color + transparent mode used when c0 <= c1;
+// BC2/BC3 color blocks always use 4-color mode.
+func decodeBC1(block []byte, out *[16][4]uint8, allowTransparent bool) {
+	c0 := binary.LittleEndian.Uint16(block[0:])
+	c1 := binary.LittleEndian.Uint16(block[2:])
+	indices := binary.LittleEndian.Uint32(block[4:])
+
+	e0 := expand565(c0)
+	e1 := expand565(c1)
+
+	var palette [4][4]uint8
+	palette[0] = [4]uint8{e0[0], e0[1], e0[2], 255}
+	palette[1] = [4]uint8{e1[0], e1[1], e1[2], 255}
+
+	if c0 > c1 || !allowTransparent {
+		for ch := 0; ch < 3; ch++ {
+			palette[2][ch] = uint8((2*uint16(e0[ch]) + uint16(e1[ch]) + 1) / 3)
+			palette[3][ch] = uint8((uint16(e0[ch]) + 2*uint16(e1[ch]) + 1) / 3)
+		}
+		palette[2][3] = 255
+		palette[3][3] = 255
+	} else {
+		for ch := 0; ch < 3; ch++ {
+			palette[2][ch] = uint8((uint16(e0[ch]) + uint16(e1[ch])) / 2)
+		}
+		palette[2][3] = 255
+		palette[3] = [4]uint8{0, 0, 0, 0}
+	}
+
+	for i := 0; i < 16; i++ {
+		out[i] = palette[(indices>>(2*i))&0x3]
+	}
+}
+
+// decodeBC2 decodes a BC2 (DXT3) block: explicit 4-bit alpha + BC1 color
+func decodeBC2(block []byte, out *[16][4]uint8) {
+	decodeBC1(block[8:], out, false)
+
+	alpha := binary.LittleEndian.Uint64(block[0:])
+	for i := 0; i < 16; i++ {
+		a := uint8(alpha>>(4*i)) & 0xF
+		out[i][3] = a<<4 | a
+	}
+}
+
+// decodeBC3 decodes a BC3 (DXT5) block: interpolated alpha + BC1 color
+func decodeBC3(block []byte, out *[16][4]uint8) {
+	decodeBC1(block[8:], out, false)
+
+	var alpha [16]uint8
+	decodeAlphaBlock(block[0:8], &alpha, false)
+	for i := 0; i < 16; i++ {
+		out[i][3] = alpha[i]
+	}
+}
+
+// decodeBC4 decodes a BC4 single-channel block as grayscale
+func decodeBC4(block []byte, out *[16][4]uint8, signed bool) {
+	var values [16]uint8
+	decodeAlphaBlock(block[0:8], &values, signed)
+	for i := 0; i < 16; i++ {
+		out[i] = [4]uint8{values[i], values[i], values[i], 255}
+	}
+}
+
+// decodeBC5 decodes a BC5 two-channel block into red and green
+func decodeBC5(block []byte, out *[16][4]uint8, signed bool) {
+	var red, green [16]uint8
+	decodeAlphaBlock(block[0:8], &red, signed)
+	decodeAlphaBlock(block[8:16], &green, signed)
+	for i := 0; i < 16; i++ {
+		out[i] = [4]uint8{red[i], green[i], 0, 255}
+	}
+}
+
+// decodeAlphaBlock decodes the 8-byte interpolated block shared by BC3 alpha,
+// BC4 and BC5. Signed (SNORM) values are remapped from [-127,127] to [0,255].
+func decodeAlphaBlock(block []byte, out *[16]uint8, signed bool) {
+	var a0, a1 int
+	if signed {
+		a0 = clampSNorm(int8(block[0]))
+		a1 = clampSNorm(int8(block[1]))
+	} else {
+		a0 = int(block[0])
+		a1 = int(block[1])
+	}
+
+	var palette [8]int
+	palette[0] = a0
+	palette[1] = a1
+
+	minValue, maxValue := 0, 255
+	if signed {
+		minValue, maxValue = -127, 127
+	}
+
+	if a0 > a1 {
+		for i := 1; i <= 6; i++ {
+			palette[i+1] = ((7-i)*a0 + i*a1) / 7
+		}
+	} else {
+		for i := 1; i <= 4; i++ {
+			palette[i+1] = ((5-i)*a0 + i*a1) / 5
+		}
+		palette[6] = minValue
+		palette[7] = maxValue
+	}
+
+	// 48 bits of 3-bit indices follow the two endpoints
+	var indices uint64
+	for i := 0; i < 6; i++ {
+		indices |= uint64(block[2+i]) << (8 * i)
+	}
+
+	for i := 0; i < 16; i++ {
+		v := palette[(indices>>(3*i))&0x7]
+		if signed {
+			v = (v + 127) * 255 / 254
+		}
+		out[i] = uint8(v)
+	}
+}
+
+// clampSNorm clamps -128 to -127 as required by the SNORM encoding
+func clampSNorm(v int8) int {
+	if v == -128 {
+		return -127
+	}
+	return int(v)
+}
@@ -0,0 +1,294 @@
+package texture
+
+import "math"
+
+// bc6hField describes a run of endpoint bits in a BC6H block.
+// Bits are read in order from bit `from` to bit `to` of component
+// (endpoint, channel); from > to marks the reversed fields of modes 13/14.
+type bc6hField struct {
+	endpoint int // 0..3 (w, x, y, z in the specification)
+	channel  int // 0 = R, 1 = G, 2 = B
+	from, to int
+}
+
+// bc6hMode describes one of the 14 BC6H block modes
+type bc6hMode struct {
+	regions     int
+	transformed bool
+	epBits      int
+	deltaBits   [3]int
+	fields      []bc6hField
+}
+
+// f is a shorthand for a BC6H field descriptor
+func f(endpoint, channel, hi, lo int) bc6hField {
+	return bc6hField{endpoint: endpoint, channel: channel, from: lo, to: hi}
+}
+
+// rf is a shorthand for a reversed BC6H field descriptor (modes 13 and 14)
+func rf(endpoint, channel, hi, lo int) bc6hField {
+	return bc6hField{endpoint: endpoint, channel: channel, from: hi, to: lo}
+}
+
+const (
+	chR = 0
+	chG = 1
+	chB = 2
+)
+
+// bc6hModes is keyed by the mode value as read from the block (2 or 5 bits)
+var bc6hModes = map[uint32]bc6hMode{
+	0x00: {2, true, 10, [3]int{5, 5, 5}, []bc6hField{
+		f(2, chG, 4, 4), f(2, chB, 4, 4), f(3, chB, 4, 4), f(0, chR, 9, 0), f(0, chG, 9, 0), f(0, chB, 9, 0),
+		f(1, chR, 4, 0), f(3, chG, 4, 4), f(2, chG, 3, 0), f(1, chG, 4, 0), f(3, chB, 0, 0), f(3, chG, 3, 0),
+		f(1, chB, 4, 0), f(3, chB, 1, 1), f(2, chB, 3, 0), f(2, chR, 4, 0), f(3, chB, 2, 2), f(3, chR, 4, 0),
+		f(3, chB, 3, 3),
+	}},
+	0x01: {2, true, 7, [3]int{6, 6, 6}, []bc6hField{
+		f(2, chG, 5, 5), f(3, chG, 4, 4), f(3, chG, 5, 5), f(0, chR, 6, 0), f(3, chB, 0, 0), f(3, chB, 1, 1),
+		f(2, chB, 4, 4), f(0, chG, 6, 0), f(2, chB, 5, 5), f(3, chB, 2, 2), f(2, chG, 4, 4), f(0, chB, 6, 0),
+		f(3, chB, 3, 3), f(3, chB, 5, 5), f(3, chB, 4, 4), f(1, chR, 5, 0), f(2, chG, 3, 0), f(1, chG, 5, 0),
+		f(3, chG, 3, 0), f(1, chB, 5, 0), f(2, chB, 3, 0), f(2, chR, 5, 0), f(3, chR, 5, 0),
+	}},
+	0x02: {2, true, 11, [3]int{5, 4, 4}, []bc6hField{
+		f(0, chR, 9, 0), f(0, chG, 9, 0), f(0, chB, 9, 0), f(1, chR, 4, 0), f(0, chR, 10, 10), f(2, chG, 3, 0),
+		f(1, chG, 3, 0), f(0, chG, 10, 10), f(3, chB, 0, 0), f(3, chG, 3, 0), f(1, chB, 3, 0), f(0, chB, 10, 10),
+		f(3, chB, 1, 1), f(2, chB, 3, 0), f(2, chR, 4, 0), f(3, chB, 2, 2), f(3, chR, 4, 0), f(3, chB, 3, 3),
+	}},
+	0x06: {2, true, 11, [3]int{4, 5, 4}, []bc6hField{
+		f(0, chR, 9, 0), f(0, chG, 9, 0), f(0, chB, 9, 0), f(1, chR, 3, 0), f(0, chR, 10, 10), f(3, chG, 4, 4),
+		f(2, chG, 3, 0), f(1, chG, 4, 0), f(0, chG, 10, 10), f(3, chG, 3, 0), f(1, chB, 3, 0), f(0, chB, 10, 10),
+		f(3, chB, 1, 1), f(2, chB, 3, 0), f(2, chR, 3, 0), f(3, chB, 0, 0), f(3, chB, 2, 2), f(3, chR, 3, 0),
+		f(2, chG, 4, 4), f(3, chB, 3, 3),
+	}},
+	0x0A: {2, true, 11, [3]int{4, 4, 5}, []bc6hField{
+		f(0, chR, 9, 0), f(0, chG, 9, 0), f(0, chB, 9, 0), f(1, chR, 3, 0), f(0, chR, 10, 10), f(2, chB, 4, 4),
+		f(2, chG, 3, 0), f(1, chG, 3, 0), f(0, chG, 10, 10), f(3, chB, 0, 0), f(3, chG, 3, 0), f(1, chB, 4, 0),
+		f(0, chB, 10, 10), f(2, chB, 3, 0), f(2, chR, 3, 0), f(3, chB, 1, 1), f(3, chB, 2, 2), f(3, chR, 3, 0),
+		f(3, chB, 4, 4), f(3, chB, 3, 3),
+	}},
+	0x0E: {2, true, 9, [3]int{5, 5, 5}, []bc6hField{
+		f(0, chR, 8, 0), f(2, chB, 4, 4), f(0, chG, 8, 0), f(2, chG, 4, 4), f(0, chB, 8, 0), f(3, chB, 4, 4),
+		f(1, chR, 4, 0), f(3, chG, 4, 4), f(2, chG, 3, 0), f(1, chG, 4, 0), f(3, chB, 0, 0), f(3, chG, 3, 0),
+		f(1, chB, 4, 0), f(3, chB, 1, 1), f(2, chB, 3, 0), f(2, chR, 4, 0), f(3, chB, 2, 2), f(3, chR, 4, 0),
+		f(3, chB, 3, 3),
+	}},
+	0x12: {2, true, 8, [3]int{6, 5, 5}, []bc6hField{
+		f(0, chR, 7, 0), f(3, chG, 4, 4), f(2, chB, 4, 4), f(0, chG, 7, 0), f(3, chB, 2, 2), f(2, chG, 4, 4),
+		f(0, chB, 7, 0), f(3, chB, 3, 3), f(3, chB, 4, 4), f(1, chR, 5, 0), f(2, chG, 3, 0), f(1, chG, 4, 0),
+		f(3, chB, 0, 0), f(3, chG, 3, 0), f(1, chB, 4, 0), f(3, chB, 1, 1), f(2, chB, 3, 0), f(2, chR, 5, 0),
+		f(3, chR, 5, 0),
+	}},
+	0x16: {2, true, 8, [3]int{5, 6, 5}, []bc6hField{
+		f(0, chR, 7, 0), f(3, chB, 0, 0), f(2, chB, 4, 4), f(0, chG, 7, 0), f(2, chG, 5, 5), f(2, chG, 4, 4),
+		f(0, chB, 7, 0), f(3, chG, 5, 5), f(3, chB, 4, 4), f(1, chR, 4, 0), f(3, chG, 4, 4), f(2, chG, 3, 0),
+		f(1, chG, 5, 0), f(3, chG, 3, 0), f(1, chB, 4, 0), f(3, chB, 1, 1), f(2, chB, 3, 0), f(2, chR, 4, 0),
+		f(3, chB, 2, 2), f(3, chR, 4, 0), f(3, chB, 3, 3),
+	}},
+	0x1A: {2, true, 8, [3]int{5, 5, 6}, []bc6hField{
+		f(0, chR, 7, 0), f(3, chB, 1, 1), f(2, chB, 4, 4), f(0, chG, 7, 0), f(2, chB, 5, 5), f(2, chG, 4, 4),
+		f(0, chB, 7, 0), f(3, chB, 5, 5), f(3, chB, 4, 4), f(1, chR, 4, 0), f(3, chG, 4, 4), f(2, chG, 3, 0),
+		f(1, chG, 4, 0), f(3, chB, 0, 0), f(3, chG, 3, 0), f(1, chB, 5, 0), f(2, chB, 3, 0), f(2, chR, 4, 0),
+		f(3, chB, 2, 2), f(3, chR, 4, 0), f(3, chB, 3, 3),
+	}},
+	0x1E: {2, false, 6, [3]int{6, 6, 6}, []bc6hField{
+		f(0, chR, 5, 0), f(3, chG, 4, 4), f(3, chB, 0, 0), f(3, chB, 1, 1), f(2, chB, 4, 4), f(0, chG, 5, 0),
+		f(2, chG, 5, 5), f(2, chB, 5, 5), f(3, chB, 2, 2), f(2, chG, 4, 4), f(0, chB, 5, 0), f(3, chG, 5, 5),
+		f(3, chB, 3, 3), f(3, chB, 5, 5), f(3, chB, 4, 4), f(1, chR, 5, 0), f(2, chG, 3, 0), f(1, chG, 5, 0),
+		f(3, chG, 3, 0), f(1, chB, 5, 0), f(2, chB, 3, 0), f(2, chR, 5, 0), f(3, chR, 5, 0),
+	}},
+	0x03: {1, false, 10, [3]int{10, 10, 10}, []bc6hField{
+		f(0, chR, 9, 0), f(0, chG, 9, 0), f(0, chB, 9, 0), f(1, chR, 9, 0), f(1, chG, 9, 0), f(1, chB, 9, 0),
+	}},
+	0x07: {1, true, 11, [3]int{9, 9, 9}, []bc6hField{
+		f(0, chR, 9, 0), f(0, chG, 9, 0), f(0, chB, 9, 0), f(1, chR, 8, 0), f(0, chR, 10, 10),
+		f(1, chG, 8, 0), f(0, chG, 10, 10), f(1, chB, 8, 0), f(0, chB, 10, 10),
+	}},
+	0x0B: {1, true, 12, [3]int{8, 8, 8}, []bc6hField{
+		f(0, chR, 9, 0), f(0, chG, 9, 0), f(0, chB, 9, 0), f(1, chR, 7, 0), rf(0, chR, 11, 10),
+		f(1, chG, 7, 0), rf(0, chG, 11, 10), f(1, chB, 7, 0), rf(0, chB, 11, 10),
+	}},
+	0x0F: {1, true, 16, [3]int{4, 4, 4}, []bc6hField{
+		f(0, chR, 9, 0), f(0, chG, 9, 0), f(0, chB, 9, 0), f(1, chR, 3, 0), rf(0, chR, 15, 10),
+		f(1, chG, 3, 0), rf(0, chG, 15, 10), f(1, chB, 3, 0), rf(0, chB, 15, 10),
+	}},
+}
+
+// decodeBC6H decodes a 16-byte BC6H (HDR) block, tone-clamping to 8 bits
+func decodeBC6H(block []byte, out *[16][4]uint8, signed bool) {
+	br := &bitReader{data: block}
+
+	modeValue := br.read(2)
+	if modeValue > 1 {
+		modeValue |= br.read(3) << 2
+	}
+
+	mode, ok := bc6hModes[modeValue]
+	if !ok {
+		// Reserved mode: opaque black
+		for i := range out {
+			out[i] = [4]uint8{0, 0, 0, 255}
+		}
+		return
+	}
+
+	var endpoints [4][3]int
+	for _, field := range mode.fields {
+		if field.from <= field.to {
+			for bit := field.from; bit <= field.to; bit++ {
+				endpoints[field.endpoint][field.channel] |= int(br.read(1)) << bit
+			}
+		} else {
+			for bit := field.from; bit >= field.to; bit-- {
+				endpoints[field.endpoint][field.channel] |= int(br.read(1)) << bit
+			}
+		}
+	}
+
+	partition := 0
+	if mode.regions == 2 {
+		partition = int(br.read(5))
+	}
+
+	numEndpoints := mode.regions * 2
+	mask := 1<<mode.epBits - 1
+
+	// Sign extension and delta transform
+	for ch := 0; ch < 3; ch++ {
+		if signed {
+			endpoints[0][ch] = signExtend(endpoints[0][ch], mode.epBits)
+		}
+		for e := 1; e < numEndpoints; e++ {
+			if mode.transformed {
+				delta := signExtend(endpoints[e][ch], mode.deltaBits[ch])
+				endpoints[e][ch] = (endpoints[0][ch] + delta) & mask
+			}
+			if signed {
+				endpoints[e][ch] = signExtend(endpoints[e][ch], mode.epBits)
+			}
+		}
+	}
+
+	// Unquantize endpoints
+	for e := 0; e < numEndpoints; e++ {
+		for ch := 0; ch < 3; ch++ {
+			endpoints[e][ch] = unquantizeBC6H(endpoints[e][ch], mode.epBits, signed)
+		}
+	}
+
+	indexBits := 4
+	subsets := 1
+	if mode.regions == 2 {
+		indexBits = 3
+		subsets = 2
+	}
+
+	for i := 0; i < 16; i++ {
+		bitsCount := indexBits
+		if isAnchor(subsets, partition, i) {
+			bitsCount--
+		}
+		index := int(br.read(bitsCount))
+
+		subset := partitionSubset(subsets, partition, i)
+		e0 := endpoints[subset*2]
+		e1 := endpoints[subset*2+1]
+
+		var pixel [4]uint8
+		for ch := 0; ch < 3; ch++ {
+			v := bcInterpolate(e0[ch], e1[ch], index, indexBits)
+			pixel[ch] = halfToUnorm8(finishUnquantizeBC6H(v, signed))
+		}
+		pixel[3] = 255
+		out[i] = pixel
+	}
+}
+
+// signExtend sign-extends an n-bit value
+func signExtend(v, n int) int {
+	shift := 32 - n
+	return int(int32(uint32(v)<<shift) >> shift)
+}
+
+// unquantizeBC6H expands an endpoint to the 16-bit interpolation range
+func unquantizeBC6H(v, bits int, signed bool) int {
+	if !signed {
+		switch {
+		case bits >= 15:
+			return v
+		case v == 0:
+			return 0
+		case v == 1<<bits-1:
+			return 0xFFFF
+		default:
+			return ((v << 16) + 0x8000) >> bits
+		}
+	}
+
+	if bits >= 16 {
+		return v
+	}
+
+	negative := v < 0
+	if negative {
+		v = -v
+	}
+
+	var unq int
+	switch {
+	case v == 0:
+		unq = 0
+	case v >= 1<<(bits-1)-1:
+		unq = 0x7FFF
+	default:
+		unq = ((v << 15) + 0x4000) >> (bits - 1)
+	}
+
+	if negative {
+		return -unq
+	}
+	return unq
+}
+
+// finishUnquantizeBC6H scales an interpolated value to half-float bits
+func finishUnquantizeBC6H(v int, signed bool) uint16 {
+	if !signed {
+		return uint16((v * 31) >> 6)
+	}
+	if v < 0 {
+		return 0x8000 | uint16(((-v)*31)>>5)
+	}
+	return uint16((v * 31) >> 5)
+}
+
+// halfToUnorm8 converts a half float to 8 bits, clamping to [0, 1]
+func halfToUnorm8(h uint16) uint8 {
+	value := halfToFloat(h)
+	if value <= 0 || math.IsNaN(float64(value)) {
+		return 0
+	}
+	if value >= 1 {
+		return 255
+	}
+	return uint8(value*255 + 0.5)
+}
+
+// halfToFloat converts IEEE 754 half-precision bits to float32
+func halfToFloat(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exponent := int(h>>10) & 0x1F
+	mantissa := uint32(h) & 0x3FF
+
+	switch exponent {
+	case 0:
+		if mantissa == 0 {
+			return math.Float32frombits(sign)
+		}
+		// Subnormal
+		return float32(math.Copysign(float64(mantissa)/1024.0/16384.0, float64(int32(sign)|1)))
+	case 0x1F:
+		return math.Float32frombits(sign | 0x7F800000 | mantissa<<13)
+	default:
+		return math.Float32frombits(sign | uint32(exponent-15+127)<<23 | mantissa<<13)
+	}
+}
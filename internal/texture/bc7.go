@@ -0,0 +1,292 @@
+package texture
+
+// bitReader reads little-endian bit fields from a 128-bit block
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+// read reads n bits (LSB first)
+func (br *bitReader) read(n int) uint32 {
+	var v uint32
+	for i := 0; i < n; i++ {
+		byteIndex := br.pos >> 3
+		bit := uint32(br.data[byteIndex]>>(br.pos&7)) & 1
+		v |= bit << i
+		br.pos++
+	}
+	return v
+}
+
+// Interpolation weights shared by BC6H and BC7
+var (
+	bcWeights2 = [4]int{0, 21, 43, 64}
+	bcWeights3 = [8]int{0, 9, 18, 27, 37, 46, 55, 64}
+	bcWeights4 = [16]int{0, 4, 9, 13, 17, 21, 26, 30, 34, 38, 43, 47, 51, 55, 60, 64}
+)
+
+// bcPartitions2 holds 2-subset partitions as masks of pixels in subset 1
+var bcPartitions2 = [64]uint16{
+	0xCCCC, 0x8888, 0xEEEE, 0xECC8, 0xC880, 0xFEEC, 0xFEC8, 0xEC80,
+	0xC800, 0xFFEC, 0xFE80, 0xE800, 0xFFE8, 0xFF00, 0xFFF0, 0xF000,
+	0xF710, 0x008E, 0x7100, 0x08CE, 0x008C, 0x7310, 0x3100, 0x8CCE,
+	0x088C, 0x3110, 0x6666, 0x366C, 0x17E8, 0x0FF0, 0x718E, 0x399C,
+	0xAAAA, 0xF0F0, 0x5A5A, 0x33CC, 0x3C3C, 0x55AA, 0x9696, 0xA55A,
+	0x73CE, 0x13C8, 0x324C, 0x3BDC, 0x6996, 0xC33C, 0x9966, 0x0660,
+	0x0272, 0x04E4, 0x4E40, 0x2720, 0xC936, 0x936C, 0x39C6, 0x639C,
+	0x9336, 0x9CC6, 0x817E, 0xE718, 0xCCF0, 0x0FCC, 0x7744, 0xEE22,
+}
+
+// bcPartitions3 holds 3-subset partitions, one subset index per pixel
+var bcPartitions3 = [64]string{
+	"0011001102212222", "0001001122112221", "0000200122112211", "0222002200110111",
+	"0000000011221122", "0011001100220022", "0022002211111111", "0011001122112211",
+	"0000000011112222", "0000111111112222", "0000111122222222", "0012001200120012",
+	"0112011201120112", "0122012201220122", "0011011211221222", "0011200122002220",
+	"0001001101121122", "0111001120012200", "0000112211221122", "0022002200221111",
+	"0111011102220222", "0001000122212221", "0000001101220122", "0000110022102210",
+	"0122012200110000", "0012001211222222", "0110122112210110", "0000011012211221",
+	"0022110211020022", "0110011020022222", "0011012201220011", "0000200022112221",
+	"0000000211221222", "0222002200120011", "0011001200220222", "0120012001200120",
+	"0000111122220000", "0120120120120120", "0120201212010120", "0011220011220011",
+	"0011112222000011", "0101010122222222", "0000000021212121", "0022112200221122",
+	"0022001100220011", "0220122102201221", "0101222222220101", "0000212121212121",
+	"0101010101012222", "0222011102220111", "0002111200021112", "0000211221122112",
+	"0222011101110222", "0002111211120002", "0110011001102222", "0000000021122112",
+	"0110011022222222", "0022001100110022", "0022112211220022", "0000000000002112",
+	"0002000100020001", "0222122202221222", "0101222222222222", "0111201122012220",
+}
+
+// Anchor pixel indices for the second subset of 2-subset partitions
+var bcAnchors2 = [64]int{
+	15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15,
+	15, 2, 8, 2, 2, 8, 8, 15, 2, 8, 2, 2, 8, 8, 2, 2,
+	15, 15, 6, 8, 2, 8, 15, 15, 2, 8, 2, 2, 2, 15, 15, 6,
+	6, 2, 6, 8, 15, 15, 2, 2, 15, 15, 15, 15, 15, 2, 2, 15,
+}
+
+// Anchor pixel indices for the second and third subsets of 3-subset partitions
+var (
+	bcAnchors3Second = [64]int{
+		3, 3, 15, 15, 8, 3, 15, 15, 8, 8, 6, 6, 6, 5, 3, 3,
+		3, 3, 8, 15, 3, 3, 6, 10, 5, 8, 8, 6, 8, 5, 15, 15,
+		8, 15, 3, 5, 6, 10, 8, 15, 15, 3, 15, 5, 15, 15, 15, 15,
+		3, 15, 5, 5, 5, 8, 5, 10, 5, 10, 8, 13, 15, 12, 3, 3,
+	}
+	bcAnchors3Third = [64]int{
+		15, 8, 8, 3, 15, 15, 3, 8, 15, 15, 15, 15, 15, 15, 15, 8,
+		15, 8, 15, 3, 15, 8, 15, 8, 3, 15, 6, 10, 15, 15, 10, 8,
+		15, 3, 15, 10, 10, 8, 9, 10, 6, 15, 8, 15, 3, 6, 6, 8,
+		15, 3, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 3, 15, 15, 8,
+	}
+)
+
+// partitionSubset returns the subset of pixel i for the given partition
+func partitionSubset(subsets, partition, i int) int {
+	switch subsets {
+	case 2:
+		return int(bcPartitions2[partition]>>i) & 1
+	case 3:
+		return int(bcPartitions3[partition][i] - '0')
+	default:
+		return 0
+	}
+}
+
+// isAnchor reports whether pixel i is an anchor (stored with one less index bit)
+func isAnchor(subsets, partition, i int) bool {
+	if i == 0 {
+		return true
+	}
+	switch subsets {
+	case 2:
+		return i == bcAnchors2[partition]
+	case 3:
+		return i == bcAnchors3Second[partition] || i == bcAnchors3Third[partition]
+	default:
+		return false
+	}
+}
+
+// bc7Mode describes the layout of a BC7 block mode
+type bc7Mode struct {
+	subsets        int
+	partitionBits  int
+	rotationBits   int
+	indexSelBits   int
+	colorBits      int
+	alphaBits      int
+	endpointPBits  int // One p-bit per endpoint
+	sharedPBits    int // One p-bit per subset
+	indexBits      int
+	secondaryIndex int // Index bits of the second index set (modes 4, 5)
+}
+
+var bc7Modes = [8]bc7Mode{
+	{3, 4, 0, 0, 4, 0, 1, 0, 3, 0},
+	{2, 6, 0, 0, 6, 0, 0, 1, 3, 0},
+	{3, 6, 0, 0, 5, 0, 0, 0, 2, 0},
+	{2, 6, 0, 0, 7, 0, 1, 0, 2, 0},
+	{1, 0, 2, 1, 5, 6, 0, 0, 2, 3},
+	{1, 0, 2, 0, 7, 8, 0, 0, 2, 2},
+	{1, 0, 0, 0, 7, 7, 1, 0, 4, 0},
+	{2, 6, 0, 0, 5, 5, 1, 0, 2, 0},
+}
+
+// decodeBC7 decodes a 16-byte BC7 block
+func decodeBC7(block []byte, out *[16][4]uint8) {
+	br := &bitReader{data: block}
+
+	// Mode is the position of the lowest set bit
+	modeIndex := 0
+	for modeIndex < 8 && br.read(1) == 0 {
+		modeIndex++
+	}
+	if modeIndex >= 8 {
+		// Reserved mode: transparent black
+		for i := range out {
+			out[i] = [4]uint8{}
+		}
+		return
+	}
+
+	mode := bc7Modes[modeIndex]
+	partition := int(br.read(mode.partitionBits))
+	rotation := int(br.read(mode.rotationBits))
+	indexSel := int(br.read(mode.indexSelBits))
+
+	// Endpoints: [subset*2 + endpoint][channel]
+	numEndpoints := mode.subsets * 2
+	var endpoints [6][4]int
+
+	for ch := 0; ch < 3; ch++ {
+		for e := 0; e < numEndpoints; e++ {
+			endpoints[e][ch] = int(br.read(mode.colorBits))
+		}
+	}
+	if mode.alphaBits > 0 {
+		for e := 0; e < numEndpoints; e++ {
+			endpoints[e][3] = int(br.read(mode.alphaBits))
+		}
+	}
+
+	// P-bits extend endpoint precision by one bit
+	colorBits, alphaBits := mode.colorBits, mode.alphaBits
+	if mode.endpointPBits > 0 || mode.sharedPBits > 0 {
+		var pbits [6]int
+		if mode.endpointPBits > 0 {
+			for e := 0; e < numEndpoints; e++ {
+				pbits[e] = int(br.read(1))
+			}
+		} else {
+			for s := 0; s < mode.subsets; s++ {
+				p := int(br.read(1))
+				pbits[s*2] = p
+				pbits[s*2+1] = p
+			}
+		}
+
+		for e := 0; e < numEndpoints; e++ {
+			for ch := 0; ch < 3; ch++ {
+				endpoints[e][ch] = endpoints[e][ch]<<1 | pbits[e]
+			}
+			if mode.alphaBits > 0 {
+				endpoints[e][3] = endpoints[e][3]<<1 | pbits[e]
+			}
+		}
+		colorBits++
+		if alphaBits > 0 {
+			alphaBits++
+		}
+	}
+
+	// Unquantize endpoints to 8 bits
+	for e := 0; e < numEndpoints; e++ {
+		for ch := 0; ch < 3; ch++ {
+			endpoints[e][ch] = unquantizeBC7(endpoints[e][ch], colorBits)
+		}
+		if alphaBits > 0 {
+			endpoints[e][3] = unquantizeBC7(endpoints[e][3], alphaBits)
+		} else {
+			endpoints[e][3] = 255
+		}
+	}
+
+	// Primary indices
+	var indices [16]int
+	for i := 0; i < 16; i++ {
+		bitsCount := mode.indexBits
+		if isAnchor(mode.subsets, partition, i) {
+			bitsCount--
+		}
+		indices[i] = int(br.read(bitsCount))
+	}
+
+	// Secondary indices (modes 4 and 5), anchor is pixel 0
+	var secondary [16]int
+	if mode.secondaryIndex > 0 {
+		for i := 0; i < 16; i++ {
+			bitsCount := mode.secondaryIndex
+			if i == 0 {
+				bitsCount--
+			}
+			secondary[i] = int(br.read(bitsCount))
+		}
+	}
+
+	for i := 0; i < 16; i++ {
+		subset := partitionSubset(mode.subsets, partition, i)
+		e0 := endpoints[subset*2]
+		e1 := endpoints[subset*2+1]
+
+		var pixel [4]int
+		if mode.secondaryIndex > 0 {
+			colorIndex, colorIndexBits := indices[i], mode.indexBits
+			alphaIndex, alphaIndexBits := secondary[i], mode.secondaryIndex
+			if indexSel == 1 {
+				colorIndex, alphaIndex = alphaIndex, colorIndex
+				colorIndexBits, alphaIndexBits = alphaIndexBits, colorIndexBits
+			}
+			for ch := 0; ch < 3; ch++ {
+				pixel[ch] = bcInterpolate(e0[ch], e1[ch], colorIndex, colorIndexBits)
+			}
+			pixel[3] = bcInterpolate(e0[3], e1[3], alphaIndex, alphaIndexBits)
+		} else {
+			for ch := 0; ch < 4; ch++ {
+				pixel[ch] = bcInterpolate(e0[ch], e1[ch], indices[i], mode.indexBits)
+			}
+		}
+
+		// Rotation swaps alpha with one of the color channels
+		switch rotation {
+		case 1:
+			pixel[0], pixel[3] = pixel[3], pixel[0]
+		case 2:
+			pixel[1], pixel[3] = pixel[3], pixel[1]
+		case 3:
+			pixel[2], pixel[3] = pixel[3], pixel[2]
+		}
+
+		out[i] = [4]uint8{uint8(pixel[0]), uint8(pixel[1]), uint8(pixel[2]), uint8(pixel[3])}
+	}
+}
+
+// unquantizeBC7 expands an n-bit value to 8 bits by bit replication
+func unquantizeBC7(v, n int) int {
+	v <<= 8 - n
+	return v | v>>n
+}
+
+// bcInterpolate interpolates between two endpoints with BC6H/BC7 weights
+func bcInterpolate(e0, e1, index, indexBits int) int {
+	var w int
+	switch indexBits {
+	case 2:
+		w = bcWeights2[index]
+	case 3:
+		w = bcWeights3[index]
+	default:
+		w = bcWeights4[index]
+	}
+	return ((64-w)*e0 + w*e1 + 32) >> 6
+}
@@ -0,0 +1,356 @@
+package texture
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"math/bits"
+)
+
+const (
+	ddsMagic      = "DDS "
+	ddsHeaderSize = 124
+	dx10HeaderLen = 20
+
+	// Pixel format flags (DDS_PIXELFORMAT.dwFlags)
+	ddpfAlphaPixels = 0x1
+	ddpfAlpha       = 0x2
+	ddpfFourCC      = 0x4
+	ddpfRGB         = 0x40
+	ddpfLuminance   = 0x20000
+)
+
+// maxTextureSize bounds the width and height of decoded textures (HOI4
+// textures are far smaller) so corrupt headers cannot exhaust memory
+const maxTextureSize = 16384
+
+// ddsFormat identifies how the top-level surface is encoded
+type ddsFormat int
+
+const (
+	formatUnknown ddsFormat = iota
+	formatBC1
+	formatBC2
+	formatBC3
+	formatBC4U
+	formatBC4S
+	formatBC5U
+	formatBC5S
+	formatBC6HU
+	formatBC6HS
+	formatBC7
+	formatMasked // Uncompressed, described by bit masks
+)
+
+// ddsPixelFormat mirrors DDS_PIXELFORMAT
+type ddsPixelFormat struct {
+	Flags    uint32
+	FourCC   string
+	BitCount uint32
+	RMask    uint32
+	GMask    uint32
+	BMask    uint32
+	AMask    uint32
+}
+
+// ddsHeader holds the fields of DDS_HEADER needed for decoding
+type ddsHeader struct {
+	Width       int
+	Height      int
+	MipMapCount int
+	PixelFormat ddsPixelFormat
+	DXGIFormat  uint32 // Only set for "DX10" files
+}
+
+// DecodeDDS decodes the top mip level of a DDS texture into an *image.NRGBA
+func DecodeDDS(data []byte) (image.Image, error) {
+	header, offset, err := parseDDSHeader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	format, masks, err := resolveDDSFormat(header)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the payload before allocating the image for the header's size
+	payload := data[offset:]
+	if need := ddsSurfaceSize(format, masks, header.Width, header.Height); len(payload) < need {
+		return nil, fmt.Errorf("truncated DDS data: need %d bytes, have %d", need, len(payload))
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, header.Width, header.Height))
+
+	switch format {
+	case formatMasked:
+		err = decodeMasked(img, payload, masks)
+	case formatBC1:
+		err = decodeBlocks(img, payload, 8, func(block []byte, out *[16][4]uint8) { decodeBC1(block, out, true) })
+	case formatBC2:
+		err = decodeBlocks(img, payload, 16, decodeBC2)
+	case formatBC3:
+		err = decodeBlocks(img, payload, 16, decodeBC3)
+	case formatBC4U, formatBC4S:
+		signed := format == formatBC4S
+		err = decodeBlocks(img, payload, 8, func(block []byte, out *[16][4]uint8) { decodeBC4(block, out, signed) })
+	case formatBC5U, formatBC5S:
+		signed := format == formatBC5S
+		err = decodeBlocks(img, payload, 16, func(block []byte, out *[16][4]uint8) { decodeBC5(block, out, signed) })
+	case formatBC6HU, formatBC6HS:
+		signed := format == formatBC6HS
+		err = decodeBlocks(img, payload, 16, func(block []byte, out *[16][4]uint8) { decodeBC6H(block, out, signed) })
+	case formatBC7:
+		err = decodeBlocks(img, payload, 16, decodeBC7)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
+
+// parseDDSHeader reads the DDS header and optional DX10 extension
+// Returns the header and the offset of the first surface
+func parseDDSHeader(data []byte) (*ddsHeader, int, error) {
+	if len(data) < 4+ddsHeaderSize || string(data[:4]) != ddsMagic {
+		return nil, 0, fmt.Errorf("not a DDS file")
+	}
+
+	le := binary.LittleEndian
+	h := data[4:]
+
+	if le.Uint32(h[0:]) != ddsHeaderSize {
+		return nil, 0, fmt.Errorf("invalid DDS header size: %d", le.Uint32(h[0:]))
+	}
+
+	header := &ddsHeader{
+		Height:      int(le.Uint32(h[8:])),
+		Width:       int(le.Uint32(h[12:])),
+		MipMapCount: int(le.Uint32(h[24:])),
+	}
+
+	// DDS_PIXELFORMAT starts at offset 72 of the header
+	pf := h[72:]
+	header.PixelFormat = ddsPixelFormat{
+		Flags:    le.Uint32(pf[4:]),
+		FourCC:   string(pf[8:12]),
+		BitCount: le.Uint32(pf[12:]),
+		RMask:    le.Uint32(pf[16:]),
+		GMask:    le.Uint32(pf[20:]),
+		BMask:    le.Uint32(pf[24:]),
+		AMask:    le.Uint32(pf[28:]),
+	}
+
+	if header.Width <= 0 || header.Height <= 0 || header.Width > maxTextureSize || header.Height > maxTextureSize {
+		return nil, 0, fmt.Errorf("invalid DDS dimensions: %dx%d", header.Width, header.Height)
+	}
+
+	offset := 4 + ddsHeaderSize
+	if header.PixelFormat.Flags&ddpfFourCC != 0 && header.PixelFormat.FourCC == "DX10" {
+		if len(data) < offset+dx10HeaderLen {
+			return nil, 0, fmt.Errorf("truncated DX10 header")
+		}
+		header.DXGIFormat = le.Uint32(data[offset:])
+		offset += dx10HeaderLen
+	}
+
+	return header, offset, nil
+}
+
+// ddsSurfaceSize returns the bytes of the top-level surface of a format
+func ddsSurfaceSize(format ddsFormat, pf ddsPixelFormat, width, height int) int {
+	blocks := ((width + 3) / 4) * ((height + 3) / 4)
+	switch format {
+	case formatMasked:
+		return width * int(pf.BitCount/8) * height
+	case formatBC1, formatBC4U, formatBC4S:
+		return blocks * 8
+	}
+	return blocks * 16
+}
+
+// resolveDDSFormat maps the pixel format to a decoder
+func resolveDDSFormat(header *ddsHeader) (ddsFormat, ddsPixelFormat, error) {
+	pf := header.PixelFormat
+
+	if pf.Flags&ddpfFourCC != 0 {
+		switch pf.FourCC {
+		case "DXT1":
+			return formatBC1, pf, nil
+		case "DXT2", "DXT3":
+			return formatBC2, pf, nil
+		case "DXT4", "DXT5":
+			return formatBC3, pf, nil
+		case "ATI1", "BC4U":
+			return formatBC4U, pf, nil
+		case "BC4S":
+			return formatBC4S, pf, nil
+		case "ATI2", "BC5U":
+			return formatBC5U, pf, nil
+		case "BC5S":
+			return formatBC5S, pf, nil
+		case "DX10":
+			return resolveDXGIFormat(header.DXGIFormat)
+		}
+		return formatUnknown, pf, fmt.Errorf("unsupported DDS FourCC: %q", pf.FourCC)
+	}
+
+	if pf.Flags&(ddpfRGB|ddpfLuminance|ddpfAlpha|ddpfAlphaPixels) != 0 {
+		switch pf.BitCount {
+		case 8, 16, 24, 32:
+			return formatMasked, pf, nil
+		}
+		return formatUnknown, pf, fmt.Errorf("unsupported DDS bit count: %d", pf.BitCount)
+	}
+
+	return formatUnknown, pf, fmt.Errorf("unsupported DDS pixel format flags: 0x%x", pf.Flags)
+}
+
+// resolveDXGIFormat maps DXGI_FORMAT values from the DX10 header
+func resolveDXGIFormat(dxgi uint32) (ddsFormat, ddsPixelFormat, error) {
+	masked := func(bitCount, r, g, b, a uint32) (ddsFormat, ddsPixelFormat, error) {
+		flags := uint32(ddpfRGB)
+		if a != 0 {
+			flags |= ddpfAlphaPixels
+		}
+		return formatMasked, ddsPixelFormat{Flags: flags, BitCount: bitCount, RMask: r, GMask: g, BMask: b, AMask: a}, nil
+	}
+
+	switch dxgi {
+	case 27, 28, 29: // R8G8B8A8_TYPELESS/UNORM/UNORM_SRGB
+		return masked(32, 0x000000FF, 0x0000FF00, 0x00FF0000, 0xFF000000)
+	case 87, 90, 91: // B8G8R8A8_UNORM/TYPELESS/UNORM_SRGB
+		return masked(32, 0x00FF0000, 0x0000FF00, 0x000000FF, 0xFF000000)
+	case 88, 92, 93: // B8G8R8X8_UNORM/TYPELESS/UNORM_SRGB
+		return masked(32, 0x00FF0000, 0x0000FF00, 0x000000FF, 0)
+	case 85: // B5G6R5_UNORM
+		return masked(16, 0xF800, 0x07E0, 0x001F, 0)
+	case 86: // B5G5R5A1_UNORM
+		return masked(16, 0x7C00, 0x03E0, 0x001F, 0x8000)
+	case 115: // B4G4R4A4_UNORM
+		return masked(16, 0x0F00, 0x00F0, 0x000F, 0xF000)
+	case 49: // R8G8_UNORM
+		return masked(16, 0x00FF, 0xFF00, 0, 0)
+	case 61: // R8_UNORM
+		return formatMasked, ddsPixelFormat{Flags: ddpfLuminance, BitCount: 8, RMask: 0xFF}, nil
+	case 65: // A8_UNORM
+		return formatMasked, ddsPixelFormat{Flags: ddpfAlpha, BitCount: 8, AMask: 0xFF}, nil
+	case 70, 71, 72: // BC1
+		return formatBC1, ddsPixelFormat{}, nil
+	case 73, 74, 75: // BC2
+		return formatBC2, ddsPixelFormat{}, nil
+	case 76, 77, 78: // BC3
+		return formatBC3, ddsPixelFormat{}, nil
+	case 79, 80: // BC4_TYPELESS/UNORM
+		return formatBC4U, ddsPixelFormat{}, nil
+	case 81: // BC4_SNORM
+		return formatBC4S, ddsPixelFormat{}, nil
+	case 82, 83: // BC5_TYPELESS/UNORM
+		return formatBC5U, ddsPixelFormat{}, nil
+	case 84: // BC5_SNORM
+		return formatBC5S, ddsPixelFormat{}, nil
+	case 94, 95: // BC6H_TYPELESS/UF16
+		return formatBC6HU, ddsPixelFormat{}, nil
+	case 96: // BC6H_SF16
+		return formatBC6HS, ddsPixelFormat{}, nil
+	case 97, 98, 99: // BC7
+		return formatBC7, ddsPixelFormat{}, nil
+	}
+
+	return formatUnknown, ddsPixelFormat{}, fmt.Errorf("unsupported DXGI format: %d", dxgi)
+}
+
+// decodeBlocks decodes a block-compressed surface made of 4x4 pixel blocks
+func decodeBlocks(img *image.NRGBA, data []byte, blockSize int, decode func(block []byte, out *[16][4]uint8)) error {
+	width, height := img.Rect.Dx(), img.Rect.Dy()
+	blocksX := (width + 3) / 4
+	blocksY := (height + 3) / 4
+
+	if len(data) < blocksX*blocksY*blockSize {
+		return fmt.Errorf("truncated DDS data: need %d bytes, have %d", blocksX*blocksY*blockSize, len(data))
+	}
+
+	var pixels [16][4]uint8
+	offset := 0
+	for by := 0; by < blocksY; by++ {
+		for bx := 0; bx < blocksX; bx++ {
+			decode(data[offset:offset+blockSize], &pixels)
+			offset += blockSize
+
+			for i := 0; i < 16; i++ {
+				x := bx*4 + i%4
+				y := by*4 + i/4
+				if x >= width || y >= height {
+					continue
+				}
+				p := img.PixOffset(x, y)
+				copy(img.Pix[p:p+4], pixels[i][:])
+			}
+		}
+	}
+
+	return nil
+}
+
+// decodeMasked decodes an uncompressed surface described by channel bit masks
+func decodeMasked(img *image.NRGBA, data []byte, pf ddsPixelFormat) error {
+	width, height := img.Rect.Dx(), img.Rect.Dy()
+	bytesPerPixel := int(pf.BitCount / 8)
+	pitch := width * bytesPerPixel
+
+	if len(data) < pitch*height {
+		return fmt.Errorf("truncated DDS data: need %d bytes, have %d", pitch*height, len(data))
+	}
+
+	luminance := pf.Flags&ddpfLuminance != 0
+	alphaOnly := pf.Flags&ddpfAlpha != 0 && pf.Flags&(ddpfRGB|ddpfLuminance) == 0
+	hasAlpha := pf.AMask != 0 && pf.Flags&(ddpfAlphaPixels|ddpfAlpha) != 0
+
+	for y := 0; y < height; y++ {
+		row := data[y*pitch:]
+		for x := 0; x < width; x++ {
+			var value uint32
+			for b := 0; b < bytesPerPixel; b++ {
+				value |= uint32(row[x*bytesPerPixel+b]) << (8 * b)
+			}
+
+			r := extractChannel(value, pf.RMask)
+			g := extractChannel(value, pf.GMask)
+			b := extractChannel(value, pf.BMask)
+			a := uint8(255)
+			if hasAlpha {
+				a = extractChannel(value, pf.AMask)
+			}
+
+			switch {
+			case alphaOnly:
+				r, g, b = 255, 255, 255
+			case luminance:
+				g, b = r, r
+			}
+
+			p := img.PixOffset(x, y)
+			img.Pix[p+0] = r
+			img.Pix[p+1] = g
+			img.Pix[p+2] = b
+			img.Pix[p+3] = a
+		}
+	}
+
+	return nil
+}
+
+// extractChannel extracts a channel by mask and scales it to 8 bits
+func extractChannel(value, mask uint32) uint8 {
+	if mask == 0 {
+		return 0
+	}
+
+	shift := bits.TrailingZeros32(mask)
+	width := bits.OnesCount32(mask)
+	v := uint64((value & mask) >> shift)
+	maxValue := uint64(1)<<width - 1
+
+	return uint8((v*255 + maxValue/2) / maxValue)
+}
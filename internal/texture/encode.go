@@ -0,0 +1,314 @@
+package texture
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// DDSEncoding selects the surface format written by EncodeDDS
+type DDSEncoding int
+
+const (
+	EncodeDXT5         DDSEncoding = iota // BC3: smallest, the vanilla focus icon format
+	EncodeUncompressed                    // A8R8G8B8: lossless
+)
+
+// String returns the FourCC / format name
+func (e DDSEncoding) String() string {
+	if e == EncodeUncompressed {
+		return "A8R8G8B8"
+	}
+	return "DXT5"
+}
+
+// DDS header flags used by the encoder
+const (
+	ddsdCaps        = 0x1
+	ddsdHeight      = 0x2
+	ddsdWidth       = 0x4
+	ddsdPitch       = 0x8
+	ddsdPixelFormat = 0x1000
+	ddsdLinearSize  = 0x80000
+	ddsCapsTexture  = 0x1000
+)
+
+// EncodeDDS encodes an image as a single-mip DDS texture
+func EncodeDDS(img image.Image, encoding DDSEncoding) ([]byte, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil, fmt.Errorf("cannot encode empty image")
+	}
+
+	nrgba := toNRGBA(img)
+
+	le := binary.LittleEndian
+	header := make([]byte, 4+ddsHeaderSize)
+	copy(header, ddsMagic)
+	h := header[4:]
+	le.PutUint32(h[0:], ddsHeaderSize)
+	le.PutUint32(h[8:], uint32(height))
+	le.PutUint32(h[12:], uint32(width))
+	le.PutUint32(h[24:], 1) // mip count
+	le.PutUint32(h[104:], ddsCapsTexture)
+
+	pf := h[72:]
+	le.PutUint32(pf[0:], 32)
+
+	var payload []byte
+	switch encoding {
+	case EncodeUncompressed:
+		le.PutUint32(h[4:], ddsdCaps|ddsdHeight|ddsdWidth|ddsdPixelFormat|ddsdPitch)
+		le.PutUint32(h[16:], uint32(width*4))
+		le.PutUint32(pf[4:], ddpfRGB|ddpfAlphaPixels)
+		le.PutUint32(pf[12:], 32)
+		le.PutUint32(pf[16:], 0x00FF0000)
+		le.PutUint32(pf[20:], 0x0000FF00)
+		le.PutUint32(pf[24:], 0x000000FF)
+		le.PutUint32(pf[28:], 0xFF000000)
+		payload = encodeBGRA(nrgba)
+	case EncodeDXT5:
+		payload = encodeBC3Image(nrgba)
+		le.PutUint32(h[4:], ddsdCaps|ddsdHeight|ddsdWidth|ddsdPixelFormat|ddsdLinearSize)
+		le.PutUint32(h[16:], uint32(len(payload)))
+		le.PutUint32(pf[4:], ddpfFourCC)
+		copy(pf[8:12], "DXT5")
+	default:
+		return nil, fmt.Errorf("unsupported DDS encoding: %d", encoding)
+	}
+
+	return append(header, payload...), nil
+}
+
+// toNRGBA converts any image to a zero-origin *image.NRGBA
+func toNRGBA(img image.Image) *image.NRGBA {
+	bounds := img.Bounds()
+	if nrgba, ok := img.(*image.NRGBA); ok && bounds.Min == (image.Point{}) {
+		return nrgba
+	}
+
+	out := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			out.Set(x-bounds.Min.X, y-bounds.Min.Y, img.At(x, y))
+		}
+	}
+	return out
+}
+
+// encodeBGRA writes pixels in A8R8G8B8 (little-endian BGRA) order
+func encodeBGRA(img *image.NRGBA) []byte {
+	bounds := img.Bounds()
+	out := make([]byte, 0, bounds.Dx()*bounds.Dy()*4)
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			c := img.NRGBAAt(x, y)
+			out = append(out, c.B, c.G, c.R, c.A)
+		}
+	}
+	return out
+}
+
+// encodeBC3Image compresses an image into BC3 (DXT5) blocks
+func encodeBC3Image(img *image.NRGBA) []byte {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	blocksX := (width + 3) / 4
+	blocksY := (height + 3) / 4
+	out := make([]byte, 0, blocksX*blocksY*16)
+
+	var pixels [16]color.NRGBA
+	for by := 0; by < blocksY; by++ {
+		for bx := 0; bx < blocksX; bx++ {
+			// Edge blocks repeat the last row/column
+			for i := 0; i < 16; i++ {
+				x := bx*4 + i%4
+				y := by*4 + i/4
+				if x >= width {
+					x = width - 1
+				}
+				if y >= height {
+					y = height - 1
+				}
+				pixels[i] = img.NRGBAAt(x, y)
+			}
+			out = append(out, encodeBC3Block(&pixels)...)
+		}
+	}
+	return out
+}
+
+// encodeBC3Block encodes 16 pixels: interpolated alpha + 4-color BC1 block
+func encodeBC3Block(pixels *[16]color.NRGBA) []byte {
+	block := make([]byte, 16)
+
+	// Alpha: min/max endpoints, 8-value mode (a0 > a1)
+	minA, maxA := uint8(255), uint8(0)
+	for _, p := range pixels {
+		if p.A < minA {
+			minA = p.A
+		}
+		if p.A > maxA {
+			maxA = p.A
+		}
+	}
+
+	if minA == maxA {
+		// Constant alpha: index 0 everywhere
+		block[0], block[1] = maxA, maxA
+	} else {
+		block[0], block[1] = maxA, minA
+		var palette [8]int
+		palette[0], palette[1] = int(maxA), int(minA)
+		for i := 1; i <= 6; i++ {
+			palette[i+1] = ((7-i)*int(maxA) + i*int(minA)) / 7
+		}
+
+		var indices uint64
+		for i, p := range pixels {
+			indices |= uint64(nearestIndex(palette[:], int(p.A))) << (3 * i)
+		}
+		for i := 0; i < 6; i++ {
+			block[2+i] = byte(indices >> (8 * i))
+		}
+	}
+
+	copy(block[8:], encodeBC1Block(pixels))
+	return block
+}
+
+// encodeBC1Block encodes colors with endpoints on the block's principal axis
+func encodeBC1Block(pixels *[16]color.NRGBA) []byte {
+	block := make([]byte, 8)
+
+	minC, maxC := principalEndpoints(pixels)
+	c0 := pack565(maxC)
+	c1 := pack565(minC)
+	if c0 < c1 {
+		c0, c1 = c1, c0
+	}
+
+	binary.LittleEndian.PutUint16(block[0:], c0)
+	binary.LittleEndian.PutUint16(block[2:], c1)
+	if c0 == c1 {
+		// Solid block: all indices 0
+		return block
+	}
+
+	// Four-color palette in the same order the decoder builds it
+	e0 := expand565(c0)
+	e1 := expand565(c1)
+	var palette [4][3]int
+	for ch := 0; ch < 3; ch++ {
+		palette[0][ch] = int(e0[ch])
+		palette[1][ch] = int(e1[ch])
+		palette[2][ch] = (2*int(e0[ch]) + int(e1[ch]) + 1) / 3
+		palette[3][ch] = (int(e0[ch]) + 2*int(e1[ch]) + 1) / 3
+	}
+
+	var indices uint32
+	for i, p := range pixels {
+		best, bestDist := 0, -1
+		for j, c := range palette {
+			dr := int(p.R) - c[0]
+			dg := int(p.G) - c[1]
+			db := int(p.B) - c[2]
+			dist := dr*dr + dg*dg + db*db
+			if bestDist < 0 || dist < bestDist {
+				best, bestDist = j, dist
+			}
+		}
+		indices |= uint32(best) << (2 * i)
+	}
+	binary.LittleEndian.PutUint32(block[4:], indices)
+
+	return block
+}
+
+// principalEndpoints projects the block colors onto their principal axis
+// (power iteration on the covariance matrix) and returns the extreme colors
+func principalEndpoints(pixels *[16]color.NRGBA) (minC, maxC [3]int) {
+	var mean [3]float64
+	for _, p := range pixels {
+		mean[0] += float64(p.R)
+		mean[1] += float64(p.G)
+		mean[2] += float64(p.B)
+	}
+	for ch := range mean {
+		mean[ch] /= 16
+	}
+
+	var cov [3][3]float64
+	for _, p := range pixels {
+		d := [3]float64{float64(p.R) - mean[0], float64(p.G) - mean[1], float64(p.B) - mean[2]}
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				cov[i][j] += d[i] * d[j]
+			}
+		}
+	}
+
+	axis := [3]float64{1, 1, 1}
+	for iter := 0; iter < 8; iter++ {
+		var next [3]float64
+		for i := 0; i < 3; i++ {
+			next[i] = cov[i][0]*axis[0] + cov[i][1]*axis[1] + cov[i][2]*axis[2]
+		}
+		norm := next[0]*next[0] + next[1]*next[1] + next[2]*next[2]
+		if norm == 0 {
+			break
+		}
+		axis = next
+		// Keep values bounded; direction is all that matters
+		scale := 1 / (abs(axis[0]) + abs(axis[1]) + abs(axis[2]))
+		for i := range axis {
+			axis[i] *= scale
+		}
+	}
+
+	minProj, maxProj := 0.0, 0.0
+	for i, p := range pixels {
+		c := [3]int{int(p.R), int(p.G), int(p.B)}
+		proj := (float64(c[0])-mean[0])*axis[0] + (float64(c[1])-mean[1])*axis[1] + (float64(c[2])-mean[2])*axis[2]
+		if i == 0 || proj < minProj {
+			minProj, minC = proj, c
+		}
+		if i == 0 || proj > maxProj {
+			maxProj, maxC = proj, c
+		}
+	}
+
+	return minC, maxC
+}
+
+// abs returns the absolute value of a float
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// pack565 converts 8-bit RGB to RGB565 with rounding
+func pack565(c [3]int) uint16 {
+	r := (c[0]*31 + 127) / 255
+	g := (c[1]*63 + 127) / 255
+	b := (c[2]*31 + 127) / 255
+	return uint16(r<<11 | g<<5 | b)
+}
+
+// nearestIndex returns the palette index closest to v
+func nearestIndex(palette []int, v int) int {
+	best, bestDist := 0, -1
+	for i, p := range palette {
+		dist := p - v
+		if dist < 0 {
+			dist = -dist
+		}
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
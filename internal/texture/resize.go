@@ -0,0 +1,80 @@
+package texture
+
+import (
+	"image"
+	"image/color"
+)
+
+// Fit scales img to fit inside width x height preserving its aspect ratio,
+// centered on a transparent canvas of exactly that size
+func Fit(img image.Image, width, height int) *image.NRGBA {
+	src := toNRGBA(img)
+	out := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+	if srcW == 0 || srcH == 0 || width == 0 || height == 0 {
+		return out
+	}
+
+	// Scaled size (at least 1px)
+	dstW, dstH := width, srcH*width/srcW
+	if dstH > height {
+		dstW, dstH = srcW*height/srcH, height
+	}
+	if dstW < 1 {
+		dstW = 1
+	}
+	if dstH < 1 {
+		dstH = 1
+	}
+
+	offsetX := (width - dstW) / 2
+	offsetY := (height - dstH) / 2
+
+	for y := 0; y < dstH; y++ {
+		for x := 0; x < dstW; x++ {
+			out.SetNRGBA(offsetX+x, offsetY+y, sampleBox(src, x, y, dstW, dstH))
+		}
+	}
+
+	return out
+}
+
+// sampleBox averages the source pixels covered by destination pixel (x, y),
+// weighting colors by alpha so transparent edges do not darken
+func sampleBox(src *image.NRGBA, x, y, dstW, dstH int) color.NRGBA {
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+
+	x0 := x * srcW / dstW
+	x1 := (x + 1) * srcW / dstW
+	y0 := y * srcH / dstH
+	y1 := (y + 1) * srcH / dstH
+	if x1 <= x0 {
+		x1 = x0 + 1
+	}
+	if y1 <= y0 {
+		y1 = y0 + 1
+	}
+
+	var r, g, b, a, count int
+	for sy := y0; sy < y1; sy++ {
+		for sx := x0; sx < x1; sx++ {
+			c := src.NRGBAAt(sx, sy)
+			r += int(c.R) * int(c.A)
+			g += int(c.G) * int(c.A)
+			b += int(c.B) * int(c.A)
+			a += int(c.A)
+			count++
+		}
+	}
+
+	if a == 0 {
+		return color.NRGBA{}
+	}
+	return color.NRGBA{
+		R: uint8(r / a),
+		G: uint8(g / a),
+		B: uint8(b / a),
+		A: uint8(a / count),
+	}
+}
@@ -0,0 +1,101 @@
+// Package texture decodes the image formats used by HOI4 interface graphics:
+// DDS (DXT1/3/5, BC4-BC7 and uncompressed variants), TGA, PNG and JPEG.
+package texture
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg" // Register JPEG decoder
+	_ "image/png"  // Register PNG decoder
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DecodeFile reads and decodes a texture file, choosing the decoder by extension
+func DecodeFile(path string) (image.Image, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read texture: %w", err)
+	}
+
+	return Decode(data, filepath.Ext(path))
+}
+
+// Decode decodes texture data; ext is the file extension (".dds", ".tga", ...)
+func Decode(data []byte, ext string) (image.Image, error) {
+	switch strings.ToLower(ext) {
+	case ".dds":
+		return DecodeDDS(data)
+	case ".tga":
+		return DecodeTGA(data)
+	case ".png", ".jpg", ".jpeg":
+		img, _, err := image.Decode(bytes.NewReader(data))
+		return img, err
+	default:
+		// Unknown extension: sniff DDS magic, otherwise let image.Decode try
+		if bytes.HasPrefix(data, []byte(ddsMagic)) {
+			return DecodeDDS(data)
+		}
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("unsupported texture format %q: %w", ext, err)
+		}
+		return img, nil
+	}
+}
+
+// subImager is implemented by all standard image types and *ebiten.Image
+type subImager interface {
+	SubImage(r image.Rectangle) image.Image
+}
+
+// SliceFrames splits a horizontal frame strip into equally sized frames.
+// Sprites with noOfFrames (e.g. tech "available/researched/unavailable"
+// backgrounds) store their frames left to right in a single texture.
+func SliceFrames(img image.Image, frames int) []image.Image {
+	if frames < 1 {
+		frames = 1
+	}
+
+	result := make([]image.Image, 0, frames)
+	for i := 0; i < frames; i++ {
+		result = append(result, Frame(img, frames, i))
+	}
+	return result
+}
+
+// Frame returns frame index (0-based) of a horizontal strip of frames
+// Out-of-range indices are clamped to the valid range
+func Frame(img image.Image, frames, index int) image.Image {
+	if frames <= 1 {
+		return img
+	}
+	if index < 0 {
+		index = 0
+	}
+	if index >= frames {
+		index = frames - 1
+	}
+
+	bounds := img.Bounds()
+	frameWidth := bounds.Dx() / frames
+	rect := image.Rect(
+		bounds.Min.X+index*frameWidth, bounds.Min.Y,
+		bounds.Min.X+(index+1)*frameWidth, bounds.Max.Y,
+	)
+
+	if sub, ok := img.(subImager); ok {
+		return sub.SubImage(rect)
+	}
+
+	// Fallback: copy pixels for image types without SubImage
+	out := image.NewNRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			out.Set(x-rect.Min.X, y-rect.Min.Y, img.At(x, y))
+		}
+	}
+	return out
+}
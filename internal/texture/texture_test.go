@@ -0,0 +1,598 @@
+package texture
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Synthetic corpus builders
+
+// ddsPF describes the pixel format written by buildDDS
+type ddsPF struct {
+	flags    uint32
+	fourCC   string
+	bitCount uint32
+	masks    [4]uint32 // R, G, B, A
+}
+
+// buildDDS assembles a DDS file with the given pixel format and payload
+func buildDDS(width, height, mipCount int, pf ddsPF, dxgi uint32, payload []byte) []byte {
+	le := binary.LittleEndian
+	header := make([]byte, 4+ddsHeaderSize)
+	copy(header, ddsMagic)
+	h := header[4:]
+	le.PutUint32(h[0:], ddsHeaderSize)
+	le.PutUint32(h[8:], uint32(height))
+	le.PutUint32(h[12:], uint32(width))
+	le.PutUint32(h[24:], uint32(mipCount))
+
+	p := h[72:]
+	le.PutUint32(p[0:], 32)
+	le.PutUint32(p[4:], pf.flags)
+	copy(p[8:12], pf.fourCC)
+	le.PutUint32(p[12:], pf.bitCount)
+	for i, mask := range pf.masks {
+		le.PutUint32(p[16+4*i:], mask)
+	}
+
+	data := header
+	if pf.fourCC == "DX10" {
+		ext := make([]byte, dx10HeaderLen)
+		le.PutUint32(ext[0:], dxgi)
+		le.PutUint32(ext[4:], 3) // TEXTURE2D
+		le.PutUint32(ext[12:], 1)
+		data = append(data, ext...)
+	}
+	return append(data, payload...)
+}
+
+// bc1Block builds a BC1 block from two RGB565 endpoints and 2-bit indices
+func bc1Block(c0, c1 uint16, indices [16]uint8) []byte {
+	block := make([]byte, 8)
+	binary.LittleEndian.PutUint16(block[0:], c0)
+	binary.LittleEndian.PutUint16(block[2:], c1)
+	var bitsValue uint32
+	for i, idx := range indices {
+		bitsValue |= uint32(idx&0x3) << (2 * i)
+	}
+	binary.LittleEndian.PutUint32(block[4:], bitsValue)
+	return block
+}
+
+// alphaBlock builds a BC3/BC4 interpolated block with 3-bit indices
+func alphaBlock(a0, a1 uint8, indices [16]uint8) []byte {
+	block := make([]byte, 8)
+	block[0], block[1] = a0, a1
+	var bitsValue uint64
+	for i, idx := range indices {
+		bitsValue |= uint64(idx&0x7) << (3 * i)
+	}
+	for i := 0; i < 6; i++ {
+		block[2+i] = byte(bitsValue >> (8 * i))
+	}
+	return block
+}
+
+// bitWriter writes LSB-first bit fields, mirroring bitReader
+type bitWriter struct {
+	data [16]byte
+	pos  int
+}
+
+func (bw *bitWriter) write(value uint32, n int) {
+	for i := 0; i < n; i++ {
+		if value>>i&1 != 0 {
+			bw.data[bw.pos/8] |= 1 << (bw.pos % 8)
+		}
+		bw.pos++
+	}
+}
+
+// buildTGA assembles a TGA file
+func buildTGA(imageType uint8, width, height, depth int, descriptor uint8, colorMap []byte, colorMapDepth int, pixels []byte) []byte {
+	header := make([]byte, tgaHeaderSize)
+	if colorMap != nil {
+		header[1] = 1
+		entries := len(colorMap) / ((colorMapDepth + 7) / 8)
+		binary.LittleEndian.PutUint16(header[5:], uint16(entries))
+		header[7] = uint8(colorMapDepth)
+	}
+	header[2] = imageType
+	binary.LittleEndian.PutUint16(header[12:], uint16(width))
+	binary.LittleEndian.PutUint16(header[14:], uint16(height))
+	header[16] = uint8(depth)
+	header[17] = descriptor
+
+	data := append(header, colorMap...)
+	return append(data, pixels...)
+}
+
+func nrgbaAt(t *testing.T, img image.Image, x, y int) color.NRGBA {
+	t.Helper()
+	return color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+}
+
+func assertColor(t *testing.T, img image.Image, x, y int, want color.NRGBA) {
+	t.Helper()
+	if got := nrgbaAt(t, img, x, y); got != want {
+		t.Errorf("pixel (%d,%d) = %v, want %v", x, y, got, want)
+	}
+}
+
+// DDS tests
+
+func TestDecodeDDS_DXT1(t *testing.T) {
+	var indices [16]uint8
+	for i := range indices {
+		indices[i] = uint8(i % 4)
+	}
+	// Red (0xF800) > blue (0x001F): 4-color mode
+	block := bc1Block(0xF800, 0x001F, indices)
+	data := buildDDS(4, 4, 1, ddsPF{flags: ddpfFourCC, fourCC: "DXT1"}, 0, block)
+
+	img, err := Decode(data, ".dds")
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if img.Bounds().Dx() != 4 || img.Bounds().Dy() != 4 {
+		t.Fatalf("unexpected size %v", img.Bounds())
+	}
+
+	assertColor(t, img, 0, 0, color.NRGBA{255, 0, 0, 255})
+	assertColor(t, img, 1, 0, color.NRGBA{0, 0, 255, 255})
+	assertColor(t, img, 2, 0, color.NRGBA{170, 0, 85, 255})
+	assertColor(t, img, 3, 0, color.NRGBA{85, 0, 170, 255})
+}
+
+func TestDecodeDDS_DXT1Transparent(t *testing.T) {
+	var indices [16]uint8
+	for i := range indices {
+		indices[i] = 3
+	}
+	indices[0] = 2
+	// c0 <= c1: 3-color + transparent mode
+	block := bc1Block(0x001F, 0xF800, indices)
+	data := buildDDS(4, 4, 1, ddsPF{flags: ddpfFourCC, fourCC: "DXT1"}, 0, block)
+
+	img, err := DecodeDDS(data)
+	if err != nil {
+		t.Fatalf("DecodeDDS failed: %v", err)
+	}
+
+	assertColor(t, img, 0, 0, color.NRGBA{127, 0, 127, 255})
+	if a := nrgbaAt(t, img, 1, 0).A; a != 0 {
+		t.Errorf("expected transparent pixel, alpha=%d", a)
+	}
+}
+
+func TestDecodeDDS_DXT3AndDXT5Alpha(t *testing.T) {
+	var zero [16]uint8
+	color := bc1Block(0xFFFF, 0xFFFF, zero)
+
+	// DXT3: explicit 4-bit alpha, pixel i has alpha i
+	dxt3 := make([]byte, 8)
+	var alphaBits uint64
+	for i := 0; i < 16; i++ {
+		alphaBits |= uint64(i) << (4 * i)
+	}
+	binary.LittleEndian.PutUint64(dxt3, alphaBits)
+	dxt3 = append(dxt3, color...)
+
+	img, err := DecodeDDS(buildDDS(4, 4, 1, ddsPF{flags: ddpfFourCC, fourCC: "DXT3"}, 0, dxt3))
+	if err != nil {
+		t.Fatalf("DXT3 decode failed: %v", err)
+	}
+	if a := nrgbaAt(t, img, 3, 3).A; a != 0xFF {
+		t.Errorf("DXT3 alpha at (3,3) = %d, want 255", a)
+	}
+	if a := nrgbaAt(t, img, 1, 0).A; a != 0x11 {
+		t.Errorf("DXT3 alpha at (1,0) = %d, want 17", a)
+	}
+
+	// DXT5: 8-alpha mode, index 0 = a0, index 1 = a1
+	var alphaIdx [16]uint8
+	alphaIdx[1] = 1
+	dxt5 := append(alphaBlock(200, 10, alphaIdx), color...)
+
+	img, err = DecodeDDS(buildDDS(4, 4, 1, ddsPF{flags: ddpfFourCC, fourCC: "DXT5"}, 0, dxt5))
+	if err != nil {
+		t.Fatalf("DXT5 decode failed: %v", err)
+	}
+	if a := nrgbaAt(t, img, 0, 0).A; a != 200 {
+		t.Errorf("DXT5 alpha at (0,0) = %d, want 200", a)
+	}
+	if a := nrgbaAt(t, img, 1, 0).A; a != 10 {
+		t.Errorf("DXT5 alpha at (1,0) = %d, want 10", a)
+	}
+}
+
+func TestDecodeDDS_BC4AndBC5(t *testing.T) {
+	var idx [16]uint8
+	idx[1] = 1
+
+	bc4 := alphaBlock(240, 16, idx)
+	img, err := DecodeDDS(buildDDS(4, 4, 1, ddsPF{flags: ddpfFourCC, fourCC: "ATI1"}, 0, bc4))
+	if err != nil {
+		t.Fatalf("BC4 decode failed: %v", err)
+	}
+	assertColor(t, img, 0, 0, color.NRGBA{240, 240, 240, 255})
+	assertColor(t, img, 1, 0, color.NRGBA{16, 16, 16, 255})
+
+	bc5 := append(alphaBlock(100, 0, idx), alphaBlock(50, 0, idx)...)
+	img, err = DecodeDDS(buildDDS(4, 4, 1, ddsPF{flags: ddpfFourCC, fourCC: "ATI2"}, 0, bc5))
+	if err != nil {
+		t.Fatalf("BC5 decode failed: %v", err)
+	}
+	assertColor(t, img, 0, 0, color.NRGBA{100, 50, 0, 255})
+}
+
+func TestDecodeDDS_BC7Mode6(t *testing.T) {
+	// Mode 6: 7-bit RGBA endpoints, per-endpoint p-bit, 4-bit indices
+	bw := &bitWriter{}
+	bw.write(1<<6, 7) // mode 6
+	for ch := 0; ch < 4; ch++ {
+		bw.write(0, 7)    // endpoint 0
+		bw.write(0x7F, 7) // endpoint 1
+	}
+	bw.write(0, 1) // p-bit endpoint 0
+	bw.write(1, 1) // p-bit endpoint 1
+	bw.write(0, 3) // pixel 0 (anchor): index 0
+	bw.write(15, 4)
+	for i := 2; i < 16; i++ {
+		bw.write(0, 4)
+	}
+
+	data := buildDDS(4, 4, 1, ddsPF{flags: ddpfFourCC, fourCC: "DX10"}, 98, bw.data[:])
+	img, err := DecodeDDS(data)
+	if err != nil {
+		t.Fatalf("BC7 decode failed: %v", err)
+	}
+
+	assertColor(t, img, 0, 0, color.NRGBA{0, 0, 0, 0})
+	assertColor(t, img, 1, 0, color.NRGBA{255, 255, 255, 255})
+}
+
+func TestDecodeDDS_BC6HMode11(t *testing.T) {
+	// Mode 11: single region, 10-bit endpoints, no transform
+	build := func(value uint32) []byte {
+		bw := &bitWriter{}
+		bw.write(0x03, 5)
+		for e := 0; e < 2; e++ {
+			for ch := 0; ch < 3; ch++ {
+				bw.write(value, 10)
+			}
+		}
+		return bw.data[:]
+	}
+
+	img, err := DecodeDDS(buildDDS(4, 4, 1, ddsPF{flags: ddpfFourCC, fourCC: "DX10"}, 95, build(0x3FF)))
+	if err != nil {
+		t.Fatalf("BC6H decode failed: %v", err)
+	}
+	assertColor(t, img, 0, 0, color.NRGBA{255, 255, 255, 255})
+
+	// 462/1023 unquantizes close to half-float 0.5
+	img, err = DecodeDDS(buildDDS(4, 4, 1, ddsPF{flags: ddpfFourCC, fourCC: "DX10"}, 95, build(462)))
+	if err != nil {
+		t.Fatalf("BC6H decode failed: %v", err)
+	}
+	if r := nrgbaAt(t, img, 2, 2).R; r < 126 || r > 130 {
+		t.Errorf("BC6H mid-gray R = %d, want ~128", r)
+	}
+}
+
+func TestDecodeDDS_Uncompressed(t *testing.T) {
+	tests := []struct {
+		name    string
+		pf      ddsPF
+		dxgi    uint32
+		payload []byte
+		want    color.NRGBA
+	}{
+		{
+			name:    "A8R8G8B8",
+			pf:      ddsPF{flags: ddpfRGB | ddpfAlphaPixels, bitCount: 32, masks: [4]uint32{0xFF0000, 0xFF00, 0xFF, 0xFF000000}},
+			payload: []byte{0x30, 0x20, 0x10, 0x80}, // BGRA
+			want:    color.NRGBA{0x10, 0x20, 0x30, 0x80},
+		},
+		{
+			name:    "X8R8G8B8",
+			pf:      ddsPF{flags: ddpfRGB, bitCount: 32, masks: [4]uint32{0xFF0000, 0xFF00, 0xFF, 0}},
+			payload: []byte{0x30, 0x20, 0x10, 0x00},
+			want:    color.NRGBA{0x10, 0x20, 0x30, 0xFF},
+		},
+		{
+			name:    "R5G6B5",
+			pf:      ddsPF{flags: ddpfRGB, bitCount: 16, masks: [4]uint32{0xF800, 0x07E0, 0x001F, 0}},
+			payload: []byte{0xE0, 0x07}, // pure green
+			want:    color.NRGBA{0, 255, 0, 255},
+		},
+		{
+			name:    "L8",
+			pf:      ddsPF{flags: ddpfLuminance, bitCount: 8, masks: [4]uint32{0xFF, 0, 0, 0}},
+			payload: []byte{0x42},
+			want:    color.NRGBA{0x42, 0x42, 0x42, 0xFF},
+		},
+		{
+			name:    "DX10 R8G8B8A8",
+			pf:      ddsPF{flags: ddpfFourCC, fourCC: "DX10"},
+			dxgi:    28,
+			payload: []byte{0x10, 0x20, 0x30, 0x40},
+			want:    color.NRGBA{0x10, 0x20, 0x30, 0x40},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1x1 top level followed by junk mip data that must be ignored
+			payload := append(append([]byte{}, tt.payload...), 0xDE, 0xAD, 0xBE, 0xEF)
+			img, err := DecodeDDS(buildDDS(1, 1, 2, tt.pf, tt.dxgi, payload))
+			if err != nil {
+				t.Fatalf("DecodeDDS failed: %v", err)
+			}
+			assertColor(t, img, 0, 0, tt.want)
+		})
+	}
+}
+
+func TestDecodeDDS_Errors(t *testing.T) {
+	if _, err := DecodeDDS([]byte("not a dds")); err == nil {
+		t.Error("expected error for invalid magic")
+	}
+
+	truncated := buildDDS(8, 8, 1, ddsPF{flags: ddpfFourCC, fourCC: "DXT1"}, 0, make([]byte, 8))
+	if _, err := DecodeDDS(truncated); err == nil {
+		t.Error("expected error for truncated block data")
+	}
+
+	// Header-only files claiming huge surfaces fail before allocating
+	huge := buildDDS(200000, 200000, 1, ddsPF{flags: ddpfFourCC, fourCC: "DXT1"}, 0, nil)
+	if _, err := DecodeDDS(huge); err == nil {
+		t.Error("expected error for oversized dimensions")
+	}
+	large := buildDDS(maxTextureSize, maxTextureSize, 1, ddsPF{flags: ddpfRGB | ddpfAlphaPixels, bitCount: 32}, 0, make([]byte, 64))
+	if _, err := DecodeDDS(large); err == nil {
+		t.Error("expected error for a header without its pixel data")
+	}
+
+	unknown := buildDDS(4, 4, 1, ddsPF{flags: ddpfFourCC, fourCC: "ABCD"}, 0, make([]byte, 16))
+	if _, err := DecodeDDS(unknown); err == nil {
+		t.Error("expected error for unknown FourCC")
+	}
+}
+
+func TestDecodeDDS_NonMultipleOfFourSize(t *testing.T) {
+	var indices [16]uint8
+	block := bc1Block(0xF800, 0xF800, indices)
+	// 6x2 needs two blocks horizontally, one vertically
+	data := buildDDS(6, 2, 1, ddsPF{flags: ddpfFourCC, fourCC: "DXT1"}, 0, append(block, block...))
+
+	img, err := DecodeDDS(data)
+	if err != nil {
+		t.Fatalf("DecodeDDS failed: %v", err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 6, 2) {
+		t.Fatalf("unexpected bounds %v", img.Bounds())
+	}
+	assertColor(t, img, 5, 1, color.NRGBA{255, 0, 0, 255})
+}
+
+// TGA tests
+
+func TestDecodeTGA_Uncompressed24BottomLeft(t *testing.T) {
+	// 2x2, bottom-left origin: first row in file is the bottom row
+	pixels := []byte{
+		0, 0, 255, 0, 255, 0, // bottom: red, green
+		255, 0, 0, 255, 255, 255, // top: blue, white
+	}
+	img, err := Decode(buildTGA(tgaTrueColor, 2, 2, 24, 0, nil, 0, pixels), ".TGA")
+	if err != nil {
+		t.Fatalf("DecodeTGA failed: %v", err)
+	}
+
+	assertColor(t, img, 0, 1, color.NRGBA{255, 0, 0, 255})
+	assertColor(t, img, 1, 1, color.NRGBA{0, 255, 0, 255})
+	assertColor(t, img, 0, 0, color.NRGBA{0, 0, 255, 255})
+	assertColor(t, img, 1, 0, color.NRGBA{255, 255, 255, 255})
+}
+
+func TestDecodeTGA_RLE32TopLeft(t *testing.T) {
+	// 3x1: run of two semi-transparent red pixels, then one raw green pixel
+	pixels := []byte{
+		0x81, 0, 0, 255, 128,
+		0x00, 0, 255, 0, 255,
+	}
+	img, err := DecodeTGA(buildTGA(tgaRLETrueColor, 3, 1, 32, 0x28, nil, 0, pixels))
+	if err != nil {
+		t.Fatalf("DecodeTGA failed: %v", err)
+	}
+
+	assertColor(t, img, 0, 0, color.NRGBA{255, 0, 0, 128})
+	assertColor(t, img, 1, 0, color.NRGBA{255, 0, 0, 128})
+	assertColor(t, img, 2, 0, color.NRGBA{0, 255, 0, 255})
+}
+
+func TestDecodeTGA_GrayscaleAndColorMapped(t *testing.T) {
+	img, err := DecodeTGA(buildTGA(tgaGrayscale, 2, 1, 8, 0x20, nil, 0, []byte{0x10, 0xF0}))
+	if err != nil {
+		t.Fatalf("grayscale DecodeTGA failed: %v", err)
+	}
+	assertColor(t, img, 1, 0, color.NRGBA{0xF0, 0xF0, 0xF0, 255})
+
+	palette := []byte{255, 0, 0, 0, 0, 255} // blue, red (BGR)
+	img, err = DecodeTGA(buildTGA(tgaColorMapped, 2, 1, 8, 0x20, palette, 24, []byte{1, 0}))
+	if err != nil {
+		t.Fatalf("color-mapped DecodeTGA failed: %v", err)
+	}
+	assertColor(t, img, 0, 0, color.NRGBA{255, 0, 0, 255})
+	assertColor(t, img, 1, 0, color.NRGBA{0, 0, 255, 255})
+}
+
+func TestDecodeTGA_Errors(t *testing.T) {
+	if _, err := DecodeTGA([]byte{0, 0}); err == nil {
+		t.Error("expected error for short data")
+	}
+	if _, err := DecodeTGA(buildTGA(tgaTrueColor, 4, 4, 24, 0, nil, 0, []byte{1, 2, 3})); err == nil {
+		t.Error("expected error for truncated pixels")
+	}
+	if _, err := DecodeTGA(buildTGA(tgaRLETrueColor, 65535, 65535, 32, 0, nil, 0, []byte{0xFF, 1, 2, 3, 4})); err == nil {
+		t.Error("expected error for oversized dimensions")
+	}
+	if _, err := DecodeTGA(buildTGA(tgaRLETrueColor, 4096, 4096, 32, 0, nil, 0, []byte{0xFF, 1, 2, 3, 4})); err == nil {
+		t.Error("expected error for a header without its RLE data")
+	}
+	if _, err := DecodeTGA(buildTGA(7, 1, 1, 24, 0, nil, 0, []byte{1, 2, 3})); err == nil {
+		t.Error("expected error for unsupported image type")
+	}
+}
+
+// Frame slicing and file decoding
+
+func TestSliceFrames(t *testing.T) {
+	// 3-frame strip, each frame 2px wide in a distinct color
+	strip := image.NewNRGBA(image.Rect(0, 0, 6, 2))
+	colors := []color.NRGBA{{255, 0, 0, 255}, {0, 255, 0, 255}, {0, 0, 255, 255}}
+	for x := 0; x < 6; x++ {
+		for y := 0; y < 2; y++ {
+			strip.SetNRGBA(x, y, colors[x/2])
+		}
+	}
+
+	frames := SliceFrames(strip, 3)
+	if len(frames) != 3 {
+		t.Fatalf("expected 3 frames, got %d", len(frames))
+	}
+	for i, frame := range frames {
+		if frame.Bounds().Dx() != 2 || frame.Bounds().Dy() != 2 {
+			t.Errorf("frame %d has size %v", i, frame.Bounds())
+		}
+		min := frame.Bounds().Min
+		assertColor(t, frame, min.X, min.Y, colors[i])
+	}
+
+	// Out-of-range index clamps to the last frame
+	last := Frame(strip, 3, 10)
+	assertColor(t, last, last.Bounds().Min.X, 0, colors[2])
+
+	// Single-frame sprites return the image unchanged
+	if Frame(strip, 1, 0) != image.Image(strip) {
+		t.Error("expected single frame to return the original image")
+	}
+}
+
+func TestDecodeFile(t *testing.T) {
+	dir := t.TempDir()
+
+	var indices [16]uint8
+	ddsPath := filepath.Join(dir, "icon.DDS")
+	if err := os.WriteFile(ddsPath, buildDDS(4, 4, 1, ddsPF{flags: ddpfFourCC, fourCC: "DXT1"}, 0, bc1Block(0x07E0, 0x07E0, indices)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := DecodeFile(ddsPath)
+	if err != nil {
+		t.Fatalf("DecodeFile(dds) failed: %v", err)
+	}
+	assertColor(t, img, 0, 0, color.NRGBA{0, 255, 0, 255})
+
+	src := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	src.SetNRGBA(0, 0, color.NRGBA{1, 2, 3, 255})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+	pngPath := filepath.Join(dir, "icon.png")
+	if err := os.WriteFile(pngPath, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err = DecodeFile(pngPath)
+	if err != nil {
+		t.Fatalf("DecodeFile(png) failed: %v", err)
+	}
+	assertColor(t, img, 0, 0, color.NRGBA{1, 2, 3, 255})
+
+	if _, err := DecodeFile(filepath.Join(dir, "missing.dds")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+// Encoding
+
+func TestEncodeDDS_RoundTrip(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 6, 5))
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 6; x++ {
+			// Diagonal gradient: typical of smooth artwork
+			v := (x + y) * 8
+			src.SetNRGBA(x, y, color.NRGBA{uint8(40 + v), uint8(200 - v), uint8(60 + v/2), uint8(255 - v)})
+		}
+	}
+
+	data, err := EncodeDDS(src, EncodeUncompressed)
+	if err != nil {
+		t.Fatalf("EncodeDDS(uncompressed) failed: %v", err)
+	}
+	img, err := DecodeDDS(data)
+	if err != nil {
+		t.Fatalf("DecodeDDS failed: %v", err)
+	}
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 6; x++ {
+			assertColor(t, img, x, y, src.NRGBAAt(x, y))
+		}
+	}
+
+	data, err = EncodeDDS(src, EncodeDXT5)
+	if err != nil {
+		t.Fatalf("EncodeDDS(DXT5) failed: %v", err)
+	}
+	img, err = DecodeDDS(data)
+	if err != nil {
+		t.Fatalf("DecodeDDS failed: %v", err)
+	}
+	if img.Bounds() != src.Bounds() {
+		t.Fatalf("unexpected bounds %v", img.Bounds())
+	}
+
+	// Lossy: every channel must stay close to the source
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 6; x++ {
+			got := nrgbaAt(t, img, x, y)
+			want := src.NRGBAAt(x, y)
+			for _, diff := range []int{
+				int(got.R) - int(want.R), int(got.G) - int(want.G),
+				int(got.B) - int(want.B), int(got.A) - int(want.A),
+			} {
+				if diff < -12 || diff > 12 {
+					t.Fatalf("pixel (%d,%d) = %v, too far from %v", x, y, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestFit(t *testing.T) {
+	// 4x2 opaque red fitted into 8x8: scaled to 8x4, centered vertically
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for i := range src.Pix {
+		if i%4 == 0 || i%4 == 3 {
+			src.Pix[i] = 255
+		}
+	}
+
+	out := Fit(src, 8, 8)
+	if out.Bounds() != image.Rect(0, 0, 8, 8) {
+		t.Fatalf("unexpected bounds %v", out.Bounds())
+	}
+	assertColor(t, out, 0, 0, color.NRGBA{})
+	assertColor(t, out, 0, 2, color.NRGBA{255, 0, 0, 255})
+	assertColor(t, out, 7, 5, color.NRGBA{255, 0, 0, 255})
+	assertColor(t, out, 7, 7, color.NRGBA{})
+}
@@ -0,0 +1,221 @@
+package texture
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+)
+
+const tgaHeaderSize = 18
+
+// TGA image types
+const (
+	tgaColorMapped    = 1
+	tgaTrueColor      = 2
+	tgaGrayscale      = 3
+	tgaRLEColorMapped = 9
+	tgaRLETrueColor   = 10
+	tgaRLEGrayscale   = 11
+)
+
+// DecodeTGA decodes an uncompressed or RLE TGA image (color-mapped,
+// true-color or grayscale) into an NRGBA image
+func DecodeTGA(data []byte) (image.Image, error) {
+	if len(data) < tgaHeaderSize {
+		return nil, fmt.Errorf("tga: file too short")
+	}
+
+	idLength := int(data[0])
+	colorMapType := data[1]
+	imageType := data[2]
+	colorMapOrigin := int(binary.LittleEndian.Uint16(data[3:]))
+	colorMapLength := int(binary.LittleEndian.Uint16(data[5:]))
+	colorMapDepth := int(data[7])
+	width := int(binary.LittleEndian.Uint16(data[12:]))
+	height := int(binary.LittleEndian.Uint16(data[14:]))
+	depth := int(data[16])
+	descriptor := data[17]
+
+	if width == 0 || height == 0 || width > maxTextureSize || height > maxTextureSize {
+		return nil, fmt.Errorf("tga: invalid dimensions %dx%d", width, height)
+	}
+
+	rle := false
+	switch imageType {
+	case tgaColorMapped, tgaTrueColor, tgaGrayscale:
+	case tgaRLEColorMapped, tgaRLETrueColor, tgaRLEGrayscale:
+		rle = true
+	default:
+		return nil, fmt.Errorf("tga: unsupported image type %d", imageType)
+	}
+
+	offset := tgaHeaderSize + idLength
+
+	// Color map (palette)
+	var palette []color.NRGBA
+	if colorMapType == 1 {
+		entrySize := (colorMapDepth + 7) / 8
+		size := colorMapLength * entrySize
+		if offset+size > len(data) {
+			return nil, fmt.Errorf("tga: truncated color map")
+		}
+		palette = make([]color.NRGBA, colorMapOrigin+colorMapLength)
+		for i := 0; i < colorMapLength; i++ {
+			c, err := tgaTrueColorPixel(data[offset+i*entrySize:], colorMapDepth, true)
+			if err != nil {
+				return nil, err
+			}
+			palette[colorMapOrigin+i] = c
+		}
+		offset += size
+	}
+
+	baseType := imageType
+	if rle {
+		baseType -= 8
+	}
+	if baseType == tgaColorMapped && palette == nil {
+		return nil, fmt.Errorf("tga: color-mapped image without color map")
+	}
+
+	// Alpha is only honoured when the descriptor declares alpha bits
+	alphaBits := int(descriptor & 0x0F)
+	hasAlpha := alphaBits > 0 || depth == 32
+
+	pixelSize := (depth + 7) / 8
+	if pixelSize == 0 {
+		return nil, fmt.Errorf("tga: invalid pixel depth %d", depth)
+	}
+
+	decodePixel := func(p []byte) (color.NRGBA, error) {
+		switch baseType {
+		case tgaColorMapped:
+			index := int(p[0])
+			if pixelSize == 2 {
+				index = int(binary.LittleEndian.Uint16(p))
+			}
+			if index >= len(palette) {
+				return color.NRGBA{}, fmt.Errorf("tga: color index %d out of range", index)
+			}
+			return palette[index], nil
+		case tgaGrayscale:
+			a := uint8(255)
+			if depth == 16 && hasAlpha {
+				a = p[1]
+			}
+			return color.NRGBA{R: p[0], G: p[0], B: p[0], A: a}, nil
+		default:
+			return tgaTrueColorPixel(p, depth, hasAlpha)
+		}
+	}
+
+	// Check the pixel data before allocating for the header's size; an RLE
+	// packet covers at most 128 pixels
+	count := width * height
+	if !rle && offset+count*pixelSize > len(data) {
+		return nil, fmt.Errorf("tga: truncated pixel data")
+	}
+	if rle && offset+(count+127)/128*(1+pixelSize) > len(data) {
+		return nil, fmt.Errorf("tga: truncated RLE data")
+	}
+	pixels := make([]color.NRGBA, count)
+
+	if !rle {
+		for i := 0; i < count; i++ {
+			c, err := decodePixel(data[offset+i*pixelSize:])
+			if err != nil {
+				return nil, err
+			}
+			pixels[i] = c
+		}
+	} else {
+		for i := 0; i < count; {
+			if offset >= len(data) {
+				return nil, fmt.Errorf("tga: truncated RLE data")
+			}
+			packet := data[offset]
+			offset++
+			run := int(packet&0x7F) + 1
+			if i+run > count {
+				run = count - i
+			}
+
+			if packet&0x80 != 0 {
+				// Run-length packet: one pixel repeated
+				if offset+pixelSize > len(data) {
+					return nil, fmt.Errorf("tga: truncated RLE data")
+				}
+				c, err := decodePixel(data[offset:])
+				if err != nil {
+					return nil, err
+				}
+				offset += pixelSize
+				for j := 0; j < run; j++ {
+					pixels[i+j] = c
+				}
+			} else {
+				// Raw packet
+				if offset+run*pixelSize > len(data) {
+					return nil, fmt.Errorf("tga: truncated RLE data")
+				}
+				for j := 0; j < run; j++ {
+					c, err := decodePixel(data[offset:])
+					if err != nil {
+						return nil, err
+					}
+					offset += pixelSize
+					pixels[i+j] = c
+				}
+			}
+			i += run
+		}
+	}
+
+	// Bit 5: top-left origin (default bottom-left); bit 4: right-to-left
+	topToBottom := descriptor&0x20 != 0
+	rightToLeft := descriptor&0x10 != 0
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for row := 0; row < height; row++ {
+		y := row
+		if !topToBottom {
+			y = height - 1 - row
+		}
+		for col := 0; col < width; col++ {
+			x := col
+			if rightToLeft {
+				x = width - 1 - col
+			}
+			img.SetNRGBA(x, y, pixels[row*width+col])
+		}
+	}
+
+	return img, nil
+}
+
+// tgaTrueColorPixel decodes a BGR(A) pixel of the given bit depth
+func tgaTrueColorPixel(p []byte, depth int, hasAlpha bool) (color.NRGBA, error) {
+	switch depth {
+	case 15, 16:
+		v := binary.LittleEndian.Uint16(p)
+		r := uint8(v>>10) & 0x1F
+		g := uint8(v>>5) & 0x1F
+		b := uint8(v) & 0x1F
+		a := uint8(255)
+		if depth == 16 && hasAlpha && v&0x8000 == 0 {
+			a = 0
+		}
+		return color.NRGBA{R: r<<3 | r>>2, G: g<<3 | g>>2, B: b<<3 | b>>2, A: a}, nil
+	case 24:
+		return color.NRGBA{R: p[2], G: p[1], B: p[0], A: 255}, nil
+	case 32:
+		a := p[3]
+		if !hasAlpha {
+			a = 255
+		}
+		return color.NRGBA{R: p[2], G: p[1], B: p[0], A: a}, nil
+	default:
+		return color.NRGBA{}, fmt.Errorf("tga: unsupported pixel depth %d", depth)
+	}
+}
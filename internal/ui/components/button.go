@@ -107,3 +107,24 @@ func (b *Button) IsClicked() bool {
 func (b *Button) IsHovered() bool {
 	return b.hovered
 }
+
+// ButtonGroup is the buttons of a scene, updated together so clicks on them
+// can be told apart from clicks on the canvas below
+type ButtonGroup []*Button
+
+// Update updates the state of every button
+func (g ButtonGroup) Update() {
+	for _, button := range g {
+		button.Update()
+	}
+}
+
+// Hovered returns true if the mouse is over any of the buttons
+func (g ButtonGroup) Hovered() bool {
+	for _, button := range g {
+		if button.IsHovered() {
+			return true
+		}
+	}
+	return false
+}
@@ -1,19 +1,27 @@
 package components
 
 import (
-	"bytes"
 	"image"
 	"image/color"
-	_ "image/jpeg"
-	_ "image/png"
 	"os"
 	"path/filepath"
 	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/lukegb/dds"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/texture"
 )
 
+// SpriteSource resolves GFX_* sprites declared in interface/*.gfx and loads
+// their textures (app.SpriteRegistry)
+type SpriteSource interface {
+	ResolveTechIcon(techID, countryTag string) (*domain.Sprite, bool)
+	ResolveFocusIcon(icon string) (*domain.Sprite, bool)
+	ResolvePortrait(portrait string) (*domain.Sprite, bool)
+	LoadImage(sprite *domain.Sprite) (image.Image, error)
+}
+
 // IconLoader handles loading and caching of technology/focus icons
 type IconLoader struct {
 	basePath string // Mod folder path
@@ -21,6 +29,10 @@ type IconLoader struct {
 	cache    map[string]*ebiten.Image
 	mu       sync.RWMutex
 
+	// Sprite resolution through interface/*.gfx (optional)
+	sprites    SpriteSource
+	countryTag string // For country-specific tech sprites (GFX_GER_<tech>_medium)
+
 	// Placeholder for missing icons
 	placeholder *ebiten.Image
 }
@@ -46,6 +58,22 @@ func (il *IconLoader) SetGamePath(gamePath string) {
 	il.gamePath = gamePath
 }
 
+// SetSpriteRegistry enables resolving icons through .gfx sprite declarations
+func (il *IconLoader) SetSpriteRegistry(registry SpriteSource) {
+	il.mu.Lock()
+	defer il.mu.Unlock()
+	il.sprites = registry
+	il.cache = make(map[string]*ebiten.Image)
+}
+
+// SetCountryTag sets the country tag used for country-specific tech sprites
+func (il *IconLoader) SetCountryTag(tag string) {
+	il.mu.Lock()
+	defer il.mu.Unlock()
+	il.countryTag = tag
+	il.cache = make(map[string]*ebiten.Image)
+}
+
 // detectGamePath tries to auto-detect HOI4 installation path
 func detectGamePath() string {
 	// Common installation paths
@@ -81,8 +109,11 @@ func (il *IconLoader) LoadTechIcon(iconName string) *ebiten.Image {
 	}
 	il.mu.RUnlock()
 
-	// Try to load from file
-	img := il.loadIconFromFile(iconName, "technologies")
+	// Resolve through sprite registry first, then guess the file name
+	img := il.loadTechSprite(iconName)
+	if img == nil {
+		img = il.loadIconFromFile(iconName, "technologies")
+	}
 
 	// Cache the result (even if it's placeholder)
 	il.mu.Lock()
@@ -106,8 +137,11 @@ func (il *IconLoader) LoadFocusIcon(iconName string) *ebiten.Image {
 	}
 	il.mu.RUnlock()
 
-	// Try to load from file
-	img := il.loadIconFromFile(iconName, "goals")
+	// Resolve through sprite registry first, then guess the file name
+	img := il.loadFocusSprite(iconName)
+	if img == nil {
+		img = il.loadIconFromFile(iconName, "goals")
+	}
 
 	// Cache the result
 	il.mu.Lock()
@@ -117,11 +151,101 @@ func (il *IconLoader) LoadFocusIcon(iconName string) *ebiten.Image {
 	return img
 }
 
+// LoadSprite loads a sprite thumbnail by GFX_* name (used by the icon browser)
+func (il *IconLoader) LoadSprite(spriteName string) *ebiten.Image {
+	key := "sprite:" + spriteName
+
+	il.mu.RLock()
+	if img, exists := il.cache[key]; exists {
+		il.mu.RUnlock()
+		return img
+	}
+	il.mu.RUnlock()
+
+	img := il.loadFocusSprite(spriteName)
+	if img == nil {
+		img = il.placeholder
+	}
+
+	il.mu.Lock()
+	il.cache[key] = img
+	il.mu.Unlock()
+
+	return img
+}
+
+// LoadPortrait loads a character portrait by sprite name or texture path
+func (il *IconLoader) LoadPortrait(portrait string) *ebiten.Image {
+	key := "portrait:" + portrait
+
+	il.mu.RLock()
+	if img, exists := il.cache[key]; exists {
+		il.mu.RUnlock()
+		return img
+	}
+	il.mu.RUnlock()
+
+	var img *ebiten.Image
+	if il.sprites != nil {
+		if sprite, ok := il.sprites.ResolvePortrait(portrait); ok {
+			img = il.loadSpriteImage(sprite)
+		}
+	}
+	if img == nil {
+		img = il.placeholder
+	}
+
+	il.mu.Lock()
+	il.cache[key] = img
+	il.mu.Unlock()
+
+	return img
+}
+
+// loadTechSprite loads a tech icon via GFX_<tech>_medium sprite lookup
+func (il *IconLoader) loadTechSprite(techID string) *ebiten.Image {
+	if il.sprites == nil {
+		return nil
+	}
+
+	sprite, ok := il.sprites.ResolveTechIcon(techID, il.countryTag)
+	if !ok {
+		return nil
+	}
+
+	return il.loadSpriteImage(sprite)
+}
+
+// loadFocusSprite loads a focus icon via its GFX_* sprite name
+func (il *IconLoader) loadFocusSprite(spriteName string) *ebiten.Image {
+	if il.sprites == nil {
+		return nil
+	}
+
+	sprite, ok := il.sprites.ResolveFocusIcon(spriteName)
+	if !ok {
+		return nil
+	}
+
+	return il.loadSpriteImage(sprite)
+}
+
+// loadSpriteImage loads the sprite texture and crops the first frame
+func (il *IconLoader) loadSpriteImage(sprite *domain.Sprite) *ebiten.Image {
+	img, err := il.sprites.LoadImage(sprite)
+	if err != nil {
+		return nil
+	}
+
+	return ebiten.NewImageFromImage(img)
+}
+
 // loadIconFromFile attempts to load icon from various possible locations
 func (il *IconLoader) loadIconFromFile(iconName, category string) *ebiten.Image {
 	// First try mod folder
 	modPaths := []string{
 		filepath.Join(il.basePath, "gfx", "interface", category, iconName+".dds"),
+		filepath.Join(il.basePath, "gfx", "interface", category, iconName+".tga"),
 		filepath.Join(il.basePath, "gfx", "interface", category, iconName+".png"),
 	}
 
@@ -135,6 +259,7 @@ func (il *IconLoader) loadIconFromFile(iconName, category string) *ebiten.Image
 	if il.gamePath != "" {
 		gamePaths := []string{
 			filepath.Join(il.gamePath, "gfx", "interface", category, iconName+".dds"),
+			filepath.Join(il.gamePath, "gfx", "interface", category, iconName+".tga"),
 			filepath.Join(il.gamePath, "gfx", "interface", category, iconName+".png"),
 		}
 
@@ -156,41 +281,14 @@ func (il *IconLoader) tryLoadImage(path string) *ebiten.Image {
 		return nil
 	}
 
-	// Read file
-	data, err := os.ReadFile(path)
+	// Decode DDS/TGA/PNG/JPEG based on extension
+	img, err := texture.DecodeFile(path)
 	if err != nil {
 		return nil
 	}
 
-	// Try to decode based on extension
-	ext := filepath.Ext(path)
-
-	var img image.Image
-	switch ext {
-	case ".dds":
-		// Decode DDS using lukegb/dds library
-		img, err = dds.Decode(bytes.NewReader(data))
-		if err != nil {
-			// DDS decode failed, return nil
-			return nil
-		}
-
-	case ".png", ".jpg", ".jpeg":
-		img, _, err = image.Decode(bytes.NewReader(data))
-		if err != nil {
-			return nil
-		}
-
-	default:
-		return nil
-	}
-
 	// Convert to ebiten image
-	if img != nil {
-		return ebiten.NewImageFromImage(img)
-	}
-
-	return nil
+	return ebiten.NewImageFromImage(img)
 }
 
 // createPlaceholder creates a placeholder image for missing icons
@@ -217,6 +315,13 @@ func (il *IconLoader) ClearCache() {
 	il.cache = make(map[string]*ebiten.Image)
 }
 
+// Invalidate drops a cached icon so the next load resolves it again
+func (il *IconLoader) Invalidate(iconName string) {
+	il.mu.Lock()
+	delete(il.cache, iconName)
+	il.mu.Unlock()
+}
+
 // GetCacheSize returns the number of cached icons
 func (il *IconLoader) GetCacheSize() int {
 	il.mu.RLock()
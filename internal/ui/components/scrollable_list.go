@@ -1,6 +1,7 @@
 package components
 
 import (
+	"fmt"
 	"image/color"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -143,7 +144,7 @@ func (sl *ScrollableList) Draw(screen *ebiten.Image) {
 	if endIndex < len(sl.items) {
 		remaining := len(sl.items) - endIndex
 		indicatorY := sl.y + sl.height - 20
-		ebitenutil.DebugPrintAt(screen, "... "+string(rune(remaining+'0'))+" more", sl.x+10, indicatorY)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("... %d more", remaining), sl.x+10, indicatorY)
 	}
 }
 
@@ -183,6 +184,21 @@ func (sl *ScrollableList) GetSelectedIndex() int {
 	return sl.selectedIndex
 }
 
+// SetSelectedIndex selects an item and scrolls it into view (-1 clears)
+func (sl *ScrollableList) SetSelectedIndex(index int) {
+	if index < 0 || index >= len(sl.items) {
+		sl.selectedIndex = -1
+		return
+	}
+
+	sl.selectedIndex = index
+	if index < sl.scrollOffset {
+		sl.scrollOffset = index
+	} else if index >= sl.scrollOffset+sl.displayCount {
+		sl.scrollOffset = index - sl.displayCount + 1
+	}
+}
+
 // ScrollUp scrolls the list up
 func (sl *ScrollableList) ScrollUp() {
 	if sl.scrollOffset > 0 {
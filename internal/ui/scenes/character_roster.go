@@ -0,0 +1,219 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
+)
+
+// Character roster layout
+const (
+	portraitWidth    = 156
+	portraitHeight   = 210
+	maxMissingRows   = 12
+	characterDetailX = 470
+)
+
+// CharacterRosterScene lists the selected country's characters and
+// reports character IDs referenced by focuses/history that do not exist
+type CharacterRosterScene struct {
+	manager    *SceneManager
+	state      *app.State
+	iconLoader *components.IconLoader
+
+	characters []*domain.Character
+	list       *components.ScrollableList
+	selected   *domain.Character
+	missing    []domain.CharacterReference
+
+	backButton *components.Button
+}
+
+// NewCharacterRosterScene creates the roster for the state's country
+func NewCharacterRosterScene(manager *SceneManager, state *app.State) *CharacterRosterScene {
+	scene := &CharacterRosterScene{
+		manager: manager,
+		state:   state,
+		list:    components.NewScrollableList(20, 110, 420, 520, 13),
+	}
+
+	scene.iconLoader = components.NewIconLoader(state.GetModPath())
+	if gamePath := state.GetGamePath(); gamePath != "" {
+		scene.iconLoader.SetGamePath(gamePath)
+	}
+	scene.iconLoader.SetSpriteRegistry(state.GetSpriteRegistry())
+
+	scene.backButton = components.NewButton(20, 650, 160, 50, "← Back")
+
+	scene.loadRoster()
+
+	return scene
+}
+
+// loadRoster collects the country's characters and validates references
+func (s *CharacterRosterScene) loadRoster() {
+	ctx := s.state.GetCountryContext()
+	if ctx == nil {
+		return
+	}
+
+	characters := s.state.GetCharacters()
+	s.characters = characters.ForTag(ctx.GetTag())
+
+	items := make([]string, len(s.characters))
+	for i, character := range s.characters {
+		items[i] = fmt.Sprintf("%-36s %s", character.ID, strings.Join(character.RoleTypes(), ","))
+	}
+	s.list.SetItems(items)
+
+	// Validate against the viewed focus tree, or the country's tree file
+	tree := s.state.FocusTree
+	if tree == nil {
+		if path, err := ctx.GetFocusPath(); err == nil {
+			if focuses, err := app.LoadFocusFile(path); err == nil {
+				tree = app.NewFocusTreeFromFocuses(ctx.GetTag(), focuses)
+			}
+		}
+	}
+	refs := app.CountryCharacterReferences(tree, s.state.GetModPath(), s.state.GetGamePath(), ctx.GetTag())
+	s.missing = characters.Missing(refs)
+}
+
+// Update updates the roster
+func (s *CharacterRosterScene) Update() error {
+	s.backButton.Update()
+
+	if s.backButton.IsClicked() || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.manager.SwitchToNamed("country_menu")
+		return nil
+	}
+
+	s.list.Update()
+	if index := s.list.GetSelectedIndex(); index >= 0 && index < len(s.characters) {
+		s.selected = s.characters[index]
+	}
+
+	return nil
+}
+
+// localize returns the display name for a key
+func (s *CharacterRosterScene) localize(key string) string {
+	if ctx := s.state.GetCountryContext(); ctx != nil {
+		return parser.GetLocalization(ctx.Localizations, key)
+	}
+	return key
+}
+
+// Draw renders the roster
+func (s *CharacterRosterScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{30, 30, 30, 255})
+
+	title := "Characters"
+	if ctx := s.state.GetCountryContext(); ctx != nil {
+		title = "Characters of " + ctx.GetDisplayName() + " (" + ctx.GetTag() + ")"
+	}
+	ebitenutil.DebugPrintAt(screen, title, 20, 20)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d characters", len(s.characters)), 20, 80)
+
+	s.list.Draw(screen)
+
+	if s.selected != nil {
+		s.drawDetails(screen)
+	} else {
+		ebitenutil.DebugPrintAt(screen, "Select a character to view it", characterDetailX, 60)
+	}
+
+	s.drawMissing(screen)
+	s.backButton.Draw(screen)
+}
+
+// drawDetails draws the selected character's portrait, roles and traits
+func (s *CharacterRosterScene) drawDetails(screen *ebiten.Image) {
+	character := s.selected
+	x := characterDetailX
+
+	if portrait := character.Portrait(); portrait != "" {
+		img := s.iconLoader.LoadPortrait(portrait)
+		bounds := img.Bounds()
+		scale := float64(portraitWidth) / float64(bounds.Dx())
+		if h := float64(portraitHeight) / float64(bounds.Dy()); h < scale {
+			scale = h
+		}
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(scale, scale)
+		op.GeoM.Translate(float64(x), 60)
+		screen.DrawImage(img, op)
+	}
+
+	textX := x + portraitWidth + 20
+	nameKey := character.ID
+	if character.Name != "" {
+		nameKey = character.Name
+	}
+	lines := []string{character.ID, s.localize(nameKey)}
+	if character.File != nil {
+		lines = append(lines, fmt.Sprintf("%s (%s)", character.File.RelPath, character.File.Source))
+	}
+	lines = append(lines, "Portrait: "+character.Portrait(), "")
+
+	for _, role := range character.Roles {
+		line := role.Type
+		switch {
+		case role.Ideology != "":
+			line += " - " + role.Ideology
+		case role.Slot != "":
+			line += " - " + role.Slot
+		case role.Skill != "":
+			line += " - skill " + role.Skill
+		}
+		if role.Cost != "" {
+			line += " (cost " + role.Cost + ")"
+		}
+		lines = append(lines, line)
+		if len(role.Traits) > 0 {
+			lines = append(lines, "  traits: "+strings.Join(role.Traits, ", "))
+		}
+	}
+
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, line, textX, 60+i*16)
+	}
+}
+
+// drawMissing lists character IDs referenced but not declared
+func (s *CharacterRosterScene) drawMissing(screen *ebiten.Image) {
+	x, y := characterDetailX, 330
+
+	if len(s.missing) == 0 {
+		ebitenutil.DebugPrintAt(screen, "All referenced characters exist", x, y)
+		return
+	}
+
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Missing characters (%d):", len(s.missing)), x, y)
+	for i, ref := range s.missing {
+		if i == maxMissingRows {
+			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("... and %d more", len(s.missing)-maxMissingRows), x, y+(i+1)*16)
+			break
+		}
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%-36s used by %s", ref.ID, ref.Source), x, y+(i+1)*16)
+	}
+}
+
+// OnEnter is called when entering this scene
+func (s *CharacterRosterScene) OnEnter() {
+	// Nothing to do
+}
+
+// OnExit is called when leaving this scene
+func (s *CharacterRosterScene) OnExit() {
+	// Nothing to do
+}
@@ -2,9 +2,12 @@ package scenes
 
 import (
 	"image/color"
+	"path/filepath"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/sqweek/dialog"
+
 	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
 	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
 	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
@@ -16,9 +19,15 @@ type CountryMenuScene struct {
 	state   *app.State
 
 	// Buttons
-	focusTreeButton *components.Button
-	techButton      *components.Button
-	backButton      *components.Button
+	focusTreeButton  *components.Button
+	techButton       *components.Button
+	iconsButton      *components.Button
+	ideasButton      *components.Button
+	decisionsButton  *components.Button
+	charactersButton *components.Button
+	mergeButton      *components.Button
+	locButton        *components.Button
+	backButton       *components.Button
 
 	// Tech categories list (shown when tech button clicked)
 	showTechCategories bool
@@ -38,6 +47,12 @@ func NewCountryMenuScene(manager *SceneManager, state *app.State) *CountryMenuSc
 	// Create main buttons
 	scene.focusTreeButton = components.NewButton(440, 250, 400, 60, "National Focus Tree")
 	scene.techButton = components.NewButton(440, 330, 400, 60, "Technologies")
+	scene.iconsButton = components.NewButton(1030, 650, 200, 50, "Icon Browser")
+	scene.ideasButton = components.NewButton(820, 650, 200, 50, "Ideas")
+	scene.decisionsButton = components.NewButton(610, 650, 200, 50, "Decisions")
+	scene.charactersButton = components.NewButton(1030, 590, 200, 50, "Characters")
+	scene.mergeButton = components.NewButton(820, 590, 200, 50, "Merge Update")
+	scene.locButton = components.NewButton(610, 590, 200, 50, "Localisation")
 	scene.backButton = components.NewButton(50, 650, 200, 50, "← Back")
 
 	// Create scrollable list for tech categories
@@ -67,19 +82,83 @@ func (s *CountryMenuScene) loadTechCategories() {
 	s.techList.SetItems(displayNames)
 }
 
+// OnFilesChanged refreshes the technology categories when the folder
+// filter or their localisation changed on disk
+func (s *CountryMenuScene) OnFilesChanged(result *app.ReloadResult) {
+	if result.TechFolders || result.Localisation {
+		s.loadTechCategories()
+	}
+}
+
 // Update updates the country menu scene
 func (s *CountryMenuScene) Update() error {
 	// Update main buttons
 	s.focusTreeButton.Update()
 	s.techButton.Update()
+	s.iconsButton.Update()
+	s.ideasButton.Update()
+	s.decisionsButton.Update()
+	s.charactersButton.Update()
+	s.mergeButton.Update()
+	s.locButton.Update()
 	s.backButton.Update()
 
+	if s.manager.updateWorkspace() {
+		return nil
+	}
+
 	// Handle back button
 	if s.backButton.IsClicked() {
 		s.manager.SwitchToNamed("country_selection")
 		return nil
 	}
 
+	// Handle icon browser button
+	if s.iconsButton.IsClicked() {
+		browser := NewIconBrowserScene(s.manager, s.state)
+		s.manager.AddScene("icon_browser", browser)
+		s.manager.SwitchToNamed("icon_browser")
+		return nil
+	}
+
+	// Handle ideas button
+	if s.ideasButton.IsClicked() {
+		editor := NewIdeaEditorScene(s.manager, s.state)
+		s.manager.AddScene("idea_editor", editor)
+		s.manager.SwitchToNamed("idea_editor")
+		return nil
+	}
+
+	// Handle decisions button
+	if s.decisionsButton.IsClicked() {
+		editor := NewDecisionEditorScene(s.manager, s.state)
+		s.manager.AddScene("decision_editor", editor)
+		s.manager.SwitchToNamed("decision_editor")
+		return nil
+	}
+
+	// Handle characters button
+	if s.charactersButton.IsClicked() {
+		roster := NewCharacterRosterScene(s.manager, s.state)
+		s.manager.AddScene("character_roster", roster)
+		s.manager.SwitchToNamed("character_roster")
+		return nil
+	}
+
+	// Handle merge button
+	if s.mergeButton.IsClicked() {
+		merge := NewMergeScene(s.manager, s.state)
+		s.manager.AddScene("merge_tool", merge)
+		s.manager.SwitchToNamed("merge_tool")
+		return nil
+	}
+
+	// Handle localisation button
+	if s.locButton.IsClicked() {
+		s.handleLocalisationClick()
+		return nil
+	}
+
 	// Handle focus tree button
 	if s.focusTreeButton.IsClicked() {
 		s.handleFocusTreeClick()
@@ -119,9 +198,14 @@ func (s *CountryMenuScene) handleFocusTreeClick() {
 		return
 	}
 
-	// TODO: Create FocusViewerScene and switch to it
-	// For now, just show message
-	s.errorMessage = "Focus tree: " + focusPath + " (Viewer not yet implemented)"
+	// Switch to the tab if the tree is open already
+	if s.manager.ShowDocument(app.FocusDocumentKey(focusPath)) {
+		return
+	}
+
+	doc := app.NewFocusDocument(focusPath, ctx.GetTag())
+	focusViewer := NewFocusViewerScene(s.manager, s.state, doc)
+	s.manager.OpenDocument(doc, "focus_viewer", focusViewer)
 }
 
 // handleTechCategoryClick handles clicking a tech category
@@ -132,6 +216,11 @@ func (s *CountryMenuScene) handleTechCategoryClick(category string) {
 		return
 	}
 
+	// Switch to the tab if the folder is open already
+	if s.manager.ShowDocument(app.TechFolderKey(ctx.GetTag(), category)) {
+		return
+	}
+
 	// Load technologies for this folder
 	technologies, err := ctx.LoadTechnologiesForFolder(category)
 	if err != nil {
@@ -145,21 +234,54 @@ func (s *CountryMenuScene) handleTechCategoryClick(category string) {
 		techTree.AddTechnology(tech)
 	}
 
-	// Detect sub-trees for this folder
-	loader := app.NewTechnologyLoader(ctx.ModPath, ctx.GamePath)
-	subTrees := loader.DetectSubTrees(category, technologies)
-	if len(subTrees) > 0 {
-		techTree.SubTrees[category] = subTrees
-		println("Detected", len(subTrees), "sub-trees in", category)
+	// Split doctrine folders into grand doctrines and tracks,
+	// other folders into sub-trees
+	if domain.IsDoctrineFolder(technologies) {
+		doctrines := domain.BuildDoctrineTree(category, technologies)
+		techTree.Doctrines[category] = doctrines
+		println("Detected", len(doctrines.Grand), "grand doctrines in", category)
+	} else {
+		loader := app.NewTechnologyLoader(ctx.ModPath, ctx.GamePath)
+		subTrees := loader.DetectSubTrees(category, technologies)
+		if len(subTrees) > 0 {
+			techTree.SubTrees[category] = subTrees
+			println("Detected", len(subTrees), "sub-trees in", category)
+		}
 	}
 
 	// Set in state
 	s.state.TechnologyTree = techTree
 
 	// Create and switch to tech viewer
-	techViewer := NewTechViewerSceneWithTree(s.manager, techTree)
-	s.manager.AddScene("tech_viewer", techViewer)
-	s.manager.SwitchToNamed("tech_viewer")
+	doc := app.NewTechDocument(ctx.GetTag(), category, ctx.ModPath, technologies)
+	techViewer := NewTechViewerSceneWithTree(s.manager, techTree, doc)
+	s.manager.OpenDocument(doc, "tech_viewer", techViewer)
+}
+
+// handleLocalisationClick asks for a localisation file of the mod and opens
+// it in the localisation editor
+func (s *CountryMenuScene) handleLocalisationClick() {
+	path, err := dialog.File().
+		Filter("Localisation Files", "yml").
+		SetStartDir(filepath.Join(s.state.GetModPath(), "localisation")).
+		Title("Open Localisation File").
+		Load()
+	if err != nil {
+		if err.Error() != "Cancelled" {
+			s.errorMessage = "Error: " + err.Error()
+		}
+		return
+	}
+
+	if s.manager.ShowDocument(app.LocalizationDocumentKey(path)) {
+		return
+	}
+	doc, err := app.LoadLocalizationDocument(path)
+	if err != nil {
+		s.errorMessage = "Failed to open localisation: " + err.Error()
+		return
+	}
+	s.manager.OpenDocument(doc, "loc_editor", NewLocalizationEditorScene(s.manager, doc))
 }
 
 // Draw renders the country menu scene
@@ -205,8 +327,16 @@ func (s *CountryMenuScene) Draw(screen *ebiten.Image) {
 		s.techList.Draw(screen)
 	}
 
-	// Draw back button
+	// Draw icon browser, ideas, decisions, characters, merge, localisation
+	// and back buttons
+	s.iconsButton.Draw(screen)
+	s.ideasButton.Draw(screen)
+	s.decisionsButton.Draw(screen)
+	s.charactersButton.Draw(screen)
+	s.mergeButton.Draw(screen)
+	s.locButton.Draw(screen)
 	s.backButton.Draw(screen)
+	s.manager.drawWorkspace(screen)
 
 	// Draw error message
 	if s.errorMessage != "" {
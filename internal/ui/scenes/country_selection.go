@@ -1,6 +1,7 @@
 package scenes
 
 import (
+	"fmt"
 	"image/color"
 	"strings"
 
@@ -35,7 +36,11 @@ type CountrySelectionScene struct {
 	filterMinorButton *components.Button
 
 	errorMessage string
-	loading      bool
+
+	// Background loads: the country list, then the selected country
+	countriesJob *app.LoadJob[[]*domain.BookmarkCountry]
+	contextJob   *app.LoadJob[*app.CountryContext]
+	overlay      *LoadingOverlay
 }
 
 // NewCountrySelectionScene creates a new country selection scene
@@ -48,7 +53,6 @@ func NewCountrySelectionScene(manager *SceneManager, state *app.State) *CountryS
 		selectedIndex:     -1,
 		scrollOffset:      0,
 		filterAll:         true,
-		loading:           true,
 	}
 
 	// Create buttons
@@ -59,32 +63,26 @@ func NewCountrySelectionScene(manager *SceneManager, state *app.State) *CountryS
 	scene.filterMinorButton = components.NewButton(700, 150, 120, 40, "Minor")
 
 	// Load countries in background
-	go scene.loadCountries()
+	modPath, gamePath := state.GetModPath(), state.GetGamePath()
+	scene.countriesJob = app.StartLoadJob(func(progress *app.Progress) ([]*domain.BookmarkCountry, error) {
+		progress.SetStage("Parsing bookmarks")
+		return loadCountries(modPath, gamePath)
+	})
 
 	return scene
 }
 
-// loadCountries loads countries from bookmarks
-func (s *CountrySelectionScene) loadCountries() {
-	defer func() {
-		s.loading = false
-	}()
-
-	// Get mod and game paths
-	modPath := s.state.GetModPath()
-	gamePath := s.state.GetGamePath()
-
+// loadCountries loads countries from bookmarks (runs off the UI goroutine)
+func loadCountries(modPath, gamePath string) ([]*domain.BookmarkCountry, error) {
 	if modPath == "" {
-		s.errorMessage = "Mod path not set"
-		return
+		return nil, fmt.Errorf("mod path not set")
 	}
 
 	// Parse bookmarks
 	bookmarkParser := parser.NewBookmarkParser(modPath, gamePath)
 	bookmarks, err := bookmarkParser.ParseBookmarks()
 	if err != nil {
-		s.errorMessage = "Failed to load bookmarks: " + err.Error()
-		return
+		return nil, fmt.Errorf("failed to load bookmarks: %w", err)
 	}
 
 	// Extract all countries from all bookmarks
@@ -99,17 +97,58 @@ func (s *CountrySelectionScene) loadCountries() {
 	}
 
 	// Convert map to slice
-	s.countries = make([]*domain.BookmarkCountry, 0, len(countryMap))
+	countries := make([]*domain.BookmarkCountry, 0, len(countryMap))
 	for _, country := range countryMap {
-		s.countries = append(s.countries, country)
+		countries = append(countries, country)
 	}
+	return countries, nil
+}
 
-	// Apply initial filter
-	s.applyFilter()
+// updateJobs hands finished background loads over to the scene and the
+// state; true while the country context loads (the scene waits for it)
+func (s *CountrySelectionScene) updateJobs() bool {
+	if s.countriesJob != nil && s.countriesJob.Done() {
+		countries, err := s.countriesJob.Result()
+		s.countriesJob = nil
+		if err != nil {
+			s.errorMessage = err.Error()
+		}
+		s.countries = countries
+		s.applyFilter()
+	}
+
+	if s.contextJob != nil {
+		if !s.contextJob.Done() {
+			s.overlay.Update()
+			return true
+		}
+		ctx, err := s.contextJob.Result()
+		snapshot := s.contextJob.Progress.Snapshot()
+		s.contextJob, s.overlay = nil, nil
+		if err != nil {
+			s.errorMessage = err.Error()
+			return false
+		}
+		if len(snapshot.Errors) > 0 {
+			println("Loaded", ctx.GetTag(), "with", len(snapshot.Errors), "files that failed to parse")
+		}
+		s.state.UseCountryContext(ctx)
+
+		// Switch to country menu scene
+		countryMenu := NewCountryMenuScene(s.manager, s.state)
+		s.manager.AddScene("country_menu", countryMenu)
+		s.manager.SwitchToNamed("country_menu")
+		return true
+	}
+	return false
 }
 
 // Update updates the country selection scene
 func (s *CountrySelectionScene) Update() error {
+	if s.updateJobs() {
+		return nil
+	}
+
 	// Update buttons
 	s.backButton.Update()
 	s.continueButton.Update()
@@ -123,16 +162,14 @@ func (s *CountrySelectionScene) Update() error {
 		return nil
 	}
 
-	// Handle continue button (only if country selected)
+	// Handle continue button (only if country selected): load the country
+	// context in the background
 	if s.continueButton.IsClicked() && s.selectedIndex >= 0 && s.selectedIndex < len(s.filteredCountries) {
-		// Set country context in state
 		selectedCountry := s.filteredCountries[s.selectedIndex]
-		s.state.SetCountryContext(selectedCountry)
-
-		// Switch to country menu scene
-		countryMenu := NewCountryMenuScene(s.manager, s.state)
-		s.manager.AddScene("country_menu", countryMenu)
-		s.manager.SwitchToNamed("country_menu")
+		s.errorMessage = ""
+		s.contextJob = s.state.StartCountryContext(selectedCountry)
+		s.overlay = NewLoadingOverlay("Loading "+selectedCountry.Tag+" - "+selectedCountry.GetDisplayName(), s.contextJob.Progress)
+		return nil
 	}
 
 	// Handle filter buttons
@@ -245,7 +282,7 @@ func (s *CountrySelectionScene) Draw(screen *ebiten.Image) {
 	}
 
 	// Draw loading message
-	if s.loading {
+	if s.countriesJob != nil {
 		ebitenutil.DebugPrintAt(screen, "Loading countries...", 540, 350)
 		return
 	}
@@ -302,6 +339,10 @@ func (s *CountrySelectionScene) Draw(screen *ebiten.Image) {
 
 	// Draw hint
 	ebitenutil.DebugPrintAt(screen, "Click on a country to select, then click Continue", 440, 680)
+
+	if s.overlay != nil {
+		s.overlay.Draw(screen)
+	}
 }
 
 // OnEnter is called when entering this scene
@@ -0,0 +1,466 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
+)
+
+// DecisionEditorScene browses and edits decisions and decision categories
+type DecisionEditorScene struct {
+	manager    *SceneManager
+	state      *app.State
+	decisions  *domain.DecisionSet
+	iconLoader *components.IconLoader
+	preview    *scriptPreview
+
+	filtered       []*domain.Decision
+	list           *components.ScrollableList
+	categories     []string // Category filter values; "" shows all
+	categoryFilter int
+	search         string
+	selected       *domain.Decision
+
+	// Inline editing (rows are shared with the idea editor)
+	editing    *ideaRow
+	editBuffer string
+
+	returnScene string
+
+	backButton     *components.Button
+	categoryButton *components.Button
+	saveButton     *components.Button
+
+	message string
+}
+
+// NewDecisionEditorScene creates the decision editor over the state's decision set
+func NewDecisionEditorScene(manager *SceneManager, state *app.State) *DecisionEditorScene {
+	scene := &DecisionEditorScene{
+		manager:     manager,
+		state:       state,
+		decisions:   state.GetDecisions(),
+		list:        components.NewScrollableList(20, 110, 420, 520, 13),
+		categories:  []string{""},
+		returnScene: "country_menu",
+	}
+	scene.preview = newScriptPreview(state.GetScripted())
+
+	scene.iconLoader = components.NewIconLoader(state.GetModPath())
+	if gamePath := state.GetGamePath(); gamePath != "" {
+		scene.iconLoader.SetGamePath(gamePath)
+	}
+	scene.iconLoader.SetSpriteRegistry(state.GetSpriteRegistry())
+
+	seen := make(map[string]bool)
+	for _, decision := range scene.decisions.All() {
+		if !seen[decision.Category] {
+			seen[decision.Category] = true
+			scene.categories = append(scene.categories, decision.Category)
+		}
+	}
+
+	scene.backButton = components.NewButton(20, 650, 160, 50, "← Back")
+	scene.categoryButton = components.NewButton(200, 50, 240, 40, "Category: all")
+	scene.saveButton = components.NewButton(1060, 650, 200, 50, "Save")
+
+	scene.applyFilter()
+
+	return scene
+}
+
+// SelectDecision selects a decision by ID (clearing filters so it is
+// visible) and makes ESC / Back return to returnScene
+func (s *DecisionEditorScene) SelectDecision(id, returnScene string) {
+	s.returnScene = returnScene
+	decision, ok := s.decisions.Get(id)
+	if !ok {
+		s.message = "Decision not found: " + id
+		return
+	}
+
+	s.search = ""
+	s.categoryFilter = 0
+	s.categoryButton.Text = "Category: all"
+	s.applyFilter()
+	s.selectInList(decision)
+}
+
+// applyFilter rebuilds the list from the category filter and search text
+func (s *DecisionEditorScene) applyFilter() {
+	category := s.categories[s.categoryFilter]
+	search := strings.ToLower(s.search)
+
+	s.filtered = s.filtered[:0]
+	items := make([]string, 0)
+	for _, decision := range s.decisions.All() {
+		if category != "" && decision.Category != category {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(decision.ID), search) &&
+			!strings.Contains(strings.ToLower(s.localize(decision.ID)), search) {
+			continue
+		}
+
+		s.filtered = append(s.filtered, decision)
+		items = append(items, fmt.Sprintf("%-36s %s", decision.ID, decision.Category))
+	}
+
+	s.list.SetItems(items)
+	s.selectInList(s.selected)
+}
+
+// selectInList selects a decision in the filtered list if present
+func (s *DecisionEditorScene) selectInList(decision *domain.Decision) {
+	s.selected = nil
+	for i, candidate := range s.filtered {
+		if candidate == decision {
+			s.selected = decision
+			s.list.SetSelectedIndex(i)
+			return
+		}
+	}
+}
+
+// localize returns the display name for a key
+func (s *DecisionEditorScene) localize(key string) string {
+	if ctx := s.state.GetCountryContext(); ctx != nil {
+		return parser.GetLocalization(ctx.Localizations, key)
+	}
+	return key
+}
+
+// Update updates the decision editor
+func (s *DecisionEditorScene) Update() error {
+	if s.editing != nil {
+		s.updateEditing()
+		return nil
+	}
+
+	s.backButton.Update()
+	s.categoryButton.Update()
+	s.saveButton.Update()
+
+	if s.backButton.IsClicked() || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.manager.SwitchToNamed(s.returnScene)
+		return nil
+	}
+
+	if s.categoryButton.IsClicked() {
+		s.categoryFilter = (s.categoryFilter + 1) % len(s.categories)
+		s.categoryButton.Text = "Category: all"
+		if category := s.categories[s.categoryFilter]; category != "" {
+			s.categoryButton.Text = "Category: " + category
+		}
+		s.applyFilter()
+	}
+
+	if s.saveButton.IsClicked() {
+		s.saveAll()
+	}
+
+	s.updateSearch()
+
+	prevIndex := s.list.GetSelectedIndex()
+	s.list.Update()
+	if index := s.list.GetSelectedIndex(); index != prevIndex && index >= 0 && index < len(s.filtered) {
+		s.selected = s.filtered[index]
+		s.message = ""
+	}
+
+	s.updateRows()
+
+	return nil
+}
+
+// updateSearch handles typing into the search filter
+func (s *DecisionEditorScene) updateSearch() {
+	changed := false
+
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if r < 128 && r != ' ' {
+			s.search += string(r)
+			changed = true
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(s.search) > 0 {
+		s.search = s.search[:len(s.search)-1]
+		changed = true
+	}
+
+	if changed {
+		s.applyFilter()
+	}
+}
+
+// updateRows starts editing a clicked row
+func (s *DecisionEditorScene) updateRows() {
+	if s.selected == nil || !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return
+	}
+
+	mx, my := ebiten.CursorPosition()
+	for i, row := range s.rows() {
+		y := ideaRowsY + i*ideaRowHeight
+		if my < y || my >= y+ideaRowHeight || mx < ideaDetailsX || mx >= ideaDetailsX+ideaRowWidth {
+			continue
+		}
+		if row.apply != nil {
+			s.startEditing(&row)
+		}
+		return
+	}
+}
+
+// rows returns the editable lines for the selected decision and its category
+func (s *DecisionEditorScene) rows() []ideaRow {
+	decision := s.selected
+	markDecision := func() {
+		if decision.File != nil {
+			decision.File.Dirty = true
+		}
+	}
+	setDecisionNumber := func(field *string) func(string) error {
+		return func(v string) error {
+			if err := setNumber(field, v); err != nil {
+				return err
+			}
+			markDecision()
+			return nil
+		}
+	}
+
+	rows := []ideaRow{
+		{label: "icon", value: decision.Icon, apply: func(v string) error { decision.Icon = v; markDecision(); return nil }},
+		{label: "cost", value: decision.Cost, apply: setDecisionNumber(&decision.Cost)},
+		{label: "days_remove", value: decision.DaysRemove, apply: setDecisionNumber(&decision.DaysRemove)},
+		{label: "days_re_enable", value: decision.DaysReEnable, apply: setDecisionNumber(&decision.DaysReEnable)},
+		{label: "fire_only_once", value: yesNo(decision.FireOnlyOnce), apply: func(v string) error {
+			if v != "yes" && v != "no" {
+				return fmt.Errorf("fire_only_once must be yes or no")
+			}
+			decision.FireOnlyOnce = v == "yes"
+			markDecision()
+			return nil
+		}},
+	}
+
+	category, ok := s.decisions.Category(decision.Category)
+	if !ok {
+		return append(rows, ideaRow{label: "category " + decision.Category + " (not declared)"})
+	}
+
+	markCategory := func() {
+		if category.File != nil {
+			category.File.Dirty = true
+		}
+	}
+	rows = append(rows,
+		ideaRow{label: "category " + category.ID + ":"},
+		ideaRow{label: "  icon", value: category.Icon, apply: func(v string) error { category.Icon = v; markCategory(); return nil }},
+		ideaRow{label: "  priority", value: category.Priority, apply: func(v string) error {
+			if err := setNumber(&category.Priority, v); err != nil {
+				return err
+			}
+			markCategory()
+			return nil
+		}},
+	)
+
+	return rows
+}
+
+// yesNo formats a boolean as a script value
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+// startEditing opens the inline editor for a row
+func (s *DecisionEditorScene) startEditing(row *ideaRow) {
+	s.editing = row
+	s.editBuffer = row.value
+	s.message = ""
+}
+
+// updateEditing handles text input while a row is being edited
+func (s *DecisionEditorScene) updateEditing() {
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if r < 128 {
+			s.editBuffer += string(r)
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(s.editBuffer) > 0 {
+		s.editBuffer = s.editBuffer[:len(s.editBuffer)-1]
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.editing = nil
+		return
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter) {
+		if err := s.editing.apply(strings.TrimSpace(s.editBuffer)); err != nil {
+			s.message = err.Error()
+		}
+		s.editing = nil
+	}
+}
+
+// saveAll writes every edited decision and category file into the mod
+func (s *DecisionEditorScene) saveAll() {
+	files := s.decisions.DirtyFiles()
+	categoryFiles := s.decisions.DirtyCategoryFiles()
+	if len(files)+len(categoryFiles) == 0 {
+		s.message = "No changes to save"
+		return
+	}
+
+	loader := app.NewDecisionLoader(s.state.GetModPath(), s.state.GetGamePath())
+	for _, file := range files {
+		if err := loader.Save(file); err != nil {
+			s.message = "Save failed: " + err.Error()
+			return
+		}
+	}
+	for _, file := range categoryFiles {
+		if err := loader.SaveCategories(file); err != nil {
+			s.message = "Save failed: " + err.Error()
+			return
+		}
+	}
+	s.message = fmt.Sprintf("Saved %d file(s)", len(files)+len(categoryFiles))
+}
+
+// Draw renders the decision editor
+func (s *DecisionEditorScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{30, 30, 30, 255})
+
+	ebitenutil.DebugPrintAt(screen, "Decisions", 20, 20)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Search: %s_", s.search), 20, 60)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d decisions", len(s.filtered)), 20, 80)
+
+	s.categoryButton.Draw(screen)
+	s.list.Draw(screen)
+
+	s.preview.begin()
+	if s.selected != nil {
+		s.drawDetails(screen)
+	} else {
+		ebitenutil.DebugPrintAt(screen, "Select a decision to view and edit it", ideaDetailsX, 60)
+	}
+
+	if dirty := len(s.decisions.DirtyFiles()) + len(s.decisions.DirtyCategoryFiles()); dirty > 0 {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d unsaved file(s)", dirty), 1060, 630)
+	}
+	if s.message != "" {
+		ebitenutil.DebugPrintAt(screen, s.message, 200, 680)
+	}
+
+	s.backButton.Draw(screen)
+	s.saveButton.Draw(screen)
+	s.preview.drawTooltip(screen)
+}
+
+// drawDetails draws the selected decision's fields, script blocks and focus links
+func (s *DecisionEditorScene) drawDetails(screen *ebiten.Image) {
+	decision := s.selected
+	x := ideaDetailsX
+
+	ebitenutil.DebugPrintAt(screen, decision.ID, x, 60)
+	ebitenutil.DebugPrintAt(screen, s.localize(decision.ID), x, 78)
+
+	source, fileName := "vanilla", ""
+	if decision.File != nil {
+		fileName = decision.File.RelPath
+		if decision.File.Source == "mod" {
+			source = "mod"
+		}
+	}
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s  (%s, %s)", s.localize(decision.Category), fileName, source), x, 96)
+
+	if icon := s.iconLoader.LoadSprite(decision.SpriteName()); icon != nil {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(x), 116)
+		screen.DrawImage(icon, op)
+	}
+	ebitenutil.DebugPrintAt(screen, decision.SpriteName(), x+80, 140)
+
+	// Editable rows
+	mx, my := ebiten.CursorPosition()
+	rows := s.rows()
+	for i, row := range rows {
+		y := ideaRowsY + i*ideaRowHeight
+		if row.apply == nil {
+			ebitenutil.DebugPrintAt(screen, row.label, x+4, y+1)
+			continue
+		}
+
+		if mx >= x && mx < x+ideaRowWidth && my >= y && my < y+ideaRowHeight {
+			vector.DrawFilledRect(screen, float32(x), float32(y), ideaRowWidth, ideaRowHeight, color.RGBA{55, 55, 70, 255}, false)
+		}
+
+		value := row.value
+		if value == "" {
+			value = "-"
+		}
+		if s.editing != nil && s.editing.label == row.label {
+			value = s.editBuffer + "_"
+		}
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%-28s %s", row.label, value), x+4, y+1)
+	}
+
+	y := ideaRowsY + len(rows)*ideaRowHeight + 10
+	if s.editing != nil {
+		ebitenutil.DebugPrintAt(screen, "Enter to apply, Esc to cancel", x, y)
+		y += ideaRowHeight
+	}
+
+	// Script blocks (read-only; hover a scripted trigger/effect to preview it)
+	for _, block := range []struct{ key, value string }{
+		{"allowed", decision.Allowed},
+		{"visible", decision.Visible},
+		{"available", decision.Available},
+		{"complete_effect", decision.CompleteEffect},
+		{"ai_will_do", decision.AIWillDo},
+	} {
+		if block.value == "" {
+			continue
+		}
+		y = s.preview.drawBlock(screen, block.key, block.value, x, y, maxTriggerRows)
+	}
+
+	// Focus links (unlock tooltips, flags and has_completed_focus in visible)
+	unlocked := app.DecisionUnlockedBy(s.state.FocusTree, decision)
+	if len(unlocked) > 0 {
+		ids := make([]string, len(unlocked))
+		for i, focus := range unlocked {
+			ids[i] = focus.ID
+		}
+		ebitenutil.DebugPrintAt(screen, "Unlocked by focus: "+strings.Join(ids, ", "), x, y)
+	}
+}
+
+// OnEnter is called when entering this scene
+func (s *DecisionEditorScene) OnEnter() {
+	s.editing = nil
+}
+
+// OnExit is called when leaving this scene
+func (s *DecisionEditorScene) OnExit() {
+	// Nothing to do
+}
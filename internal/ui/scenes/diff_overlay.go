@@ -0,0 +1,107 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
+)
+
+// Node colours of the diff overlay
+var (
+	diffAddedColor = color.RGBA{40, 110, 50, 255}  // New node
+	diffMovedColor = color.RGBA{40, 80, 130, 255}  // Position changed
+	diffLinkColor  = color.RGBA{130, 80, 30, 255}  // Links changed
+	diffValueColor = color.RGBA{110, 100, 30, 255} // Other fields changed
+	diffTextColor  = color.RGBA{200, 90, 90, 255}  // Removed nodes (listed)
+)
+
+// DiffOverlay colours the nodes of a viewer that changed since an older
+// version of the tree and lists removed nodes
+type DiffOverlay struct {
+	diff    *domain.TreeDiff
+	against string // Revision or file compared with
+}
+
+// NewDiffOverlay creates an overlay for a diff against a revision or file
+func NewDiffOverlay(diff *domain.TreeDiff, against string) *DiffOverlay {
+	return &DiffOverlay{diff: diff, against: against}
+}
+
+// diffNodeColor returns the fill colour of a changed node (links win over
+// moves, moves over other fields)
+func diffNodeColor(change domain.NodeChange) color.Color {
+	switch {
+	case change.Kind == domain.ChangeAdded:
+		return diffAddedColor
+	case change.LinksChanged():
+		return diffLinkColor
+	case change.Moved():
+		return diffMovedColor
+	default:
+		return diffValueColor
+	}
+}
+
+// Apply colours the changed nodes; rebuilt nodes need it again
+func (o *DiffOverlay) Apply(nodes []*components.Node) {
+	for _, node := range nodes {
+		if change, ok := o.diff.Change(node.ID); ok {
+			node.Color = diffNodeColor(change)
+		}
+	}
+}
+
+// Draw draws the legend, the removed nodes and the changes of the selected
+// node in a panel at (x, y)
+func (o *DiffOverlay) Draw(screen *ebiten.Image, x, y int, selectedID string) {
+	lines := []string{"Diff vs " + o.against + ": " + o.diff.Summary()}
+	removed := make([]string, 0)
+	for _, change := range o.diff.Changes {
+		if change.Kind == domain.ChangeRemoved {
+			removed = append(removed, change.ID)
+		}
+	}
+	for i, id := range removed {
+		if i == 5 {
+			lines = append(lines, fmt.Sprintf("- ... %d more removed", len(removed)-5))
+			break
+		}
+		lines = append(lines, "- "+id)
+	}
+	if change, ok := o.diff.Change(selectedID); ok && change.Kind == domain.ChangeModified {
+		lines = append(lines, selectedID+":")
+		for i, line := range change.Lines() {
+			if i == 8 {
+				break
+			}
+			lines = append(lines, "  "+truncateText(line, 44))
+		}
+	}
+
+	height := float32(35 + len(lines)*15)
+	vector.DrawFilledRect(screen, float32(x), float32(y), 300, height, color.RGBA{30, 30, 30, 220}, false)
+	vector.StrokeRect(screen, float32(x), float32(y), 300, height, 2, color.RGBA{80, 80, 80, 255}, false)
+
+	// Legend
+	legend := []struct {
+		color color.Color
+		label string
+	}{
+		{diffAddedColor, "added"}, {diffMovedColor, "moved"}, {diffLinkColor, "links"}, {diffValueColor, "fields"}, {diffTextColor, "removed"},
+	}
+	for i, entry := range legend {
+		lx := float32(x + 10 + i*58)
+		vector.DrawFilledRect(screen, lx, float32(y+10), 10, 10, entry.color, false)
+		ebitenutil.DebugPrintAt(screen, entry.label, int(lx)+13, y+6)
+	}
+
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, truncateText(line, 48), x+10, y+25+i*15)
+	}
+}
@@ -0,0 +1,272 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
+)
+
+// Equipment lineage layout in canvas grid cells
+const (
+	lineageDepthSpacing = 5
+	lineageRowSpacing   = 2
+	lineageNodeWidth    = 200
+	lineageNodeHeight   = 50
+)
+
+// keyEquipmentStats are shown first when summarising equipment stats
+var keyEquipmentStats = []string{
+	"soft_attack", "hard_attack", "defense", "breakthrough", "ap_attack", "armor_value",
+	"air_attack", "air_defence", "naval_speed", "maximum_speed", "reliability", "build_cost_ic",
+}
+
+// keySubUnitStats are shown first when summarising sub-unit stats
+var keySubUnitStats = []string{"combat_width", "max_strength", "max_organisation", "default_morale", "manpower", "training_time"}
+
+// EquipmentLineageScene shows the upgrade line of an equipment archetype
+type EquipmentLineageScene struct {
+	manager      *SceneManager
+	state        *app.State
+	canvas       *components.Canvas
+	equipment    *domain.EquipmentSet
+	technologies []*domain.Technology
+	archetype    *domain.Equipment
+	lineage      []*domain.EquipmentLineageNode
+	nodes        []*components.Node
+
+	selectedNode *components.Node
+	hoveredNode  *components.Node
+
+	returnScene string
+}
+
+// NewEquipmentLineageScene lays out the lineage of an equipment; the
+// technologies are used to show which tech unlocks each equipment
+func NewEquipmentLineageScene(manager *SceneManager, state *app.State, equipmentID string, technologies []*domain.Technology, returnScene string) *EquipmentLineageScene {
+	scene := &EquipmentLineageScene{
+		manager:      manager,
+		state:        state,
+		canvas:       components.NewCanvas(1280, 720),
+		equipment:    state.GetEquipment(),
+		technologies: technologies,
+		nodes:        make([]*components.Node, 0),
+		returnScene:  returnScene,
+	}
+	scene.archetype, scene.lineage = scene.equipment.Lineage(equipmentID)
+
+	scene.createNodes()
+	for _, node := range scene.nodes {
+		if node.ID == equipmentID {
+			node.IsSelected = true
+			scene.selectedNode = node
+		}
+	}
+	scene.canvas.OffsetX = 40
+	scene.canvas.OffsetY = 120
+
+	return scene
+}
+
+// createNodes places each upgrade step in a column, left to right
+func (s *EquipmentLineageScene) createNodes() {
+	for _, item := range s.lineage {
+		title := item.Equipment.ID
+		if item.Equipment.Year > 0 {
+			title = fmt.Sprintf("%s (%d)", item.Equipment.ID, item.Equipment.Year)
+		}
+		node := components.NewNode(item.Equipment.ID, title, item.Depth*lineageDepthSpacing, item.Column*lineageRowSpacing)
+		node.Width, node.Height = lineageNodeWidth, lineageNodeHeight
+		if len(app.EquipmentUnlockedBy(s.technologies, item.Equipment.ID)) == 0 {
+			node.Color = color.RGBA{45, 45, 45, 255}
+		}
+		s.nodes = append(s.nodes, node)
+	}
+}
+
+// nodeByID finds a node by equipment ID
+func (s *EquipmentLineageScene) nodeByID(id string) *components.Node {
+	for _, node := range s.nodes {
+		if node.ID == id {
+			return node
+		}
+	}
+	return nil
+}
+
+// Update updates the scene
+func (s *EquipmentLineageScene) Update() error {
+	s.canvas.Update()
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.manager.SwitchToNamed(s.returnScene)
+		return nil
+	}
+
+	mouseX, mouseY := ebiten.CursorPosition()
+	s.hoveredNode = nil
+	for _, node := range s.nodes {
+		node.IsHovered = node.Contains(float64(mouseX), float64(mouseY), s.canvas)
+		if node.IsHovered {
+			s.hoveredNode = node
+		}
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && s.hoveredNode != nil {
+		if s.selectedNode != nil {
+			s.selectedNode.IsSelected = false
+		}
+		s.selectedNode = s.hoveredNode
+		s.selectedNode.IsSelected = true
+	}
+
+	return nil
+}
+
+// Draw draws the scene
+func (s *EquipmentLineageScene) Draw(screen *ebiten.Image) {
+	s.canvas.Draw(screen)
+
+	// Parent -> child links
+	for _, item := range s.lineage {
+		from, to := s.nodeByID(item.Equipment.Parent), s.nodeByID(item.Equipment.ID)
+		if from == nil || to == nil {
+			continue
+		}
+		fx, fy := s.nodeAnchor(from, true)
+		tx, ty := s.nodeAnchor(to, false)
+		vector.StrokeLine(screen, fx, fy, tx, ty, 2, color.RGBA{150, 150, 150, 255}, false)
+	}
+
+	for _, node := range s.nodes {
+		node.Draw(screen, s.canvas)
+	}
+
+	s.drawUI(screen)
+}
+
+// nodeAnchor returns the right-middle (right=true) or left-middle screen point of a node
+func (s *EquipmentLineageScene) nodeAnchor(node *components.Node, right bool) (float32, float32) {
+	worldX, worldY := s.canvas.GridToWorld(node.X, node.Y)
+	x, y := s.canvas.WorldToScreen(worldX, worldY)
+	y += float64(node.Height) * s.canvas.Zoom / 2
+	if right {
+		x += float64(node.Width) * s.canvas.Zoom
+	}
+	return float32(x), float32(y)
+}
+
+// drawUI draws the archetype summary and the selected equipment
+func (s *EquipmentLineageScene) drawUI(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 10, 10, 500, 70, color.RGBA{30, 30, 30, 220}, false)
+	vector.StrokeRect(screen, 10, 10, 500, 70, 2, color.RGBA{80, 80, 80, 255}, false)
+
+	if s.archetype != nil {
+		ebitenutil.DebugPrintAt(screen, "Equipment lineage of archetype "+s.archetype.ID, 20, 20)
+		ebitenutil.DebugPrintAt(screen, truncateText("Base: "+statsSummary(s.archetype.Stats, keyEquipmentStats, 5), 78), 20, 35)
+	} else {
+		ebitenutil.DebugPrintAt(screen, "Equipment lineage (archetype not declared)", 20, 20)
+	}
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d equipment, grey = not unlocked by any technology", len(s.lineage)), 20, 50)
+
+	if s.selectedNode != nil {
+		if equipment, ok := s.equipment.Get(s.selectedNode.ID); ok {
+			s.drawEquipmentInfo(screen, equipment)
+		}
+	}
+
+	ebitenutil.DebugPrintAt(screen, "Arrow Keys: Pan | +/-: Zoom | R: Reset | Click: Select | ESC: Back", 20, s.canvas.Height-30)
+}
+
+// drawEquipmentInfo draws the effective stats of the selected equipment
+func (s *EquipmentLineageScene) drawEquipmentInfo(screen *ebiten.Image, equipment *domain.Equipment) {
+	panelX := float32(s.canvas.Width - 360)
+	x := int(panelX + 10)
+
+	lines := []string{equipment.ID}
+	if equipment.File != nil {
+		lines = append(lines, fmt.Sprintf("%s (%s)", equipment.File.RelPath, equipment.File.Source))
+	}
+	if equipment.Parent != "" {
+		lines = append(lines, "Parent: "+equipment.Parent)
+	}
+	if techs := app.EquipmentUnlockedBy(s.technologies, equipment.ID); len(techs) > 0 {
+		ids := make([]string, len(techs))
+		for i, tech := range techs {
+			ids[i] = tech.ID
+		}
+		lines = append(lines, truncateText("Unlocked by: "+strings.Join(ids, ", "), 56))
+	}
+	lines = append(lines, "")
+
+	stats := s.equipment.Stats(equipment.ID)
+	for _, key := range sortedStats(stats, keyEquipmentStats) {
+		lines = append(lines, fmt.Sprintf("%-24s %g", key, stats[key]))
+	}
+	if len(equipment.Resources) > 0 {
+		resources := make([]string, 0, len(equipment.Resources))
+		for resource, amount := range equipment.Resources {
+			resources = append(resources, fmt.Sprintf("%s %d", resource, amount))
+		}
+		sort.Strings(resources)
+		lines = append(lines, "Resources: "+strings.Join(resources, ", "))
+	}
+
+	height := float32(len(lines)*15 + 20)
+	vector.DrawFilledRect(screen, panelX, 10, 350, height, color.RGBA{30, 30, 30, 220}, false)
+	vector.StrokeRect(screen, panelX, 10, 350, height, 2, color.RGBA{80, 120, 160, 255}, false)
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, line, x, 20+i*15)
+	}
+}
+
+// sortedStats orders stat keys: the key stats first, then the rest alphabetically
+func sortedStats(stats map[string]float64, keyStats []string) []string {
+	keys := make([]string, 0, len(stats))
+	seen := make(map[string]bool)
+	for _, key := range keyStats {
+		if _, ok := stats[key]; ok {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+	rest := make([]string, 0)
+	for key := range stats {
+		if !seen[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+// statsSummary formats up to max stats as "soft_attack 3, defense 20"
+func statsSummary(stats map[string]float64, keyStats []string, max int) string {
+	parts := make([]string, 0, max)
+	for _, key := range sortedStats(stats, keyStats) {
+		if len(parts) == max {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s %g", key, stats[key]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// OnEnter is called when entering the scene
+func (s *EquipmentLineageScene) OnEnter() {
+	// Nothing to do
+}
+
+// OnExit is called when leaving the scene
+func (s *EquipmentLineageScene) OnExit() {
+	// Nothing to do
+}
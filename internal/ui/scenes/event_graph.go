@@ -0,0 +1,275 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
+)
+
+// Event graph layout in canvas grid cells
+const (
+	eventColumnSpacing = 4
+	eventLayerSpacing  = 2
+	eventNodeWidth     = 180
+	eventNodeHeight    = 50
+)
+
+// EventGraphScene shows the chain of events fired from a focus
+type EventGraphScene struct {
+	manager *SceneManager
+	state   *app.State
+	canvas  *components.Canvas
+	focus   *domain.Focus
+	chain   *domain.EventChain
+	nodes   []*components.Node
+
+	selectedNode *components.Node
+	hoveredNode  *components.Node
+
+	returnScene string
+}
+
+// NewEventGraphScene lays out the events reachable from a focus's completion reward
+func NewEventGraphScene(manager *SceneManager, state *app.State, focus *domain.Focus, returnScene string) *EventGraphScene {
+	scene := &EventGraphScene{
+		manager:     manager,
+		state:       state,
+		canvas:      components.NewCanvas(1280, 720),
+		focus:       focus,
+		chain:       state.GetEvents().Chain(focus.RewardEvents),
+		nodes:       make([]*components.Node, 0),
+		returnScene: returnScene,
+	}
+
+	scene.createNodes()
+	if len(scene.nodes) > 0 {
+		root := scene.nodes[0]
+		worldX, worldY := scene.canvas.GridToWorld(root.X, root.Y)
+		scene.canvas.OffsetX = float64(scene.canvas.Width/2) - float64(worldX) - float64(root.Width/2)
+		scene.canvas.OffsetY = 120 - float64(worldY)
+	}
+
+	return scene
+}
+
+// createNodes places the focus on top and each chain layer below it,
+// centered horizontally
+func (s *EventGraphScene) createNodes() {
+	root := components.NewNode(s.focus.ID, s.focus.ID, -eventNodeWidth/2/s.canvas.GridSize, 0)
+	root.Width, root.Height = eventNodeWidth, eventNodeHeight
+	root.Color = color.RGBA{50, 70, 100, 255}
+	s.nodes = append(s.nodes, root)
+
+	layerSize := make(map[int]int)
+	for _, node := range s.chain.Nodes {
+		layerSize[node.Layer]++
+	}
+
+	for _, chainNode := range s.chain.Nodes {
+		x := chainNode.Column*eventColumnSpacing - (layerSize[chainNode.Layer]-1)*eventColumnSpacing/2
+		y := (chainNode.Layer + 1) * eventLayerSpacing
+
+		node := components.NewNode(chainNode.ID, chainNode.ID, x-eventNodeWidth/2/s.canvas.GridSize, y)
+		node.Width, node.Height = eventNodeWidth, eventNodeHeight
+		if chainNode.Event == nil {
+			node.Color = color.RGBA{90, 30, 30, 255}
+			node.BorderColor = color.RGBA{200, 60, 60, 255}
+		} else if chainNode.Event.Hidden {
+			node.Color = color.RGBA{45, 45, 45, 255}
+		}
+		s.nodes = append(s.nodes, node)
+	}
+}
+
+// nodeByID finds a node by event (or focus) ID
+func (s *EventGraphScene) nodeByID(id string) *components.Node {
+	for _, node := range s.nodes {
+		if node.ID == id {
+			return node
+		}
+	}
+	return nil
+}
+
+// Update updates the scene
+func (s *EventGraphScene) Update() error {
+	s.canvas.Update()
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.manager.SwitchToNamed(s.returnScene)
+		return nil
+	}
+
+	mouseX, mouseY := ebiten.CursorPosition()
+	s.hoveredNode = nil
+	for _, node := range s.nodes {
+		node.IsHovered = node.Contains(float64(mouseX), float64(mouseY), s.canvas)
+		if node.IsHovered {
+			s.hoveredNode = node
+		}
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if s.selectedNode != nil {
+			s.selectedNode.IsSelected = false
+		}
+		s.selectedNode = s.hoveredNode
+		if s.selectedNode != nil {
+			s.selectedNode.IsSelected = true
+		}
+	}
+
+	return nil
+}
+
+// Draw draws the scene
+func (s *EventGraphScene) Draw(screen *ebiten.Image) {
+	s.canvas.Draw(screen)
+	s.drawEdges(screen)
+
+	for _, node := range s.nodes {
+		node.Draw(screen, s.canvas)
+	}
+
+	s.drawUI(screen)
+}
+
+// drawEdges draws focus -> root and event -> fired event links;
+// links back to an earlier layer (loops) are highlighted
+func (s *EventGraphScene) drawEdges(screen *ebiten.Image) {
+	lineColor := color.RGBA{150, 150, 150, 255}
+	loopColor := color.RGBA{220, 150, 60, 255}
+
+	for _, id := range s.focus.RewardEvents {
+		s.drawEdge(screen, s.nodes[0], s.nodeByID(id), lineColor)
+	}
+
+	for _, edge := range s.chain.Edges {
+		from, _ := s.chain.Node(edge.From)
+		to, _ := s.chain.Node(edge.To)
+		edgeColor := lineColor
+		if to.Layer <= from.Layer {
+			edgeColor = loopColor
+		}
+		s.drawEdge(screen, s.nodeByID(edge.From), s.nodeByID(edge.To), edgeColor)
+	}
+}
+
+// drawEdge draws a line from the bottom of one node to the top of another
+func (s *EventGraphScene) drawEdge(screen *ebiten.Image, from, to *components.Node, edgeColor color.Color) {
+	if from == nil || to == nil {
+		return
+	}
+
+	fx, fy := s.nodeAnchor(from, true)
+	tx, ty := s.nodeAnchor(to, false)
+	vector.StrokeLine(screen, fx, fy, tx, ty, 2, edgeColor, false)
+}
+
+// nodeAnchor returns the bottom-center (bottom=true) or top-center screen point of a node
+func (s *EventGraphScene) nodeAnchor(node *components.Node, bottom bool) (float32, float32) {
+	worldX, worldY := s.canvas.GridToWorld(node.X, node.Y)
+	x, y := s.canvas.WorldToScreen(worldX, worldY)
+	x += float64(node.Width) * s.canvas.Zoom / 2
+	if bottom {
+		y += float64(node.Height) * s.canvas.Zoom
+	}
+	return float32(x), float32(y)
+}
+
+// drawUI draws the chain summary, dangling IDs and the selected event
+func (s *EventGraphScene) drawUI(screen *ebiten.Image) {
+	missing := s.chain.Missing()
+
+	vector.DrawFilledRect(screen, 10, 10, 400, 70, color.RGBA{30, 30, 30, 220}, false)
+	vector.StrokeRect(screen, 10, 10, 400, 70, 2, color.RGBA{80, 80, 80, 255}, false)
+
+	ebitenutil.DebugPrintAt(screen, "Event chain of "+s.focus.ID, 20, 20)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Events: %d  Layers: %d  Dangling: %d", len(s.chain.Nodes), s.chain.Layers(), len(missing)), 20, 35)
+	if len(missing) > 0 {
+		ebitenutil.DebugPrintAt(screen, "Missing: "+truncateText(strings.Join(missing, ", "), 60), 20, 50)
+	}
+
+	if s.selectedNode != nil {
+		if chainNode, ok := s.chain.Node(s.selectedNode.ID); ok {
+			s.drawEventInfo(screen, chainNode)
+		}
+	}
+
+	ebitenutil.DebugPrintAt(screen, "Arrow Keys: Pan | +/-: Zoom | R: Reset | Click: Select | ESC: Back", 20, s.canvas.Height-30)
+}
+
+// drawEventInfo draws details about the selected event
+func (s *EventGraphScene) drawEventInfo(screen *ebiten.Image, node *domain.EventChainNode) {
+	panelX := float32(s.canvas.Width - 410)
+	x := int(panelX + 10)
+
+	lines := []string{"ID: " + node.ID}
+	if event := node.Event; event == nil {
+		lines = append(lines, "Not declared in any events file")
+	} else {
+		lines = append(lines,
+			"Title: "+truncateText(s.localize(event.Title), 50),
+			fmt.Sprintf("%s in %s (%s)", event.Type, event.File.RelPath, event.File.Source),
+		)
+		if event.Hidden {
+			lines = append(lines, "Hidden")
+		}
+		if len(event.ImmediateEvents) > 0 {
+			lines = append(lines, "Immediate -> "+strings.Join(event.ImmediateEvents, ", "))
+		}
+		for _, option := range event.Options {
+			line := "Option " + truncateText(s.localize(option.Name), 30)
+			if len(option.Events) > 0 {
+				line += " -> " + strings.Join(option.Events, ", ")
+			}
+			lines = append(lines, truncateText(line, 64))
+		}
+	}
+
+	height := float32(len(lines)*15 + 20)
+	vector.DrawFilledRect(screen, panelX, 10, 400, height, color.RGBA{30, 30, 30, 220}, false)
+	vector.StrokeRect(screen, panelX, 10, 400, height, 2, color.RGBA{80, 120, 160, 255}, false)
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, line, x, 20+i*15)
+	}
+}
+
+// localize returns the display text for a localisation key
+func (s *EventGraphScene) localize(key string) string {
+	if key == "" {
+		return "-"
+	}
+	if ctx := s.state.GetCountryContext(); ctx != nil {
+		return parser.GetLocalization(ctx.Localizations, key)
+	}
+	return key
+}
+
+// truncateText shortens text to max characters with a trailing ellipsis
+func truncateText(text string, max int) string {
+	if len(text) <= max {
+		return text
+	}
+	return text[:max-3] + "..."
+}
+
+// OnEnter is called when entering the scene
+func (s *EventGraphScene) OnEnter() {
+	// Nothing to do
+}
+
+// OnExit is called when leaving the scene
+func (s *EventGraphScene) OnExit() {
+	// Nothing to do
+}
@@ -0,0 +1,84 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
+)
+
+// exitPromptMaxDocuments is how many unsaved documents the prompt lists
+const exitPromptMaxDocuments = 8
+
+// ExitPrompt asks what to do with unsaved documents when the window is
+// closed: save them all and exit, exit without saving, or keep working
+type ExitPrompt struct {
+	manager       *SceneManager
+	saveButton    *components.Button
+	discardButton *components.Button
+	cancelButton  *components.Button
+	message       string
+}
+
+// NewExitPrompt creates the prompt over the current scene
+func NewExitPrompt(manager *SceneManager) *ExitPrompt {
+	buttonY := loadingPanelY + loadingPanelHeight - 60
+	return &ExitPrompt{
+		manager:       manager,
+		saveButton:    components.NewButton(loadingPanelX+20, buttonY, 180, 45, "Save All & Exit"),
+		discardButton: components.NewButton(loadingPanelX+210, buttonY, 180, 45, "Discard & Exit"),
+		cancelButton:  components.NewButton(loadingPanelX+400, buttonY, 180, 45, "Cancel"),
+	}
+}
+
+// Update handles the buttons; it returns ebiten.Termination to exit, and
+// done is true when the prompt should close
+func (p *ExitPrompt) Update() (done bool, err error) {
+	p.saveButton.Update()
+	p.discardButton.Update()
+	p.cancelButton.Update()
+
+	switch {
+	case p.saveButton.IsClicked():
+		if err := p.manager.SaveAll(); err != nil {
+			p.message = p.manager.workspaceMessage
+			return false, nil
+		}
+		return true, ebiten.Termination
+	case p.discardButton.IsClicked():
+		return true, ebiten.Termination
+	case p.cancelButton.IsClicked() || inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		return true, nil
+	}
+	return false, nil
+}
+
+// Draw renders the panel with the unsaved documents
+func (p *ExitPrompt) Draw(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 0, 0, float32(screen.Bounds().Dx()), float32(screen.Bounds().Dy()), color.RGBA{0, 0, 0, 140}, false)
+	vector.DrawFilledRect(screen, loadingPanelX, loadingPanelY, loadingPanelWidth, loadingPanelHeight, color.RGBA{30, 30, 30, 245}, false)
+	vector.StrokeRect(screen, loadingPanelX, loadingPanelY, loadingPanelWidth, loadingPanelHeight, 2, color.RGBA{200, 150, 60, 255}, false)
+
+	x, y := loadingPanelX+20, loadingPanelY+15
+	dirty := p.manager.state.Workspace.DirtyDocuments()
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d documents have unsaved changes:", len(dirty)), x, y)
+	for i, doc := range dirty {
+		if i == exitPromptMaxDocuments {
+			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("... and %d more", len(dirty)-i), x+10, y+20+i*15)
+			break
+		}
+		ebitenutil.DebugPrintAt(screen, truncateText(doc.Kind().String()+": "+doc.Title(), 90), x+10, y+20+i*15)
+	}
+	if p.message != "" {
+		ebitenutil.DebugPrintAt(screen, truncateText(p.message, 95), x, loadingPanelY+loadingPanelHeight-85)
+	}
+
+	p.saveButton.Draw(screen)
+	p.discardButton.Draw(screen)
+	p.cancelButton.Draw(screen)
+}
@@ -0,0 +1,562 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"path/filepath"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/sqweek/dialog"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
+)
+
+// Focus positions are multiplied by this factor to get canvas grid cells
+// (focus x/y units are wider than the 50px canvas grid)
+const focusGridScale = 3
+
+// FocusViewerScene displays a national focus tree
+type FocusViewerScene struct {
+	manager    *SceneManager
+	state      *app.State
+	canvas     *components.Canvas
+	doc        *app.FocusDocument // Focuses, tree and unsaved positions
+	nodes      []*components.Node
+	iconLoader *components.IconLoader
+
+	// UI state
+	selectedNode *components.Node
+	hoveredNode  *components.Node
+
+	changeIconButton   *components.Button
+	openIdeaButton     *components.Button
+	openDecisionButton *components.Button
+	eventChainButton   *components.Button
+	lintButton         *components.Button
+	layoutButton       *components.Button
+	saveButton         *components.Button
+	buttons            components.ButtonGroup
+	message            string
+
+	// Auto layout: locked focuses keep their position (L), M switches
+	// between absolute x/y and relative_position_id output
+	locked     map[string]bool
+	layoutMode domain.FocusLayoutMode
+
+	// The file changed on disk while positions were unsaved (F5 reloads)
+	externalChange bool
+
+	// Changes since git HEAD (D) or an older file (Shift+D), nil when off
+	diff *DiffOverlay
+
+	// Decisions unlocked by the selected focus (recomputed on selection)
+	selectedDecisions []*domain.Decision
+}
+
+// NewFocusViewerScene creates a focus viewer for a national focus file
+// open in the workspace
+func NewFocusViewerScene(manager *SceneManager, state *app.State, doc *app.FocusDocument) *FocusViewerScene {
+	scene := &FocusViewerScene{
+		manager: manager,
+		state:   state,
+		canvas:  components.NewCanvas(1280, 720),
+		nodes:   make([]*components.Node, 0),
+		doc:     doc,
+		locked:  make(map[string]bool),
+	}
+
+	scene.changeIconButton = components.NewButton(1060, 650, 200, 50, "Change Icon")
+	scene.openIdeaButton = components.NewButton(850, 650, 200, 50, "Open Idea")
+	scene.openDecisionButton = components.NewButton(640, 650, 200, 50, "Open Decision")
+	scene.eventChainButton = components.NewButton(430, 650, 200, 50, "Event Chain")
+	scene.lintButton = components.NewButton(220, 650, 200, 50, "Lint")
+	scene.layoutButton = components.NewButton(10, 590, 200, 50, "Auto Layout")
+	scene.saveButton = components.NewButton(220, 590, 200, 50, "Save")
+	scene.buttons = components.ButtonGroup{scene.changeIconButton, scene.openIdeaButton, scene.openDecisionButton, scene.eventChainButton, scene.lintButton, scene.layoutButton, scene.saveButton}
+
+	scene.iconLoader = components.NewIconLoader(state.GetModPath())
+	if gamePath := state.GetGamePath(); gamePath != "" {
+		scene.iconLoader.SetGamePath(gamePath)
+	}
+	scene.iconLoader.SetSpriteRegistry(state.GetSpriteRegistry())
+
+	if err := scene.loadFocusTree(); err != nil {
+		scene.message = err.Error()
+		return scene
+	}
+
+	scene.createNodes()
+	if len(scene.nodes) > 0 {
+		scene.centerOnNode(scene.nodes[0])
+	}
+
+	return scene
+}
+
+// loadFocusTree parses the focus file of the document into a domain.FocusTree
+func (s *FocusViewerScene) loadFocusTree() error {
+	if err := s.doc.Load(); err != nil {
+		return err
+	}
+	s.state.LoadFocusTree(s.doc.Tree)
+	return nil
+}
+
+// createNodes creates visual nodes from focuses in file order
+func (s *FocusViewerScene) createNodes() {
+	for _, focus := range s.doc.Focuses {
+		pos := s.doc.Tree.AbsolutePosition(focus.ID)
+		node := components.NewNode(focus.ID, focus.ID, pos.X*focusGridScale, pos.Y*focusGridScale)
+		node.Icon = s.iconLoader.LoadFocusIcon(focus.Icon)
+		s.updateLockedBorder(node)
+		s.nodes = append(s.nodes, node)
+	}
+	if s.diff != nil {
+		s.diff.Apply(s.nodes)
+	}
+}
+
+// centerOnNode centers the view on a specific node
+func (s *FocusViewerScene) centerOnNode(node *components.Node) {
+	worldX, worldY := s.canvas.GridToWorld(node.X, node.Y)
+	s.canvas.OffsetX = float64(s.canvas.Width/2) - float64(worldX) - float64(node.Width/2)
+	s.canvas.OffsetY = float64(s.canvas.Height/4) - float64(worldY)
+}
+
+// nodeByID finds a node by focus ID
+func (s *FocusViewerScene) nodeByID(id string) *components.Node {
+	for _, node := range s.nodes {
+		if node.ID == id {
+			return node
+		}
+	}
+	return nil
+}
+
+// Update updates the scene
+func (s *FocusViewerScene) Update() error {
+	s.canvas.Update()
+	s.buttons.Update()
+
+	if s.manager.updateWorkspace() {
+		return nil
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.manager.SwitchToNamed("country_menu")
+		return nil
+	}
+
+	if s.selectedNode != nil && s.changeIconButton.IsClicked() {
+		s.openIconPicker()
+		return nil
+	}
+
+	if idea := s.selectedRewardIdea(); idea != "" && s.openIdeaButton.IsClicked() {
+		editor := NewIdeaEditorScene(s.manager, s.state)
+		editor.SelectIdea(idea, "focus_viewer")
+		s.manager.AddScene("idea_editor", editor)
+		s.manager.SwitchToNamed("idea_editor")
+		return nil
+	}
+
+	if len(s.selectedDecisions) > 0 && s.openDecisionButton.IsClicked() {
+		editor := NewDecisionEditorScene(s.manager, s.state)
+		editor.SelectDecision(s.selectedDecisions[0].ID, "focus_viewer")
+		s.manager.AddScene("decision_editor", editor)
+		s.manager.SwitchToNamed("decision_editor")
+		return nil
+	}
+
+	if focus := s.selectedFocus(); focus != nil && len(focus.RewardEvents) > 0 && s.eventChainButton.IsClicked() {
+		graph := NewEventGraphScene(s.manager, s.state, focus, "focus_viewer")
+		s.manager.AddScene("event_graph", graph)
+		s.manager.SwitchToNamed("event_graph")
+		return nil
+	}
+
+	if s.doc.Tree != nil && s.lintButton.IsClicked() {
+		s.openLint()
+		return nil
+	}
+
+	if s.doc.Tree != nil && s.layoutButton.IsClicked() {
+		s.autoLayout()
+		return nil
+	}
+
+	if s.doc.Modified && s.saveButton.IsClicked() {
+		if err := s.doc.Save(); err != nil {
+			s.message = "Save failed: " + err.Error()
+		} else {
+			s.externalChange = false
+			s.message = "Saved " + filepath.Base(s.doc.Path) + " (.bak backup kept)"
+		}
+		return nil
+	}
+
+	if s.externalChange && inpututil.IsKeyJustPressed(ebiten.KeyF5) {
+		s.reload()
+		return nil
+	}
+
+	if s.selectedNode != nil && inpututil.IsKeyJustPressed(ebiten.KeyL) {
+		id := s.selectedNode.ID
+		s.locked[id] = !s.locked[id]
+		if !s.locked[id] {
+			delete(s.locked, id)
+		}
+		s.updateLockedBorder(s.selectedNode)
+	}
+	if s.doc.Tree != nil && inpututil.IsKeyJustPressed(ebiten.KeyD) {
+		s.toggleDiff(ebiten.IsKeyPressed(ebiten.KeyShift))
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyM) {
+		if s.layoutMode == domain.FocusLayoutAbsolute {
+			s.layoutMode = domain.FocusLayoutRelative
+		} else {
+			s.layoutMode = domain.FocusLayoutAbsolute
+		}
+	}
+
+	// Hover and selection (ignore clicks on the button)
+	mouseX, mouseY := ebiten.CursorPosition()
+	s.hoveredNode = nil
+	for _, node := range s.nodes {
+		node.IsHovered = node.Contains(float64(mouseX), float64(mouseY), s.canvas)
+		if node.IsHovered {
+			s.hoveredNode = node
+		}
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && !s.buttons.Hovered() {
+		if s.selectedNode != nil {
+			s.selectedNode.IsSelected = false
+		}
+		s.selectedNode = s.hoveredNode
+		s.selectedDecisions = nil
+		if s.selectedNode != nil {
+			s.selectedNode.IsSelected = true
+			if focus, ok := s.doc.Tree.GetFocus(s.selectedNode.ID); ok {
+				s.selectedDecisions = s.state.GetDecisions().UnlockedBy(focus)
+			}
+		}
+	}
+
+	return nil
+}
+
+// selectedFocus returns the focus of the selected node, or nil
+func (s *FocusViewerScene) selectedFocus() *domain.Focus {
+	if s.selectedNode == nil {
+		return nil
+	}
+	focus, ok := s.doc.Tree.GetFocus(s.selectedNode.ID)
+	if !ok {
+		return nil
+	}
+	return focus
+}
+
+// selectedRewardIdea returns the first idea granted by the selected focus, or ""
+func (s *FocusViewerScene) selectedRewardIdea() string {
+	focus := s.selectedFocus()
+	if focus == nil || len(focus.RewardIdeas) == 0 {
+		return ""
+	}
+	return focus.RewardIdeas[0]
+}
+
+// openIconPicker opens the icon browser to choose the selected focus's icon
+func (s *FocusViewerScene) openIconPicker() {
+	focus, ok := s.doc.Tree.GetFocus(s.selectedNode.ID)
+	if !ok {
+		return
+	}
+
+	browser := NewIconBrowserScene(s.manager, s.state)
+	browser.SetPicker("focus "+focus.ID, "focus_viewer", func(sprite *domain.Sprite) {
+		focus.Icon = sprite.Name
+		if node := s.nodeByID(focus.ID); node != nil {
+			node.Icon = s.iconLoader.LoadFocusIcon(focus.Icon)
+		}
+		s.doc.Modified = true
+		s.message = "Icon of " + focus.ID + " set to " + sprite.Name + " (not saved)"
+	})
+
+	s.manager.AddScene("icon_browser", browser)
+	s.manager.SwitchToNamed("icon_browser")
+}
+
+// autoLayout arranges the tree (locked focuses stay) and rebuilds the nodes;
+// positions are written to the file with Save
+func (s *FocusViewerScene) autoLayout() {
+	options := domain.FocusLayoutOptions{Mode: s.layoutMode, Locked: s.locked}
+	before := domain.CountFocusCrossings(s.doc.Tree, s.doc.Tree.AbsolutePositions())
+	layout := domain.LayoutFocusTree(s.doc.Tree, options)
+	layout.Apply(s.doc.Tree, options)
+	after := domain.CountFocusCrossings(s.doc.Tree, layout.Positions)
+
+	s.rebuildNodes()
+	s.doc.Modified = true
+	s.message = fmt.Sprintf("Auto layout: %d rows, crossings %d -> %d (not saved)", len(layout.Layers), before, after)
+}
+
+// rebuildNodes recreates the nodes after positions changed, keeping the selection
+func (s *FocusViewerScene) rebuildNodes() {
+	selectedID := ""
+	if s.selectedNode != nil {
+		selectedID = s.selectedNode.ID
+	}
+	s.nodes = make([]*components.Node, 0, len(s.doc.Focuses))
+	s.createNodes()
+	s.selectedNode = s.nodeByID(selectedID)
+	if s.selectedNode != nil {
+		s.selectedNode.IsSelected = true
+	}
+}
+
+// OnFilesChanged reloads the focus file after an external edit, keeping the
+// camera and the selection; unsaved positions are kept and the conflict is
+// reported (saving patches them into the new version of the file)
+func (s *FocusViewerScene) OnFilesChanged(result *app.ReloadResult) {
+	if !result.Changed(s.doc.Path) {
+		return
+	}
+	if s.doc.Modified {
+		s.externalChange = true
+		s.message = "Warning: " + filepath.Base(s.doc.Path) + " changed on disk - Save keeps your changes, F5 reloads and drops them"
+		return
+	}
+	s.reload()
+}
+
+// reload parses the focus file again and rebuilds the nodes in place
+func (s *FocusViewerScene) reload() {
+	if err := s.loadFocusTree(); err != nil {
+		s.message = "Reload failed: " + err.Error()
+		return
+	}
+	s.externalChange = false
+	s.diff = nil // Computed for the previous version
+	s.rebuildNodes()
+	s.selectedDecisions = nil
+	if focus := s.selectedFocus(); focus != nil {
+		s.selectedDecisions = s.state.GetDecisions().UnlockedBy(focus)
+	}
+	s.message = "Reloaded " + filepath.Base(s.doc.Path) + " (changed on disk)"
+}
+
+// toggleDiff shows the changes of the tree (including unsaved ones) since
+// git HEAD, or since a file chosen by the user; pressed again it hides them
+func (s *FocusViewerScene) toggleDiff(pickFile bool) {
+	if s.diff != nil {
+		s.diff = nil
+		s.rebuildNodes()
+		return
+	}
+
+	oldPath, against := "", "HEAD"
+	if pickFile {
+		path, err := dialog.File().
+			Filter("Focus Files", "txt").
+			Title("Select Older Version").
+			Load()
+		if err != nil {
+			if err.Error() != "Cancelled" {
+				s.message = "Error: " + err.Error()
+			}
+			return
+		}
+		oldPath, against = path, filepath.Base(path)
+	}
+
+	old, err := app.LoadFocusVersion(s.doc.Path, oldPath, "HEAD")
+	if err != nil {
+		s.message = "Diff failed: " + err.Error()
+		return
+	}
+	s.diff = NewDiffOverlay(domain.DiffFocusTrees(old, s.doc.Tree), against)
+	s.rebuildNodes()
+}
+
+// updateLockedBorder marks locked focuses with a gold border
+func (s *FocusViewerScene) updateLockedBorder(node *components.Node) {
+	if s.locked[node.ID] {
+		node.BorderColor = color.RGBA{220, 180, 60, 255}
+	} else {
+		node.BorderColor = color.RGBA{100, 100, 120, 255}
+	}
+}
+
+// openLint opens the lint report of the tree; fixes move or unlink focuses,
+// so the nodes are rebuilt afterwards
+func (s *FocusViewerScene) openLint() {
+	input := LintInput(s.state)
+	input.FocusTree = s.doc.Tree
+	input.FocusFile = s.doc.Path
+
+	report := NewLintScene(s.manager, s.state, input, func() {
+		s.rebuildNodes()
+		s.doc.Modified = true
+		s.message = "Lint fixes applied (not saved)"
+	}, "focus_viewer")
+
+	s.manager.AddScene("lint", report)
+	s.manager.SwitchToNamed("lint")
+}
+
+// Draw draws the scene
+func (s *FocusViewerScene) Draw(screen *ebiten.Image) {
+	s.canvas.Draw(screen)
+	s.drawConnections(screen)
+
+	for _, node := range s.nodes {
+		node.Draw(screen, s.canvas)
+	}
+
+	s.drawUI(screen)
+	s.manager.drawWorkspace(screen)
+}
+
+// drawConnections draws prerequisite lines from parents to children
+func (s *FocusViewerScene) drawConnections(screen *ebiten.Image) {
+	lineColor := color.RGBA{150, 150, 150, 255}
+
+	for _, child := range s.nodes {
+		focus, ok := s.doc.Tree.GetFocus(child.ID)
+		if !ok {
+			continue
+		}
+
+		for _, group := range focus.Prerequisites {
+			for _, prereqID := range group {
+				parent := s.nodeByID(prereqID)
+				if parent == nil {
+					continue
+				}
+
+				px, py := s.nodeAnchor(parent, true)
+				cx, cy := s.nodeAnchor(child, false)
+				vector.StrokeLine(screen, px, py, cx, cy, 2, lineColor, false)
+			}
+		}
+	}
+}
+
+// nodeAnchor returns the bottom-center (bottom=true) or top-center screen point of a node
+func (s *FocusViewerScene) nodeAnchor(node *components.Node, bottom bool) (float32, float32) {
+	worldX, worldY := s.canvas.GridToWorld(node.X, node.Y)
+	x, y := s.canvas.WorldToScreen(worldX, worldY)
+	x += float64(node.Width) * s.canvas.Zoom / 2
+	if bottom {
+		y += float64(node.Height) * s.canvas.Zoom
+	}
+	return float32(x), float32(y)
+}
+
+// drawUI draws the info panel, selection details and controls
+func (s *FocusViewerScene) drawUI(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 10, 10, 300, 85, color.RGBA{30, 30, 30, 220}, false)
+	vector.StrokeRect(screen, 10, 10, 300, 85, 2, color.RGBA{80, 80, 80, 255}, false)
+
+	focusCount := 0
+	if s.doc.Tree != nil {
+		focusCount = len(s.doc.Tree.Focuses)
+	}
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Focuses: %d", focusCount), 20, 20)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Zoom: %.1f%%", s.canvas.Zoom*100), 20, 35)
+
+	if s.selectedNode != nil {
+		if focus, ok := s.doc.Tree.GetFocus(s.selectedNode.ID); ok {
+			s.drawFocusInfo(screen, focus)
+		}
+		s.changeIconButton.Draw(screen)
+		if s.selectedRewardIdea() != "" {
+			s.openIdeaButton.Draw(screen)
+		}
+		if len(s.selectedDecisions) > 0 {
+			s.openDecisionButton.Draw(screen)
+		}
+		if focus := s.selectedFocus(); focus != nil && len(focus.RewardEvents) > 0 {
+			s.eventChainButton.Draw(screen)
+		}
+	}
+
+	if s.doc.Tree != nil {
+		s.lintButton.Draw(screen)
+		s.layoutButton.Draw(screen)
+		mode := "absolute x/y"
+		if s.layoutMode == domain.FocusLayoutRelative {
+			mode = "relative_position_id"
+		}
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Layout: %s, %d locked", mode, len(s.locked)), 20, 50)
+	}
+	if s.doc.Modified {
+		s.saveButton.Draw(screen)
+	}
+
+	if s.message != "" {
+		ebitenutil.DebugPrintAt(screen, s.message, 20, 65)
+	}
+	if s.diff != nil {
+		selectedID := ""
+		if s.selectedNode != nil {
+			selectedID = s.selectedNode.ID
+		}
+		s.diff.Draw(screen, 10, 105, selectedID)
+	}
+
+	ebitenutil.DebugPrintAt(screen, "Arrow Keys: Pan | +/-: Zoom | R: Reset | Click: Select | L: Lock | M: Layout Mode | D: Diff (Shift: file) | ESC: Back", 20, s.canvas.Height-30)
+}
+
+// drawFocusInfo draws details about the selected focus
+func (s *FocusViewerScene) drawFocusInfo(screen *ebiten.Image, focus *domain.Focus) {
+	panelX := float32(s.canvas.Width - 310)
+	modifiers := modifierTooltips(s.state.GetModifiers(), focus.RewardModifiers)
+	height := float32(125 + len(modifiers)*15)
+
+	vector.DrawFilledRect(screen, panelX, 10, 300, height, color.RGBA{30, 30, 30, 220}, false)
+	vector.StrokeRect(screen, panelX, 10, 300, height, 2, color.RGBA{80, 120, 160, 255}, false)
+
+	x := int(panelX + 10)
+	ebitenutil.DebugPrintAt(screen, "ID: "+focus.ID, x, 20)
+	ebitenutil.DebugPrintAt(screen, "Icon: "+focus.Icon, x, 35)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Position: (%d, %d)", focus.Position.X, focus.Position.Y), x, 50)
+	if focus.RelativePositionID != "" {
+		ebitenutil.DebugPrintAt(screen, "Relative to: "+focus.RelativePositionID, x, 65)
+	}
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Cost: %d", focus.Cost), x, 80)
+	if len(focus.RewardIdeas) > 0 {
+		ebitenutil.DebugPrintAt(screen, "Ideas: "+strings.Join(focus.RewardIdeas, ", "), x, 95)
+	}
+	if len(s.selectedDecisions) > 0 {
+		ids := make([]string, len(s.selectedDecisions))
+		for i, decision := range s.selectedDecisions {
+			ids[i] = decision.ID
+		}
+		ebitenutil.DebugPrintAt(screen, "Decisions: "+strings.Join(ids, ", "), x, 110)
+	}
+	for i, line := range modifiers {
+		ebitenutil.DebugPrintAt(screen, truncateText(line, 46), x, 125+i*15)
+	}
+}
+
+// OnEnter is called when entering the scene; the tree becomes the
+// current one again when switching between tabs
+func (s *FocusViewerScene) OnEnter() {
+	if s.doc.Tree != nil {
+		s.state.LoadFocusTree(s.doc.Tree)
+	}
+}
+
+// OnExit is called when exiting the scene
+func (s *FocusViewerScene) OnExit() {
+	// Nothing to do for now
+}
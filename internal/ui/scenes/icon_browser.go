@@ -0,0 +1,420 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"path/filepath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/sqweek/dialog"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
+)
+
+// Icon grid layout
+const (
+	iconGridX       = 20
+	iconGridY       = 110
+	iconCellWidth   = 124
+	iconCellHeight  = 104
+	iconGridColumns = 10
+	iconGridRows    = 5
+	iconThumbSize   = 64
+)
+
+// IconBrowserScene is a searchable gallery of all registered sprites.
+// In picker mode the chosen sprite is handed to a callback and the
+// browser returns to the scene that opened it.
+type IconBrowserScene struct {
+	manager    *SceneManager
+	state      *app.State
+	catalog    *app.IconCatalog
+	iconLoader *components.IconLoader
+
+	entries   []app.IconEntry
+	filter    app.IconFilter
+	scrollRow int
+	selected  int
+	hovered   int
+
+	// Picker mode (optional)
+	pickLabel   string
+	returnScene string
+	onPick      func(sprite *domain.Sprite)
+
+	// PNG import target (focus icon unless opened for a technology)
+	importKind   app.IconKind
+	importTechID string
+	onImport     func(sprite *domain.Sprite)
+
+	// Focuses of every focus file by name, parsed on first count
+	focusFiles map[string][]*domain.Focus
+
+	// Buttons
+	backButton   *components.Button
+	sourceButton *components.Button
+	unusedButton *components.Button
+	pickButton   *components.Button
+	importButton *components.Button
+
+	message string
+}
+
+// NewIconBrowserScene creates an icon browser over the state's sprite registry
+func NewIconBrowserScene(manager *SceneManager, state *app.State) *IconBrowserScene {
+	scene := &IconBrowserScene{
+		manager:     manager,
+		state:       state,
+		selected:    -1,
+		hovered:     -1,
+		returnScene: "country_menu",
+	}
+
+	registry := state.GetSpriteRegistry()
+	scene.catalog = app.NewIconCatalog(registry)
+
+	scene.iconLoader = components.NewIconLoader(state.GetModPath())
+	if gamePath := state.GetGamePath(); gamePath != "" {
+		scene.iconLoader.SetGamePath(gamePath)
+	}
+	scene.iconLoader.SetSpriteRegistry(registry)
+
+	scene.backButton = components.NewButton(20, 650, 160, 50, "← Back")
+	scene.sourceButton = components.NewButton(640, 50, 160, 40, "Source: All")
+	scene.unusedButton = components.NewButton(810, 50, 160, 40, "Unused: off")
+	scene.pickButton = components.NewButton(1060, 650, 200, 50, "Use Icon")
+	scene.importButton = components.NewButton(980, 50, 160, 40, "Import PNG")
+
+	scene.RefreshUsage()
+
+	return scene
+}
+
+// SetPicker switches the browser into picker mode
+// onPick receives the chosen sprite; the browser then returns to returnScene
+func (s *IconBrowserScene) SetPicker(label, returnScene string, onPick func(sprite *domain.Sprite)) {
+	s.pickLabel = label
+	s.returnScene = returnScene
+	s.onPick = onPick
+}
+
+// SetTechImport makes "Import PNG" create a technology icon for techID;
+// onImport (optional) receives the imported sprite
+func (s *IconBrowserScene) SetTechImport(techID string, onImport func(sprite *domain.Sprite)) {
+	s.importKind = app.IconKindTech
+	s.importTechID = techID
+	s.onImport = onImport
+}
+
+// RefreshUsage recounts "used by" numbers from all focus files (open focus
+// documents with their unsaved icons), ideas, decisions and the technologies
+// of the selected country
+func (s *IconBrowserScene) RefreshUsage() {
+	if s.focusFiles == nil {
+		s.focusFiles = app.LoadAllFocusFiles(s.state.GetModPath(), s.state.GetGamePath())
+	}
+	files := make(map[string][]*domain.Focus, len(s.focusFiles))
+	for name, focuses := range s.focusFiles {
+		files[name] = focuses
+	}
+	for _, doc := range s.state.Workspace.Documents() {
+		if focusDoc, ok := doc.(*app.FocusDocument); ok {
+			files[filepath.Base(focusDoc.Path)] = focusDoc.Focuses
+		}
+	}
+
+	refs := app.IconReferences{}
+	for _, focuses := range files {
+		refs.Focuses = append(refs.Focuses, focuses...)
+	}
+	if ideas := s.state.GetIdeas(); ideas != nil {
+		refs.Ideas = ideas.All()
+	}
+	if decisions := s.state.GetDecisions(); decisions != nil {
+		refs.Decisions = decisions.All()
+	}
+	if ctx := s.state.GetCountryContext(); ctx != nil {
+		refs.Technologies = ctx.AllTechnologies
+		refs.CountryTag = ctx.GetTag()
+	}
+
+	s.catalog.CountUsage(refs)
+	s.applyFilter()
+}
+
+// applyFilter rebuilds the visible entry list
+func (s *IconBrowserScene) applyFilter() {
+	s.entries = s.catalog.Entries(s.filter)
+	s.scrollRow = 0
+	s.selected = -1
+}
+
+// Update updates the icon browser
+func (s *IconBrowserScene) Update() error {
+	s.backButton.Update()
+	s.sourceButton.Update()
+	s.unusedButton.Update()
+	s.pickButton.Update()
+	s.importButton.Update()
+
+	if s.backButton.IsClicked() || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.manager.SwitchToNamed(s.returnScene)
+		return nil
+	}
+
+	if s.sourceButton.IsClicked() {
+		s.filter.Source = s.filter.Source.Next()
+		s.sourceButton.Text = "Source: " + s.filter.Source.String()
+		s.applyFilter()
+	}
+
+	if s.unusedButton.IsClicked() {
+		s.filter.UnusedOnly = !s.filter.UnusedOnly
+		s.unusedButton.Text = "Unused: off"
+		if s.filter.UnusedOnly {
+			s.unusedButton.Text = "Unused: on"
+		}
+		s.applyFilter()
+	}
+
+	if s.importButton.IsClicked() {
+		s.handleImport()
+	}
+
+	s.updateSearch()
+	s.updateGrid()
+
+	if s.onPick != nil && s.selected >= 0 && (s.pickButton.IsClicked() || inpututil.IsKeyJustPressed(ebiten.KeyEnter)) {
+		s.onPick(s.entries[s.selected].Sprite)
+		s.manager.SwitchToNamed(s.returnScene)
+	}
+
+	return nil
+}
+
+// handleImport converts a PNG chosen by the user into a mod icon
+func (s *IconBrowserScene) handleImport() {
+	s.message = ""
+
+	filePath, err := dialog.File().
+		Filter("Images", "png", "tga", "jpg").
+		Title("Select Icon Image").
+		Load()
+	if err != nil {
+		if err.Error() != "Cancelled" {
+			s.message = "Error: " + err.Error()
+		}
+		return
+	}
+
+	importer := app.NewIconImporter(s.state.GetModPath(), s.state.GetSpriteRegistry())
+	sprite, err := importer.Import(app.IconImportOptions{
+		SourcePath: filePath,
+		Kind:       s.importKind,
+		TechID:     s.importTechID,
+	})
+	if err != nil {
+		s.message = "Import failed: " + err.Error()
+		return
+	}
+
+	// Drop stale thumbnails and show the new sprite selected
+	s.iconLoader.Invalidate("sprite:" + sprite.Name)
+	if s.onImport != nil {
+		s.onImport(sprite)
+	}
+	s.filter.Prefix = sprite.Name
+	s.RefreshUsage()
+	for i, entry := range s.entries {
+		if entry.Sprite.Name == sprite.Name {
+			s.selected = i
+			break
+		}
+	}
+
+	s.message = "Imported " + sprite.Name + " -> " + sprite.TextureFile
+}
+
+// updateSearch handles typing into the name prefix filter
+func (s *IconBrowserScene) updateSearch() {
+	changed := false
+
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if r < 128 && r != ' ' {
+			s.filter.Prefix += string(r)
+			changed = true
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(s.filter.Prefix) > 0 {
+		s.filter.Prefix = s.filter.Prefix[:len(s.filter.Prefix)-1]
+		changed = true
+	}
+
+	if changed {
+		s.applyFilter()
+	}
+}
+
+// updateGrid handles scrolling, hovering and selecting icons
+func (s *IconBrowserScene) updateGrid() {
+	totalRows := (len(s.entries) + iconGridColumns - 1) / iconGridColumns
+	maxRow := totalRows - iconGridRows
+	if maxRow < 0 {
+		maxRow = 0
+	}
+
+	_, dy := ebiten.Wheel()
+	if dy > 0 && s.scrollRow > 0 {
+		s.scrollRow--
+	} else if dy < 0 && s.scrollRow < maxRow {
+		s.scrollRow++
+	}
+
+	s.hovered = -1
+	mx, my := ebiten.CursorPosition()
+	if mx < iconGridX || my < iconGridY {
+		return
+	}
+
+	col := (mx - iconGridX) / iconCellWidth
+	row := (my - iconGridY) / iconCellHeight
+	if col >= iconGridColumns || row >= iconGridRows {
+		return
+	}
+
+	index := (s.scrollRow+row)*iconGridColumns + col
+	if index >= len(s.entries) {
+		return
+	}
+
+	s.hovered = index
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		s.selected = index
+	}
+}
+
+// Draw renders the icon browser
+func (s *IconBrowserScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{30, 30, 30, 255})
+
+	title := "Icon Browser"
+	if s.pickLabel != "" {
+		title = "Pick icon: " + s.pickLabel
+	}
+	ebitenutil.DebugPrintAt(screen, title, 20, 20)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Search: %s_", s.filter.Prefix), 20, 60)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d icons", len(s.entries)), 20, 80)
+
+	s.sourceButton.Draw(screen)
+	s.unusedButton.Draw(screen)
+	s.importButton.Draw(screen)
+
+	s.drawGrid(screen)
+	s.drawDetails(screen)
+
+	if s.message != "" {
+		ebitenutil.DebugPrintAt(screen, s.message, 200, 680)
+	}
+
+	s.backButton.Draw(screen)
+	if s.onPick != nil {
+		s.pickButton.Draw(screen)
+	}
+}
+
+// drawGrid draws the visible page of thumbnails
+func (s *IconBrowserScene) drawGrid(screen *ebiten.Image) {
+	start := s.scrollRow * iconGridColumns
+	for i := 0; i < iconGridColumns*iconGridRows; i++ {
+		index := start + i
+		if index >= len(s.entries) {
+			break
+		}
+		entry := s.entries[index]
+
+		x := float32(iconGridX + (i%iconGridColumns)*iconCellWidth)
+		y := float32(iconGridY + (i/iconGridColumns)*iconCellHeight)
+
+		bg := color.RGBA{45, 45, 55, 255}
+		if index == s.selected {
+			bg = color.RGBA{80, 120, 160, 255}
+		} else if index == s.hovered {
+			bg = color.RGBA{60, 60, 75, 255}
+		}
+		vector.DrawFilledRect(screen, x, y, iconCellWidth-4, iconCellHeight-4, bg, false)
+
+		// Mod icons get a green border, vanilla grey
+		border := color.RGBA{90, 90, 100, 255}
+		if entry.Sprite.Source == "mod" {
+			border = color.RGBA{90, 160, 90, 255}
+		}
+		vector.StrokeRect(screen, x, y, iconCellWidth-4, iconCellHeight-4, 1, border, false)
+
+		s.drawThumbnail(screen, s.iconLoader.LoadSprite(entry.Sprite.Name), x+float32(iconCellWidth-4-iconThumbSize)/2, y+4)
+
+		name := entry.Sprite.Name
+		if len(name) > 19 {
+			name = name[:18] + "~"
+		}
+		ebitenutil.DebugPrintAt(screen, name, int(x)+2, int(y)+iconThumbSize+6)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("used: %d", entry.UsedBy), int(x)+2, int(y)+iconThumbSize+20)
+	}
+}
+
+// drawThumbnail draws an icon scaled to fit the thumbnail box
+func (s *IconBrowserScene) drawThumbnail(screen, img *ebiten.Image, x, y float32) {
+	if img == nil {
+		return
+	}
+
+	w := float64(img.Bounds().Dx())
+	h := float64(img.Bounds().Dy())
+	scale := 1.0
+	if w > iconThumbSize || h > iconThumbSize {
+		scale = iconThumbSize / w
+		if iconThumbSize/h < scale {
+			scale = iconThumbSize / h
+		}
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(float64(x)+(iconThumbSize-w*scale)/2, float64(y)+(iconThumbSize-h*scale)/2)
+	screen.DrawImage(img, op)
+}
+
+// drawDetails shows the full name and texture of the hovered or selected icon
+func (s *IconBrowserScene) drawDetails(screen *ebiten.Image) {
+	index := s.hovered
+	if index < 0 {
+		index = s.selected
+	}
+	if index < 0 || index >= len(s.entries) {
+		ebitenutil.DebugPrintAt(screen, "Type to filter by name, mouse wheel to scroll", 200, 630)
+		return
+	}
+
+	sprite := s.entries[index].Sprite
+	source := "vanilla"
+	if sprite.Source == "mod" {
+		source = "mod"
+	}
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s (%s, used by %d)", sprite.Name, source, s.entries[index].UsedBy), 200, 630)
+	ebitenutil.DebugPrintAt(screen, sprite.TextureFile, 200, 648)
+}
+
+// OnEnter is called when entering this scene
+func (s *IconBrowserScene) OnEnter() {
+	s.RefreshUsage()
+}
+
+// OnExit is called when leaving this scene
+func (s *IconBrowserScene) OnExit() {
+	// Nothing to do
+}
@@ -0,0 +1,515 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
+)
+
+// Idea editor layout
+const (
+	ideaDetailsX   = 470
+	ideaRowsY      = 190
+	ideaRowHeight  = 18
+	ideaRowWidth   = 560
+	maxTriggerRows = 6
+	ideaHintX      = 340 // Modifier tooltip column within a row
+
+	newModifierLabel = "new modifier (key = value)"
+)
+
+// ideaKindFilters cycles the category filter; -1 shows all kinds
+var ideaKindFilters = []domain.IdeaKind{-1, domain.IdeaKindCountry, domain.IdeaKindHidden, domain.IdeaKindLaw, domain.IdeaKindAdvisor}
+
+// ideaRow is an editable line of the details panel
+type ideaRow struct {
+	label   string
+	value   string
+	hint    string // Tooltip text drawn after the value
+	unknown bool   // Flags a modifier missing from the catalogue
+	apply   func(value string) error
+	remove  func()
+}
+
+// IdeaEditorScene browses and edits national spirits, laws and advisors
+type IdeaEditorScene struct {
+	manager    *SceneManager
+	state      *app.State
+	ideas      *domain.IdeaSet
+	iconLoader *components.IconLoader
+	preview    *scriptPreview
+
+	filtered   []*domain.Idea
+	list       *components.ScrollableList
+	kindFilter int // Index into ideaKindFilters
+	search     string
+	selected   *domain.Idea
+	starting   map[string]bool // Starting ideas of the selected country
+
+	// Inline editing
+	editing    *ideaRow
+	editBuffer string
+
+	returnScene string
+
+	backButton        *components.Button
+	kindButton        *components.Button
+	addModifierButton *components.Button
+	saveButton        *components.Button
+
+	message string
+}
+
+// NewIdeaEditorScene creates the idea editor over the state's idea set
+func NewIdeaEditorScene(manager *SceneManager, state *app.State) *IdeaEditorScene {
+	scene := &IdeaEditorScene{
+		manager:     manager,
+		state:       state,
+		ideas:       state.GetIdeas(),
+		list:        components.NewScrollableList(20, 110, 420, 520, 13),
+		starting:    make(map[string]bool),
+		returnScene: "country_menu",
+	}
+	scene.preview = newScriptPreview(state.GetScripted())
+
+	scene.iconLoader = components.NewIconLoader(state.GetModPath())
+	if gamePath := state.GetGamePath(); gamePath != "" {
+		scene.iconLoader.SetGamePath(gamePath)
+	}
+	scene.iconLoader.SetSpriteRegistry(state.GetSpriteRegistry())
+
+	if ctx := state.GetCountryContext(); ctx != nil {
+		for _, id := range ctx.Country.Ideas {
+			scene.starting[id] = true
+		}
+	}
+
+	scene.backButton = components.NewButton(20, 650, 160, 50, "← Back")
+	scene.kindButton = components.NewButton(260, 50, 180, 40, "Kind: all")
+	scene.addModifierButton = components.NewButton(ideaDetailsX, 650, 200, 50, "Add Modifier")
+	scene.saveButton = components.NewButton(1060, 650, 200, 50, "Save")
+
+	scene.applyFilter()
+
+	return scene
+}
+
+// SelectIdea selects an idea by ID (clearing filters so it is visible)
+// and makes ESC / Back return to returnScene
+func (s *IdeaEditorScene) SelectIdea(id, returnScene string) {
+	s.returnScene = returnScene
+	idea, ok := s.ideas.Get(id)
+	if !ok {
+		s.message = "Idea not found: " + id
+		return
+	}
+
+	s.search = ""
+	s.kindFilter = 0
+	s.kindButton.Text = "Kind: all"
+	s.applyFilter()
+	s.selectInList(idea)
+}
+
+// applyFilter rebuilds the list from the kind filter and search text
+func (s *IdeaEditorScene) applyFilter() {
+	kind := ideaKindFilters[s.kindFilter]
+	search := strings.ToLower(s.search)
+
+	s.filtered = s.filtered[:0]
+	items := make([]string, 0)
+	for _, idea := range s.ideas.All() {
+		if kind >= 0 && idea.Kind() != kind {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(idea.ID), search) &&
+			!strings.Contains(strings.ToLower(s.localize(idea.ID)), search) {
+			continue
+		}
+
+		s.filtered = append(s.filtered, idea)
+		label := idea.ID
+		if s.starting[idea.ID] {
+			label = "* " + label
+		}
+		items = append(items, fmt.Sprintf("%-36s %s", label, idea.CategoryName()))
+	}
+
+	s.list.SetItems(items)
+	s.selectInList(s.selected)
+}
+
+// selectInList selects an idea in the filtered list if present
+func (s *IdeaEditorScene) selectInList(idea *domain.Idea) {
+	s.selected = nil
+	for i, candidate := range s.filtered {
+		if candidate == idea {
+			s.selected = idea
+			s.list.SetSelectedIndex(i)
+			return
+		}
+	}
+}
+
+// localize returns the display name for a key
+func (s *IdeaEditorScene) localize(key string) string {
+	if ctx := s.state.GetCountryContext(); ctx != nil {
+		return parser.GetLocalization(ctx.Localizations, key)
+	}
+	return key
+}
+
+// Update updates the idea editor
+func (s *IdeaEditorScene) Update() error {
+	if s.editing != nil {
+		s.updateEditing()
+		return nil
+	}
+
+	s.backButton.Update()
+	s.kindButton.Update()
+	s.addModifierButton.Update()
+	s.saveButton.Update()
+
+	if s.backButton.IsClicked() || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.manager.SwitchToNamed(s.returnScene)
+		return nil
+	}
+
+	if s.kindButton.IsClicked() {
+		s.kindFilter = (s.kindFilter + 1) % len(ideaKindFilters)
+		s.kindButton.Text = "Kind: all"
+		if kind := ideaKindFilters[s.kindFilter]; kind >= 0 {
+			s.kindButton.Text = "Kind: " + kind.String()
+		}
+		s.applyFilter()
+	}
+
+	if s.saveButton.IsClicked() {
+		s.saveAll()
+	}
+
+	if s.selected != nil && s.addModifierButton.IsClicked() {
+		s.startEditing(&ideaRow{
+			label: newModifierLabel,
+			apply: s.addModifier,
+		})
+		return nil
+	}
+
+	s.updateSearch()
+
+	prevIndex := s.list.GetSelectedIndex()
+	s.list.Update()
+	if index := s.list.GetSelectedIndex(); index != prevIndex && index >= 0 && index < len(s.filtered) {
+		s.selected = s.filtered[index]
+		s.message = ""
+	}
+
+	s.updateRows()
+
+	return nil
+}
+
+// updateSearch handles typing into the search filter
+func (s *IdeaEditorScene) updateSearch() {
+	changed := false
+
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if r < 128 && r != ' ' {
+			s.search += string(r)
+			changed = true
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(s.search) > 0 {
+		s.search = s.search[:len(s.search)-1]
+		changed = true
+	}
+
+	if changed {
+		s.applyFilter()
+	}
+}
+
+// updateRows starts editing a clicked row or removes a modifier
+func (s *IdeaEditorScene) updateRows() {
+	if s.selected == nil || !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return
+	}
+
+	mx, my := ebiten.CursorPosition()
+	for i, row := range s.rows() {
+		y := ideaRowsY + i*ideaRowHeight
+		if my < y || my >= y+ideaRowHeight || mx < ideaDetailsX || mx >= ideaDetailsX+ideaRowWidth+40 {
+			continue
+		}
+		if row.remove != nil && mx >= ideaDetailsX+ideaRowWidth {
+			row.remove()
+			s.markDirty()
+			return
+		}
+		if row.apply != nil {
+			s.startEditing(&row)
+		}
+		return
+	}
+}
+
+// rows returns the editable lines for the selected idea
+func (s *IdeaEditorScene) rows() []ideaRow {
+	idea := s.selected
+	rows := []ideaRow{
+		{label: "picture", value: idea.Picture, apply: func(v string) error { idea.Picture = v; return nil }},
+		{label: "cost", value: idea.Cost, apply: func(v string) error { return setNumber(&idea.Cost, v) }},
+		{label: "removal_cost", value: idea.RemovalCost, apply: func(v string) error { return setNumber(&idea.RemovalCost, v) }},
+		{label: "modifier:"},
+	}
+
+	catalogue := s.state.GetModifiers()
+	for _, m := range idea.Modifiers {
+		key := m.Key
+		rows = append(rows, ideaRow{
+			label:   "  " + key,
+			value:   m.Value,
+			hint:    modifierTooltips(catalogue, []domain.Modifier{m})[0],
+			unknown: catalogue.Check(key, "") != nil,
+			apply:   func(v string) error { idea.SetModifier(key, v); return nil },
+			remove:  func() { idea.RemoveModifier(key) },
+		})
+	}
+
+	return rows
+}
+
+// startEditing opens the inline editor for a row
+func (s *IdeaEditorScene) startEditing(row *ideaRow) {
+	s.editing = row
+	s.editBuffer = row.value
+	s.message = ""
+}
+
+// updateEditing handles text input while a row is being edited
+func (s *IdeaEditorScene) updateEditing() {
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if r < 128 {
+			s.editBuffer += string(r)
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(s.editBuffer) > 0 {
+		s.editBuffer = s.editBuffer[:len(s.editBuffer)-1]
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.editing = nil
+		return
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter) {
+		value := strings.TrimSpace(s.editBuffer)
+		if err := s.editing.apply(value); err != nil {
+			s.message = err.Error()
+		} else {
+			s.markDirty()
+		}
+		s.editing = nil
+	}
+}
+
+// addModifier parses "key = value" and adds it to the selected idea
+func (s *IdeaEditorScene) addModifier(text string) error {
+	key, value, ok := strings.Cut(text, "=")
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+	if !ok || key == "" || value == "" {
+		return fmt.Errorf("expected key = value")
+	}
+	s.selected.SetModifier(key, value)
+	return nil
+}
+
+// markDirty flags the selected idea's file for saving
+func (s *IdeaEditorScene) markDirty() {
+	if s.selected != nil && s.selected.File != nil {
+		s.selected.File.Dirty = true
+	}
+}
+
+// saveAll writes every edited file into the mod
+func (s *IdeaEditorScene) saveAll() {
+	dirty := s.ideas.DirtyFiles()
+	if len(dirty) == 0 {
+		s.message = "No changes to save"
+		return
+	}
+
+	loader := app.NewIdeaLoader(s.state.GetModPath(), s.state.GetGamePath())
+	for _, file := range dirty {
+		if err := loader.Save(file); err != nil {
+			s.message = "Save failed: " + err.Error()
+			return
+		}
+	}
+	s.message = fmt.Sprintf("Saved %d file(s)", len(dirty))
+}
+
+// setNumber stores value in field if it is "" or a number
+func setNumber(field *string, value string) error {
+	if value != "" {
+		if _, ok := (domain.Modifier{Value: value}).Number(); !ok {
+			return fmt.Errorf("%q is not a number", value)
+		}
+	}
+	*field = value
+	return nil
+}
+
+// Draw renders the idea editor
+func (s *IdeaEditorScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{30, 30, 30, 255})
+
+	ebitenutil.DebugPrintAt(screen, "National Spirits, Laws & Advisors", 20, 20)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Search: %s_", s.search), 20, 60)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d ideas (* = starting idea)", len(s.filtered)), 20, 80)
+
+	s.kindButton.Draw(screen)
+	s.list.Draw(screen)
+
+	s.preview.begin()
+	if s.selected != nil {
+		s.drawDetails(screen)
+		s.addModifierButton.Draw(screen)
+	} else {
+		ebitenutil.DebugPrintAt(screen, "Select an idea to view and edit it", ideaDetailsX, 60)
+	}
+
+	if dirty := len(s.ideas.DirtyFiles()); dirty > 0 {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d unsaved file(s)", dirty), 1060, 630)
+	}
+	if s.message != "" {
+		ebitenutil.DebugPrintAt(screen, s.message, 200, 680)
+	}
+
+	s.backButton.Draw(screen)
+	s.saveButton.Draw(screen)
+	s.preview.drawTooltip(screen)
+}
+
+// drawDetails draws the selected idea's fields, triggers and focus links
+func (s *IdeaEditorScene) drawDetails(screen *ebiten.Image) {
+	idea := s.selected
+	x := ideaDetailsX
+
+	nameKey := idea.ID
+	if idea.Name != "" {
+		nameKey = idea.Name
+	}
+	ebitenutil.DebugPrintAt(screen, idea.ID, x, 60)
+	ebitenutil.DebugPrintAt(screen, s.localize(nameKey), x, 78)
+
+	source := "vanilla"
+	if idea.File != nil && idea.File.Source == "mod" {
+		source = "mod"
+	}
+	fileName := ""
+	if idea.File != nil {
+		fileName = idea.File.RelPath
+	}
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s / %s  (%s, %s)", idea.Kind(), idea.CategoryName(), fileName, source), x, 96)
+
+	if icon := s.iconLoader.LoadSprite(idea.SpriteName()); icon != nil {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(x), 116)
+		screen.DrawImage(icon, op)
+	}
+	ebitenutil.DebugPrintAt(screen, idea.SpriteName(), x+80, 140)
+
+	// Editable rows
+	mx, my := ebiten.CursorPosition()
+	for i, row := range s.rows() {
+		y := ideaRowsY + i*ideaRowHeight
+		if row.apply == nil {
+			ebitenutil.DebugPrintAt(screen, row.label, x+4, y+1)
+			continue
+		}
+
+		if mx >= x && mx < x+ideaRowWidth && my >= y && my < y+ideaRowHeight {
+			vector.DrawFilledRect(screen, float32(x), float32(y), ideaRowWidth, ideaRowHeight, color.RGBA{55, 55, 70, 255}, false)
+		}
+
+		value := row.value
+		if value == "" {
+			value = "-"
+		}
+		if s.editing != nil && s.editing.label == row.label {
+			value = s.editBuffer + "_"
+		}
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%-28s %s", row.label, value), x+4, y+1)
+		if row.unknown {
+			vector.DrawFilledRect(screen, float32(x), float32(y), 3, ideaRowHeight, color.RGBA{200, 60, 60, 255}, false)
+		}
+		if row.hint != "" {
+			ebitenutil.DebugPrintAt(screen, truncateText(row.hint, 36), x+ideaHintX, y+1)
+		}
+		if row.remove != nil {
+			ebitenutil.DebugPrintAt(screen, "[x]", x+ideaRowWidth+8, y+1)
+		}
+	}
+
+	y := ideaRowsY + len(s.rows())*ideaRowHeight + 10
+	if s.editing != nil && s.editing.label == newModifierLabel {
+		ebitenutil.DebugPrintAt(screen, "New modifier: "+s.editBuffer+"_", x, y)
+		y += ideaRowHeight
+	}
+	if s.editing != nil {
+		ebitenutil.DebugPrintAt(screen, "Enter to apply, Esc to cancel", x, y)
+		y += ideaRowHeight
+	}
+
+	// Triggers and other blocks (read-only; hover a scripted trigger to preview it)
+	for _, trigger := range []struct{ key, value string }{{"allowed", idea.Allowed}, {"available", idea.Available}, {"visible", idea.Visible}} {
+		if trigger.value == "" {
+			continue
+		}
+		y = s.preview.drawBlock(screen, trigger.key, trigger.value, x, y, maxTriggerRows)
+	}
+
+	if len(idea.Extra) > 0 {
+		keys := make([]string, len(idea.Extra))
+		for i, field := range idea.Extra {
+			keys[i] = field.Key
+		}
+		ebitenutil.DebugPrintAt(screen, "Other: "+strings.Join(keys, ", "), x, y)
+		y += 20
+	}
+
+	// Focus links (completion_reward add_ideas)
+	granted := app.IdeaGrantedBy(s.state.FocusTree, idea.ID)
+	if len(granted) > 0 {
+		ids := make([]string, len(granted))
+		for i, focus := range granted {
+			ids[i] = focus.ID
+		}
+		ebitenutil.DebugPrintAt(screen, "Granted by focus: "+strings.Join(ids, ", "), x, y)
+	}
+}
+
+// OnEnter is called when entering this scene
+func (s *IdeaEditorScene) OnEnter() {
+	s.editing = nil
+}
+
+// OnExit is called when leaving this scene
+func (s *IdeaEditorScene) OnExit() {
+	// Nothing to do
+}
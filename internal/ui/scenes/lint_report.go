@@ -0,0 +1,200 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/lint"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
+)
+
+// lintDetailX is the left edge of the selected issue panel
+const lintDetailX = 860
+
+// LintScene lists the lint issues of a focus tree or technology folder and
+// applies their automatic fixes to the loaded model
+type LintScene struct {
+	manager *SceneManager
+	engine  *lint.Engine
+	input   *lint.Input
+	onFix   func() // Called after fixes changed the model (rebuild nodes)
+
+	issues   []lint.Issue
+	list     *components.ScrollableList
+	selected int
+	message  string
+
+	fixesAvailable int
+	configWarning  string // Rules file problem (defaults are used)
+
+	backButton   *components.Button
+	fixButton    *components.Button
+	fixAllButton *components.Button
+	returnScene  string
+}
+
+// NewLintScene runs the project's lint rules (hoi4lint.txt in the mod root)
+// on the input
+func NewLintScene(manager *SceneManager, state *app.State, input *lint.Input, onFix func(), returnScene string) *LintScene {
+	scene := &LintScene{
+		manager:     manager,
+		input:       input,
+		onFix:       onFix,
+		list:        components.NewScrollableList(20, 110, 820, 520, 13),
+		selected:    -1,
+		returnScene: returnScene,
+	}
+
+	engine, err := app.NewLintEngine(state.GetModPath(), "")
+	if err != nil {
+		scene.configWarning = "Rules file: " + err.Error()
+	}
+	scene.engine = engine
+
+	scene.backButton = components.NewButton(20, 650, 160, 50, "← Back")
+	scene.fixButton = components.NewButton(200, 650, 200, 50, "Apply Fix")
+	scene.fixAllButton = components.NewButton(420, 650, 200, 50, "Apply All Fixes")
+
+	scene.run()
+	return scene
+}
+
+// LintInput builds the lint input of the state: localisation of the
+// selected country and the sprite registry
+func LintInput(state *app.State) *lint.Input {
+	input := &lint.Input{}
+	localisation := map[string]string(nil)
+	tag := ""
+	if ctx := state.GetCountryContext(); ctx != nil {
+		localisation = ctx.Localizations
+		tag = ctx.GetTag()
+	}
+	app.SetLintAssets(input, localisation, state.GetSpriteRegistry(), tag)
+	return input
+}
+
+// run checks the input and refreshes the list
+func (s *LintScene) run() {
+	s.issues = s.engine.Run(s.input)
+	s.fixesAvailable = 0
+	items := make([]string, len(s.issues))
+	for i, issue := range s.issues {
+		fix := ""
+		if issue.Fix != nil {
+			fix = " [fix]"
+			s.fixesAvailable++
+		}
+		items[i] = fmt.Sprintf("%-7s %-28s %s%s", issue.Severity, issue.Location.Object, issue.Rule, fix)
+	}
+	s.list.SetItems(items)
+	s.selected = -1
+}
+
+// applyFixes applies the fixes of issues, reruns the rules and notifies the caller
+func (s *LintScene) applyFixes(issues []lint.Issue) {
+	applied := lint.ApplyFixes(issues)
+	if applied == 0 {
+		s.message = "No automatic fix"
+		return
+	}
+	if s.onFix != nil {
+		s.onFix()
+	}
+	s.run()
+	s.message = fmt.Sprintf("Applied %d fixes (not saved to file)", applied)
+}
+
+// Update updates the scene
+func (s *LintScene) Update() error {
+	s.backButton.Update()
+	s.fixButton.Update()
+	s.fixAllButton.Update()
+
+	if s.backButton.IsClicked() || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.manager.SwitchToNamed(s.returnScene)
+		return nil
+	}
+
+	if s.selected >= 0 && s.issues[s.selected].Fix != nil && s.fixButton.IsClicked() {
+		s.applyFixes(s.issues[s.selected : s.selected+1])
+		return nil
+	}
+	if s.fixesAvailable > 0 && s.fixAllButton.IsClicked() {
+		s.applyFixes(s.issues)
+		return nil
+	}
+
+	s.list.Update()
+	if index := s.list.GetSelectedIndex(); index >= 0 && index < len(s.issues) {
+		s.selected = index
+	}
+
+	return nil
+}
+
+// Draw renders the issue list and the selected issue
+func (s *LintScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{30, 30, 30, 255})
+
+	counts := lint.Count(s.issues)
+	ebitenutil.DebugPrintAt(screen, "Lint", 20, 20)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d errors, %d warnings, %d infos", counts[lint.SeverityError], counts[lint.SeverityWarning], counts[lint.SeverityInfo]), 20, 40)
+	if s.configWarning != "" {
+		ebitenutil.DebugPrintAt(screen, s.configWarning, 20, 60)
+	}
+	if s.message != "" {
+		ebitenutil.DebugPrintAt(screen, s.message, 20, 80)
+	}
+
+	if len(s.issues) == 0 {
+		ebitenutil.DebugPrintAt(screen, "No issues found", 20, 110)
+	} else {
+		s.list.Draw(screen)
+	}
+
+	if s.selected >= 0 {
+		s.drawIssue(screen, s.issues[s.selected])
+	} else {
+		ebitenutil.DebugPrintAt(screen, "Select an issue to view it", lintDetailX, 110)
+	}
+
+	s.backButton.Draw(screen)
+	if s.selected >= 0 && s.issues[s.selected].Fix != nil {
+		s.fixButton.Draw(screen)
+	}
+	if s.fixesAvailable > 0 {
+		s.fixAllButton.Draw(screen)
+	}
+}
+
+// drawIssue draws the details of an issue
+func (s *LintScene) drawIssue(screen *ebiten.Image, issue lint.Issue) {
+	lines := []string{
+		issue.Severity.String() + " " + issue.Rule,
+		issue.Location.Object,
+		issue.Location.String(),
+		"",
+	}
+	lines = append(lines, issue.Message)
+	if issue.Fix != nil {
+		lines = append(lines, "", "Fix: "+issue.Fix.Description)
+	}
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, truncateText(line, 64), lintDetailX, 110+i*16)
+	}
+}
+
+// OnEnter is called when entering the scene
+func (s *LintScene) OnEnter() {
+	// Nothing to do for now
+}
+
+// OnExit is called when exiting the scene
+func (s *LintScene) OnExit() {
+	// Nothing to do for now
+}
@@ -0,0 +1,71 @@
+package scenes
+
+import (
+	"time"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+)
+
+// reloadInterval is how often the mod folder is checked for external edits
+const reloadInterval = time.Second
+
+// Reloadable is implemented by scenes that refresh their data when mod
+// files change on disk
+type Reloadable interface {
+	OnFilesChanged(result *app.ReloadResult)
+}
+
+// pollFileChanges watches the mod folder once it is known and applies
+// external changes to the state and every open scene
+func (sm *SceneManager) pollFileChanges() {
+	if sm.state == nil {
+		return
+	}
+	modPath := sm.state.GetModPath()
+	if modPath == "" {
+		return
+	}
+	if sm.watcher == nil || sm.watcher.Roots()[0] != modPath {
+		if sm.watcher != nil {
+			sm.watcher.Stop()
+		}
+		sm.watcher = app.NewFileWatcher([]string{modPath}, ".txt", ".yml")
+		sm.watcher.Start(reloadInterval)
+		return
+	}
+
+	select {
+	case changes := <-sm.watcher.Changes():
+		result := sm.state.ApplyFileChanges(changes)
+		println(result.Summary())
+		for _, scene := range sm.openScenes() {
+			if reloadable, ok := scene.(Reloadable); ok {
+				reloadable.OnFilesChanged(result)
+			}
+		}
+	default:
+	}
+}
+
+// openScenes returns the registered scenes, each once
+func (sm *SceneManager) openScenes() []Scene {
+	seen := make(map[Scene]bool)
+	scenes := make([]Scene, 0, len(sm.scenes)+len(sm.dynamicScenes)+len(sm.documents)+1)
+	add := func(scene Scene) {
+		if scene != nil && !seen[scene] {
+			seen[scene] = true
+			scenes = append(scenes, scene)
+		}
+	}
+	add(sm.currentScene)
+	for _, scene := range sm.scenes {
+		add(scene)
+	}
+	for _, scene := range sm.dynamicScenes {
+		add(scene)
+	}
+	for _, view := range sm.documents {
+		add(view.scene)
+	}
+	return scenes
+}
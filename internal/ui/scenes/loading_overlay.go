@@ -0,0 +1,92 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
+)
+
+// Loading overlay layout (centred panel)
+const (
+	loadingPanelX      = 340
+	loadingPanelY      = 220
+	loadingPanelWidth  = 600
+	loadingPanelHeight = 260
+	loadingMaxErrors   = 5
+)
+
+// LoadingOverlay shows the progress of a background load over a scene and
+// lets the user cancel it (button or ESC)
+type LoadingOverlay struct {
+	title        string
+	progress     *app.Progress
+	cancelButton *components.Button
+}
+
+// NewLoadingOverlay creates an overlay for a load
+func NewLoadingOverlay(title string, progress *app.Progress) *LoadingOverlay {
+	return &LoadingOverlay{
+		title:        title,
+		progress:     progress,
+		cancelButton: components.NewButton(loadingPanelX+loadingPanelWidth/2-80, loadingPanelY+loadingPanelHeight-60, 160, 45, "Cancel"),
+	}
+}
+
+// Update handles the cancel button; the load stops at the next file
+func (o *LoadingOverlay) Update() {
+	o.cancelButton.Update()
+	if o.cancelButton.IsClicked() || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		o.progress.Cancel()
+	}
+}
+
+// Draw renders the panel with the stage, a progress bar, the current file
+// and the errors so far
+func (o *LoadingOverlay) Draw(screen *ebiten.Image) {
+	snapshot := o.progress.Snapshot()
+
+	vector.DrawFilledRect(screen, 0, 0, float32(screen.Bounds().Dx()), float32(screen.Bounds().Dy()), color.RGBA{0, 0, 0, 140}, false)
+	vector.DrawFilledRect(screen, loadingPanelX, loadingPanelY, loadingPanelWidth, loadingPanelHeight, color.RGBA{30, 30, 30, 245}, false)
+	vector.StrokeRect(screen, loadingPanelX, loadingPanelY, loadingPanelWidth, loadingPanelHeight, 2, color.RGBA{80, 120, 160, 255}, false)
+
+	x, y := loadingPanelX+20, loadingPanelY+15
+	ebitenutil.DebugPrintAt(screen, o.title, x, y)
+	ebitenutil.DebugPrintAt(screen, truncateText(snapshot.Stage, 90), x, y+20)
+
+	// Progress bar (files parsed of the files known so far)
+	barWidth := float32(loadingPanelWidth - 40)
+	vector.DrawFilledRect(screen, float32(x), float32(y+42), barWidth, 14, color.RGBA{60, 60, 60, 255}, false)
+	vector.DrawFilledRect(screen, float32(x), float32(y+42), barWidth*float32(snapshot.Fraction()), 14, color.RGBA{70, 130, 180, 255}, false)
+
+	files := fmt.Sprintf("%d files parsed", snapshot.FilesDone)
+	if snapshot.FilesTotal > 0 {
+		files = fmt.Sprintf("%d / %d files parsed", snapshot.FilesDone, snapshot.FilesTotal)
+	}
+	if snapshot.Current != "" {
+		files += " - " + snapshot.Current
+	}
+	ebitenutil.DebugPrintAt(screen, truncateText(files, 90), x, y+62)
+
+	if snapshot.Cancelled {
+		ebitenutil.DebugPrintAt(screen, "Cancelling...", x, y+80)
+	} else if len(snapshot.Errors) > 0 {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d errors:", len(snapshot.Errors)), x, y+80)
+		for i, line := range snapshot.Errors {
+			if i == loadingMaxErrors {
+				break
+			}
+			ebitenutil.DebugPrintAt(screen, truncateText(line, 90), x+10, y+96+i*15)
+		}
+	}
+
+	if !snapshot.Cancelled {
+		o.cancelButton.Draw(screen)
+	}
+}
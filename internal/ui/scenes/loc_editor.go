@@ -0,0 +1,314 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"path/filepath"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
+)
+
+// Localisation editor layout
+const (
+	locDetailsX   = 560
+	locValueWidth = 110 // Characters per line of the value
+)
+
+// LocalizationEditorScene edits the strings of a localisation file open in
+// the workspace: pick a key, Enter edits its value
+type LocalizationEditorScene struct {
+	manager *SceneManager
+	doc     *app.LocalizationDocument
+
+	list     *components.ScrollableList
+	search   string
+	filtered []string
+	selected string
+
+	// Text being typed: the value of the selected key, or "key = value"
+	// for a new key
+	editing    bool
+	adding     bool
+	editBuffer string
+
+	backButton   *components.Button
+	editButton   *components.Button
+	addKeyButton *components.Button
+	saveButton   *components.Button
+
+	// The file changed on disk while edits were unsaved (F5 reloads)
+	externalChange bool
+	message        string
+}
+
+// NewLocalizationEditorScene creates the editor for a localisation document
+func NewLocalizationEditorScene(manager *SceneManager, doc *app.LocalizationDocument) *LocalizationEditorScene {
+	scene := &LocalizationEditorScene{
+		manager: manager,
+		doc:     doc,
+		list:    components.NewScrollableList(20, 110, 520, 520, 13),
+	}
+
+	scene.backButton = components.NewButton(20, 650, 160, 50, "← Back")
+	scene.editButton = components.NewButton(locDetailsX, 650, 200, 50, "Edit Value")
+	scene.addKeyButton = components.NewButton(locDetailsX+210, 650, 200, 50, "Add Key")
+	scene.saveButton = components.NewButton(1060, 650, 200, 50, "Save")
+
+	scene.applyFilter()
+
+	return scene
+}
+
+// applyFilter rebuilds the key list from the search text (edited keys are
+// marked with *)
+func (s *LocalizationEditorScene) applyFilter() {
+	search := strings.ToLower(s.search)
+	s.filtered = s.filtered[:0]
+	items := make([]string, 0)
+	for _, key := range s.doc.Keys() {
+		if search != "" && !strings.Contains(strings.ToLower(key), search) && !strings.Contains(strings.ToLower(s.doc.Values[key]), search) {
+			continue
+		}
+		s.filtered = append(s.filtered, key)
+		if s.doc.Changed(key) {
+			items = append(items, "*"+key)
+		} else {
+			items = append(items, key)
+		}
+	}
+
+	s.list.SetItems(items)
+	for i, key := range s.filtered {
+		if key == s.selected {
+			s.list.SetSelectedIndex(i)
+			return
+		}
+	}
+}
+
+// Update updates the scene
+func (s *LocalizationEditorScene) Update() error {
+	if s.editing {
+		s.updateEditing()
+		return nil
+	}
+
+	s.backButton.Update()
+	s.editButton.Update()
+	s.addKeyButton.Update()
+	s.saveButton.Update()
+
+	if s.manager.updateWorkspace() {
+		return nil
+	}
+
+	if s.backButton.IsClicked() || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.manager.SwitchToNamed("country_menu")
+		return nil
+	}
+
+	if s.saveButton.IsClicked() {
+		s.save()
+		return nil
+	}
+
+	if s.externalChange && inpututil.IsKeyJustPressed(ebiten.KeyF5) {
+		s.reload()
+		return nil
+	}
+
+	if s.selected != "" && (s.editButton.IsClicked() || inpututil.IsKeyJustPressed(ebiten.KeyEnter)) {
+		s.editing, s.adding = true, false
+		s.editBuffer = s.doc.Values[s.selected]
+		return nil
+	}
+
+	if s.addKeyButton.IsClicked() {
+		s.editing, s.adding = true, true
+		s.editBuffer = ""
+		return nil
+	}
+
+	s.updateSearch()
+
+	prevIndex := s.list.GetSelectedIndex()
+	s.list.Update()
+	if index := s.list.GetSelectedIndex(); index != prevIndex && index >= 0 && index < len(s.filtered) {
+		s.selected = s.filtered[index]
+		s.message = ""
+	}
+
+	return nil
+}
+
+// updateSearch handles typing into the search filter
+func (s *LocalizationEditorScene) updateSearch() {
+	changed := false
+
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if r < 128 && r != ' ' {
+			s.search += string(r)
+			changed = true
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(s.search) > 0 {
+		s.search = s.search[:len(s.search)-1]
+		changed = true
+	}
+
+	if changed {
+		s.applyFilter()
+	}
+}
+
+// updateEditing handles text input while a value or a new key is typed
+func (s *LocalizationEditorScene) updateEditing() {
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if r != '"' && r != '\n' {
+			s.editBuffer += string(r)
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(s.editBuffer) > 0 {
+		runes := []rune(s.editBuffer)
+		s.editBuffer = string(runes[:len(runes)-1])
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.editing = false
+		return
+	}
+
+	if !inpututil.IsKeyJustPressed(ebiten.KeyEnter) && !inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter) {
+		return
+	}
+	s.editing = false
+
+	key, value := s.selected, s.editBuffer
+	if s.adding {
+		var ok bool
+		key, value, ok = strings.Cut(s.editBuffer, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" || strings.ContainsAny(key, " :") {
+			s.message = "Expected: key = value"
+			return
+		}
+		if _, exists := s.doc.Values[key]; exists {
+			s.message = key + " already exists - edit it instead"
+			return
+		}
+	}
+
+	s.doc.Set(key, value)
+	s.selected = key
+	s.applyFilter()
+}
+
+// save writes the edited keys into the file
+func (s *LocalizationEditorScene) save() {
+	if !s.doc.Dirty() {
+		s.message = "Nothing to save"
+		return
+	}
+	if err := s.doc.Save(); err != nil {
+		s.message = "Save failed: " + err.Error()
+		return
+	}
+	s.externalChange = false
+	s.message = "Saved " + filepath.Base(s.doc.Path) + " (.bak backup kept)"
+	s.applyFilter()
+}
+
+// OnFilesChanged reloads the file after an external edit; unsaved edits
+// are kept and the conflict is reported (saving patches them into the new
+// version of the file)
+func (s *LocalizationEditorScene) OnFilesChanged(result *app.ReloadResult) {
+	if !result.Changed(s.doc.Path) {
+		return
+	}
+	if s.doc.Dirty() {
+		s.externalChange = true
+		s.message = "Warning: " + filepath.Base(s.doc.Path) + " changed on disk - Save keeps your edits, F5 reloads and drops them"
+		return
+	}
+	s.reload()
+}
+
+// reload parses the file again, keeping the selection
+func (s *LocalizationEditorScene) reload() {
+	if err := s.doc.Reload(); err != nil {
+		s.message = "Reload failed: " + err.Error()
+		return
+	}
+	s.externalChange = false
+	s.applyFilter()
+	s.message = "Reloaded " + filepath.Base(s.doc.Path) + " (changed on disk)"
+}
+
+// Draw renders the scene
+func (s *LocalizationEditorScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{30, 30, 30, 255})
+
+	ebitenutil.DebugPrintAt(screen, "Localisation ("+s.doc.Language+")", 20, 20)
+	ebitenutil.DebugPrintAt(screen, truncateText(filepath.Base(s.doc.Path), 48), 20, 40)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Search: %s_", s.search), 20, 70)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d keys (* = edited)", len(s.filtered)), 20, 88)
+	s.list.Draw(screen)
+
+	switch {
+	case s.editing && s.adding:
+		ebitenutil.DebugPrintAt(screen, "New key (key = value, Enter: add, ESC: cancel):", locDetailsX, 110)
+		s.drawWrapped(screen, s.editBuffer+"_", 130)
+	case s.selected != "":
+		ebitenutil.DebugPrintAt(screen, "Key: "+s.selected, locDetailsX, 110)
+		if s.doc.Changed(s.selected) {
+			ebitenutil.DebugPrintAt(screen, "(edited, not saved)", locDetailsX+400, 110)
+		}
+		if s.editing {
+			ebitenutil.DebugPrintAt(screen, "Value (Enter: apply, ESC: cancel):", locDetailsX, 135)
+			s.drawWrapped(screen, s.editBuffer+"_", 155)
+		} else {
+			ebitenutil.DebugPrintAt(screen, "Value (Enter: edit):", locDetailsX, 135)
+			s.drawWrapped(screen, s.doc.Values[s.selected], 155)
+		}
+		s.editButton.Draw(screen)
+	default:
+		ebitenutil.DebugPrintAt(screen, "Select a key to view and edit its string", locDetailsX, 110)
+	}
+
+	if s.message != "" {
+		ebitenutil.DebugPrintAt(screen, s.message, 200, 680)
+	}
+
+	s.backButton.Draw(screen)
+	s.addKeyButton.Draw(screen)
+	s.saveButton.Draw(screen)
+	s.manager.drawWorkspace(screen)
+}
+
+// drawWrapped draws text in lines of the details panel width
+func (s *LocalizationEditorScene) drawWrapped(screen *ebiten.Image, text string, y int) {
+	runes := []rune(text)
+	for line := 0; len(runes) > 0 && line < 25; line++ {
+		n := min(len(runes), locValueWidth)
+		ebitenutil.DebugPrintAt(screen, string(runes[:n]), locDetailsX, y+line*15)
+		runes = runes[n:]
+	}
+}
+
+// OnEnter is called when entering the scene
+func (s *LocalizationEditorScene) OnEnter() {
+	// Nothing to do for now
+}
+
+// OnExit is called when exiting the scene
+func (s *LocalizationEditorScene) OnExit() {
+	// Nothing to do for now
+}
@@ -0,0 +1,302 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/sqweek/dialog"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
+)
+
+// Merge tool layout
+const (
+	mergeDetailX     = 560
+	mergeColumnWidth = 235
+	mergeMaxLines    = 28
+)
+
+// Colours of the merge block list markers
+var (
+	mergeConflictColor = color.RGBA{200, 90, 90, 255}
+	mergeResolvedColor = color.RGBA{60, 150, 70, 255}
+)
+
+// MergeScene merges a game update into the mod copy of a technology or
+// focus file: non-conflicting block changes are merged automatically,
+// conflicts are resolved per block
+type MergeScene struct {
+	manager *SceneManager
+	state   *app.State
+
+	// Files: mod copy, old game version, new game version
+	oursPath, basePath, theirsPath string
+
+	merged   *domain.MergeFile
+	blocks   []*domain.MergeBlock // Changed blocks, conflicts first
+	list     *components.ScrollableList
+	selected int
+	message  string
+
+	oursButton   *components.Button
+	baseButton   *components.Button
+	theirsButton *components.Button
+	mergeButton  *components.Button
+	takeOurs     *components.Button
+	takeTheirs   *components.Button
+	saveButton   *components.Button
+	backButton   *components.Button
+}
+
+// NewMergeScene creates the merge tool
+func NewMergeScene(manager *SceneManager, state *app.State) *MergeScene {
+	scene := &MergeScene{
+		manager:  manager,
+		state:    state,
+		list:     components.NewScrollableList(20, 170, 520, 460, 11),
+		selected: -1,
+	}
+	scene.oursButton = components.NewButton(20, 20, 170, 40, "Mod File")
+	scene.baseButton = components.NewButton(200, 20, 170, 40, "Old Vanilla")
+	scene.theirsButton = components.NewButton(380, 20, 170, 40, "New Vanilla")
+	scene.mergeButton = components.NewButton(560, 20, 120, 40, "Merge")
+	scene.takeOurs = components.NewButton(560, 650, 160, 50, "Take Mod")
+	scene.takeTheirs = components.NewButton(730, 650, 160, 50, "Take Vanilla")
+	scene.saveButton = components.NewButton(1030, 650, 200, 50, "Save Merged")
+	scene.backButton = components.NewButton(20, 650, 160, 50, "← Back")
+	return scene
+}
+
+// Update updates the scene
+func (s *MergeScene) Update() error {
+	s.oursButton.Update()
+	s.baseButton.Update()
+	s.theirsButton.Update()
+	s.mergeButton.Update()
+	s.takeOurs.Update()
+	s.takeTheirs.Update()
+	s.saveButton.Update()
+	s.backButton.Update()
+
+	if s.backButton.IsClicked() || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.manager.SwitchToNamed("country_menu")
+		return nil
+	}
+
+	if s.oursButton.IsClicked() {
+		if path := s.pickFile("Select Mod File"); path != "" {
+			s.oursPath = path
+			if s.theirsPath == "" {
+				s.theirsPath = app.GameCounterpart(s.state.GetModPath(), s.state.GetGamePath(), path)
+			}
+		}
+	}
+	if s.baseButton.IsClicked() {
+		if path := s.pickFile("Select Old Game Version"); path != "" {
+			s.basePath = path
+		}
+	}
+	if s.theirsButton.IsClicked() {
+		if path := s.pickFile("Select New Game Version"); path != "" {
+			s.theirsPath = path
+		}
+	}
+	if s.mergeButton.IsClicked() {
+		s.merge()
+	}
+
+	if s.merged == nil {
+		return nil
+	}
+
+	s.list.Update()
+	if index := s.list.GetSelectedIndex(); index >= 0 && index < len(s.blocks) {
+		s.selected = index
+	}
+	if s.selected >= 0 && s.blocks[s.selected].Status == domain.MergeConflict {
+		if s.takeOurs.IsClicked() {
+			s.resolve(domain.MergeSideOurs)
+		}
+		if s.takeTheirs.IsClicked() {
+			s.resolve(domain.MergeSideTheirs)
+		}
+	}
+	if s.saveButton.IsClicked() {
+		s.save()
+	}
+	return nil
+}
+
+// pickFile asks for a script file ("" if cancelled)
+func (s *MergeScene) pickFile(title string) string {
+	path, err := dialog.File().
+		Filter("Script Files", "txt").
+		Title(title).
+		Load()
+	if err != nil {
+		if err.Error() != "Cancelled" {
+			s.message = "Error: " + err.Error()
+		}
+		return ""
+	}
+	return path
+}
+
+// merge merges the selected files and lists the changed blocks
+func (s *MergeScene) merge() {
+	if s.oursPath == "" || s.basePath == "" || s.theirsPath == "" {
+		s.message = "Select the mod file, the old and the new vanilla file first"
+		return
+	}
+	merged, err := app.MergeFiles(s.basePath, s.oursPath, s.theirsPath)
+	if err != nil {
+		s.message = "Error: " + err.Error()
+		return
+	}
+	s.merged = merged
+	s.selected = -1
+
+	s.blocks = make([]*domain.MergeBlock, 0)
+	merged.Walk(func(block *domain.MergeBlock) {
+		if block.Status != domain.MergeUnchanged {
+			s.blocks = append(s.blocks, block)
+		}
+	})
+	sort.SliceStable(s.blocks, func(i, j int) bool {
+		return s.blocks[i].Status == domain.MergeConflict && s.blocks[j].Status != domain.MergeConflict
+	})
+	s.refreshList()
+	s.message = merged.Summary()
+}
+
+// refreshList updates the block list labels
+func (s *MergeScene) refreshList() {
+	items := make([]string, len(s.blocks))
+	for i, block := range s.blocks {
+		label := string(block.Status)
+		if block.Status == domain.MergeConflict {
+			label = "! conflict"
+			if block.Resolution != domain.MergeSideNone {
+				label = "conflict: " + string(block.Resolution)
+			}
+		}
+		if block.Result() == "" {
+			label += " (removed)"
+		}
+		items[i] = fmt.Sprintf("%-22s %s", label, block.Key)
+	}
+	s.list.SetItems(items)
+}
+
+// resolve resolves the selected conflict with one side
+func (s *MergeScene) resolve(side domain.MergeSide) {
+	s.blocks[s.selected].Resolution = side
+	s.refreshList()
+	s.message = s.merged.Summary()
+}
+
+// save writes the merged file over the mod copy (a .bak backup is kept)
+func (s *MergeScene) save() {
+	if unresolved := s.merged.Unresolved(); unresolved > 0 {
+		s.message = fmt.Sprintf("Resolve %d conflicts before saving", unresolved)
+		return
+	}
+	if err := app.SaveMerge(s.merged, s.oursPath); err != nil {
+		s.message = "Error: " + err.Error()
+		return
+	}
+	s.message = "Merged file saved to " + filepath.Base(s.oursPath)
+}
+
+// Draw renders the files, the changed blocks and the selected block
+func (s *MergeScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{30, 30, 30, 255})
+
+	s.oursButton.Draw(screen)
+	s.baseButton.Draw(screen)
+	s.theirsButton.Draw(screen)
+	s.mergeButton.Draw(screen)
+
+	files := []struct{ label, path string }{
+		{"Mod:         ", s.oursPath}, {"Old vanilla: ", s.basePath}, {"New vanilla: ", s.theirsPath},
+	}
+	for i, file := range files {
+		ebitenutil.DebugPrintAt(screen, file.label+truncateText(diffValue(file.path), 110), 20, 75+i*16)
+	}
+	if s.message != "" {
+		ebitenutil.DebugPrintAt(screen, s.message, 20, 145)
+	}
+
+	if s.merged != nil {
+		if len(s.blocks) == 0 {
+			ebitenutil.DebugPrintAt(screen, "Nothing changed", 20, 170)
+		} else {
+			s.list.Draw(screen)
+		}
+		if s.selected >= 0 {
+			s.drawBlock(screen, s.blocks[s.selected])
+		} else {
+			ebitenutil.DebugPrintAt(screen, "Select a block to compare its versions", mergeDetailX, 170)
+		}
+		s.saveButton.Draw(screen)
+	}
+
+	s.backButton.Draw(screen)
+}
+
+// drawBlock draws the old vanilla, mod and new vanilla versions of a block
+// side by side
+func (s *MergeScene) drawBlock(screen *ebiten.Image, block *domain.MergeBlock) {
+	header := fmt.Sprintf("%s: %s", block.Key, block.Status)
+	headerColor := color.RGBA{110, 110, 110, 255}
+	if block.Status == domain.MergeConflict {
+		headerColor = mergeConflictColor
+		if block.Resolution != domain.MergeSideNone {
+			headerColor = mergeResolvedColor
+			header += " (resolved: " + string(block.Resolution) + ")"
+		}
+		s.takeOurs.Draw(screen)
+		s.takeTheirs.Draw(screen)
+	}
+	vector.DrawFilledRect(screen, mergeDetailX, 130, 12, 12, headerColor, false)
+	ebitenutil.DebugPrintAt(screen, header, mergeDetailX+18, 128)
+
+	columns := []struct{ title, text string }{
+		{"Old vanilla", block.Base}, {"Mod", block.Ours}, {"New vanilla", block.Theirs},
+	}
+	for i, column := range columns {
+		x := mergeDetailX + i*mergeColumnWidth
+		ebitenutil.DebugPrintAt(screen, column.title, x, 170)
+		if column.text == "" {
+			ebitenutil.DebugPrintAt(screen, "(absent)", x, 190)
+			continue
+		}
+		lines := strings.Split(column.text, "\n")
+		for j, line := range lines {
+			if j == mergeMaxLines {
+				ebitenutil.DebugPrintAt(screen, fmt.Sprintf("... %d more lines", len(lines)-j), x, 190+j*16)
+				break
+			}
+			ebitenutil.DebugPrintAt(screen, truncateText(strings.ReplaceAll(line, "\t", "  "), 38), x, 190+j*16)
+		}
+	}
+}
+
+// OnEnter is called when entering the scene
+func (s *MergeScene) OnEnter() {
+	// Nothing to do for now
+}
+
+// OnExit is called when exiting the scene
+func (s *MergeScene) OnExit() {
+	// Nothing to do for now
+}
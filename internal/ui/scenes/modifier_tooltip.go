@@ -0,0 +1,60 @@
+package scenes
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+)
+
+// modifierTooltips renders modifier lines as in-game tooltips; unknown
+// modifiers are prefixed with "!" and a suggestion when one is close
+func modifierTooltips(catalogue *domain.ModifierCatalogue, modifiers []domain.Modifier) []string {
+	lines := make([]string, 0, len(modifiers))
+	for _, m := range modifiers {
+		if issue := catalogue.Check(m.Key, ""); issue != nil {
+			line := "! unknown " + m.Key
+			if issue.Suggestion != "" {
+				line += " (did you mean " + issue.Suggestion + "?)"
+			}
+			lines = append(lines, line)
+			continue
+		}
+		lines = append(lines, catalogue.TooltipText(m.Key, m.Value))
+	}
+	return lines
+}
+
+// techEffectTooltips renders a technology's effects, top-level modifiers
+// first, then each category block sorted by name
+func techEffectTooltips(catalogue *domain.ModifierCatalogue, tech *domain.Technology) []string {
+	categories := make([]string, 0, len(tech.Effects))
+	for category := range tech.Effects {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	lines := make([]string, 0)
+	for _, category := range categories {
+		names := make([]string, 0, len(tech.Effects[category]))
+		for name := range tech.Effects[category] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		if category != "" {
+			lines = append(lines, domain.HumanizeModifier(category)+":")
+		}
+		modifiers := make([]domain.Modifier, len(names))
+		for i, name := range names {
+			modifiers[i] = domain.Modifier{Key: name, Value: strconv.FormatFloat(tech.Effects[category][name], 'f', -1, 64)}
+		}
+		for _, line := range modifierTooltips(catalogue, modifiers) {
+			if category != "" {
+				line = "  " + line
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,149 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"path/filepath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
+)
+
+// Provenance badge colours
+var provenanceColors = map[domain.Provenance]color.RGBA{
+	domain.ProvenanceGame:     {110, 110, 110, 255},
+	domain.ProvenanceOverride: {200, 130, 40, 255},
+	domain.ProvenanceMod:      {60, 150, 70, 255},
+}
+
+// provenanceBadges are the letters drawn on nodes in vanilla diff mode
+var provenanceBadges = map[domain.Provenance]string{
+	domain.ProvenanceGame:     "V",
+	domain.ProvenanceOverride: "O",
+	domain.ProvenanceMod:      "M",
+}
+
+// drawProvenanceBadge draws the provenance letter of a node at the
+// top-right corner of its screen rectangle
+func drawProvenanceBadge(screen *ebiten.Image, provenance domain.Provenance, x, y, width float64) {
+	bx := float32(x + width - 14)
+	vector.DrawFilledRect(screen, bx, float32(y), 14, 16, provenanceColors[provenance], false)
+	ebitenutil.DebugPrintAt(screen, provenanceBadges[provenance], int(bx)+4, int(y))
+}
+
+// provenanceDetailX is the left edge of the selected override panel
+const provenanceDetailX = 700
+
+// ProvenanceScene lists the vanilla technologies and focuses the mod
+// overrides, with the fields that differ from the game
+type ProvenanceScene struct {
+	manager     *SceneManager
+	report      *domain.ProvenanceReport
+	overrides   []domain.ProvenanceEntry
+	list        *components.ScrollableList
+	selected    int
+	backButton  *components.Button
+	returnScene string
+}
+
+// NewProvenanceScene creates the override report
+func NewProvenanceScene(manager *SceneManager, report *domain.ProvenanceReport, returnScene string) *ProvenanceScene {
+	scene := &ProvenanceScene{
+		manager:     manager,
+		report:      report,
+		overrides:   report.Overrides(),
+		list:        components.NewScrollableList(20, 110, 660, 520, 13),
+		selected:    -1,
+		returnScene: returnScene,
+	}
+	scene.backButton = components.NewButton(20, 650, 160, 50, "← Back")
+
+	items := make([]string, len(scene.overrides))
+	for i, entry := range scene.overrides {
+		items[i] = fmt.Sprintf("%-10s %-40s %d fields", entry.Kind, entry.ID, len(entry.Fields))
+	}
+	scene.list.SetItems(items)
+	return scene
+}
+
+// Update updates the scene
+func (s *ProvenanceScene) Update() error {
+	s.backButton.Update()
+	if s.backButton.IsClicked() || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.manager.SwitchToNamed(s.returnScene)
+		return nil
+	}
+
+	s.list.Update()
+	if index := s.list.GetSelectedIndex(); index >= 0 && index < len(s.overrides) {
+		s.selected = index
+	}
+	return nil
+}
+
+// Draw renders the override list and the selected override
+func (s *ProvenanceScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{30, 30, 30, 255})
+
+	ebitenutil.DebugPrintAt(screen, "Vanilla Overrides", 20, 20)
+	for i, kind := range []string{domain.ProvenanceTechnology, domain.ProvenanceFocus} {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s: %d vanilla, %d overridden, %d new", kind,
+			s.report.Count(kind, domain.ProvenanceGame), s.report.Count(kind, domain.ProvenanceOverride), s.report.Count(kind, domain.ProvenanceMod)), 20, 45+i*15)
+	}
+
+	if len(s.overrides) == 0 {
+		ebitenutil.DebugPrintAt(screen, "The mod overrides no vanilla technologies or focuses", 20, 110)
+	} else {
+		s.list.Draw(screen)
+	}
+
+	if s.selected >= 0 {
+		s.drawOverride(screen, s.overrides[s.selected])
+	} else {
+		ebitenutil.DebugPrintAt(screen, "Select an override to view it", provenanceDetailX, 110)
+	}
+
+	s.backButton.Draw(screen)
+}
+
+// drawOverride draws the files and changed fields of an override
+func (s *ProvenanceScene) drawOverride(screen *ebiten.Image, entry domain.ProvenanceEntry) {
+	lines := []string{
+		entry.Kind + " " + entry.ID,
+		"Mod:  " + filepath.Base(entry.File),
+		"Game: " + filepath.Base(entry.GameFile),
+		"",
+	}
+	if len(entry.Fields) == 0 {
+		lines = append(lines, "Identical to vanilla")
+	}
+	for _, field := range entry.Fields {
+		lines = append(lines, field.Field+":", "  - "+diffValue(field.Old), "  + "+diffValue(field.New))
+	}
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, truncateText(line, 90), provenanceDetailX, 110+i*16)
+	}
+}
+
+// diffValue shows empty values as "-"
+func diffValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// OnEnter is called when entering the scene
+func (s *ProvenanceScene) OnEnter() {
+	// Nothing to do for now
+}
+
+// OnExit is called when exiting the scene
+func (s *ProvenanceScene) OnExit() {
+	// Nothing to do for now
+}
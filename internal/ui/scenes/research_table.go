@@ -0,0 +1,270 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
+	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
+)
+
+// Research table layout
+const (
+	researchTableX      = 20
+	researchTableY      = 110
+	researchRowHeight   = 16
+	researchVisibleRows = 30
+	sharingPanelX       = 870
+	sharingPanelY       = 90
+	sharingRowHeight    = 30
+)
+
+// researchColumn is a sortable column of the research table
+type researchColumn struct {
+	title string
+	x     int
+	less  func(a, b researchRow) bool
+}
+
+// researchRow is a technology with its computed research time
+type researchRow struct {
+	tech       *domain.Technology
+	time       domain.ResearchTime
+	researched bool
+}
+
+// researchColumns are the table columns, sorted by clicking their header
+var researchColumns = []researchColumn{
+	{"Technology", 0, func(a, b researchRow) bool { return a.tech.ID < b.tech.ID }},
+	{"Year", 380, func(a, b researchRow) bool { return a.time.StartYear < b.time.StartYear }},
+	{"Cost", 450, func(a, b researchRow) bool { return a.time.BaseCost < b.time.BaseCost }},
+	{"Coeff", 520, func(a, b researchRow) bool { return a.time.PathCoeff < b.time.PathCoeff }},
+	{"Ahead", 590, func(a, b researchRow) bool { return a.time.YearsAhead < b.time.YearsAhead }},
+	{"Speed", 670, func(a, b researchRow) bool { return a.time.Speed < b.time.Speed }},
+	{"Days", 750, func(a, b researchRow) bool { return a.time.Days < b.time.Days }},
+}
+
+// ResearchTableScene lists the research times of a technology folder for
+// the selected country
+type ResearchTableScene struct {
+	manager      *SceneManager
+	research     *app.ResearchSetup
+	technologies []*domain.Technology
+	rows         []researchRow
+
+	sortColumn int
+	descending bool
+	scroll     int
+
+	backButton  *components.Button
+	returnScene string
+}
+
+// NewResearchTableScene computes the research time of every technology
+func NewResearchTableScene(manager *SceneManager, research *app.ResearchSetup, technologies []*domain.Technology, returnScene string) *ResearchTableScene {
+	scene := &ResearchTableScene{
+		manager:      manager,
+		research:     research,
+		technologies: technologies,
+		sortColumn:   len(researchColumns) - 1,
+		returnScene:  returnScene,
+	}
+	scene.backButton = components.NewButton(20, 650, 160, 50, "← Back")
+	scene.calculate()
+
+	return scene
+}
+
+// calculate recomputes the rows (after the sharing setup changes)
+func (s *ResearchTableScene) calculate() {
+	s.rows = make([]researchRow, 0, len(s.technologies))
+	for _, tech := range s.technologies {
+		s.rows = append(s.rows, researchRow{
+			tech:       tech,
+			time:       s.research.Calculate(tech),
+			researched: s.research.Researched[tech.ID],
+		})
+	}
+	s.sortRows()
+}
+
+// sortRows orders the rows by the selected column (ties by technology ID)
+func (s *ResearchTableScene) sortRows() {
+	column := researchColumns[s.sortColumn]
+	sort.SliceStable(s.rows, func(i, j int) bool {
+		a, b := s.rows[i], s.rows[j]
+		if s.descending {
+			a, b = b, a
+		}
+		if column.less(a, b) {
+			return true
+		}
+		if column.less(b, a) {
+			return false
+		}
+		return s.rows[i].tech.ID < s.rows[j].tech.ID
+	})
+}
+
+// Update handles header clicks, scrolling and navigation
+func (s *ResearchTableScene) Update() error {
+	s.backButton.Update()
+
+	if s.backButton.IsClicked() || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.manager.SwitchToNamed(s.returnScene)
+		return nil
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		mouseX, mouseY := ebiten.CursorPosition()
+		if mouseX < sharingPanelX && mouseY >= researchTableY-20 && mouseY < researchTableY {
+			for i := len(researchColumns) - 1; i >= 0; i-- {
+				if mouseX >= researchTableX+researchColumns[i].x {
+					if s.sortColumn == i {
+						s.descending = !s.descending
+					} else {
+						s.sortColumn, s.descending = i, false
+					}
+					s.sortRows()
+					break
+				}
+			}
+		}
+		if index := (mouseY - sharingPanelY - 40) / sharingRowHeight; mouseX >= sharingPanelX && mouseX < sharingPanelX+390 && mouseY >= sharingPanelY+40 && index < len(s.research.Sharing) {
+			s.research.ToggleSharing(s.research.Sharing[index].Group.ID)
+			s.calculate()
+		}
+	}
+
+	// [ / ] change the assumed number of members sharing a technology
+	if inpututil.IsKeyJustPressed(ebiten.KeyBracketLeft) {
+		s.research.SetSharingCountries(s.research.SharingCountries - 1)
+		s.calculate()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyBracketRight) {
+		s.research.SetSharingCountries(s.research.SharingCountries + 1)
+		s.calculate()
+	}
+
+	_, dy := ebiten.Wheel()
+	if dy != 0 {
+		s.scroll -= int(dy * 3)
+	}
+	if s.scroll > len(s.rows)-researchVisibleRows {
+		s.scroll = len(s.rows) - researchVisibleRows
+	}
+	if s.scroll < 0 {
+		s.scroll = 0
+	}
+
+	return nil
+}
+
+// Draw renders the table
+func (s *ResearchTableScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{30, 30, 30, 255})
+
+	input, defines := s.research.Input, s.research.Defines
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Research times at %.2f (%d technologies)", input.Date, len(s.rows)), researchTableX, 20)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Speed bonus %+.0f%%, sharing %+.0f%% | base cost %g days, ahead penalty %g/year",
+		input.SpeedBonus*100, input.SharingBonus*100, defines.BaseTechCost, defines.AheadPenaltyPerYear), researchTableX, 40)
+	ebitenutil.DebugPrintAt(screen, truncateText(fmt.Sprintf("Sources: %v", s.research.Sources), 140), researchTableX, 60)
+
+	// Header
+	vector.DrawFilledRect(screen, researchTableX-5, researchTableY-22, 830, 20, color.RGBA{50, 50, 70, 255}, false)
+	for i, column := range researchColumns {
+		title := column.title
+		if i == s.sortColumn {
+			if s.descending {
+				title += " v"
+			} else {
+				title += " ^"
+			}
+		}
+		ebitenutil.DebugPrintAt(screen, title, researchTableX+column.x, researchTableY-20)
+	}
+
+	for i := 0; i < researchVisibleRows && s.scroll+i < len(s.rows); i++ {
+		row := s.rows[s.scroll+i]
+		y := researchTableY + i*researchRowHeight
+		if row.researched {
+			vector.DrawFilledRect(screen, researchTableX-5, float32(y), 830, researchRowHeight, color.RGBA{40, 70, 40, 255}, false)
+		}
+
+		year := "-"
+		if row.time.StartYear > 0 {
+			year = fmt.Sprintf("%d", row.time.StartYear)
+		}
+		days := fmt.Sprintf("%.0f", row.time.Days)
+		if row.researched {
+			days = "done"
+		}
+		cells := []string{
+			truncateText(row.tech.ID, 60),
+			year,
+			fmt.Sprintf("%g", row.time.BaseCost),
+			fmt.Sprintf("%g", row.time.PathCoeff),
+			fmt.Sprintf("%.1f", row.time.YearsAhead),
+			fmt.Sprintf("%.2f", row.time.Speed),
+			days,
+		}
+		for c, cell := range cells {
+			ebitenutil.DebugPrintAt(screen, cell, researchTableX+researchColumns[c].x, y)
+		}
+	}
+
+	s.drawSharing(screen)
+
+	ebitenutil.DebugPrintAt(screen, "Click a header to sort | Wheel: Scroll | green = researched at start | ESC: Back", 200, 670)
+	s.backButton.Draw(screen)
+}
+
+// drawSharing draws the technology sharing groups; clicking an available
+// group counts it in the research times
+func (s *ResearchTableScene) drawSharing(screen *ebiten.Image) {
+	height := float32(50 + len(s.research.Sharing)*sharingRowHeight)
+	vector.DrawFilledRect(screen, sharingPanelX, sharingPanelY, 390, height, color.RGBA{40, 40, 40, 255}, false)
+	vector.StrokeRect(screen, sharingPanelX, sharingPanelY, 390, height, 2, color.RGBA{80, 80, 80, 255}, false)
+
+	ebitenutil.DebugPrintAt(screen, "Technology sharing groups", sharingPanelX+10, sharingPanelY+5)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Members with the tech: %d ([ / ] to change)", s.research.SharingCountries), sharingPanelX+10, sharingPanelY+20)
+	if len(s.research.Sharing) == 0 {
+		ebitenutil.DebugPrintAt(screen, "No groups in common/technology_sharing", sharingPanelX+10, sharingPanelY+45)
+	}
+
+	for i, status := range s.research.Sharing {
+		y := sharingPanelY + 40 + i*sharingRowHeight
+		mark, state := "[ ]", "can join"
+		switch {
+		case !status.Available:
+			mark, state = " - ", "not available"
+			vector.DrawFilledRect(screen, sharingPanelX+5, float32(y), 380, sharingRowHeight-2, color.RGBA{60, 35, 35, 255}, false)
+		case status.Joined:
+			mark = "[x]"
+			vector.DrawFilledRect(screen, sharingPanelX+5, float32(y), 380, sharingRowHeight-2, color.RGBA{40, 70, 40, 255}, false)
+		}
+		if status.Available && len(status.Unknown) > 0 {
+			state = "unchecked: " + strings.Join(status.Unknown, ", ")
+		}
+		ebitenutil.DebugPrintAt(screen, truncateText(fmt.Sprintf("%s %s %s/country", mark, status.Group.ID, domain.FormatModifierValue(status.Group.PerCountryBonus, domain.ModifierPercent)), 60), sharingPanelX+10, y)
+		ebitenutil.DebugPrintAt(screen, truncateText("    "+state, 60), sharingPanelX+10, y+13)
+	}
+}
+
+// OnEnter is called when entering the scene
+func (s *ResearchTableScene) OnEnter() {
+	// Nothing to do
+}
+
+// OnExit is called when leaving the scene
+func (s *ResearchTableScene) OnExit() {
+	// Nothing to do
+}
@@ -29,6 +29,15 @@ type SceneManager struct {
 	scenes        map[SceneType]Scene
 	dynamicScenes map[string]Scene // For dynamically created scenes
 	state         *app.State
+	watcher       *app.FileWatcher // Live reload of the mod folder
+
+	// Scenes of the documents open in the workspace, by document key
+	documents        map[string]documentView
+	workspaceMessage string
+	closePending     string // Document closed on the next Ctrl+W despite unsaved changes
+
+	// Asks about unsaved documents when the window is closed (nil otherwise)
+	exitPrompt *ExitPrompt
 }
 
 // NewSceneManager creates a new SceneManager
@@ -36,6 +45,7 @@ func NewSceneManager(state *app.State) *SceneManager {
 	sm := &SceneManager{
 		scenes:        make(map[SceneType]Scene),
 		dynamicScenes: make(map[string]Scene),
+		documents:     make(map[string]documentView),
 		state:         state,
 	}
 	
@@ -52,6 +62,22 @@ func NewSceneManager(state *app.State) *SceneManager {
 
 // Update updates the current scene
 func (sm *SceneManager) Update() error {
+	if sm.exitPrompt != nil {
+		done, err := sm.exitPrompt.Update()
+		if done {
+			sm.exitPrompt = nil
+		}
+		return err
+	}
+	if ebiten.IsWindowBeingClosed() {
+		if len(sm.state.Workspace.DirtyDocuments()) == 0 {
+			return ebiten.Termination
+		}
+		sm.exitPrompt = NewExitPrompt(sm)
+		return nil
+	}
+
+	sm.pollFileChanges()
 	if sm.currentScene != nil {
 		return sm.currentScene.Update()
 	}
@@ -63,6 +89,9 @@ func (sm *SceneManager) Draw(screen *ebiten.Image) {
 	if sm.currentScene != nil {
 		sm.currentScene.Draw(screen)
 	}
+	if sm.exitPrompt != nil {
+		sm.exitPrompt.Draw(screen)
+	}
 }
 
 // SwitchTo switches to a different scene by SceneType
@@ -0,0 +1,112 @@
+package scenes
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
+)
+
+// Scripted preview tooltip layout
+const (
+	scriptLineHeight    = 16
+	scriptCharWidth     = 6
+	maxPreviewRows      = 24
+	previewTooltipWidth = 460
+)
+
+// scriptPreview draws read-only script blocks and shows the expanded body
+// of a scripted trigger/effect when the mouse hovers over a reference
+type scriptPreview struct {
+	library *parser.ScriptedLibrary
+	hovered string // Scripted name under the mouse during the last draw
+	mouseX  int
+	mouseY  int
+}
+
+// newScriptPreview creates a preview over the library (nil disables tooltips)
+func newScriptPreview(library *parser.ScriptedLibrary) *scriptPreview {
+	return &scriptPreview{library: library}
+}
+
+// begin resets hover state; call once per frame before drawing blocks
+func (p *scriptPreview) begin() {
+	p.hovered = ""
+	p.mouseX, p.mouseY = ebiten.CursorPosition()
+}
+
+// drawBlock draws key = value (at most maxRows lines) and returns the next y
+func (p *scriptPreview) drawBlock(screen *ebiten.Image, key, value string, x, y, maxRows int) int {
+	lines := strings.Split(key+" = "+value, "\n")
+	if len(lines) > maxRows {
+		lines = append(lines[:maxRows-1], "\t...")
+	}
+
+	for _, line := range lines {
+		text := strings.ReplaceAll(line, "\t", "  ")
+		name := scriptLineKey(text)
+		if _, ok := p.lookup(name); ok {
+			width := len(text) * scriptCharWidth
+			vector.DrawFilledRect(screen, float32(x), float32(y+scriptLineHeight-2), float32(width), 1, color.RGBA{120, 170, 220, 255}, false)
+			if p.mouseX >= x && p.mouseX < x+width && p.mouseY >= y && p.mouseY < y+scriptLineHeight {
+				p.hovered = name
+			}
+		}
+		ebitenutil.DebugPrintAt(screen, text, x, y)
+		y += scriptLineHeight
+	}
+	return y
+}
+
+// drawTooltip draws the expanded body of the hovered reference; call last
+func (p *scriptPreview) drawTooltip(screen *ebiten.Image) {
+	if p.hovered == "" {
+		return
+	}
+	preview, ok := p.library.Preview(p.hovered)
+	if !ok {
+		return
+	}
+
+	lines := strings.Split(strings.TrimRight(preview, "\n"), "\n")
+	if len(lines) > maxPreviewRows {
+		lines = append(lines[:maxPreviewRows-1], "...")
+	}
+
+	bounds := screen.Bounds()
+	height := len(lines)*scriptLineHeight + 12
+	x, y := p.mouseX+16, p.mouseY+16
+	if x+previewTooltipWidth > bounds.Dx() {
+		x = bounds.Dx() - previewTooltipWidth
+	}
+	if y+height > bounds.Dy() {
+		y = bounds.Dy() - height
+	}
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), previewTooltipWidth, float32(height), color.RGBA{20, 25, 35, 240}, false)
+	vector.StrokeRect(screen, float32(x), float32(y), previewTooltipWidth, float32(height), 2, color.RGBA{120, 170, 220, 255}, false)
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, truncateText(strings.ReplaceAll(line, "\t", "  "), 74), x+6, y+6+i*scriptLineHeight)
+	}
+}
+
+// lookup finds a scripted trigger or effect by name
+func (p *scriptPreview) lookup(name string) (*parser.ScriptedDefinition, bool) {
+	if p.library == nil || name == "" {
+		return nil, false
+	}
+	return p.library.Get(name)
+}
+
+// scriptLineKey returns the leading key of a script line ("  key = ...")
+func scriptLineKey(line string) string {
+	line = strings.TrimSpace(line)
+	if end := strings.IndexAny(line, " =<>"); end >= 0 {
+		line = line[:end]
+	}
+	return line
+}
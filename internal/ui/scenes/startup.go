@@ -102,7 +102,7 @@ func (s *StartupScene) handleModSelection() {
 
 	// Save to state
 	if err := s.state.SetModDescriptor(modDesc); err != nil {
-		s.errorMessage = "Failed to save mod config: " + err.Error()
+		s.errorMessage = "Failed to set mod: " + err.Error()
 		return
 	}
 
@@ -136,7 +136,7 @@ func (s *StartupScene) handleGameSelection() {
 
 	// Save to state
 	if err := s.state.SetGameInstallation(game); err != nil {
-		s.errorMessage = "Failed to save game config: " + err.Error()
+		s.errorMessage = "Failed to set game: " + err.Error()
 		return
 	}
 
@@ -164,7 +164,7 @@ func (s *StartupScene) handleGameAutoDetect() {
 
 	// Save to state
 	if err := s.state.SetGameInstallation(game); err != nil {
-		s.errorMessage = "Failed to save game config: " + err.Error()
+		s.errorMessage = "Failed to set game: " + err.Error()
 		return
 	}
 
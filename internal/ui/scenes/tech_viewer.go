@@ -4,11 +4,16 @@ import (
 	"fmt"
 	"image/color"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/sqweek/dialog"
 
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
 	"github.com/shinomontaz/hoi4_visual_modder/internal/domain"
 	"github.com/shinomontaz/hoi4_visual_modder/internal/parser"
 	"github.com/shinomontaz/hoi4_visual_modder/internal/ui/components"
@@ -16,11 +21,18 @@ import (
 
 // TechViewerScene displays technology tree visually
 type TechViewerScene struct {
-	manager      *SceneManager
-	canvas       *components.Canvas
-	nodes        []*components.Node
-	technologies []*domain.Technology
-	iconLoader   *components.IconLoader
+	manager    *SceneManager
+	canvas     *components.Canvas
+	nodes      []*components.Node
+	iconLoader *components.IconLoader
+
+	// Technologies with their unsaved grid positions; their source for
+	// live reload is a file, or a folder of the country context
+	doc *app.TechDocument
+
+	// Doctrine folders use the grand doctrine/track layout
+	doctrines      *domain.DoctrineTree
+	doctrineLayout map[string]domain.Position
 
 	// UI state
 	selectedNode *components.Node
@@ -28,6 +40,25 @@ type TechViewerScene struct {
 
 	// Info panel
 	showInfo bool
+
+	changeIconButton *components.Button
+	lineageButton    *components.Button
+	researchButton   *components.Button
+	lintButton       *components.Button
+	gridButton       *components.Button
+	saveButton       *components.Button
+	buttons          components.ButtonGroup
+	message          string
+
+	// Technology files changed on disk while the grid was unsaved (F5 reloads)
+	externalChange bool
+
+	// Changes since git HEAD (D) or an older file (Shift+D), nil when off
+	diff *DiffOverlay
+
+	// Vanilla diff mode (V): game/override/mod badges on the nodes
+	provenance      *domain.ProvenanceReport
+	overridesButton *components.Button
 }
 
 // NewTechViewerScene creates a new tech viewer scene
@@ -37,13 +68,20 @@ func NewTechViewerScene(manager *SceneManager, filePath string) *TechViewerScene
 		canvas:   components.NewCanvas(1280, 720),
 		nodes:    make([]*components.Node, 0),
 		showInfo: true,
+		doc:      &app.TechDocument{Path: filePath},
+	}
+	if manager.state != nil {
+		scene.doc.ModPath = manager.state.GetModPath()
 	}
+	scene.createButtons()
 
 	// Parse the technology file
-	if err := scene.loadTechnologies(filePath); err != nil {
+	technologies, err := parseTechnologyFile(filePath)
+	if err != nil {
 		fmt.Printf("Error loading technologies: %v\n", err)
 		return scene
 	}
+	scene.doc.Technologies = technologies
 
 	// Initialize icon loader with paths from manager state
 	if manager.state != nil {
@@ -54,6 +92,10 @@ func NewTechViewerScene(manager *SceneManager, filePath string) *TechViewerScene
 		if gamePath != "" {
 			scene.iconLoader.SetGamePath(gamePath)
 		}
+		scene.iconLoader.SetSpriteRegistry(manager.state.GetSpriteRegistry())
+		if ctx := manager.state.GetCountryContext(); ctx != nil {
+			scene.iconLoader.SetCountryTag(ctx.GetTag())
+		}
 	} else {
 		// Fallback: try to detect base path from file path
 		scene.iconLoader = components.NewIconLoader(detectBasePath(filePath))
@@ -70,21 +112,21 @@ func NewTechViewerScene(manager *SceneManager, filePath string) *TechViewerScene
 	return scene
 }
 
-// NewTechViewerSceneWithTree creates a new tech viewer scene from a pre-loaded technology tree
-func NewTechViewerSceneWithTree(manager *SceneManager, techTree *domain.TechnologyTree) *TechViewerScene {
-	// Convert map to slice
-	technologies := make([]*domain.Technology, 0, len(techTree.Technologies))
-	for _, tech := range techTree.Technologies {
-		technologies = append(technologies, tech)
-	}
-
+// NewTechViewerSceneWithTree creates a new tech viewer scene from a pre-loaded
+// technology tree of a folder open in the workspace
+func NewTechViewerSceneWithTree(manager *SceneManager, techTree *domain.TechnologyTree, doc *app.TechDocument) *TechViewerScene {
 	scene := &TechViewerScene{
-		manager:      manager,
-		canvas:       components.NewCanvas(1280, 720),
-		nodes:        make([]*components.Node, 0),
-		showInfo:     true,
-		technologies: technologies,
+		manager:  manager,
+		canvas:   components.NewCanvas(1280, 720),
+		nodes:    make([]*components.Node, 0),
+		showInfo: true,
+		doc:      doc,
 	}
+	for _, doctrines := range techTree.Doctrines {
+		scene.doctrines = doctrines
+		scene.doctrineLayout = doctrines.Layout()
+	}
+	scene.createButtons()
 
 	// Initialize icon loader with paths from manager state
 	if manager.state != nil {
@@ -95,6 +137,10 @@ func NewTechViewerSceneWithTree(manager *SceneManager, techTree *domain.Technolo
 		if gamePath != "" {
 			scene.iconLoader.SetGamePath(gamePath)
 		}
+		scene.iconLoader.SetSpriteRegistry(manager.state.GetSpriteRegistry())
+		if ctx := manager.state.GetCountryContext(); ctx != nil {
+			scene.iconLoader.SetCountryTag(ctx.GetTag())
+		}
 	}
 
 	// Create nodes from technologies
@@ -130,36 +176,50 @@ func detectBasePath(filePath string) string {
 	return ""
 }
 
-// loadTechnologies loads and parses the technology file
-func (s *TechViewerScene) loadTechnologies(filePath string) error {
+// parseTechnologyFile loads and parses a technology file
+func parseTechnologyFile(filePath string) ([]*domain.Technology, error) {
 	// Read file
 	content, err := os.ReadFile(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
 	// Parse with lexer and parser
 	p := parser.NewParser(string(content))
 	program, err := p.Parse()
 	if err != nil {
-		return fmt.Errorf("failed to parse: %w", err)
+		return nil, fmt.Errorf("failed to parse: %w", err)
 	}
 
 	// Parse technologies
 	techParser := parser.NewTechParser()
 	technologies, err := techParser.ParseTechnologies(program)
 	if err != nil {
-		return fmt.Errorf("failed to parse technologies: %w", err)
+		return nil, fmt.Errorf("failed to parse technologies: %w", err)
+	}
+	for _, tech := range technologies {
+		tech.File = filePath
 	}
 
-	s.technologies = technologies
-	return nil
+	return technologies, nil
+}
+
+// loadSource parses the technologies of the document's file or folder again
+func (s *TechViewerScene) loadSource() ([]*domain.Technology, error) {
+	if s.doc.Path != "" {
+		return parseTechnologyFile(s.doc.Path)
+	}
+	return s.manager.state.GetCountryContext().LoadTechnologiesForFolder(s.doc.Folder)
 }
 
 // createNodes creates visual nodes from technologies
 func (s *TechViewerScene) createNodes() {
-	for _, tech := range s.technologies {
-		node := components.NewNode(tech.ID, tech.ID, tech.Position.X, tech.Position.Y)
+	for _, tech := range s.doc.Technologies {
+		x, y := tech.Position.X, tech.Position.Y
+		if position, ok := s.doctrineLayout[tech.ID]; ok {
+			x, y = position.X, position.Y
+		}
+		node := components.NewNode(tech.ID, tech.ID, x, y)
 
 		// Load icon if icon loader is available
 		if s.iconLoader != nil {
@@ -169,6 +229,21 @@ func (s *TechViewerScene) createNodes() {
 
 		s.nodes = append(s.nodes, node)
 	}
+	if s.diff != nil {
+		s.diff.Apply(s.nodes)
+	}
+}
+
+// createButtons creates the buttons of the scene
+func (s *TechViewerScene) createButtons() {
+	s.changeIconButton = components.NewButton(1060, 650, 200, 50, "Change Icon")
+	s.lineageButton = components.NewButton(850, 650, 200, 50, "Equipment Lineage")
+	s.researchButton = components.NewButton(640, 650, 200, 50, "Research Times")
+	s.lintButton = components.NewButton(430, 650, 200, 50, "Lint")
+	s.gridButton = components.NewButton(220, 650, 200, 50, "Grid Layout")
+	s.saveButton = components.NewButton(10, 650, 200, 50, "Save")
+	s.overridesButton = components.NewButton(10, 590, 200, 50, "Vanilla Overrides")
+	s.buttons = components.ButtonGroup{s.changeIconButton, s.lineageButton, s.researchButton, s.lintButton, s.gridButton, s.saveButton, s.overridesButton}
 }
 
 // centerOnNode centers the view on a specific node
@@ -182,6 +257,57 @@ func (s *TechViewerScene) centerOnNode(node *components.Node) {
 func (s *TechViewerScene) Update() error {
 	// Update canvas (pan/zoom)
 	s.canvas.Update()
+	s.buttons.Update()
+
+	if s.manager.updateWorkspace() {
+		return nil
+	}
+
+	if s.selectedNode != nil && s.changeIconButton.IsClicked() && s.manager.state != nil {
+		s.openIconPicker()
+		return nil
+	}
+
+	if tech := s.selectedTech(); tech != nil && len(tech.EnableEquipments) > 0 && s.lineageButton.IsClicked() && s.manager.state != nil {
+		lineage := NewEquipmentLineageScene(s.manager, s.manager.state, tech.EnableEquipments[0], s.doc.Technologies, "tech_viewer")
+		s.manager.AddScene("equipment_lineage", lineage)
+		s.manager.SwitchToNamed("equipment_lineage")
+		return nil
+	}
+
+	if research := s.research(); research != nil && s.researchButton.IsClicked() {
+		table := NewResearchTableScene(s.manager, research, s.doc.Technologies, "tech_viewer")
+		s.manager.AddScene("research_table", table)
+		s.manager.SwitchToNamed("research_table")
+		return nil
+	}
+
+	if s.manager.state != nil && s.lintButton.IsClicked() {
+		s.openLint()
+		return nil
+	}
+
+	if s.manager.state != nil && s.doctrines == nil && s.gridButton.IsClicked() {
+		s.gridLayout()
+		return nil
+	}
+
+	if s.doc.Modified && s.saveButton.IsClicked() {
+		s.save()
+		return nil
+	}
+
+	if s.externalChange && inpututil.IsKeyJustPressed(ebiten.KeyF5) {
+		s.reload()
+		return nil
+	}
+
+	if s.provenance != nil && s.overridesButton.IsClicked() {
+		report := NewProvenanceScene(s.manager, s.provenance, "tech_viewer")
+		s.manager.AddScene("provenance", report)
+		s.manager.SwitchToNamed("provenance")
+		return nil
+	}
 
 	// Handle mouse hover
 	mouseX, mouseY := ebiten.CursorPosition()
@@ -196,7 +322,7 @@ func (s *TechViewerScene) Update() error {
 	}
 
 	// Handle mouse click
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && !s.buttons.Hovered() {
 		if s.hoveredNode != nil {
 			if s.selectedNode != nil {
 				s.selectedNode.IsSelected = false
@@ -206,8 +332,21 @@ func (s *TechViewerScene) Update() error {
 		}
 	}
 
+	if inpututil.IsKeyJustPressed(ebiten.KeyD) {
+		s.toggleDiff(ebiten.IsKeyPressed(ebiten.KeyShift))
+	}
+
+	// Toggle vanilla diff mode with V
+	if s.manager.state != nil && inpututil.IsKeyJustPressed(ebiten.KeyV) {
+		if s.provenance == nil {
+			s.provenance = s.manager.state.GetProvenance()
+		} else {
+			s.provenance = nil
+		}
+	}
+
 	// ESC to go back
-	if ebiten.IsKeyPressed(ebiten.KeyEscape) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
 		s.manager.SwitchTo(SceneStartup)
 	}
 
@@ -219,21 +358,365 @@ func (s *TechViewerScene) Update() error {
 	return nil
 }
 
+// openIconPicker opens the icon browser to register an icon for the selected technology
+func (s *TechViewerScene) openIconPicker() {
+	node := s.selectedNode
+	techID := node.ID
+	state := s.manager.state
+	countryTag := ""
+	if ctx := state.GetCountryContext(); ctx != nil {
+		countryTag = ctx.GetTag()
+	}
+
+	browser := NewIconBrowserScene(s.manager, state)
+	browser.SetTechImport(techID, func(sprite *domain.Sprite) {
+		s.reloadIcon(node)
+	})
+	browser.SetPicker("technology "+techID, "tech_viewer", func(sprite *domain.Sprite) {
+		importer := app.NewIconImporter(state.GetModPath(), state.GetSpriteRegistry())
+		alias, err := importer.DeclareTechIcon(techID, countryTag, sprite)
+		if err != nil {
+			s.message = "Icon not changed: " + err.Error()
+			return
+		}
+		s.reloadIcon(node)
+		s.message = "Declared " + alias.Name + " -> " + sprite.TextureFile + " in " + filepath.Base(alias.GfxFile)
+	})
+
+	s.manager.AddScene("icon_browser", browser)
+	s.manager.SwitchToNamed("icon_browser")
+}
+
+// reloadIcon resolves the icon of a node again after its sprite changed
+func (s *TechViewerScene) reloadIcon(node *components.Node) {
+	if s.iconLoader != nil {
+		s.iconLoader.Invalidate(node.ID)
+		node.Icon = s.iconLoader.LoadTechIcon(node.ID)
+	}
+}
+
+// openLint opens the lint report of the viewed technologies; the other
+// technologies are known targets of paths and xor across folders
+func (s *TechViewerScene) openLint() {
+	state := s.manager.state
+	input := LintInput(state)
+	input.Technologies = s.doc.Technologies
+	others, err := app.NewTechnologyLoader(state.GetModPath(), state.GetGamePath()).SetCache(state.Cache).LoadAllTechnologies()
+	if err != nil {
+		println("Warning: Failed to load technologies:", err.Error())
+	}
+	input.OtherTechnologies = others
+
+	report := NewLintScene(s.manager, state, input, func() {
+		s.rebuildNodes()
+		s.doc.Modified = true
+		s.message = "Lint fixes applied (not saved)"
+	}, "tech_viewer")
+
+	s.manager.AddScene("lint", report)
+	s.manager.SwitchToNamed("lint")
+}
+
+// gridLayout places the technologies of every folder on the year rows of
+// their files and gives their columns variables
+func (s *TechViewerScene) gridLayout() {
+	grid, err := app.LoadTechGrid(s.doc.Technologies)
+	if err != nil {
+		s.message = "Grid layout failed: " + err.Error()
+		return
+	}
+
+	folders := make(map[string][]*domain.Technology)
+	for _, tech := range s.doc.Technologies {
+		folders[tech.Folder] = append(folders[tech.Folder], tech)
+	}
+	s.doc.Columns = make(map[string]int)
+	before, after := 0, 0
+	for folder, technologies := range folders {
+		layout := domain.NewTechGridLayout(grid, folder)
+		before += len(layout.Check(technologies))
+		layout.Layout(technologies)
+		after += len(layout.Check(technologies))
+		for name, value := range layout.NewColumns {
+			s.doc.Columns[name] = value
+		}
+	}
+
+	s.rebuildNodes()
+	s.doc.Modified = true
+	s.message = fmt.Sprintf("Grid layout: issues %d -> %d, %d new column variables (not saved)", before, after, len(s.doc.Columns))
+}
+
+// save writes the positions and links of the technologies into the mod
+// technology files
+func (s *TechViewerScene) save() {
+	if err := s.doc.Save(); err != nil {
+		s.message = "Save failed: " + err.Error()
+		return
+	}
+	s.externalChange = false
+	s.message = "Saved technologies (.bak backups kept)"
+}
+
+// toggleDiff shows the changes of the technologies (including unsaved ones)
+// since git HEAD of their files, or since a file chosen by the user;
+// pressed again it hides them
+func (s *TechViewerScene) toggleDiff(pickFile bool) {
+	if s.diff != nil {
+		s.diff = nil
+		s.rebuildNodes()
+		return
+	}
+
+	oldPath, against := "", "HEAD"
+	if pickFile {
+		path, err := dialog.File().
+			Filter("Technology Files", "txt").
+			Title("Select Older Version").
+			Load()
+		if err != nil {
+			if err.Error() != "Cancelled" {
+				s.message = "Error: " + err.Error()
+			}
+			return
+		}
+		oldPath, against = path, filepath.Base(path)
+	}
+
+	diff, err := app.DiffTechnologies(s.doc.Technologies, s.doc.ModPath, oldPath, "HEAD")
+	if err != nil {
+		s.message = "Diff failed: " + err.Error()
+		return
+	}
+	s.diff = NewDiffOverlay(diff, against)
+	s.rebuildNodes()
+}
+
+// OnFilesChanged reloads the technologies after an external edit of their
+// files, keeping the camera and the selection; an unsaved grid is kept and
+// the conflict is reported
+func (s *TechViewerScene) OnFilesChanged(result *app.ReloadResult) {
+	if !s.sourceChanged(result) {
+		return
+	}
+	if s.doc.Modified {
+		s.externalChange = true
+		s.message = "Warning: technology files changed on disk - Save keeps your changes, F5 reloads and drops them"
+		return
+	}
+	s.reload()
+}
+
+// sourceChanged reports whether the reload touched the shown technologies
+func (s *TechViewerScene) sourceChanged(result *app.ReloadResult) bool {
+	if s.doc.Path != "" {
+		return result.Changed(s.doc.Path)
+	}
+	if !result.Technologies || s.manager.state == nil || s.manager.state.GetCountryContext() == nil {
+		return false
+	}
+	// Reloaded files get new technology objects
+	shown := make(map[*domain.Technology]bool, len(s.doc.Technologies))
+	for _, tech := range s.doc.Technologies {
+		shown[tech] = true
+	}
+	current, _ := s.manager.state.GetCountryContext().LoadTechnologiesForFolder(s.doc.Folder)
+	if len(current) != len(s.doc.Technologies) {
+		return true
+	}
+	for _, tech := range current {
+		if !shown[tech] {
+			return true
+		}
+	}
+	return false
+}
+
+// reload loads the technologies again and rebuilds the nodes in place
+func (s *TechViewerScene) reload() {
+	technologies, err := s.loadSource()
+	if err != nil {
+		s.message = "Reload failed: " + err.Error()
+		return
+	}
+	s.doc.Revert(technologies)
+
+	selectedID := ""
+	if s.selectedNode != nil {
+		selectedID = s.selectedNode.ID
+	}
+	s.externalChange = false
+	s.diff = nil // Computed for the previous version
+	if s.provenance != nil {
+		s.provenance = s.manager.state.GetProvenance()
+	}
+	s.rebuildNodes()
+	for _, node := range s.nodes {
+		if node.ID == selectedID {
+			s.selectedNode = node
+			node.IsSelected = true
+		}
+	}
+	s.message = "Reloaded technologies (changed on disk)"
+}
+
+// rebuildNodes recreates the nodes after technologies moved
+func (s *TechViewerScene) rebuildNodes() {
+	if s.doctrines != nil {
+		s.doctrines = domain.BuildDoctrineTree(s.doctrines.Folder, s.doc.Technologies)
+		s.doctrineLayout = s.doctrines.Layout()
+	}
+	s.nodes = make([]*components.Node, 0, len(s.doc.Technologies))
+	s.selectedNode = nil
+	s.createNodes()
+}
+
 // Draw draws the scene
 func (s *TechViewerScene) Draw(screen *ebiten.Image) {
 	// Draw canvas (background + grid)
 	s.canvas.Draw(screen)
 
-	// Draw connection lines (TODO: implement later)
-	// s.drawConnections(screen)
+	// Draw connection lines (TODO: implement later for regular folders)
+	if s.doctrines != nil {
+		s.drawDoctrineConnections(screen)
+	}
 
 	// Draw nodes
 	for _, node := range s.nodes {
 		node.Draw(screen, s.canvas)
 	}
 
+	if s.doctrines != nil {
+		s.drawDoctrineLabels(screen)
+	}
+	if s.provenance != nil {
+		s.drawProvenanceBadges(screen)
+	}
+
 	// Draw UI overlay
 	s.drawUI(screen)
+	s.manager.drawWorkspace(screen)
+}
+
+// nodeScreenPosition returns the top-left screen point of a grid cell
+func (s *TechViewerScene) nodeScreenPosition(x, y int) (float64, float64) {
+	worldX, worldY := s.canvas.GridToWorld(x, y)
+	return s.canvas.WorldToScreen(worldX, worldY)
+}
+
+// drawProvenanceBadges marks every node as vanilla, overridden or new in the mod
+func (s *TechViewerScene) drawProvenanceBadges(screen *ebiten.Image) {
+	for _, node := range s.nodes {
+		entry, ok := s.provenance.Get(domain.ProvenanceTechnology, node.ID)
+		if !ok {
+			continue
+		}
+		x, y := s.nodeScreenPosition(node.X, node.Y)
+		drawProvenanceBadge(screen, entry.Provenance, x, y, float64(node.Width)*s.canvas.Zoom)
+	}
+}
+
+// provenanceLines describes where the technology comes from; overrides
+// list the fields changed from vanilla
+func (s *TechViewerScene) provenanceLines(tech *domain.Technology) []string {
+	entry, ok := s.provenance.Get(domain.ProvenanceTechnology, tech.ID)
+	if !ok {
+		return nil
+	}
+	switch entry.Provenance {
+	case domain.ProvenanceGame:
+		return []string{"Origin: vanilla"}
+	case domain.ProvenanceMod:
+		return []string{"Origin: new in the mod"}
+	}
+	lines := []string{fmt.Sprintf("Origin: overrides vanilla (%d fields changed)", len(entry.Fields))}
+	for _, field := range entry.Fields {
+		lines = append(lines, fmt.Sprintf("  %s: %s -> %s", field.Field, diffValue(field.Old), diffValue(field.New)))
+	}
+	return lines
+}
+
+// drawDoctrineConnections draws the paths between doctrine technologies
+func (s *TechViewerScene) drawDoctrineConnections(screen *ebiten.Image) {
+	nodes := make(map[string]*components.Node, len(s.nodes))
+	for _, node := range s.nodes {
+		nodes[node.ID] = node
+	}
+	for _, tech := range s.doc.Technologies {
+		from, ok := nodes[tech.ID]
+		if !ok {
+			continue
+		}
+		for _, path := range tech.Paths {
+			to, ok := nodes[path.LeadsToTech]
+			if !ok {
+				continue
+			}
+			fx, fy := s.nodeScreenPosition(from.X, from.Y)
+			tx, ty := s.nodeScreenPosition(to.X, to.Y)
+			half := float64(from.Width) * s.canvas.Zoom / 2
+			vector.StrokeLine(screen, float32(fx+half), float32(fy+float64(from.Height)*s.canvas.Zoom), float32(tx+half), float32(ty), 2, color.RGBA{150, 150, 150, 255}, false)
+		}
+	}
+}
+
+// drawDoctrineLabels names the grand doctrines and their tracks with their
+// costs and xor exclusions
+func (s *TechViewerScene) drawDoctrineLabels(screen *ebiten.Image) {
+	for _, grand := range s.doctrines.Grand {
+		position := s.doctrineLayout[grand.Root.ID]
+		x, y := s.nodeScreenPosition(position.X, 0)
+		cost := grand.Cost()
+		ebitenutil.DebugPrintAt(screen, grand.Name(), int(x), int(y))
+		ebitenutil.DebugPrintAt(screen, doctrineCostText(cost), int(x), int(y)+15)
+		if len(grand.Exclusive) > 0 {
+			ebitenutil.DebugPrintAt(screen, truncateText("xor: "+strings.Join(grand.Exclusive, ", "), 40), int(x), int(y)+30)
+		}
+
+		for _, track := range grand.Tracks {
+			position := s.doctrineLayout[track.Start.ID]
+			x, y := s.nodeScreenPosition(position.X, position.Y)
+			ebitenutil.DebugPrintAt(screen, truncateText(track.Name()+" | "+doctrineCostText(track.Cost()), 40), int(x), int(y)-16)
+		}
+	}
+}
+
+// doctrineCostText formats a doctrine cost as "cost 3.5, XP 150"
+func doctrineCostText(cost domain.DoctrineCost) string {
+	text := fmt.Sprintf("cost %g", cost.Research)
+	if cost.XPBoost > 0 {
+		text += fmt.Sprintf(", XP %d", cost.XPBoost)
+	}
+	if cost.XPUnlock > 0 {
+		text += fmt.Sprintf(", mastery %d XP", cost.XPUnlock)
+	}
+	return text
+}
+
+// doctrineLines describes where a technology sits in the doctrine tree
+func (s *TechViewerScene) doctrineLines(tech *domain.Technology) []string {
+	if s.doctrines == nil {
+		return nil
+	}
+	for _, grand := range s.doctrines.Grand {
+		for _, core := range grand.Core {
+			if core == tech {
+				return []string{"Grand doctrine: " + grand.Name()}
+			}
+		}
+		for _, track := range grand.Tracks {
+			for _, member := range track.Technologies {
+				if member == tech {
+					lines := []string{"Grand doctrine: " + grand.Name(), "Track: " + track.Name()}
+					if len(track.Exclusive) > 0 {
+						lines = append(lines, "  excludes "+strings.Join(track.Exclusive, ", "))
+					}
+					return lines
+				}
+			}
+		}
+	}
+	return nil
 }
 
 // drawUI draws the UI overlay (info panel, controls)
@@ -246,9 +729,43 @@ func (s *TechViewerScene) drawUI(screen *ebiten.Image) {
 	// Draw controls help
 	s.drawControls(screen)
 
+	if s.research() != nil {
+		s.researchButton.Draw(screen)
+	}
+	if s.manager.state != nil {
+		s.lintButton.Draw(screen)
+		if s.doctrines == nil {
+			s.gridButton.Draw(screen)
+		}
+	}
+	if s.doc.Modified {
+		s.saveButton.Draw(screen)
+	}
+	if s.provenance != nil {
+		s.overridesButton.Draw(screen)
+	}
+
 	// Draw selected node info
 	if s.selectedNode != nil {
 		s.drawNodeInfo(screen)
+		if s.manager.state != nil {
+			s.changeIconButton.Draw(screen)
+			if tech := s.selectedTech(); tech != nil && len(tech.EnableEquipments) > 0 {
+				s.lineageButton.Draw(screen)
+			}
+		}
+	}
+
+	if s.message != "" {
+		ebitenutil.DebugPrintAt(screen, s.message, 20, s.canvas.Height-50)
+	}
+
+	if s.diff != nil {
+		selectedID := ""
+		if s.selectedNode != nil {
+			selectedID = s.selectedNode.ID
+		}
+		s.diff.Draw(screen, 10, 120, selectedID)
 	}
 }
 
@@ -267,14 +784,14 @@ func (s *TechViewerScene) drawInfoPanel(screen *ebiten.Image) {
 
 	// Text
 	y := int(panelY + 20)
-	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Technologies: %d", len(s.technologies)), int(panelX+10), y)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Technologies: %d", len(s.doc.Technologies)), int(panelX+10), y)
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Zoom: %.1f%%", s.canvas.Zoom*100), int(panelX+10), y+15)
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Offset: (%.0f, %.0f)", s.canvas.OffsetX, s.canvas.OffsetY), int(panelX+10), y+30)
 }
 
 // drawControls draws the controls help
 func (s *TechViewerScene) drawControls(screen *ebiten.Image) {
-	controlsText := "Arrow Keys: Pan | +/-: Zoom | R: Reset | I: Toggle Info | ESC: Back"
+	controlsText := "Arrow Keys: Pan | +/-: Zoom | R: Reset | I: Toggle Info | D: Diff (Shift: file) | V: Vanilla Diff | ESC: Back"
 
 	// Draw at bottom center (approximate)
 	x := s.canvas.Width/2 - len(controlsText)*3
@@ -285,24 +802,30 @@ func (s *TechViewerScene) drawControls(screen *ebiten.Image) {
 
 // drawNodeInfo draws info about the selected node
 func (s *TechViewerScene) drawNodeInfo(screen *ebiten.Image) {
-	// Find the technology for this node
-	var tech *domain.Technology
-	for _, t := range s.technologies {
-		if t.ID == s.selectedNode.ID {
-			tech = t
-			break
-		}
-	}
-
+	tech := s.selectedTech()
 	if tech == nil {
 		return
 	}
 
+	// Unlocked equipment/units and effects as in-game tooltips
+	// (when the equipment set and modifier catalogue are available)
+	var effects []string
+	if s.manager.state != nil {
+		effects = append(s.unlockLines(tech), techEffectTooltips(s.manager.state.GetModifiers(), tech)...)
+	}
+	if research := s.research(); research != nil {
+		effects = append(researchLines(research, tech), effects...)
+	}
+	effects = append(s.doctrineLines(tech), effects...)
+	if s.provenance != nil {
+		effects = append(s.provenanceLines(tech), effects...)
+	}
+
 	// Draw panel on the right side
 	panelX := float32(s.canvas.Width - 310)
 	panelY := float32(10)
 	panelWidth := float32(300)
-	panelHeight := float32(200)
+	panelHeight := float32(100 + len(effects)*15)
 
 	// Background
 	vector.DrawFilledRect(screen, panelX, panelY, panelWidth, panelHeight,
@@ -332,6 +855,68 @@ func (s *TechViewerScene) drawNodeInfo(screen *ebiten.Image) {
 
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Research Cost: %.1f", tech.ResearchCost), int(panelX+10), y+45)
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Paths: %d", len(tech.Paths)), int(panelX+10), y+60)
+	for i, line := range effects {
+		ebitenutil.DebugPrintAt(screen, truncateText(line, 46), int(panelX+10), y+80+i*15)
+	}
+}
+
+// selectedTech returns the technology of the selected node, or nil
+func (s *TechViewerScene) selectedTech() *domain.Technology {
+	if s.selectedNode == nil {
+		return nil
+	}
+	for _, tech := range s.doc.Technologies {
+		if tech.ID == s.selectedNode.ID {
+			return tech
+		}
+	}
+	return nil
+}
+
+// research returns the research context of the selected country, or nil
+func (s *TechViewerScene) research() *app.ResearchSetup {
+	if s.manager.state == nil {
+		return nil
+	}
+	return s.manager.state.GetResearch()
+}
+
+// researchLines describes a technology's research time for the country
+func researchLines(research *app.ResearchSetup, tech *domain.Technology) []string {
+	if research.Researched[tech.ID] {
+		return []string{"Research: researched at start"}
+	}
+	result := research.Calculate(tech)
+	line := fmt.Sprintf("Research: %.0f days (speed %+.0f%%)", result.Days, (result.Speed-1)*100)
+	if result.AheadPenalty > 0 {
+		return []string{line, fmt.Sprintf("  %.1f years ahead: +%.0f%% time", result.YearsAhead, result.AheadPenalty*100)}
+	}
+	return []string{line}
+}
+
+// unlockLines describes the equipment and sub-units a technology unlocks
+func (s *TechViewerScene) unlockLines(tech *domain.Technology) []string {
+	if len(tech.EnableEquipments) == 0 && len(tech.EnableSubunits) == 0 {
+		return nil
+	}
+	equipment := s.manager.state.GetEquipment()
+
+	lines := make([]string, 0)
+	for _, id := range tech.EnableEquipments {
+		if item, ok := equipment.Get(id); ok {
+			lines = append(lines, "Equipment "+id, "  "+statsSummary(equipment.Stats(item.ID), keyEquipmentStats, 3))
+		} else {
+			lines = append(lines, "Equipment "+id+" (not declared)")
+		}
+	}
+	for _, id := range tech.EnableSubunits {
+		if unit, ok := equipment.SubUnit(id); ok {
+			lines = append(lines, "Unit "+id, "  "+statsSummary(unit.Stats, keySubUnitStats, 3))
+		} else {
+			lines = append(lines, "Unit "+id+" (not declared)")
+		}
+	}
+	return lines
 }
 
 // OnEnter is called when entering the scene
@@ -0,0 +1,171 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/shinomontaz/hoi4_visual_modder/internal/app"
+)
+
+// Tab bar layout (top centre, between the info panels of the viewers)
+const (
+	tabsX        = 320
+	tabsY        = 10
+	tabsWidth    = 640
+	tabHeight    = 22
+	tabMaxWidth  = 160
+	tabCharWidth = 6
+)
+
+// documentView is the scene of an open document and the name it is shown
+// under (scenes opened from it return to that name)
+type documentView struct {
+	name  string
+	scene Scene
+}
+
+// OpenDocument adds a document to the workspace and shows its scene
+func (sm *SceneManager) OpenDocument(doc app.Document, name string, scene Scene) {
+	sm.state.Workspace.Open(doc)
+	sm.documents[doc.Key()] = documentView{name: name, scene: scene}
+	sm.ShowDocument(doc.Key())
+}
+
+// ShowDocument switches to the scene of an open document; false if the
+// document is not open
+func (sm *SceneManager) ShowDocument(key string) bool {
+	view, ok := sm.documents[key]
+	if !ok || !sm.state.Workspace.SetActive(key) {
+		return false
+	}
+	sm.closePending = ""
+	sm.AddScene(view.name, view.scene)
+	sm.SwitchToNamed(view.name)
+	return true
+}
+
+// CloseDocument closes a document (unsaved changes are dropped) and shows
+// the next active one, or the country menu when none is left
+func (sm *SceneManager) CloseDocument(key string) {
+	sm.state.Workspace.Close(key)
+	delete(sm.documents, key)
+	sm.closePending = ""
+	if active := sm.state.Workspace.Active(); active != nil && sm.ShowDocument(active.Key()) {
+		return
+	}
+	sm.SwitchToNamed("country_menu")
+}
+
+// SaveAll saves every document with unsaved changes and reports the result
+// in the tab bar
+func (sm *SceneManager) SaveAll() error {
+	saved, err := sm.state.Workspace.SaveAll()
+	switch {
+	case err != nil:
+		sm.workspaceMessage = "Save failed: " + strings.ReplaceAll(err.Error(), "\n", "; ")
+	case saved == 0:
+		sm.workspaceMessage = "Nothing to save"
+	default:
+		sm.workspaceMessage = fmt.Sprintf("Saved %d documents (.bak backups kept)", saved)
+	}
+	return err
+}
+
+// updateWorkspace handles the tab bar of document scenes: click a tab or
+// Ctrl+Tab to switch, Ctrl+S saves all documents, Ctrl+W closes the active
+// one (pressed twice if it has unsaved changes); returns true when the
+// input was used and the scene should not handle it
+func (sm *SceneManager) updateWorkspace() bool {
+	if sm.state == nil || len(sm.state.Workspace.Documents()) == 0 {
+		return false
+	}
+	workspace := sm.state.Workspace
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		mx, my := ebiten.CursorPosition()
+		for i, doc := range workspace.Documents() {
+			x, width := sm.tabBounds(i)
+			if mx >= x && mx < x+width && my >= tabsY && my < tabsY+tabHeight {
+				sm.ShowDocument(doc.Key())
+				return true
+			}
+		}
+	}
+
+	if !ebiten.IsKeyPressed(ebiten.KeyControl) {
+		return false
+	}
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyTab):
+		if next := workspace.Next(); next != nil {
+			sm.ShowDocument(next.Key())
+		}
+		return true
+	case inpututil.IsKeyJustPressed(ebiten.KeyS):
+		sm.SaveAll()
+		return true
+	case inpututil.IsKeyJustPressed(ebiten.KeyW):
+		active := workspace.Active()
+		if active == nil {
+			return true
+		}
+		if active.Dirty() && sm.closePending != active.Key() {
+			sm.closePending = active.Key()
+			sm.workspaceMessage = active.Title() + " has unsaved changes - Ctrl+W again discards them, Ctrl+S saves"
+			return true
+		}
+		sm.CloseDocument(active.Key())
+		return true
+	}
+	return false
+}
+
+// tabBounds returns the x and width of the i-th tab
+func (sm *SceneManager) tabBounds(i int) (int, int) {
+	width := tabMaxWidth
+	if count := len(sm.state.Workspace.Documents()); count*tabMaxWidth > tabsWidth {
+		width = tabsWidth / count
+	}
+	return tabsX + i*width, width
+}
+
+// drawWorkspace draws the tabs of the open documents (unsaved ones marked
+// with *) and the last workspace message
+func (sm *SceneManager) drawWorkspace(screen *ebiten.Image) {
+	if sm.state == nil {
+		return
+	}
+	workspace := sm.state.Workspace
+	active := workspace.Active()
+
+	for i, doc := range workspace.Documents() {
+		x, width := sm.tabBounds(i)
+		background := color.RGBA{40, 40, 50, 230}
+		if doc == active {
+			background = color.RGBA{60, 80, 110, 240}
+		}
+		vector.DrawFilledRect(screen, float32(x), tabsY, float32(width-2), tabHeight, background, false)
+		vector.StrokeRect(screen, float32(x), tabsY, float32(width-2), tabHeight, 1, color.RGBA{100, 100, 120, 255}, false)
+
+		label := doc.Kind().String() + ": " + doc.Title()
+		if doc.Dirty() {
+			label = "*" + label
+		}
+		ebitenutil.DebugPrintAt(screen, truncateText(label, max((width-10)/tabCharWidth, 4)), x+5, tabsY+4)
+	}
+
+	if dirty := len(workspace.DirtyDocuments()); dirty > 0 {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d unsaved - Ctrl+S: Save All | Ctrl+Tab: Next | Ctrl+W: Close", dirty), tabsX, tabsY+tabHeight+4)
+	} else if len(workspace.Documents()) > 0 {
+		ebitenutil.DebugPrintAt(screen, "Ctrl+Tab: Next | Ctrl+W: Close", tabsX, tabsY+tabHeight+4)
+	}
+	if sm.workspaceMessage != "" {
+		ebitenutil.DebugPrintAt(screen, truncateText(sm.workspaceMessage, tabsWidth/tabCharWidth), tabsX, tabsY+tabHeight+19)
+	}
+}